@@ -0,0 +1,35 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+// Annotations are schema annotations of a definition, field, enum value or method,
+// i.e. `name string 1 [deprecated=true, go_type="x.Y"]`.
+//
+// Values are bool, int64, float64 or string, identifiers except true and false are strings.
+type Annotations map[string]any
+
+// Has returns true if an annotation is present.
+func (a Annotations) Has(name string) bool {
+	_, ok := a[name]
+	return ok
+}
+
+// Bool returns true if a bool annotation is present and true.
+func (a Annotations) Bool(name string) bool {
+	v, _ := a[name].(bool)
+	return v
+}
+
+// Int returns an integer annotation value or false.
+func (a Annotations) Int(name string) (int64, bool) {
+	v, ok := a[name].(int64)
+	return v, ok
+}
+
+// String returns a string annotation value or false.
+func (a Annotations) String(name string) (string, bool) {
+	v, ok := a[name].(string)
+	return v, ok
+}
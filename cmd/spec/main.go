@@ -5,12 +5,17 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
 
+	"github.com/basecomplextech/spec"
 	"github.com/basecomplextech/spec/internal/lang"
+	"github.com/basecomplextech/spec/internal/lang/lint"
+	"github.com/basecomplextech/spec/internal/lang/lsp"
 	"github.com/urfave/cli/v2"
 )
 
@@ -22,7 +27,7 @@ func main() {
 			{
 				Name:        "generate",
 				Description: "Generate a Go package from a Spec package",
-				UsageText:   "spec generate [-i import-paths] [--skip-rpc] [src-dir] [dst-dir]",
+				UsageText:   "spec generate [-i import-paths] [--skip-rpc] [--objects] [--plugin name] [src-dir] [dst-dir]",
 				Args:        true,
 				Flags: []cli.Flag{
 					&cli.StringSliceFlag{
@@ -34,6 +39,14 @@ func main() {
 						Name:  "skip-rpc",
 						Usage: "skip generating RPC code",
 					},
+					&cli.BoolFlag{
+						Name:  "objects",
+						Usage: "generate owned message objects in all packages",
+					},
+					&cli.StringSliceFlag{
+						Name:  "plugin",
+						Usage: "run spec-gen-<name> plugins instead of generating Go code",
+					},
 				},
 				Action: func(x *cli.Context) error {
 					// Source/dest args
@@ -56,12 +69,196 @@ func main() {
 					// Flags
 					imports := x.StringSlice("import")
 					skipRPC := x.Bool("skip-rpc")
+					objects := x.Bool("objects")
+					plugins := x.StringSlice("plugin")
 
 					// Generate
-					spec := lang.New(imports, skipRPC)
+					spec := lang.New(imports, skipRPC, objects)
+					if len(plugins) > 0 {
+						return spec.GeneratePlugins(src, dst, plugins)
+					}
 					return spec.Generate(src, dst)
 				},
 			},
+			{
+				Name:        "compat",
+				Description: "Check that a new Spec package is wire-compatible with an old one",
+				UsageText:   "spec compat [-i import-paths] old-dir new-dir",
+				Args:        true,
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:    "import",
+						Aliases: []string{"i"},
+						Usage:   "import paths",
+					},
+				},
+				Action: func(x *cli.Context) error {
+					args := x.Args().Slice()
+					if len(args) != 2 {
+						return fmt.Errorf("invalid old/new args: %v", args)
+					}
+					old := strings.TrimSpace(args[0])
+					new := strings.TrimSpace(args[1])
+
+					// Flags
+					imports := x.StringSlice("import")
+
+					// Check
+					spec := lang.New(imports, false, false)
+					changes, err := spec.Compat(old, new)
+					if err != nil {
+						return err
+					}
+					breaking := 0
+					for _, change := range changes {
+						if change.Note {
+							fmt.Printf("note: %v\n", change)
+							continue
+						}
+
+						fmt.Println(change)
+						breaking++
+					}
+					if breaking == 0 {
+						return nil
+					}
+					return fmt.Errorf("breaking changes found: %d", breaking)
+				},
+			},
+			{
+				Name:        "lint",
+				Description: "Check a Spec package for style and safety issues",
+				UsageText:   "spec lint [-i import-paths] [-r rule=severity] [--rules] [src-dir]",
+				Args:        true,
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:    "import",
+						Aliases: []string{"i"},
+						Usage:   "import paths",
+					},
+					&cli.StringSliceFlag{
+						Name:    "rule",
+						Aliases: []string{"r"},
+						Usage:   "rule severity, i.e. tag-gap=error or unused=off",
+					},
+					&cli.BoolFlag{
+						Name:  "rules",
+						Usage: "list rules and their default severities",
+					},
+				},
+				Action: func(x *cli.Context) error {
+					if x.Bool("rules") {
+						for _, rule := range lint.Rules() {
+							fmt.Printf("%-20v %-8v %v\n", rule.Name, rule.Severity, rule.Doc)
+						}
+						return nil
+					}
+
+					// Source arg
+					src := "."
+					args := x.Args().Slice()
+					switch len(args) {
+					case 0:
+					case 1:
+						src = strings.TrimSpace(args[0])
+					default:
+						return fmt.Errorf("invalid src args: %v", args)
+					}
+
+					// Flags
+					imports := x.StringSlice("import")
+					config, err := lint.ParseConfig(x.StringSlice("rule"))
+					if err != nil {
+						return err
+					}
+
+					// Lint
+					spec := lang.New(imports, false, false)
+					issues, err := spec.Lint(src, config)
+					if err != nil {
+						return err
+					}
+
+					failed := 0
+					for _, issue := range issues {
+						fmt.Println(issue)
+						if issue.Severity >= lint.SeverityWarning {
+							failed++
+						}
+					}
+					if failed > 0 {
+						return fmt.Errorf("lint issues found: %d", failed)
+					}
+					return nil
+				},
+			},
+			{
+				Name:        "fmt",
+				Description: "Format Spec files, formats spec files in a directory recursively",
+				UsageText:   "spec fmt [-w] [-l] [path ...]",
+				Args:        true,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "w",
+						Usage: "write result to source files instead of stdout",
+					},
+					&cli.BoolFlag{
+						Name:  "l",
+						Usage: "list files whose formatting differs",
+					},
+				},
+				Action: func(x *cli.Context) error {
+					paths := x.Args().Slice()
+					if len(paths) == 0 {
+						paths = []string{"."}
+					}
+
+					list := x.Bool("l")
+					write := x.Bool("w")
+					return lang.Format(paths, list, write, os.Stdout)
+				},
+			},
+			{
+				Name:        "lsp",
+				Description: "Run a language server for Spec files over stdio",
+				UsageText:   "spec lsp [-i import-paths]",
+				Flags: []cli.Flag{
+					&cli.StringSliceFlag{
+						Name:    "import",
+						Aliases: []string{"i"},
+						Usage:   "import paths",
+					},
+				},
+				Action: func(x *cli.Context) error {
+					imports := x.StringSlice("import")
+					return lsp.Serve(os.Stdin, os.Stdout, lsp.Options{ImportPaths: imports})
+				},
+			},
+			{
+				Name:        "dump",
+				Description: "Dump a binary value as a schema-less tree, reads hex from stdin when no file",
+				UsageText:   "spec dump [file]",
+				Args:        true,
+				Action: func(x *cli.Context) error {
+					var b []byte
+					var err error
+
+					args := x.Args().Slice()
+					switch len(args) {
+					case 0:
+						b, err = readHex(os.Stdin)
+					case 1:
+						b, err = os.ReadFile(args[0])
+					default:
+						return fmt.Errorf("invalid file args: %v", args)
+					}
+					if err != nil {
+						return err
+					}
+
+					return spec.Dump(b, os.Stdout)
+				},
+			},
 		},
 	}
 
@@ -69,3 +266,15 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// readHex reads a hex string from a reader, skips whitespace and an optional 0x prefix.
+func readHex(r io.Reader) ([]byte, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	s := strings.Join(strings.Fields(string(b)), "")
+	s = strings.TrimPrefix(s, "0x")
+	return hex.DecodeString(s)
+}
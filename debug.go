@@ -0,0 +1,540 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+)
+
+// Debug text
+//
+// Generated messages, objects and structs, lists and maps implement fmt.Stringer and
+// fmt.Formatter, they print field names and values for logs and test failures, i.e.
+//
+//	Order{id: 01234567-89ab-cdef-0123-456789abcdef, name: "alice", items: [Item{count: 1}]}
+//
+// Absent message fields are omitted, strings are quoted, bytes are printed in hex,
+// enums by their names, well-known structs in their canonical text forms. Long strings
+// and bytes are truncated. Values of fields with a sensitive annotation are redacted.
+//
+// Objects are marshaled into messages and printed the same way, including redaction.
+// Decoding and marshaling errors are printed inline. The text is for humans, it is not stable and
+// must not be parsed, use canonical JSON instead.
+
+const (
+	debugMaxBytes       = 32  // max number of printed bytes
+	debugMaxString      = 256 // max number of printed string bytes
+	debugRedacted       = "<redacted>"
+	annotationSensitive = "sensitive"
+)
+
+// MessageString returns a debug text representation of a message.
+func MessageString(desc *MessageDescriptor, msg Message) string {
+	p := debugPrinter{}
+	p.message(desc, msg)
+	return string(p.b)
+}
+
+// StructString returns a debug text representation of a struct.
+func StructString[T any](desc *StructDescriptor, s T, encode func(buffer.Buffer, T) (int, error)) string {
+	p := debugPrinter{}
+	debugStruct(&p, desc, s, encode)
+	return string(p.b)
+}
+
+// ObjectString returns a debug text representation of an object marshaled into a message,
+// or of a marshaling error.
+func ObjectString(desc *MessageDescriptor, msg Message, err error) string {
+	p := debugPrinter{}
+	p.object(desc, msg, err)
+	return string(p.b)
+}
+
+// FormatMessage writes a debug text representation of a message, supports %v, %s and %q verbs.
+func FormatMessage(f fmt.State, verb rune, desc *MessageDescriptor, msg Message) {
+	p := debugPrinter{}
+	p.message(desc, msg)
+	debugFormat(f, verb, p.b)
+}
+
+// FormatObject writes a debug text representation of an object marshaled into a message,
+// or of a marshaling error, supports %v, %s and %q verbs.
+func FormatObject(f fmt.State, verb rune, desc *MessageDescriptor, msg Message, err error) {
+	p := debugPrinter{}
+	p.object(desc, msg, err)
+	debugFormat(f, verb, p.b)
+}
+
+// FormatStruct writes a debug text representation of a struct, supports %v, %s and %q verbs.
+func FormatStruct[T any](f fmt.State, verb rune, desc *StructDescriptor, s T,
+	encode func(buffer.Buffer, T) (int, error)) {
+
+	p := debugPrinter{}
+	debugStruct(&p, desc, s, encode)
+	debugFormat(f, verb, p.b)
+}
+
+// internal
+
+type debugPrinter struct {
+	b []byte
+}
+
+func (p *debugPrinter) message(desc *MessageDescriptor, msg Message) {
+	if desc == nil {
+		p.anyMessage(msg)
+		return
+	}
+
+	p.b = append(p.b, desc.Name...)
+	p.b = append(p.b, '{')
+	first := true
+
+	for _, field := range desc.Fields {
+		v := msg.Field(field.Tag)
+		if v == nil {
+			continue
+		}
+
+		if !first {
+			p.b = append(p.b, ", "...)
+		}
+		first = false
+
+		p.b = append(p.b, field.Name...)
+		p.b = append(p.b, ": "...)
+
+		if field.Annotations.Bool(annotationSensitive) {
+			p.b = append(p.b, debugRedacted...)
+			continue
+		}
+		p.value(field.Type, v)
+	}
+
+	p.b = append(p.b, '}')
+}
+
+func (p *debugPrinter) object(desc *MessageDescriptor, msg Message, err error) {
+	if err != nil {
+		p.error(err)
+		return
+	}
+	p.message(desc, msg)
+}
+
+func (p *debugPrinter) value(typ *TypeDescriptor, v Value) {
+	var err error
+
+	switch typ.Kind {
+	case KindAny:
+		p.any(v)
+
+	case KindBool:
+		var b bool
+		if b, err = v.BoolErr(); err == nil {
+			p.b = strconv.AppendBool(p.b, b)
+		}
+	case KindByte:
+		var b byte
+		if b, err = v.ByteErr(); err == nil {
+			p.b = strconv.AppendUint(p.b, uint64(b), 10)
+		}
+
+	case KindInt16:
+		var i int16
+		if i, err = v.Int16Err(); err == nil {
+			p.b = strconv.AppendInt(p.b, int64(i), 10)
+		}
+	case KindInt32:
+		var i int32
+		if i, err = v.Int32Err(); err == nil {
+			p.b = strconv.AppendInt(p.b, int64(i), 10)
+		}
+	case KindInt64:
+		var i int64
+		if i, err = v.Int64Err(); err == nil {
+			p.b = strconv.AppendInt(p.b, i, 10)
+		}
+
+	case KindUint16:
+		var u uint16
+		if u, err = v.Uint16Err(); err == nil {
+			p.b = strconv.AppendUint(p.b, uint64(u), 10)
+		}
+	case KindUint32:
+		var u uint32
+		if u, err = v.Uint32Err(); err == nil {
+			p.b = strconv.AppendUint(p.b, uint64(u), 10)
+		}
+	case KindUint64:
+		var u uint64
+		if u, err = v.Uint64Err(); err == nil {
+			p.b = strconv.AppendUint(p.b, u, 10)
+		}
+
+	case KindBin64:
+		var b bin.Bin64
+		if b, err = v.Bin64Err(); err == nil {
+			p.b = b.AppendHexTo(p.b)
+		}
+	case KindBin128:
+		var b bin.Bin128
+		if b, err = v.Bin128Err(); err == nil {
+			p.b = b.AppendHexTo(p.b)
+		}
+	case KindBin256:
+		var b bin.Bin256
+		if b, err = v.Bin256Err(); err == nil {
+			p.b = b.AppendHexTo(p.b)
+		}
+
+	case KindFloat32:
+		var f float32
+		if f, err = v.Float32Err(); err == nil {
+			p.b = strconv.AppendFloat(p.b, float64(f), 'g', -1, 32)
+		}
+	case KindFloat64:
+		var f float64
+		if f, err = v.Float64Err(); err == nil {
+			p.b = strconv.AppendFloat(p.b, f, 'g', -1, 64)
+		}
+
+	case KindBytes:
+		var b Bytes
+		if b, err = v.BytesErr(); err == nil {
+			p.bytes(b)
+		}
+	case KindString:
+		var s String
+		if s, err = v.StringErr(); err == nil {
+			p.string(s.Unwrap())
+		}
+
+	case KindAnyMessage:
+		var msg Message
+		if msg, err = v.MessageErr(); err == nil {
+			p.anyMessage(msg)
+		}
+
+	case KindList:
+		p.list(typ.Element, v)
+
+	case KindMap:
+		p.map_(typ, v)
+
+	case KindEnum:
+		var i int32
+		if i, err = v.Int32Err(); err == nil {
+			p.enum(typ.Enum, i)
+		}
+
+	case KindMessage:
+		var msg Message
+		if msg, err = v.MessageErr(); err == nil {
+			p.message(typ.Message, msg)
+		}
+
+	case KindStruct:
+		p.struct_(typ.Struct, v)
+
+	default:
+		err = fmt.Errorf("unsupported kind %v", typ.Kind)
+	}
+
+	if err != nil {
+		p.error(err)
+	}
+}
+
+func (p *debugPrinter) list(elem *TypeDescriptor, v Value) {
+	list, err := v.ListErr()
+	if err != nil {
+		p.error(err)
+		return
+	}
+
+	p.b = append(p.b, '[')
+	for i := 0; i < list.Len(); i++ {
+		if i > 0 {
+			p.b = append(p.b, ", "...)
+		}
+		p.value(elem, list.Get(i))
+	}
+	p.b = append(p.b, ']')
+}
+
+func (p *debugPrinter) map_(typ *TypeDescriptor, v Value) {
+	m, err := v.MapErr()
+	if err != nil {
+		p.error(err)
+		return
+	}
+
+	p.b = append(p.b, '{')
+	for i := 0; i < m.Len(); i++ {
+		if i > 0 {
+			p.b = append(p.b, ", "...)
+		}
+
+		p.value(typ.Key, m.Key(i))
+		p.b = append(p.b, ": "...)
+		p.value(typ.Element, m.Value(i))
+	}
+	p.b = append(p.b, '}')
+}
+
+func (p *debugPrinter) struct_(desc *StructDescriptor, v Value) {
+	if desc == nil {
+		p.bytes(v)
+		return
+	}
+
+	values, err := structFieldValues(desc, v)
+	if err != nil {
+		p.error(err)
+		return
+	}
+
+	if kind, ok := jsonWellknown(desc); ok {
+		s, err := jsonFormatWellknown(kind, values)
+		if err != nil {
+			p.error(err)
+			return
+		}
+		p.b = append(p.b, s...)
+		return
+	}
+
+	p.b = append(p.b, desc.Name...)
+	p.b = append(p.b, '{')
+	for i, field := range desc.Fields {
+		if i > 0 {
+			p.b = append(p.b, ", "...)
+		}
+
+		p.b = append(p.b, field.Name...)
+		p.b = append(p.b, ": "...)
+
+		if field.Annotations.Bool(annotationSensitive) {
+			p.b = append(p.b, debugRedacted...)
+			continue
+		}
+		p.value(field.Type, values[i])
+	}
+	p.b = append(p.b, '}')
+}
+
+func (p *debugPrinter) enum(desc *EnumDescriptor, v int32) {
+	if desc != nil {
+		if val := desc.ValueByNumber(v); val != nil {
+			p.b = append(p.b, val.Name...)
+			return
+		}
+	}
+	p.b = strconv.AppendInt(p.b, int64(v), 10)
+}
+
+// any
+
+func (p *debugPrinter) any(v Value) {
+	typ, _, err := DecodeType(v)
+	if err != nil {
+		p.error(err)
+		return
+	}
+
+	switch typ {
+	case TypeTrue, TypeFalse:
+		p.value(jsonBuiltin[KindBool], v)
+	case TypeByte:
+		p.value(jsonBuiltin[KindByte], v)
+
+	case TypeInt16:
+		p.value(jsonBuiltin[KindInt16], v)
+	case TypeInt32:
+		p.value(jsonBuiltin[KindInt32], v)
+	case TypeInt64:
+		p.value(jsonBuiltin[KindInt64], v)
+
+	case TypeUint16:
+		p.value(jsonBuiltin[KindUint16], v)
+	case TypeUint32:
+		p.value(jsonBuiltin[KindUint32], v)
+	case TypeUint64:
+		p.value(jsonBuiltin[KindUint64], v)
+
+	case TypeBin64:
+		p.value(jsonBuiltin[KindBin64], v)
+	case TypeBin128:
+		p.value(jsonBuiltin[KindBin128], v)
+	case TypeBin256:
+		p.value(jsonBuiltin[KindBin256], v)
+
+	case TypeFloat32:
+		p.value(jsonBuiltin[KindFloat32], v)
+	case TypeFloat64:
+		p.value(jsonBuiltin[KindFloat64], v)
+
+	case TypeBytes:
+		p.value(jsonBuiltin[KindBytes], v)
+	case TypeString:
+		p.value(jsonBuiltin[KindString], v)
+
+	case TypeList, TypeBigList:
+		p.list(jsonBuiltin[KindAny], v)
+
+	case TypeMap, TypeBigMap:
+		p.map_(debugAnyMap, v)
+
+	case TypeMessage, TypeBigMessage:
+		p.value(jsonBuiltin[KindAnyMessage], v)
+
+	case TypeStruct:
+		p.bytes(v)
+
+	default:
+		p.error(fmt.Errorf("unsupported type %v", typ))
+	}
+}
+
+func (p *debugPrinter) anyMessage(msg Message) {
+	p.b = append(p.b, '{')
+
+	n := msg.Fields()
+	first := true
+	for i := 0; i < n; i++ {
+		tag, ok := msg.TagAt(i)
+		if !ok {
+			continue
+		}
+
+		if !first {
+			p.b = append(p.b, ", "...)
+		}
+		first = false
+
+		p.b = strconv.AppendUint(p.b, uint64(tag), 10)
+		p.b = append(p.b, ": "...)
+		p.any(msg.FieldAt(i))
+	}
+
+	p.b = append(p.b, '}')
+}
+
+// primitives
+
+// string writes a quoted string, truncates it to debugMaxString bytes.
+func (p *debugPrinter) string(s string) {
+	if len(s) <= debugMaxString {
+		p.b = strconv.AppendQuote(p.b, s)
+		return
+	}
+
+	n := debugMaxString
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+
+	p.b = strconv.AppendQuote(p.b, s[:n])
+	p.b = fmt.Appendf(p.b, "...(%d bytes)", len(s))
+}
+
+// bytes writes bytes in hex, truncates them to debugMaxBytes.
+func (p *debugPrinter) bytes(b []byte) {
+	if len(b) <= debugMaxBytes {
+		p.b = hex.AppendEncode(p.b, b)
+		return
+	}
+
+	p.b = hex.AppendEncode(p.b, b[:debugMaxBytes])
+	p.b = fmt.Appendf(p.b, "...(%d bytes)", len(b))
+}
+
+func (p *debugPrinter) error(err error) {
+	p.b = fmt.Appendf(p.b, "<error: %v>", err)
+}
+
+// elements
+
+// debugList returns a debug text representation of a generic list.
+func debugList[T any](n int, get func(int) T) string {
+	p := debugPrinter{}
+	p.b = append(p.b, '[')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			p.b = append(p.b, ", "...)
+		}
+		p.element(get(i))
+	}
+	p.b = append(p.b, ']')
+	return string(p.b)
+}
+
+// debugMap returns a debug text representation of a generic map.
+func debugMap[K, V any](n int, key func(int) K, value func(int) V) string {
+	p := debugPrinter{}
+	p.b = append(p.b, '{')
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			p.b = append(p.b, ", "...)
+		}
+		p.element(key(i))
+		p.b = append(p.b, ": "...)
+		p.element(value(i))
+	}
+	p.b = append(p.b, '}')
+	return string(p.b)
+}
+
+// element writes a generic list or map element, generated types print themselves.
+func (p *debugPrinter) element(v any) {
+	switch v := v.(type) {
+	case String:
+		p.string(v.Unwrap())
+	case Bytes:
+		p.bytes(v)
+	default:
+		p.b = fmt.Append(p.b, v)
+	}
+}
+
+// util
+
+// debugStruct encodes a struct and prints it.
+func debugStruct[T any](p *debugPrinter, desc *StructDescriptor, s T,
+	encode func(buffer.Buffer, T) (int, error)) {
+
+	buf := buffer.New()
+
+	if _, err := encode(buf, s); err != nil {
+		p.error(err)
+		return
+	}
+	p.struct_(desc, buf.Bytes())
+}
+
+var debugAnyMap = &TypeDescriptor{
+	Kind:    KindMap,
+	Key:     jsonBuiltin[KindAny],
+	Element: jsonBuiltin[KindAny],
+}
+
+// debugFormat writes a debug text to a formatter.
+func debugFormat(f fmt.State, verb rune, b []byte) {
+	switch verb {
+	case 'v', 's':
+		f.Write(b)
+	case 'q':
+		f.Write(strconv.AppendQuote(nil, string(b)))
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, b)
+	}
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDebugDescriptor() *MessageDescriptor {
+	sub := &MessageDescriptor{
+		Name: "Sub",
+		Fields: []*FieldDescriptor{
+			{Name: "value", Tag: 1, Type: &TypeDescriptor{Kind: KindString, Name: "string"}},
+		},
+	}
+
+	return &MessageDescriptor{
+		Name: "Test",
+		Fields: []*FieldDescriptor{
+			{Name: "int", Tag: 1, Type: &TypeDescriptor{Kind: KindInt64, Name: "int64"}},
+			{Name: "string", Tag: 2, Type: &TypeDescriptor{Kind: KindString, Name: "string"}},
+			{Name: "bytes", Tag: 3, Type: &TypeDescriptor{Kind: KindBytes, Name: "bytes"}},
+			{Name: "password", Tag: 4, Type: &TypeDescriptor{Kind: KindString, Name: "string"},
+				Annotations: Annotations{"sensitive": true}},
+			{Name: "subs", Tag: 5, Type: &TypeDescriptor{Kind: KindList, Name: "[]Sub",
+				Element: &TypeDescriptor{Kind: KindMessage, Name: "Sub", Message: sub}}},
+			{Name: "any", Tag: 6, Type: &TypeDescriptor{Kind: KindAny, Name: "any"}},
+			{Name: "absent", Tag: 7, Type: &TypeDescriptor{Kind: KindInt32, Name: "int32"}},
+		},
+	}
+}
+
+func testDebugMessage(t *testing.T) Message {
+	w := NewMessageWriter()
+	w.Field(1).Int64(-1)
+	w.Field(2).String("hello")
+	w.Field(3).Bytes([]byte{0xca, 0xfe})
+	w.Field(4).String("secret")
+
+	subs := w.Field(5).List()
+	sub := subs.Message()
+	sub.Field(1).String("sub")
+	require.NoError(t, sub.End())
+	require.NoError(t, subs.End())
+
+	w.Field(6).Int32(123)
+
+	b, err := w.Build()
+	require.NoError(t, err)
+	return OpenMessage(b)
+}
+
+func TestMessageString__should_print_field_names_and_values(t *testing.T) {
+	msg := testDebugMessage(t)
+	desc := testDebugDescriptor()
+
+	s := MessageString(desc, msg)
+	assert.Equal(t, `Test{int: -1, string: "hello", bytes: cafe, password: <redacted>, `+
+		`subs: [Sub{value: "sub"}], any: 123}`, s)
+}
+
+func TestMessageString__should_print_messages_without_descriptors_by_tags(t *testing.T) {
+	msg := testDebugMessage(t)
+
+	s := MessageString(nil, msg)
+	assert.Equal(t, `{1: -1, 2: "hello", 3: cafe, 4: "secret", 5: [{1: "sub"}], 6: 123}`, s)
+}
+
+func TestMessageString__should_truncate_long_strings_and_bytes(t *testing.T) {
+	w := NewMessageWriter()
+	w.Field(2).String(strings.Repeat("a", debugMaxString+1))
+	w.Field(3).Bytes(make([]byte, 100))
+
+	b, err := w.Build()
+	require.NoError(t, err)
+	msg := OpenMessage(b)
+
+	s := MessageString(testDebugDescriptor(), msg)
+	assert.Contains(t, s, `"`+strings.Repeat("a", debugMaxString)+`"...(257 bytes)`)
+	assert.Contains(t, s, strings.Repeat("00", debugMaxBytes)+"...(100 bytes)")
+}
+
+func TestFormatMessage__should_support_verbs(t *testing.T) {
+	msg := testDebugMessage(t)
+	m := testDebugFormatter{desc: testDebugDescriptor(), msg: msg}
+	s := MessageString(m.desc, msg)
+
+	assert.Equal(t, s, fmt.Sprintf("%v", m))
+	assert.Equal(t, s, fmt.Sprintf("%s", m))
+	assert.Equal(t, fmt.Sprintf("%q", s), fmt.Sprintf("%q", m))
+	assert.Equal(t, "%!d("+s+")", fmt.Sprintf("%d", m))
+}
+
+func TestMessageString__should_print_decode_errors_inline(t *testing.T) {
+	w := NewMessageWriter()
+	w.Field(1).String("not an int")
+
+	b, err := w.Build()
+	require.NoError(t, err)
+	msg := OpenMessage(b)
+
+	s := MessageString(testDebugDescriptor(), msg)
+	assert.Contains(t, s, "int: <error:")
+}
+
+// Object
+
+func TestObjectString__should_print_message_or_marshal_error(t *testing.T) {
+	desc := testDebugDescriptor()
+	msg := testDebugMessage(t)
+
+	s := ObjectString(desc, msg, nil)
+	assert.Equal(t, MessageString(desc, msg), s)
+
+	s = ObjectString(desc, Message{}, errors.New("oneof body: no field set"))
+	assert.Equal(t, "<error: oneof body: no field set>", s)
+}
+
+// private
+
+type testDebugFormatter struct {
+	desc *MessageDescriptor
+	msg  Message
+}
+
+func (m testDebugFormatter) Format(f fmt.State, verb rune) {
+	FormatMessage(f, verb, m.desc, m.msg)
+}
@@ -6,6 +6,14 @@ package spec
 
 import "github.com/basecomplextech/spec/internal/decode"
 
+type (
+	// DecodeError is a decode error with a byte offset, expected and found types, and a logical path.
+	DecodeError = decode.Error
+
+	// DecodePathElem is a decode error path element, a message field or a list element.
+	DecodePathElem = decode.PathElem
+)
+
 var (
 	DecodeType     = decode.DecodeType
 	DecodeTypeSize = decode.DecodeTypeSize
@@ -27,6 +35,7 @@ var (
 	DecodeInt64 = decode.DecodeInt64
 
 	DecodeListTable    = decode.DecodeListTable
+	DecodeMapTable     = decode.DecodeMapTable
 	DecodeMessageTable = decode.DecodeMessageTable
 
 	DecodeString      = decode.DecodeString
@@ -0,0 +1,426 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Descriptors describe generated types at runtime, they are emitted by the generator
+// as package-level variables and must not be modified.
+
+// Kind specifies a descriptor type kind.
+type Kind int32
+
+const (
+	KindUndefined Kind = iota
+	KindAny
+
+	KindBool
+	KindByte
+
+	KindInt16
+	KindInt32
+	KindInt64
+
+	KindUint16
+	KindUint32
+	KindUint64
+
+	KindBin64
+	KindBin128
+	KindBin256
+
+	KindFloat32
+	KindFloat64
+
+	KindBytes
+	KindString
+	KindAnyMessage
+
+	// List/map
+
+	KindList
+	KindMap
+
+	// References
+
+	KindEnum
+	KindMessage
+	KindStruct
+	KindService
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindAny:
+		return "any"
+
+	case KindBool:
+		return "bool"
+	case KindByte:
+		return "byte"
+
+	case KindInt16:
+		return "int16"
+	case KindInt32:
+		return "int32"
+	case KindInt64:
+		return "int64"
+
+	case KindUint16:
+		return "uint16"
+	case KindUint32:
+		return "uint32"
+	case KindUint64:
+		return "uint64"
+
+	case KindBin64:
+		return "bin64"
+	case KindBin128:
+		return "bin128"
+	case KindBin256:
+		return "bin256"
+
+	case KindFloat32:
+		return "float32"
+	case KindFloat64:
+		return "float64"
+
+	case KindBytes:
+		return "bytes"
+	case KindString:
+		return "string"
+	case KindAnyMessage:
+		return "message"
+
+	case KindList:
+		return "list"
+	case KindMap:
+		return "map"
+
+	case KindEnum:
+		return "enum"
+	case KindMessage:
+		return "message"
+	case KindStruct:
+		return "struct"
+	case KindService:
+		return "service"
+	}
+
+	return "undefined"
+}
+
+// TypeDescriptor
+
+// TypeDescriptor describes a field, a list element, a map value or a method type.
+type TypeDescriptor struct {
+	Kind Kind
+	Name string // type name, i.e. "int64", "Message", "pkg.Message", "[]string", "map[string]int64"
+
+	Import  string          // import name for imported types, i.e. "pkg"
+	Key     *TypeDescriptor // map key type
+	Element *TypeDescriptor // list element or map value type
+
+	// Reference descriptors, only one is set depending on the kind.
+	Enum    *EnumDescriptor
+	Message *MessageDescriptor
+	Struct  *StructDescriptor
+	Service *ServiceDescriptor
+}
+
+// EnumDescriptor
+
+// EnumDescriptor describes a generated enum.
+type EnumDescriptor struct {
+	Package string
+	Name    string
+	Values  []*EnumValueDescriptor
+
+	Annotations Annotations
+}
+
+// EnumValueDescriptor describes an enum value.
+type EnumValueDescriptor struct {
+	Name   string
+	Number int32
+
+	Annotations Annotations
+}
+
+// Value returns a value by its name or nil.
+func (d *EnumDescriptor) Value(name string) *EnumValueDescriptor {
+	for _, v := range d.Values {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// ValueByNumber returns a value by its number or nil.
+func (d *EnumDescriptor) ValueByNumber(number int32) *EnumValueDescriptor {
+	for _, v := range d.Values {
+		if v.Number == number {
+			return v
+		}
+	}
+	return nil
+}
+
+// MessageDescriptor
+
+// MessageDescriptor describes a generated message.
+type MessageDescriptor struct {
+	Package string
+	Name    string
+	Fields  []*FieldDescriptor // ordered as in the schema
+	Oneofs  []*OneofDescriptor // ordered as in the schema
+
+	Annotations Annotations
+}
+
+// FieldDescriptor describes a message field.
+type FieldDescriptor struct {
+	Name string
+	Tag  uint16
+	Type *TypeDescriptor
+
+	Annotations Annotations
+}
+
+// Field returns a field by its name or nil.
+func (d *MessageDescriptor) Field(name string) *FieldDescriptor {
+	for _, f := range d.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// FieldByTag returns a field by its tag or nil.
+func (d *MessageDescriptor) FieldByTag(tag uint16) *FieldDescriptor {
+	for _, f := range d.Fields {
+		if f.Tag == tag {
+			return f
+		}
+	}
+	return nil
+}
+
+// Oneof returns a oneof by its name or nil.
+func (d *MessageDescriptor) Oneof(name string) *OneofDescriptor {
+	for _, o := range d.Oneofs {
+		if o.Name == name {
+			return o
+		}
+	}
+	return nil
+}
+
+// ValidateOneofs returns an error if a message has more than one field set in any oneof.
+// Messages without oneof fields are valid, they may be written by newer schemas
+// which added oneof fields unknown to this schema.
+func (d *MessageDescriptor) ValidateOneofs(msg Message) error {
+	for _, o := range d.Oneofs {
+		if err := o.Validate(msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateOneofsWriter fails a message writer if it does not have exactly one field set
+// in each oneof, and returns the error.
+func (d *MessageDescriptor) ValidateOneofsWriter(w MessageWriter) error {
+	for _, o := range d.Oneofs {
+		if err := o.ValidateWriter(w); err != nil {
+			return w.Fail(err)
+		}
+	}
+	return nil
+}
+
+// DescribeError returns a decode error with field names resolved using the descriptor,
+// or the error itself if it is not a decode error.
+func (d *MessageDescriptor) DescribeError(err error) error {
+	var e *DecodeError
+	if !errors.As(err, &e) {
+		return err
+	}
+
+	e1 := *e
+	e1.Path = make([]DecodePathElem, len(e.Path))
+	copy(e1.Path, e.Path)
+
+	typ := &TypeDescriptor{Kind: KindMessage, Message: d}
+	for i, elem := range e1.Path {
+		if typ == nil {
+			break
+		}
+
+		switch {
+		case !elem.Field && !elem.Entry && typ.Kind == KindList:
+			typ = typ.Element
+
+		case elem.Entry && typ.Kind == KindMap:
+			typ = typ.Element
+
+		case elem.Field && typ.Kind == KindMessage && typ.Message != nil:
+			field := typ.Message.FieldByTag(elem.Tag)
+			if field == nil {
+				typ = nil
+				continue
+			}
+
+			e1.Path[i].Name = field.Name
+			typ = field.Type
+
+		default:
+			typ = nil
+		}
+	}
+	return &e1
+}
+
+// OneofDescriptor
+
+// OneofDescriptor describes a set of mutually exclusive message fields.
+type OneofDescriptor struct {
+	Name   string
+	Fields []*FieldDescriptor // ordered as in the schema
+}
+
+// Which returns a set oneof field or nil.
+func (d *OneofDescriptor) Which(msg Message) *FieldDescriptor {
+	for _, f := range d.Fields {
+		if msg.HasField(f.Tag) {
+			return f
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if a message has more than one oneof field set.
+func (d *OneofDescriptor) Validate(msg Message) error {
+	return d.validate(msg.HasField, false)
+}
+
+// ValidateWriter returns an error if a message writer does not have exactly one oneof field set.
+func (d *OneofDescriptor) ValidateWriter(w MessageWriter) error {
+	return d.validate(w.HasField, true)
+}
+
+// CheckWriter fails a message writer if a oneof field other than the tag is already set,
+// and returns the error. Generated writers call it before setting a oneof field.
+func (d *OneofDescriptor) CheckWriter(w MessageWriter, tag uint16) error {
+	for _, f := range d.Fields {
+		if f.Tag == tag || !w.HasField(f.Tag) {
+			continue
+		}
+
+		name := any(tag)
+		if field := d.field(tag); field != nil {
+			name = field.Name
+		}
+		return w.Fail(fmt.Errorf("oneof %v: multiple fields set, %v and %v", d.Name, f.Name, name))
+	}
+	return nil
+}
+
+func (d *OneofDescriptor) field(tag uint16) *FieldDescriptor {
+	for _, f := range d.Fields {
+		if f.Tag == tag {
+			return f
+		}
+	}
+	return nil
+}
+
+func (d *OneofDescriptor) validate(has func(tag uint16) bool, required bool) error {
+	var set *FieldDescriptor
+	for _, f := range d.Fields {
+		if !has(f.Tag) {
+			continue
+		}
+		if set != nil {
+			return fmt.Errorf("oneof %v: multiple fields set, %v and %v", d.Name, set.Name, f.Name)
+		}
+		set = f
+	}
+	if set == nil && required {
+		return fmt.Errorf("oneof %v: no field set", d.Name)
+	}
+	return nil
+}
+
+// StructDescriptor
+
+// StructDescriptor describes a generated struct.
+type StructDescriptor struct {
+	Package string
+	Name    string
+	Fields  []*StructFieldDescriptor // ordered as in the schema
+
+	Annotations Annotations
+}
+
+// StructFieldDescriptor describes a struct field.
+type StructFieldDescriptor struct {
+	Name string
+	Type *TypeDescriptor
+
+	Annotations Annotations
+}
+
+// Field returns a field by its name or nil.
+func (d *StructDescriptor) Field(name string) *StructFieldDescriptor {
+	for _, f := range d.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// ServiceDescriptor
+
+// ServiceDescriptor describes a generated service.
+type ServiceDescriptor struct {
+	Package string
+	Name    string
+	Sub     bool // subservice
+	Methods []*MethodDescriptor
+
+	Annotations Annotations
+}
+
+// MethodDescriptor describes a service method.
+type MethodDescriptor struct {
+	Name   string
+	Oneway bool
+
+	Request    *TypeDescriptor // request message or nil
+	Response   *TypeDescriptor // response message or nil
+	Subservice *TypeDescriptor // subservice or nil
+
+	ChannelIn  *TypeDescriptor // channel input message or nil
+	ChannelOut *TypeDescriptor // channel output message or nil
+
+	Annotations Annotations
+}
+
+// Method returns a method by its name or nil.
+func (d *ServiceDescriptor) Method(name string) *MethodDescriptor {
+	for _, m := range d.Methods {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"io"
+
+	"github.com/basecomplextech/spec/internal/types"
+)
+
+// Dump writes a schema-less tree representation of a value to a writer.
+//
+// Each line contains a value type, its byte range in the input, size, and a decoded value,
+// messages are printed with field tags, lists and maps with element indices. Decoding errors are
+// printed inline with their byte offsets, the method returns the first decoding error.
+func Dump(b []byte, w io.Writer) error {
+	return types.Dump(b, w)
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDumpMessage(t *testing.T) []byte {
+	w := NewMessageWriter()
+	w.Field(1).Int32(100)
+	w.Field(2).String("hello")
+
+	list := w.Field(3).List()
+	list.Bool(true)
+	list.Uint16(2)
+	if err := list.End(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestDump__should_dump_value_tree(t *testing.T) {
+	b := testDumpMessage(t)
+
+	buf := &bytes.Buffer{}
+	if err := Dump(b, buf); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := fmt.Sprintf(`message [0:%d] size=%d fields=3
+  field 1: int32 [0:2] size=2 value=100
+  field 2: string [2:10] size=8 len=5 value="hello"
+  field 3: list [10:20] size=10 len=2
+    [0]: true [10:11] size=1 value=true
+    [1]: uint16 [11:13] size=2 value=2
+`, len(b), len(b))
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestDump__should_return_error_with_offset(t *testing.T) {
+	b := testDumpMessage(t)
+	b[1] = 0xff // corrupt int32 type
+
+	buf := &bytes.Buffer{}
+	err := Dump(b, buf)
+	require.Error(t, err)
+
+	assert.Contains(t, buf.String(), "field 1: error at offset 1")
+	assert.Contains(t, buf.String(), `field 2: string [2:10]`)
+}
+
+func TestValue_Format__should_format_value_as_tree(t *testing.T) {
+	b := testDumpMessage(t)
+	v := OpenValue(b)
+
+	s := fmt.Sprintf("%v", v)
+	assert.Contains(t, s, "field 1: int32")
+
+	s = fmt.Sprintf("%x", v)
+	assert.Equal(t, fmt.Sprintf("%x", b), s)
+}
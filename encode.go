@@ -27,6 +27,7 @@ var (
 	EncodeInt64 = encode.EncodeInt64
 
 	EncodeListTable    = encode.EncodeListTable
+	EncodeMapTable     = encode.EncodeMapTable
 	EncodeMessageTable = encode.EncodeMessageTable
 
 	EncodeString = encode.EncodeString
@@ -43,6 +43,9 @@ const (
 	TypeBigMessage = format.TypeBigMessage
 
 	TypeStruct = format.TypeStruct
+
+	TypeMap    = format.TypeMap
+	TypeBigMap = format.TypeBigMap
 )
 
 type (
@@ -59,4 +62,7 @@ type (
 
 	// MessageTable is a table of message fields ordered by tags.
 	MessageTable = format.MessageTable
+
+	// MapTable is a table of map entries ordered by keys.
+	MapTable = format.MapTable
 )
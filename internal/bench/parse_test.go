@@ -15,7 +15,7 @@ import (
 
 func BenchmarkParseMessage(b *testing.B) {
 	obj := pkg1.TestObject(b)
-	msg, err := obj.Write(pkg1.NewMessageWriter())
+	msg, err := obj.Marshal()
 	if err != nil {
 		b.Fatal(err)
 	}
@@ -47,7 +47,7 @@ func BenchmarkParseMessage(b *testing.B) {
 
 func BenchmarkReadMessage(b *testing.B) {
 	obj := pkg1.TestObject(b)
-	msg, err := obj.Write(pkg1.NewMessageWriter())
+	msg, err := obj.Marshal()
 	if err != nil {
 		b.Fatal(err)
 	}
@@ -114,7 +114,7 @@ func BenchmarkReadMessage(b *testing.B) {
 
 func BenchmarkNewMessage(b *testing.B) {
 	obj := pkg1.TestObject(b)
-	msg, err := obj.Write(pkg1.NewMessageWriter())
+	msg, err := obj.Marshal()
 	if err != nil {
 		b.Fatal(err)
 	}
@@ -156,7 +156,7 @@ func BenchmarkJSON_Unmarshal(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
 
-	msg1 := &pkg1.Object{}
+	msg1 := &pkg1.MessageObject{}
 	for i := 0; i < b.N; i++ {
 		if err := json.Unmarshal(data, msg1); err != nil {
 			b.Fatal(err)
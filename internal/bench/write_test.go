@@ -14,7 +14,7 @@ import (
 )
 
 func BenchmarkWrite_Small(b *testing.B) {
-	obj := pkg1.TestSubobject(1)
+	obj := pkg1.TestSubmessageObject(1)
 	buf := buffer.NewSize(4096)
 
 	b.ReportAllocs()
@@ -25,7 +25,10 @@ func BenchmarkWrite_Small(b *testing.B) {
 		buf.Reset()
 		w := pkg1.NewSubmessageWriterBuffer(buf)
 
-		data, err := obj.Write(w)
+		if err := obj.MarshalTo(w.Unwrap()); err != nil {
+			b.Fatal(err)
+		}
+		data, err := w.Build()
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -56,7 +59,10 @@ func BenchmarkWrite_Large(b *testing.B) {
 		buf.Reset()
 		w := pkg1.NewMessageWriterBuffer(buf)
 
-		data, err := obj.Write(w)
+		if err := obj.MarshalTo(w.Unwrap()); err != nil {
+			b.Fatal(err)
+		}
+		data, err := w.Build()
 		if err != nil {
 			b.Fatal(err)
 		}
@@ -78,7 +84,7 @@ func BenchmarkWrite_Large(b *testing.B) {
 // JSON
 
 func BenchmarkJSON_Marshal_Small(b *testing.B) {
-	obj := pkg1.TestSubobject(1)
+	obj := pkg1.TestSubmessageObject(1)
 
 	data, err := json.Marshal(obj)
 	if err != nil {
@@ -0,0 +1,202 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package canonical implements canonical text forms of well-known types,
+// they are shared by the wellknown package and canonical JSON.
+//
+//	timestamp  RFC 3339 in UTC with up to 9 fractional digits, i.e. "2025-01-02T03:04:05.5Z"
+//	duration   seconds with up to 9 fractional digits, i.e. "1.5s", "-0.000000001s"
+//	decimal    plain decimal, i.e. "-123.4500", the scale is preserved
+//	uuid       lowercase hex groups, i.e. "01234567-89ab-cdef-0123-456789abcdef"
+package canonical
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/bin"
+)
+
+// MaxDecimalScale is the max number of decimal fractional digits.
+const MaxDecimalScale = 18
+
+// Timestamp
+
+// FormatTimestamp returns a canonical timestamp string.
+func FormatTimestamp(seconds int64, nanos int32) string {
+	return time.Unix(seconds, int64(nanos)).UTC().Format(time.RFC3339Nano)
+}
+
+// ParseTimestamp parses an RFC 3339 timestamp with any offset.
+func ParseTimestamp(s string) (seconds int64, nanos int32, err error) {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+	return t.Unix(), int32(t.Nanosecond()), nil
+}
+
+// Duration
+
+// FormatDuration returns a canonical duration string.
+func FormatDuration(nanos int64) string {
+	b := make([]byte, 0, 32)
+
+	u := uint64(nanos)
+	if nanos < 0 {
+		b = append(b, '-')
+		u = -u
+	}
+
+	b = strconv.AppendUint(b, u/1e9, 10)
+	if frac := u % 1e9; frac != 0 {
+		s := fmt.Sprintf("%09d", frac)
+		b = append(b, '.')
+		b = append(b, strings.TrimRight(s, "0")...)
+	}
+
+	b = append(b, 's')
+	return string(b)
+}
+
+// ParseDuration parses a canonical duration or a Go duration, i.e. "1.5s" or "1h30m".
+func ParseDuration(s string) (int64, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return int64(d), nil
+}
+
+// Decimal
+
+// FormatDecimal returns a canonical decimal string of value * 10^-scale.
+//
+// Scales outside [0, MaxDecimalScale] are formatted in the exponent form, i.e. "123e-20",
+// which is exact but is not accepted by ParseDecimal.
+func FormatDecimal(value int64, scale int32) string {
+	if scale < 0 || scale > MaxDecimalScale {
+		return strconv.FormatInt(value, 10) + "e" + strconv.FormatInt(-int64(scale), 10)
+	}
+
+	u := uint64(value)
+	neg := value < 0
+	if neg {
+		u = -u
+	}
+
+	digits := strconv.FormatUint(u, 10)
+	if n := int(scale) + 1 - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+
+	b := make([]byte, 0, len(digits)+2)
+	if neg {
+		b = append(b, '-')
+	}
+
+	point := len(digits) - int(scale)
+	b = append(b, digits[:point]...)
+	if scale > 0 {
+		b = append(b, '.')
+		b = append(b, digits[point:]...)
+	}
+	return string(b)
+}
+
+// ParseDecimal parses a plain decimal, i.e. "-123.4500", returns its value and scale.
+func ParseDecimal(s string) (value int64, scale int32, err error) {
+	value, scale, err = parseDecimal(s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid decimal %q: %w", s, err)
+	}
+	return value, scale, nil
+}
+
+func parseDecimal(s string) (int64, int32, error) {
+	digits := s
+	neg := false
+
+	switch {
+	case strings.HasPrefix(digits, "-"):
+		neg = true
+		digits = digits[1:]
+	case strings.HasPrefix(digits, "+"):
+		digits = digits[1:]
+	}
+
+	intPart, fracPart, point := strings.Cut(digits, ".")
+	if intPart == "" || (point && fracPart == "") {
+		return 0, 0, errors.New("expected digits")
+	}
+	if len(fracPart) > MaxDecimalScale {
+		return 0, 0, fmt.Errorf("scale exceeds %d", MaxDecimalScale)
+	}
+
+	var u uint64
+	for _, c := range intPart + fracPart {
+		if c < '0' || c > '9' {
+			return 0, 0, fmt.Errorf("unexpected character %q", c)
+		}
+
+		d := uint64(c - '0')
+		if u > (math.MaxUint64-d)/10 {
+			return 0, 0, errors.New("value out of range")
+		}
+		u = u*10 + d
+	}
+
+	switch {
+	case neg && u > 1<<63:
+		return 0, 0, errors.New("value out of range")
+	case !neg && u > math.MaxInt64:
+		return 0, 0, errors.New("value out of range")
+	}
+
+	value := int64(u)
+	if neg {
+		value = int64(-u)
+	}
+	return value, int32(len(fracPart)), nil
+}
+
+// UUID
+
+// FormatUUID returns a canonical UUID string.
+func FormatUUID(v bin.Bin128) string {
+	var b [bin.Len128]byte
+	v.MarshalTo(b[:])
+
+	buf := make([]byte, 36)
+	hex.Encode(buf[0:8], b[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], b[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], b[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], b[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], b[10:])
+	return string(buf)
+}
+
+// ParseUUID parses a UUID in the hex groups form, letters may be in any case.
+func ParseUUID(s string) (bin.Bin128, error) {
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return bin.Bin128{}, fmt.Errorf("invalid uuid %q", s)
+	}
+
+	h := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+
+	var b [bin.Len128]byte
+	if _, err := hex.Decode(b[:], []byte(h)); err != nil {
+		return bin.Bin128{}, fmt.Errorf("invalid uuid %q", s)
+	}
+	return bin.New128(b), nil
+}
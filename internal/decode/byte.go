@@ -6,7 +6,6 @@ package decode
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/basecomplextech/spec/internal/format"
 )
@@ -23,7 +22,7 @@ func DecodeByte(b []byte) (byte, int, error) {
 		return 0, 0, errors.New("decode byte: invalid data")
 	}
 	if typ != format.TypeByte {
-		return 0, 0, fmt.Errorf("decode byte: invalid type, type=%v", typ)
+		return 0, 0, errInvalidType("byte", format.TypeByte, typ, b)
 	}
 
 	end := len(b) - 2
@@ -6,7 +6,6 @@ package decode
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/basecomplextech/spec/internal/format"
 )
@@ -23,7 +22,7 @@ func DecodeBytes(b []byte) (_ format.Bytes, size int, err error) {
 		return
 	}
 	if typ != format.TypeBytes {
-		err = fmt.Errorf("decode bytes: invalid type, type=%v", typ)
+		err = errInvalidType("bytes", format.TypeBytes, typ, b)
 		return
 	}
 
@@ -0,0 +1,140 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package decode
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/format"
+)
+
+// Error is a decode error with a byte offset, expected and found types, and a logical path.
+type Error struct {
+	Err error // underlying error
+
+	// Offset is an offset of the failed value type byte from the start of the input.
+	// Values are encoded in reverse, so the type byte is the last byte of a value.
+	Offset int
+
+	Expected format.Type // expected type or undefined
+	Found    format.Type // found type or undefined
+
+	// Path is a path from the root value to the failed value.
+	Path []PathElem
+}
+
+// PathElem is a decode error path element, a message field, a list element or a map entry.
+type PathElem struct {
+	Field bool   // message field or list element
+	Entry bool   // map entry
+	Tag   uint16 // message field tag
+	Index int    // list element or map entry index
+	Name  string // optional field name
+}
+
+// Error returns an error string.
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Err.Error())
+
+	if e.Expected != format.TypeUndefined {
+		b.WriteString(", expected=")
+		b.WriteString(e.Expected.String())
+	}
+	if e.Found != format.TypeUndefined {
+		b.WriteString(", found=")
+		b.WriteString(e.Found.String())
+	}
+
+	b.WriteString(", offset=")
+	b.WriteString(strconv.Itoa(e.Offset))
+
+	if len(e.Path) > 0 {
+		b.WriteString(", path=")
+		b.WriteString(e.PathString())
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// PathString returns a path string, i.e. "field 63 -> list[4] -> field 2".
+func (e *Error) PathString() string {
+	ss := make([]string, 0, len(e.Path))
+	for _, elem := range e.Path {
+		ss = append(ss, elem.String())
+	}
+	return strings.Join(ss, " -> ")
+}
+
+// String returns a field name, "field N", "list[N]" or "map[N]".
+func (p PathElem) String() string {
+	switch {
+	case p.Entry:
+		return fmt.Sprintf("map[%d]", p.Index)
+	case !p.Field:
+		return fmt.Sprintf("list[%d]", p.Index)
+	case p.Name != "":
+		return p.Name
+	}
+	return fmt.Sprintf("field %d", p.Tag)
+}
+
+// Wrapping
+
+// WrapError returns a decode error at an offset, or the error itself if it is a decode error.
+func WrapError(err error, offset int) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+
+	if offset < 0 {
+		offset = 0
+	}
+	return &Error{Err: err, Offset: offset}
+}
+
+// WrapFieldError returns a decode error of a message field, shifts its offset by delta.
+func WrapFieldError(err error, tag uint16, delta int) *Error {
+	e := WrapError(err, 0)
+	e.Offset += delta
+	e.Path = append([]PathElem{{Field: true, Tag: tag}}, e.Path...)
+	return e
+}
+
+// WrapElementError returns a decode error of a list element, shifts its offset by delta.
+func WrapElementError(err error, index int, delta int) *Error {
+	e := WrapError(err, 0)
+	e.Offset += delta
+	e.Path = append([]PathElem{{Index: index}}, e.Path...)
+	return e
+}
+
+// WrapEntryError returns a decode error of a map entry, shifts its offset by delta.
+func WrapEntryError(err error, index int, delta int) *Error {
+	e := WrapError(err, 0)
+	e.Offset += delta
+	e.Path = append([]PathElem{{Entry: true, Index: index}}, e.Path...)
+	return e
+}
+
+// internal
+
+// errInvalidType returns an invalid type error at the type byte of b.
+func errInvalidType(name string, expected format.Type, found format.Type, b []byte) error {
+	return &Error{
+		Err:      fmt.Errorf("decode %v: invalid type", name),
+		Offset:   max(len(b)-1, 0),
+		Expected: expected,
+		Found:    found,
+	}
+}
@@ -6,7 +6,6 @@ package decode
 
 import (
 	"errors"
-	"fmt"
 	"math"
 
 	"github.com/basecomplextech/baselibrary/encoding/compactint"
@@ -58,7 +57,7 @@ func DecodeInt16(b []byte) (int16, int, error) {
 		return int16(v), n, nil
 	}
 
-	return 0, 0, fmt.Errorf("decode int16: invalid type, type=%v", typ)
+	return 0, 0, errInvalidType("int16", format.TypeInt16, typ, b)
 }
 
 func DecodeInt32(b []byte) (int32, int, error) {
@@ -99,7 +98,7 @@ func DecodeInt32(b []byte) (int32, int, error) {
 		return int32(v), n, nil
 	}
 
-	return 0, 0, fmt.Errorf("decode int32: invalid type, type=%v", typ)
+	return 0, 0, errInvalidType("int32", format.TypeInt32, typ, b)
 }
 
 func DecodeInt64(b []byte) (int64, int, error) {
@@ -131,5 +130,5 @@ func DecodeInt64(b []byte) (int64, int, error) {
 		return int64(v), n, nil
 	}
 
-	return 0, 0, fmt.Errorf("decode int64: invalid type, type=%v", typ)
+	return 0, 0, errInvalidType("int64", format.TypeInt64, typ, b)
 }
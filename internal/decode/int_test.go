@@ -93,3 +93,20 @@ func TestDecodeInt64__should_decode_int64_from_int32(t *testing.T) {
 	assert.Equal(t, n, b.Len())
 	assert.Equal(t, int64(math.MaxInt32), v)
 }
+
+func TestDecodeInt16__should_return_decode_error_on_invalid_type(t *testing.T) {
+	b := buffer.New()
+	encode.EncodeString(b, "hello")
+	p := b.Bytes()
+
+	_, _, err := DecodeInt16(p)
+
+	e, ok := err.(*Error)
+	if !assert.True(t, ok) {
+		return
+	}
+	assert.Equal(t, format.TypeInt16, e.Expected)
+	assert.Equal(t, format.TypeString, e.Found)
+	assert.Equal(t, len(p)-1, e.Offset)
+	assert.Equal(t, "decode int16: invalid type, expected=int16, found=string, offset=7", e.Error())
+}
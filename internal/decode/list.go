@@ -6,7 +6,6 @@ package decode
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/basecomplextech/spec/internal/format"
 )
@@ -24,7 +23,7 @@ func DecodeListTable(b []byte) (_ format.ListTable, size int, err error) {
 		return
 	}
 	if typ != format.TypeList && typ != format.TypeBigList {
-		err = fmt.Errorf("decode list: invalid type, type=%v", typ)
+		err = errInvalidType("list", format.TypeList, typ, b)
 		return
 	}
 
@@ -0,0 +1,173 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package decode
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/basecomplextech/spec/internal/format"
+)
+
+func DecodeMapTable(b []byte) (_ format.MapTable, size int, err error) {
+	if len(b) == 0 {
+		return
+	}
+
+	// Decode type
+	typ, n := decodeType(b)
+	if n < 0 {
+		n = 0
+		err = errors.New("decode map: invalid data")
+		return
+	}
+	if typ != format.TypeMap && typ != format.TypeBigMap {
+		err = errInvalidType("map", format.TypeMap, typ, b)
+		return
+	}
+
+	// Start
+	size = n
+	end := len(b) - n
+	big := typ == format.TypeBigMap
+
+	// Table size
+	tableSize, n := decodeSize(b[:end])
+	if n < 0 {
+		err = errors.New("decode map: invalid table size")
+		return
+	}
+	end -= n
+	size += n
+
+	// Data size
+	dataSize, n := decodeSize(b[:end])
+	if n < 0 {
+		err = errors.New("decode map: invalid data size")
+		return
+	}
+	end -= n
+	size += n
+
+	// Table
+	table, err := decodeMapTable(b[:end], tableSize, big)
+	if err != nil {
+		return
+	}
+	end -= int(tableSize) + int(dataSize)
+	size += int(tableSize)
+
+	// Data
+	if end < 0 {
+		err = errors.New("decode map: invalid data")
+		return
+	}
+	size += int(dataSize)
+
+	// Done
+	t := format.NewMapTable(table, dataSize, big)
+	return t, size, nil
+}
+
+// CompareKeys compares two encoded map keys, integers are compared by values,
+// strings and bins by bytes.
+func CompareKeys(a, b []byte) (int, error) {
+	typ, _, err := DecodeType(a)
+	if err != nil {
+		return 0, err
+	}
+
+	switch typ {
+	case format.TypeInt16, format.TypeInt32, format.TypeInt64:
+		a1, _, err := DecodeInt64(a)
+		if err != nil {
+			return 0, err
+		}
+		b1, _, err := DecodeInt64(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareOrdered(a1, b1), nil
+
+	case format.TypeUint16, format.TypeUint32, format.TypeUint64:
+		a1, _, err := DecodeUint64(a)
+		if err != nil {
+			return 0, err
+		}
+		b1, _, err := DecodeUint64(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareOrdered(a1, b1), nil
+
+	case format.TypeString:
+		a1, _, err := DecodeString(a)
+		if err != nil {
+			return 0, err
+		}
+		b1, _, err := DecodeString(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareOrdered(a1, b1), nil
+
+	case format.TypeBin64, format.TypeBin128, format.TypeBin256:
+		_, n, err := DecodeTypeSize(a)
+		if err != nil {
+			return 0, err
+		}
+		_, m, err := DecodeTypeSize(b)
+		if err != nil {
+			return 0, err
+		}
+		return bytes.Compare(a[len(a)-n:], b[len(b)-m:]), nil
+	}
+
+	return 0, errInvalidKeyType(typ, a)
+}
+
+// private
+
+func decodeMapTable(b []byte, size uint32, big bool) (_ []byte, err error) {
+	// Entry size
+	entrySize := format.MapEntrySize_Small
+	if big {
+		entrySize = format.MapEntrySize_Big
+	}
+
+	// Check offset
+	start := len(b) - int(size)
+	if start < 0 {
+		err = errors.New("decode map: invalid table")
+		return
+	}
+
+	// Check divisible
+	if size%uint32(entrySize) != 0 {
+		err = errors.New("decode map: invalid table")
+		return
+	}
+
+	table := b[start:]
+	return table, nil
+}
+
+func compareOrdered[T int64 | uint64 | format.String](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func errInvalidKeyType(typ format.Type, b []byte) error {
+	return &Error{
+		Err:    errors.New("decode map: invalid key type"),
+		Offset: max(len(b)-1, 0),
+		Found:  typ,
+	}
+}
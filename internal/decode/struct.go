@@ -6,7 +6,6 @@ package decode
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/basecomplextech/spec/internal/format"
 )
@@ -23,7 +22,7 @@ func DecodeStruct(b []byte) (dataSize int, size int, err error) {
 		return
 	}
 	if typ != format.TypeStruct {
-		err = fmt.Errorf("decode struct: invalid type, type=%v", typ)
+		err = errInvalidType("struct", format.TypeStruct, typ, b)
 		return
 	}
 
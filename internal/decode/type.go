@@ -154,6 +154,32 @@ func DecodeTypeSize(b []byte) (format.Type, int, error) {
 		}
 		return t, size, nil
 
+	// Map
+
+	case format.TypeMap, format.TypeBigMap:
+		size := n
+
+		// Table size
+		tableSize, m := decodeSize(b[:end])
+		if m < 0 {
+			return 0, 0, errors.New("decode map: invalid table size")
+		}
+		end -= m
+		size += m + int(tableSize)
+
+		// Data size
+		dataSize, m := decodeSize(b[:end])
+		if m < 0 {
+			return 0, 0, errors.New("decode map: invalid data size")
+		}
+		end -= m
+		size += m + int(dataSize)
+
+		if len(b) < size {
+			return 0, 0, errors.New("decode map: invalid data")
+		}
+		return t, size, nil
+
 	// Message
 
 	case format.TypeMessage, format.TypeBigMessage:
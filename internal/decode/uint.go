@@ -6,7 +6,6 @@ package decode
 
 import (
 	"errors"
-	"fmt"
 	"math"
 
 	"github.com/basecomplextech/baselibrary/encoding/compactint"
@@ -52,7 +51,7 @@ func DecodeUint16(b []byte) (uint16, int, error) {
 		return uint16(v), n, nil
 	}
 
-	return 0, 0, fmt.Errorf("decode uint32: invalid type, type=%v", typ)
+	return 0, 0, errInvalidType("uint16", format.TypeUint16, typ, b)
 }
 
 func DecodeUint32(b []byte) (uint32, int, error) {
@@ -89,7 +88,7 @@ func DecodeUint32(b []byte) (uint32, int, error) {
 		return uint32(v), n, nil
 	}
 
-	return 0, 0, fmt.Errorf("decode uint32: invalid type, type=%v", typ)
+	return 0, 0, errInvalidType("uint32", format.TypeUint32, typ, b)
 }
 
 func DecodeUint64(b []byte) (uint64, int, error) {
@@ -121,5 +120,5 @@ func DecodeUint64(b []byte) (uint64, int, error) {
 		return v, n, nil
 	}
 
-	return 0, 0, fmt.Errorf("decode uint64: invalid type, type=%v", typ)
+	return 0, 0, errInvalidType("uint64", format.TypeUint64, typ, b)
 }
@@ -0,0 +1,78 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package encode
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/format"
+)
+
+// EncodeMapTable encodes a map table, the entries must be sorted by keys.
+func EncodeMapTable(b buffer.Buffer, dataSize int, table []format.MapEntry) (int, error) {
+	if dataSize > format.MaxSize {
+		return 0, fmt.Errorf("encode: map too large, max size=%d, actual size=%d", format.MaxSize, dataSize)
+	}
+
+	// format.Type
+	big := format.IsBigMap(table)
+	type_ := format.TypeMap
+	if big {
+		type_ = format.TypeBigMap
+	}
+
+	// Write table
+	tableSize, err := encodeMapTable(b, table, big)
+	if err != nil {
+		return int(tableSize), err
+	}
+	n := tableSize
+
+	// Write data size
+	n += encodeSize(b, uint32(dataSize))
+
+	// Write table size and type
+	n += encodeSizeType(b, uint32(tableSize), type_)
+	return n, nil
+}
+
+// private
+
+func encodeMapTable(b buffer.Buffer, table []format.MapEntry, big bool) (int, error) {
+	// Entry size
+	entrySize := format.MapEntrySize_Small
+	if big {
+		entrySize = format.MapEntrySize_Big
+	}
+
+	// Check table size
+	size := len(table) * entrySize
+	if size > format.MaxSize {
+		return 0, fmt.Errorf("encode: map table too large, max size=%d, actual size=%d", format.MaxSize, size)
+	}
+
+	// Write table
+	p := b.Grow(size)
+	off := 0
+
+	// Put entries
+	for _, entry := range table {
+		q := p[off : off+entrySize]
+
+		if big {
+			binary.BigEndian.PutUint32(q, entry.KeyOffset)
+			binary.BigEndian.PutUint32(q[4:], entry.Offset)
+		} else {
+			binary.BigEndian.PutUint16(q, uint16(entry.KeyOffset))
+			binary.BigEndian.PutUint16(q[2:], uint16(entry.Offset))
+		}
+
+		off += entrySize
+	}
+
+	return size, nil
+}
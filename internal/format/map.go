@@ -0,0 +1,167 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package format
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+const (
+	MapEntrySize_Small = 2 + 2 // key(2) + value(2)
+	MapEntrySize_Big   = 4 + 4 // key(4) + value(4)
+)
+
+// MapTable is a table of map entries ordered by keys.
+// The serialization format depends on whether the map is big or small, see IsBigMap().
+//
+//	        entry0                entry1                entry2
+//	+---------------------+---------------------+---------------------+
+//	|   key0  |  value0   |   key1  |  value1   |   key2  |  value2   |
+//	+---------------------+---------------------+---------------------+
+type MapTable struct {
+	table mapTable
+
+	data uint32 // data size
+	big  bool   // small/big table format
+}
+
+// MapEntry specifies key and value end offsets in a map byte array.
+//
+//	+-------------------+-------------------+
+//	|  key offset(2/4)  |    offset(2/4)    |
+//	+-------------------+-------------------+
+type MapEntry struct {
+	KeyOffset uint32 // key end offset
+	Offset    uint32 // value end offset
+}
+
+// IsBigMap returns true if table count > uint8 or any offset > uint16.
+func IsBigMap(entries []MapEntry) bool {
+	ln := len(entries)
+	if ln == 0 {
+		return false
+	}
+
+	// Len > uint8
+	if ln > math.MaxUint8 {
+		return true
+	}
+
+	// Or offset > uint16, entries are sorted by keys, not by offsets
+	for _, entry := range entries {
+		if entry.KeyOffset > math.MaxUint16 || entry.Offset > math.MaxUint16 {
+			return true
+		}
+	}
+	return false
+}
+
+// MapTable
+
+func NewMapTable(table mapTable, data uint32, big bool) MapTable {
+	return MapTable{
+		table: table,
+		data:  data,
+		big:   big,
+	}
+}
+
+// Len returns the number of entries in the table.
+func (t MapTable) Len() int {
+	return t.table.len(t.big)
+}
+
+// DataSize returns the size of the map data.
+func (t MapTable) DataSize() uint32 {
+	return t.data
+}
+
+// Entries parses the table and returns a slice of entries.
+func (t MapTable) Entries() []MapEntry {
+	n := t.Len()
+
+	result := make([]MapEntry, 0, n)
+	for i := 0; i < n; i++ {
+		entry, ok := t.Entry(i)
+		if !ok {
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// Entry returns an entry by an index or false.
+func (t MapTable) Entry(i int) (MapEntry, bool) {
+	if t.big {
+		return t.table.entry_big(i)
+	} else {
+		return t.table.entry_small(i)
+	}
+}
+
+// internal
+
+type mapTable []byte
+
+// len returns the number of entries in the table.
+func (t mapTable) len(big bool) int {
+	var size int
+	if big {
+		size = MapEntrySize_Big
+	} else {
+		size = MapEntrySize_Small
+	}
+	return len(t) / size
+}
+
+func (t mapTable) entry_big(i int) (e MapEntry, ok bool) {
+	size := MapEntrySize_Big
+	n := len(t) / size
+
+	// Check count
+	switch {
+	case i < 0:
+		return
+	case i >= n:
+		return
+	}
+
+	off := i * size
+	b := t[off : off+size]
+
+	e = MapEntry{
+		KeyOffset: binary.BigEndian.Uint32(b),
+		Offset:    binary.BigEndian.Uint32(b[4:]),
+	}
+
+	ok = true
+	return
+}
+
+func (t mapTable) entry_small(i int) (e MapEntry, ok bool) {
+	size := MapEntrySize_Small
+	n := len(t) / size
+
+	// Check count
+	switch {
+	case i < 0:
+		return
+	case i >= n:
+		return
+	}
+
+	off := i * size
+	b := t[off : off+size]
+
+	e = MapEntry{
+		KeyOffset: uint32(binary.BigEndian.Uint16(b)),
+		Offset:    uint32(binary.BigEndian.Uint16(b[2:])),
+	}
+
+	ok = true
+	return
+}
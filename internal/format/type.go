@@ -49,6 +49,9 @@ const (
 	TypeBigMessage Type = 81
 
 	TypeStruct Type = 90
+
+	TypeMap    Type = 100
+	TypeBigMap Type = 101
 )
 
 func (t Type) Check() error {
@@ -82,7 +85,10 @@ func (t Type) Check() error {
 		TypeMessage,
 		TypeBigMessage,
 
-		TypeStruct:
+		TypeStruct,
+
+		TypeMap,
+		TypeBigMap:
 		return nil
 	}
 
@@ -141,6 +147,11 @@ func (t Type) String() string {
 
 	case TypeStruct:
 		return "struct"
+
+	case TypeMap:
+		return "map"
+	case TypeBigMap:
+		return "big_map"
 	}
 
 	return strconv.Itoa(int(t))
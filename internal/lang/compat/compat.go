@@ -0,0 +1,293 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package compat
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// Change is a wire change between two package versions.
+type Change struct {
+	Path string // definition path, i.e. "Message.field" or "Service.method"
+	Text string // change description
+	Note bool   // wire compatible change, i.e. a renamed field
+}
+
+// String returns a change string, i.e. "Message.field: type changed from int64 to int32".
+func (c Change) String() string {
+	return fmt.Sprintf("%v: %v", c.Path, c.Text)
+}
+
+// Check compares an old and a new package and returns breaking changes and notes.
+//
+// Fields can be added, removed fields must be reserved, reserved tags cannot be reused,
+// field types can only be widened as decoding allows, i.e. int16 to int32 to int64,
+// uint16 to uint32 to uint64, float32 to float64. Renamed fields are returned as notes.
+func Check(old *model.Package, new *model.Package) []Change {
+	c := &checker{}
+	c.pkg(old, new)
+	return c.changes
+}
+
+// internal
+
+type checker struct {
+	changes []Change
+}
+
+func (c *checker) addf(path string, format string, args ...any) {
+	change := Change{
+		Path: path,
+		Text: fmt.Sprintf(format, args...),
+	}
+	c.changes = append(c.changes, change)
+}
+
+func (c *checker) notef(path string, format string, args ...any) {
+	change := Change{
+		Path: path,
+		Text: fmt.Sprintf(format, args...),
+		Note: true,
+	}
+	c.changes = append(c.changes, change)
+}
+
+// package
+
+func (c *checker) pkg(old *model.Package, new *model.Package) {
+	olds := definitions(old)
+	news := definitions(new)
+
+	names := make([]string, 0, len(olds))
+	for name := range olds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		def0 := olds[name]
+		def1, ok := news[name]
+		if !ok {
+			c.addf(name, "%v removed", def0.Type)
+			continue
+		}
+
+		c.def(def0, def1)
+	}
+}
+
+func (c *checker) def(old *model.Definition, new *model.Definition) {
+	if old.Type != new.Type {
+		c.addf(old.Name, "changed from %v to %v", old.Type, new.Type)
+		return
+	}
+
+	switch old.Type {
+	case model.DefinitionEnum:
+		c.enum(old, new)
+	case model.DefinitionMessage:
+		c.message(old, new)
+	case model.DefinitionStruct:
+		c.struct_(old, new)
+	case model.DefinitionService:
+		c.service(old, new)
+	}
+}
+
+// enum
+
+func (c *checker) enum(old *model.Definition, new *model.Definition) {
+	enum0 := old.Enum
+	enum1 := new.Enum
+
+	for _, val0 := range enum0.Values {
+		path := fmt.Sprintf("%v.%v", old.Name, val0.Name)
+
+		val1, ok := enum1.ValueNames[val0.Name]
+		switch {
+		case ok && val1.Number != val0.Number:
+			c.addf(path, "value renumbered from %d to %d", val0.Number, val1.Number)
+		case !ok && !enum1.Reserved.Contains(val0.Number):
+			if _, ok := enum1.ValueNumbers[val0.Number]; !ok {
+				c.addf(path, "value %d removed, not reserved", val0.Number)
+			}
+		}
+	}
+}
+
+// message
+
+func (c *checker) message(old *model.Definition, new *model.Definition) {
+	msg0 := old.Message
+	msg1 := new.Message
+
+	for _, field0 := range msg0.Fields.List {
+		path := fmt.Sprintf("%v.%v", old.Name, field0.Name)
+
+		field1, ok := msg1.Fields.Tags[field0.Tag]
+		if !ok {
+			if !msg1.Reserved.Contains(field0.Tag) {
+				c.addf(path, "tag %d removed, not reserved", field0.Tag)
+			}
+			continue
+		}
+
+		renamed := field1.Name != field0.Name
+		switch {
+		case !compatible(field0.Type, field1.Type) && renamed:
+			c.addf(path, "tag %d reused by field %q", field0.Tag, field1.Name)
+		case !compatible(field0.Type, field1.Type):
+			c.addf(path, "type changed from %v to %v", typeString(field0.Type), typeString(field1.Type))
+		case renamed:
+			c.notef(path, "renamed to %q", field1.Name)
+		}
+	}
+
+	for _, field1 := range msg1.Fields.List {
+		if msg0.Reserved.Contains(field1.Tag) {
+			path := fmt.Sprintf("%v.%v", new.Name, field1.Name)
+			c.addf(path, "reserved tag %d reused", field1.Tag)
+		}
+	}
+}
+
+// struct
+
+func (c *checker) struct_(old *model.Definition, new *model.Definition) {
+	fields0 := old.Struct.Fields.Values()
+	fields1 := new.Struct.Fields.Values()
+
+	if len(fields0) != len(fields1) {
+		c.addf(old.Name, "layout changed, fields changed from %d to %d", len(fields0), len(fields1))
+		return
+	}
+
+	for i, field0 := range fields0 {
+		field1 := fields1[i]
+
+		type0 := typeString(field0.Type)
+		type1 := typeString(field1.Type)
+		if type0 != type1 {
+			path := fmt.Sprintf("%v.%v", old.Name, field0.Name)
+			c.addf(path, "layout changed, type changed from %v to %v", type0, type1)
+		}
+	}
+}
+
+// service
+
+func (c *checker) service(old *model.Definition, new *model.Definition) {
+	srv0 := old.Service
+	srv1 := new.Service
+
+	for _, m0 := range srv0.Methods {
+		path := fmt.Sprintf("%v.%v", old.Name, m0.Name)
+
+		m1, ok := srv1.MethodNames[m0.Name]
+		if !ok {
+			c.addf(path, "method removed")
+			continue
+		}
+
+		c.method(path, m0, m1)
+	}
+}
+
+func (c *checker) method(path string, old *model.Method, new *model.Method) {
+	if old.Type != new.Type {
+		c.addf(path, "method type changed from %v to %v", old.Type, new.Type)
+		return
+	}
+
+	c.methodType(path, "request", old.Request, new.Request)
+	c.methodType(path, "response", old.Response, new.Response)
+	c.methodType(path, "subservice", old.Subservice, new.Subservice)
+
+	if old.Channel != nil && new.Channel != nil {
+		c.methodType(path, "channel input", old.Channel.In, new.Channel.In)
+		c.methodType(path, "channel output", old.Channel.Out, new.Channel.Out)
+	}
+}
+
+func (c *checker) methodType(path string, name string, old *model.Type, new *model.Type) {
+	switch {
+	case old == nil && new == nil:
+		return
+	case old == nil:
+		c.addf(path, "%v %v added", name, typeString(new))
+	case new == nil:
+		c.addf(path, "%v %v removed", name, typeString(old))
+	case !compatible(old, new):
+		c.addf(path, "%v changed from %v to %v", name, typeString(old), typeString(new))
+	}
+}
+
+// util
+
+// definitions returns all package definitions including generated ones.
+func definitions(pkg *model.Package) map[string]*model.Definition {
+	m := make(map[string]*model.Definition)
+	for _, file := range pkg.Files {
+		for _, def := range file.Definitions {
+			m[def.Name] = def
+		}
+	}
+	return m
+}
+
+// compatible returns true if a value of an old type can be decoded as a new type.
+func compatible(old *model.Type, new *model.Type) bool {
+	switch new.Kind {
+	case model.KindAny:
+		return true
+	case model.KindAnyMessage:
+		return old.Kind == model.KindAnyMessage || old.Kind == model.KindMessage
+	}
+
+	switch old.Kind {
+	case model.KindInt16:
+		return new.Kind == model.KindInt16 || new.Kind == model.KindInt32 || new.Kind == model.KindInt64
+	case model.KindInt32:
+		return new.Kind == model.KindInt32 || new.Kind == model.KindInt64
+	case model.KindUint16:
+		return new.Kind == model.KindUint16 || new.Kind == model.KindUint32 || new.Kind == model.KindUint64
+	case model.KindUint32:
+		return new.Kind == model.KindUint32 || new.Kind == model.KindUint64
+	case model.KindFloat32:
+		return new.Kind == model.KindFloat32 || new.Kind == model.KindFloat64
+
+	case model.KindList:
+		return new.Kind == model.KindList && compatible(old.Element, new.Element)
+
+	case model.KindMap:
+		return new.Kind == model.KindMap &&
+			old.Key.Kind == new.Key.Kind &&
+			compatible(old.Element, new.Element)
+	}
+
+	return typeString(old) == typeString(new)
+}
+
+// typeString returns a type string with a package id for imported types, i.e. "[]pkg2.Submessage".
+func typeString(t *model.Type) string {
+	switch t.Kind {
+	case model.KindList:
+		return "[]" + typeString(t.Element)
+
+	case model.KindMap:
+		return fmt.Sprintf("map[%v]%v", typeString(t.Key), typeString(t.Element))
+
+	case model.KindEnum, model.KindMessage, model.KindStruct, model.KindService:
+		if t.Import != nil {
+			return fmt.Sprintf("%v.%v", t.Import.ID, t.Name)
+		}
+		return t.Name
+	}
+
+	return t.Kind.String()
+}
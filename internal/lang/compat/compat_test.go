@@ -0,0 +1,214 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package compat
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/lang/compiler"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// compileSource compiles a single file package from the source,
+// both versions are compiled as package "pkg".
+func compileSource(t *testing.T, src string) *model.Package {
+	dir := filepath.Join(t.TempDir(), "pkg")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "test.spec")
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := compiler.New(compiler.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkg, err := c.Compile(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func testCheck(t *testing.T, old string, new string) []string {
+	pkg0 := compileSource(t, old)
+	pkg1 := compileSource(t, new)
+
+	var result []string
+	for _, change := range Check(pkg0, pkg1) {
+		result = append(result, change.String())
+	}
+	return result
+}
+
+func TestCheck__should_return_no_changes_when_compatible(t *testing.T) {
+	old := `
+enum Enum { UNDEFINED = 0; ONE = 1; }
+message Message {
+	a int16 1;
+	b uint32 2;
+	c float32 3;
+	d []int32 4;
+	e map[string]int32 5;
+	f Message 6;
+	g int32 7;
+}
+struct Struct { a int32; b int64; }
+service Service {
+	method(a int32 1) (b int32 1);
+}
+`
+	new := `
+enum Enum { UNDEFINED = 0; ONE = 1; TWO = 2; }
+message Message {
+	a int64 1;
+	b uint64 2;
+	c float64 3;
+	d []int64 4;
+	e map[string]int64 5;
+	f message 6;
+	h string 8;
+	reserved 7;
+}
+struct Struct { key int32; value int64; }
+service Service {
+	method(a int64 1, c string 2) (b int64 1);
+	method1();
+}
+`
+	changes := testCheck(t, old, new)
+	assert.Empty(t, changes)
+}
+
+func TestCheck__should_return_changed_field_types(t *testing.T) {
+	old := `message Message { a int64 1; b string 2; c []int32 3; d Message 4; }`
+	new := `message Message { a int32 1; b bytes 2; c []string 3; d int32 4; }`
+
+	changes := testCheck(t, old, new)
+	assert.Equal(t, []string{
+		"Message.a: type changed from int64 to int32",
+		"Message.b: type changed from string to bytes",
+		"Message.c: type changed from []int32 to []string",
+		"Message.d: type changed from Message to int32",
+	}, changes)
+}
+
+func TestCheck__should_return_removed_and_reused_tags(t *testing.T) {
+	old := `message Message { a int32 1; b int32 2; c int32 3; }`
+	new := `message Message { a int32 1; d string 2; reserved 3; }`
+
+	changes := testCheck(t, old, new)
+	assert.Equal(t, []string{
+		`Message.b: tag 2 reused by field "d"`,
+	}, changes)
+
+	new = `message Message { a int32 1; }`
+	changes = testCheck(t, old, new)
+	assert.Equal(t, []string{
+		"Message.b: tag 2 removed, not reserved",
+		"Message.c: tag 3 removed, not reserved",
+	}, changes)
+}
+
+func TestCheck__should_return_reused_reserved_tags(t *testing.T) {
+	old := `message Message { a int32 1; reserved 2, 3; }`
+	new := `message Message { a int32 1; b int32 2; reserved 3; }`
+
+	changes := testCheck(t, old, new)
+	assert.Equal(t, []string{
+		"Message.b: reserved tag 2 reused",
+	}, changes)
+}
+
+func TestCheck__should_return_renamed_fields_as_notes(t *testing.T) {
+	old := `message Message { a int32 1; b int32 2; }`
+	new := `message Message { a int32 1; c int64 2; }`
+
+	pkg0 := compileSource(t, old)
+	pkg1 := compileSource(t, new)
+
+	changes := Check(pkg0, pkg1)
+	require.Len(t, changes, 1)
+	assert.Equal(t, `Message.b: renamed to "c"`, changes[0].String())
+	assert.True(t, changes[0].Note)
+}
+
+func TestCheck__should_return_removed_and_renumbered_enum_values(t *testing.T) {
+	old := `enum Enum { UNDEFINED = 0; ONE = 1; TWO = 2; THREE = 3; }`
+	new := `enum Enum { UNDEFINED = 0; ONE = 10; THREE = 3; }`
+
+	changes := testCheck(t, old, new)
+	assert.Equal(t, []string{
+		"Enum.ONE: value renumbered from 1 to 10",
+		"Enum.TWO: value 2 removed, not reserved",
+	}, changes)
+
+	new = `enum Enum { UNDEFINED = 0; ONE = 1; THREE = 3; reserved 2; }`
+	changes = testCheck(t, old, new)
+	assert.Empty(t, changes)
+}
+
+func TestCheck__should_return_changed_struct_layouts(t *testing.T) {
+	old := `struct Struct { a int32; b int32; }`
+	new := `struct Struct { a int32; b int64; }`
+
+	changes := testCheck(t, old, new)
+	assert.Equal(t, []string{
+		"Struct.b: layout changed, type changed from int32 to int64",
+	}, changes)
+
+	new = `struct Struct { a int32; b int32; c int32; }`
+	changes = testCheck(t, old, new)
+	assert.Equal(t, []string{
+		"Struct: layout changed, fields changed from 2 to 3",
+	}, changes)
+}
+
+func TestCheck__should_return_removed_and_changed_methods(t *testing.T) {
+	old := `
+message Request { a int32 1; }
+message Response { a int32 1; }
+service Service {
+	method0(Request) Response;
+	method1(Request) Response;
+	method2(Request);
+	method3(a int32 1);
+}
+`
+	new := `
+message Request { a int32 1; }
+message Response { a int32 1; }
+service Service {
+	method1(Response) Response;
+	method2(Request) oneway;
+	method3(a string 1);
+}
+`
+	changes := testCheck(t, old, new)
+	assert.Equal(t, []string{
+		"Service.method0: method removed",
+		"Service.method1: request changed from Request to Response",
+		"Service.method2: method type changed from request to oneway",
+		"ServiceMethod3Request.a: type changed from int32 to string",
+	}, changes)
+}
+
+func TestCheck__should_return_removed_definitions(t *testing.T) {
+	old := `message Message { a int32 1; } struct Struct { a int32; }`
+	new := `message Struct { a int32 1; }`
+
+	changes := testCheck(t, old, new)
+	require.Len(t, changes, 2)
+	assert.Equal(t, "Message: message removed", changes[0])
+	assert.Equal(t, "Struct: changed from struct to message", changes[1])
+}
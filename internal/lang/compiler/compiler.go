@@ -70,6 +70,7 @@ func (c *compiler) Compile(dir string) (*model.Package, error) {
 	}
 
 	x := model.NewContext(c.parser, c.paths)
+	x.ModuleDir = dir
 	return x.Compile(id, dir)
 }
 
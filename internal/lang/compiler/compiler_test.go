@@ -5,6 +5,9 @@
 package compiler
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/basecomplextech/spec/internal/lang/model"
@@ -23,6 +26,18 @@ func testCompiler(t *testing.T) *compiler {
 	return c
 }
 
+// compileSource compiles a single file package from the source.
+func compileSource(t *testing.T, src string) (*model.Package, error) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.spec")
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := testCompiler(t)
+	return c.Compile(dir)
+}
+
 // Package
 
 func TestCompiler__should_compile_package(t *testing.T) {
@@ -118,6 +133,54 @@ func TestCompiler__should_recursively_resolve_imports(t *testing.T) {
 	assert.True(t, imp2.Resolved)
 }
 
+func TestCompiler__should_resolve_imports_through_go_modules(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"app/go.mod": `module example.com/app
+
+go 1.24
+
+require example.com/schemas v0.0.0
+
+replace example.com/schemas => ../schemas
+`,
+		"app/api/api.spec": `import (
+    "example.com/schemas/common"
+)
+
+message Request {
+    id common.ID 1;
+}
+`,
+		"schemas/go.mod": `module example.com/schemas
+
+go 1.24
+`,
+		"schemas/common/common.spec": `struct ID {
+    value int64;
+}
+`,
+	}
+	for name, src := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(src), 0644))
+	}
+
+	c := testCompiler(t)
+	pkg, err := c.Compile(filepath.Join(root, "app", "api"))
+	require.NoError(t, err)
+
+	imp := pkg.Files[0].Imports[0]
+	require.NotNil(t, imp.Package)
+	assert.Equal(t, "example.com/schemas/common", imp.Package.ID)
+	assert.Equal(t, filepath.Join(root, "schemas", "common"), imp.Package.Path)
+
+	gopkg := imp.Package.OptionNames["go_package"]
+	require.NotNil(t, gopkg)
+	assert.Equal(t, "example.com/schemas/common", gopkg.Value)
+}
+
 // Options
 
 func TestCompiler__should_compile_options(t *testing.T) {
@@ -131,7 +194,7 @@ func TestCompiler__should_compile_options(t *testing.T) {
 	file0 := pkg.Files[0]
 	file1 := pkg.Files[1]
 	assert.Len(t, file0.Options, 0)
-	assert.Len(t, file1.Options, 1)
+	assert.Len(t, file1.Options, 2)
 
 	gopkg := file1.OptionMap["go_package"]
 	require.NotNil(t, gopkg)
@@ -152,7 +215,7 @@ func TestCompiler__should_compile_file_definitions(t *testing.T) {
 	file1 := pkg.Files[1]
 
 	assert.Len(t, file0.Definitions, 1)
-	assert.Len(t, file1.Definitions, 4)
+	assert.Len(t, file1.Definitions, 7)
 }
 
 func TestCompiler__should_compile_package_definitions(t *testing.T) {
@@ -163,10 +226,11 @@ func TestCompiler__should_compile_package_definitions(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	assert.Len(t, pkg.Definitions, 5)
+	assert.Len(t, pkg.Definitions, 8)
 
 	assert.Contains(t, pkg.DefinitionNames, "Enum")
 	assert.Contains(t, pkg.DefinitionNames, "Message")
+	assert.Contains(t, pkg.DefinitionNames, "Union")
 	assert.Contains(t, pkg.DefinitionNames, "Submessage")
 	assert.Contains(t, pkg.DefinitionNames, "Struct")
 }
@@ -238,7 +302,7 @@ func TestCompiler__should_compile_message(t *testing.T) {
 	def := pkg.Files[1].Definitions[0]
 	assert.Equal(t, model.DefinitionMessage, def.Type)
 	assert.NotNil(t, def.Message)
-	assert.Len(t, def.Message.Fields.List, 26)
+	assert.Len(t, def.Message.Fields.List, 28)
 }
 
 func TestCompiler__should_compile_message_field_names(t *testing.T) {
@@ -253,7 +317,7 @@ func TestCompiler__should_compile_message_field_names(t *testing.T) {
 	require.Equal(t, model.DefinitionMessage, def.Type)
 
 	msg := def.Message
-	require.Len(t, def.Message.Fields.List, 26)
+	require.Len(t, def.Message.Fields.List, 28)
 	assert.Contains(t, msg.Fields.Names, "bool")
 	assert.Contains(t, msg.Fields.Names, "enum1")
 	assert.Contains(t, msg.Fields.Names, "byte")
@@ -271,12 +335,197 @@ func TestCompiler__should_compile_message_field_tags(t *testing.T) {
 	require.Equal(t, model.DefinitionMessage, def.Type)
 
 	msg := def.Message
-	require.Len(t, def.Message.Fields.Tags, 26)
+	require.Len(t, def.Message.Fields.Tags, 28)
 	assert.Contains(t, msg.Fields.Tags, 1)
 	assert.Contains(t, msg.Fields.Tags, 2)
 	assert.Contains(t, msg.Fields.Tags, 10)
 }
 
+func TestCompiler__should_compile_message_oneofs(t *testing.T) {
+	c := testCompiler(t)
+
+	pkg, err := c.Compile("../../tests/pkg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := pkg.Files[1].Definitions[1]
+	require.Equal(t, "Union", def.Name)
+
+	msg := def.Message
+	require.Len(t, msg.Oneofs, 1)
+
+	oneof := msg.Oneofs[0]
+	assert.Equal(t, "body", oneof.Name)
+	require.Len(t, oneof.Fields, 3)
+	assert.Equal(t, "number", oneof.Fields[0].Name)
+	assert.Same(t, oneof, oneof.Fields[0].Oneof)
+	assert.Nil(t, msg.Fields.Names["id"].Oneof)
+}
+
+func TestCompiler__should_compile_message_field_defaults(t *testing.T) {
+	c := testCompiler(t)
+
+	pkg, err := c.Compile("../../tests/pkg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := pkg.Files[1].Definitions[2]
+	require.Equal(t, "Defaults", def.Name)
+
+	fields := def.Message.Fields
+	assert.Equal(t, true, fields.Get("bool").Default.Value)
+	assert.Equal(t, int64(-100), fields.Get("int32").Default.Value)
+	assert.Equal(t, uint64(100), fields.Get("uint64").Default.Value)
+	assert.Equal(t, -1.5, fields.Get("float64").Default.Value)
+	assert.Equal(t, "hello\tworld", fields.Get("string").Default.Value)
+	assert.Equal(t, "ONE", fields.Get("enum1").Default.Value.(*model.EnumValue).Name)
+	assert.Nil(t, fields.Get("plain").Default)
+}
+
+func TestCompiler__should_return_error_when_invalid_field_default(t *testing.T) {
+	tests := []struct {
+		field string
+		err   string
+	}{
+		{`field int32 1 = "text"`, "invalid int32 default"},
+		{`field int16 1 = 100000`, "invalid int16 default"},
+		{`field uint32 1 = -1`, "invalid uint32 default"},
+		{`field bool 1 = 1`, "invalid bool default"},
+		{`field string 1 = 1`, "invalid string default"},
+		{`field Enum 1 = FOUR`, "value not found"},
+		{`field bytes 1 = "text"`, "not supported for bytes"},
+		{`oneof body { field int32 1 = 1; }`, "oneof fields cannot have defaults"},
+	}
+
+	for _, tt := range tests {
+		src := "enum Enum { NONE = 0; ONE = 1; }\nmessage Message { " + tt.field + "; }\n"
+
+		_, err := compileSource(t, src)
+		assert.ErrorContains(t, err, tt.err, tt.field)
+	}
+}
+
+func TestCompiler__should_compile_message_reserved(t *testing.T) {
+	c := testCompiler(t)
+
+	pkg, err := c.Compile("../../tests/pkg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := pkg.Files[1].Definitions[0].Message
+	assert.True(t, msg.Reserved.Contains(3))
+	assert.True(t, msg.Reserved.Contains(9))
+	assert.True(t, msg.Reserved.Contains(91))
+	assert.False(t, msg.Reserved.Contains(10))
+	assert.True(t, msg.Reserved.ContainsName("deleted"))
+}
+
+func TestCompiler__should_return_error_when_reserved_reused(t *testing.T) {
+	tests := []struct {
+		src string
+		err string
+	}{
+		{`message Message { reserved 4; field int32 4; }`, `invalid field "field": tag 4 is reserved`},
+		{`message Message { reserved 2 to 5; field int32 3; }`, `invalid field "field": tag 3 is reserved`},
+		{`message Message { reserved "field"; field int32 1; }`, `invalid field "field": name is reserved`},
+		{`message Message { reserved 5 to 2; }`, `invalid reserved range 5 to 2`},
+		{`enum Enum { NONE = 0; reserved 1; ONE = 1; }`, `Enum.ONE: enum value number is reserved`},
+		{`enum Enum { NONE = 0; reserved "ONE"; ONE = 1; }`, `Enum.ONE: enum value name is reserved`},
+	}
+
+	for _, tt := range tests {
+		_, err := compileSource(t, tt.src)
+		assert.ErrorContains(t, err, tt.err, tt.src)
+	}
+}
+
+func TestCompiler__should_compile_annotations(t *testing.T) {
+	c := testCompiler(t)
+
+	pkg, err := c.Compile("../../tests/pkg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := pkg.Files[1].Definitions[1]
+	require.Equal(t, "Union", def.Name)
+	assert.Equal(t, int64(2), def.Annotations.Get("version").Value)
+
+	field := def.Message.Fields.Get("id")
+	label, ok := field.Annotations.String("label")
+	assert.True(t, ok)
+	assert.Equal(t, "identifier", label)
+	assert.Equal(t, int64(1), field.Annotations.Get("min").Value)
+	assert.Equal(t, 0.5, field.Annotations.Get("ratio").Value)
+
+	enum := pkg.Files[0].Definitions[0].Enum
+	assert.Equal(t, "ten", enum.ValueNames["TEN"].Annotations.Get("alias").Value)
+}
+
+func TestCompiler__should_return_error_when_duplicate_annotation(t *testing.T) {
+	src := `message Message { field int32 1 [deprecated=true, deprecated=false]; }`
+
+	_, err := compileSource(t, src)
+	assert.ErrorContains(t, err, `duplicate annotation "deprecated"`)
+}
+
+// Deprecation
+
+func TestCompiler__should_compile_deprecated(t *testing.T) {
+	c := testCompiler(t)
+
+	pkg, err := c.Compile("../../tests/pkg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := pkg.DefinitionNames["Legacy"]
+	_, ok := def.Annotations.Deprecated()
+	assert.False(t, ok)
+
+	_, ok = def.Message.Fields.Get("value").Annotations.Deprecated()
+	assert.False(t, ok)
+
+	reason, ok := def.Message.Fields.Get("old").Annotations.Deprecated()
+	assert.True(t, ok)
+	assert.Equal(t, "", reason)
+
+	enum := pkg.DefinitionNames["Enum"].Enum
+	reason, ok = enum.ValueNames["THREE"].Annotations.Deprecated()
+	assert.True(t, ok)
+	assert.Equal(t, "use TEN", reason)
+}
+
+func TestCompiler__should_warn_when_deprecated_imported_definition_referenced(t *testing.T) {
+	c := testCompiler(t)
+
+	pkg, err := c.Compile("../../tests/pkg1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	require.Len(t, pkg.Warnings, 1)
+	assert.True(t, strings.HasSuffix(pkg.Warnings[0],
+		"pkg1.spec: pkg2.OldSubmessage is deprecated: use Submessage"), pkg.Warnings[0])
+}
+
+func TestCompiler__should_return_error_when_invalid_deprecated_annotation(t *testing.T) {
+	src := `message Message { field int32 1 [deprecated=1]; }`
+
+	_, err := compileSource(t, src)
+	assert.ErrorContains(t, err, `invalid annotation "deprecated": must be a bool or a string`)
+}
+
+func TestCompiler__should_return_error_when_invalid_sensitive_annotation(t *testing.T) {
+	src := `message Message { field string 1 [sensitive="yes"]; }`
+
+	_, err := compileSource(t, src)
+	assert.ErrorContains(t, err, `invalid annotation "sensitive": must be a bool, got "yes"`)
+}
+
 // Structs
 
 func TestCompiler__should_compile_struct(t *testing.T) {
@@ -548,3 +797,100 @@ func TestCompiler__should_generate_response_from_fields(t *testing.T) {
 	assert.Equal(t, "ServiceMethod11Response", resp.Name)
 	assert.True(t, resp.Ref.Message.Generated)
 }
+
+// Errors
+
+func TestCompiler__should_return_all_errors_with_positions(t *testing.T) {
+	src := `message Message {
+    field1 int32 1;
+    field2 int32 1;
+    field3 Unknown 3;
+}
+
+message Message2 {
+    field Unknown2 1;
+}
+`
+
+	_, err := compileSource(t, src)
+	require.Error(t, err)
+
+	// Duplicate tag is a parse error, types are still resolved in valid definitions
+	var errs model.Errors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), `test.spec:3:5: invalid field "field2": duplicate tag 1`)
+	assert.Contains(t, errs[1].Error(), `test.spec:8:5: field: type not found: Unknown2`)
+
+	// Fix tag, expect both unresolved types
+	src = strings.Replace(src, "field2 int32 1", "field2 int32 2", 1)
+	_, err = compileSource(t, src)
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), `test.spec:4:5: field3: type not found: Unknown`)
+	assert.Contains(t, errs[1].Error(), `test.spec:8:5: field: type not found: Unknown2`)
+}
+
+func TestCompiler__should_return_errors_from_all_files(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.spec": "message A { field int32 0; }\n",
+		"b.spec": "enum B { ONE = 1; }\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := testCompiler(t)
+	_, err := c.Compile(dir)
+
+	var errs model.Errors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), `a.spec:1:13: invalid field "field": zero tag`)
+	assert.Contains(t, errs[1].Error(), `b.spec:1:1: zero enum value required`)
+}
+
+func TestCompiler__should_return_parse_and_resolve_errors(t *testing.T) {
+	src := `enum Enum {
+    ONE = 1;
+}
+
+message Message {
+    a Enum 1;
+    b Unknown 2;
+}
+`
+
+	_, err := compileSource(t, src)
+
+	var errs model.Errors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), `test.spec:1:1: zero enum value required`)
+	assert.Contains(t, errs[1].Error(), `test.spec:7:5: b: type not found: Unknown`)
+}
+
+func TestCompiler__should_resolve_types_in_files_without_import_errors(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.spec": "import (\"unknown\")\nmessage A { field unknown.Type 1; }\n",
+		"b.spec": "message B { field Unknown 1; }\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := testCompiler(t)
+	_, err := c.Compile(dir)
+
+	var errs model.Errors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Contains(t, errs[0].Error(), `package not found: unknown`)
+	assert.Contains(t, errs[1].Error(), `b.spec:1:13: field: type not found: Unknown`)
+}
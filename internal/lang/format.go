@@ -0,0 +1,97 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/basecomplextech/spec/internal/lang/printer"
+)
+
+// Format formats spec files and directories recursively.
+//
+// By default, formatted files are written to out. When list is set, paths of files
+// which formatting differs are written to out instead. When write is set, files
+// are rewritten in place.
+func Format(paths []string, list bool, write bool, out io.Writer) error {
+	var errs []error
+	for _, path := range paths {
+		files, err := specFiles(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, file := range files {
+			if err := formatFile(file, list, write, out); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// internal
+
+func formatFile(path string, list bool, write bool, out io.Writer) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	result, err := printer.Format(src)
+	if err != nil {
+		return fmt.Errorf("%v: %w", path, err)
+	}
+	changed := !bytes.Equal(src, result)
+
+	if list && changed {
+		fmt.Fprintln(out, path)
+	}
+	if write && changed {
+		info, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, result, info.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	if !list && !write {
+		_, err = out.Write(result)
+	}
+	return err
+}
+
+// specFiles returns a file or spec files in a directory and its subdirectories.
+func specFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(path string, d fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case d.IsDir():
+			return nil
+		case filepath.Ext(path) == ".spec":
+			files = append(files, path)
+		}
+		return nil
+	})
+	return files, err
+}
@@ -43,11 +43,13 @@ func (w *clientWriter) iface(def *model.Definition) error {
 	if def.Service.Sub {
 		w.linef(`// %vCall`, def.Name)
 		w.line()
+		w.doc(def.Doc, def.Annotations)
 		w.linef(`type %vCall interface {`, def.Name)
 		w.line()
 	} else {
 		w.linef(`// %vClient`, def.Name)
 		w.line()
+		w.doc(def.Doc, def.Annotations)
 		w.linef(`type %vClient interface {`, def.Name)
 		w.line()
 	}
@@ -91,6 +93,7 @@ func (w *clientWriter) methods(def *model.Definition) error {
 
 func (w *clientWriter) method(def *model.Definition, m *model.Method) error {
 	methodName := toUpperCamelCase(m.Name)
+	w.doc(m.Doc, m.Annotations)
 	w.write(methodName)
 
 	if err := w.method_input(def, m); err != nil {
@@ -0,0 +1,368 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+type descriptorWriter struct {
+	*writer
+}
+
+func newDescriptorWriter(w *writer) *descriptorWriter {
+	return &descriptorWriter{w}
+}
+
+func (w *descriptorWriter) descriptor(def *model.Definition) error {
+	switch def.Type {
+	case model.DefinitionEnum:
+		return w.enum(def)
+	case model.DefinitionMessage:
+		return w.message(def)
+	case model.DefinitionStruct:
+		return w.struct_(def)
+	case model.DefinitionService:
+		return w.service(def)
+	}
+	return nil
+}
+
+// enum
+
+func (w *descriptorWriter) enum(def *model.Definition) error {
+	name := descriptor_name(def)
+
+	w.linef(`var %v = &spec.EnumDescriptor{`, name)
+	w.linef(`Package: %q,`, def.Package.Name)
+	w.linef(`Name: %q,`, def.Name)
+	w.line(`Values: []*spec.EnumValueDescriptor{`)
+	for _, val := range def.Enum.Values {
+		w.linef(`{Name: %q, Number: %d%v},`, val.Name, val.Number, annotationsField(val.Annotations))
+	}
+	w.line(`},`)
+	w.annotations(def.Annotations)
+	w.line(`}`)
+	w.line()
+
+	w.linef(`func (e %v) Descriptor() *spec.EnumDescriptor {`, def.Name)
+	w.linef(`return %v`, name)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+// message
+
+func (w *descriptorWriter) message(def *model.Definition) error {
+	name := descriptor_name(def)
+
+	w.linef(`var %v = &spec.MessageDescriptor{`, name)
+	w.linef(`Package: %q,`, def.Package.Name)
+	w.linef(`Name: %q,`, def.Name)
+	w.annotations(def.Annotations)
+	w.line(`}`)
+	w.line()
+
+	// Fields are initialized in init to allow recursive references
+	w.line(`func init() {`)
+	w.linef(`%v.Fields = []*spec.FieldDescriptor{`, name)
+	for _, field := range def.Message.Fields.List {
+		typ := typeDescriptor(field.Type)
+		w.linef(`{Name: %q, Tag: %d, Type: %v%v},`, field.Name, field.Tag, typ, annotationsField(field.Annotations))
+	}
+	w.line(`}`)
+
+	if oneofs := def.Message.Oneofs; len(oneofs) > 0 {
+		index := make(map[*model.Field]int, len(def.Message.Fields.List))
+		for i, field := range def.Message.Fields.List {
+			index[field] = i
+		}
+
+		w.linef(`%v.Oneofs = []*spec.OneofDescriptor{`, name)
+		for _, oneof := range oneofs {
+			fields := make([]string, 0, len(oneof.Fields))
+			for _, field := range oneof.Fields {
+				fields = append(fields, fmt.Sprintf(`%v.Fields[%d]`, name, index[field]))
+			}
+			w.linef(`{Name: %q, Fields: []*spec.FieldDescriptor{%v}},`, oneof.Name, strings.Join(fields, ", "))
+		}
+		w.line(`}`)
+	}
+	w.line(`}`)
+	w.line()
+
+	w.linef(`func (m %v) Descriptor() *spec.MessageDescriptor {`, def.Name)
+	w.linef(`return %v`, name)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+// struct
+
+func (w *descriptorWriter) struct_(def *model.Definition) error {
+	name := descriptor_name(def)
+
+	w.linef(`var %v = &spec.StructDescriptor{`, name)
+	w.linef(`Package: %q,`, def.Package.Name)
+	w.linef(`Name: %q,`, def.Name)
+	w.annotations(def.Annotations)
+	w.line(`}`)
+	w.line()
+
+	w.line(`func init() {`)
+	w.linef(`%v.Fields = []*spec.StructFieldDescriptor{`, name)
+	for _, field := range def.Struct.Fields.Values() {
+		typ := typeDescriptor(field.Type)
+		w.linef(`{Name: %q, Type: %v%v},`, field.Name, typ, annotationsField(field.Annotations))
+	}
+	w.line(`}`)
+	w.line(`}`)
+	w.line()
+
+	w.linef(`func (s %v) Descriptor() *spec.StructDescriptor {`, def.Name)
+	w.linef(`return %v`, name)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+// service
+
+func (w *descriptorWriter) service(def *model.Definition) error {
+	name := descriptor_name(def)
+
+	w.linef(`var %v = &spec.ServiceDescriptor{`, name)
+	w.linef(`Package: %q,`, def.Package.Name)
+	w.linef(`Name: %q,`, def.Name)
+	if def.Service.Sub {
+		w.line(`Sub: true,`)
+	}
+	w.annotations(def.Annotations)
+	w.line(`}`)
+	w.line()
+
+	w.line(`func init() {`)
+	w.linef(`%v.Methods = []*spec.MethodDescriptor{`, name)
+	for _, m := range def.Service.Methods {
+		w.line(`{`)
+		w.linef(`Name: %q,`, m.Name)
+		if m.Oneway {
+			w.line(`Oneway: true,`)
+		}
+		if m.Request != nil {
+			w.linef(`Request: %v,`, typeDescriptor(m.Request))
+		}
+		if m.Response != nil {
+			w.linef(`Response: %v,`, typeDescriptor(m.Response))
+		}
+		if m.Subservice != nil {
+			w.linef(`Subservice: %v,`, typeDescriptor(m.Subservice))
+		}
+		if ch := m.Channel; ch != nil {
+			if ch.In != nil {
+				w.linef(`ChannelIn: %v,`, typeDescriptor(ch.In))
+			}
+			if ch.Out != nil {
+				w.linef(`ChannelOut: %v,`, typeDescriptor(ch.Out))
+			}
+		}
+		w.annotations(m.Annotations)
+		w.line(`},`)
+	}
+	w.line(`}`)
+	w.line(`}`)
+	w.line()
+
+	w.linef(`func %vDescriptor() *spec.ServiceDescriptor {`, def.Name)
+	w.linef(`return %v`, name)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+// annotations
+
+// annotations writes an annotations field if not empty.
+func (w *descriptorWriter) annotations(a *model.Annotations) {
+	if len(a.List) == 0 {
+		return
+	}
+	w.linef(`Annotations: %v,`, annotationsLiteral(a))
+}
+
+// annotationsField returns an inline annotations field or an empty string.
+func annotationsField(a *model.Annotations) string {
+	if len(a.List) == 0 {
+		return ""
+	}
+	return ", Annotations: " + annotationsLiteral(a)
+}
+
+// annotationsLiteral returns an annotations map literal.
+func annotationsLiteral(a *model.Annotations) string {
+	b := strings.Builder{}
+	b.WriteString(`spec.Annotations{`)
+
+	for i, annot := range a.List {
+		if i > 0 {
+			b.WriteString(`, `)
+		}
+
+		switch v := annot.Value.(type) {
+		case bool:
+			fmt.Fprintf(&b, `%q: %v`, annot.Name, v)
+		case int64:
+			fmt.Fprintf(&b, `%q: int64(%d)`, annot.Name, v)
+		case float64:
+			fmt.Fprintf(&b, `%q: float64(%v)`, annot.Name, annot.Text)
+		case string:
+			fmt.Fprintf(&b, `%q: %q`, annot.Name, v)
+		}
+	}
+
+	b.WriteString(`}`)
+	return b.String()
+}
+
+// util
+
+func descriptor_name(def *model.Definition) string {
+	name := strings.ToLower(def.Name[:1]) + def.Name[1:]
+	return fmt.Sprintf(`%vDescriptor`, name)
+}
+
+// typeDescriptor returns a type descriptor literal.
+func typeDescriptor(typ *model.Type) string {
+	b := strings.Builder{}
+	b.WriteString(`&spec.TypeDescriptor{`)
+	fmt.Fprintf(&b, `Kind: %v, Name: %q`, typeDescriptorKind(typ), typeDescriptorName(typ))
+
+	if typ.Import != nil {
+		fmt.Fprintf(&b, `, Import: %q`, typ.ImportName)
+	}
+
+	switch typ.Kind {
+	case model.KindList:
+		fmt.Fprintf(&b, `, Element: %v`, typeDescriptor(typ.Element))
+	case model.KindMap:
+		fmt.Fprintf(&b, `, Key: %v, Element: %v`, typeDescriptor(typ.Key), typeDescriptor(typ.Element))
+	case model.KindEnum:
+		fmt.Fprintf(&b, `, Enum: %v`, typeDescriptorRef(typ))
+	case model.KindMessage:
+		fmt.Fprintf(&b, `, Message: %v`, typeDescriptorRef(typ))
+	case model.KindStruct:
+		fmt.Fprintf(&b, `, Struct: %v`, typeDescriptorRef(typ))
+	case model.KindService:
+		fmt.Fprintf(&b, `, Service: %v`, typeDescriptorRef(typ))
+	}
+
+	b.WriteString(`}`)
+	return b.String()
+}
+
+func typeDescriptorName(typ *model.Type) string {
+	switch typ.Kind {
+	case model.KindList:
+		return "[]" + typeDescriptorName(typ.Element)
+	case model.KindMap:
+		return fmt.Sprintf("map[%v]%v", typeDescriptorName(typ.Key), typeDescriptorName(typ.Element))
+
+	case model.KindEnum,
+		model.KindMessage,
+		model.KindStruct,
+		model.KindService:
+		if typ.Import != nil {
+			return fmt.Sprintf("%v.%v", typ.ImportName, typ.Name)
+		}
+		return typ.Name
+	}
+
+	return typ.Kind.String()
+}
+
+func typeDescriptorKind(typ *model.Type) string {
+	switch typ.Kind {
+	case model.KindAny:
+		return "spec.KindAny"
+
+	case model.KindBool:
+		return "spec.KindBool"
+	case model.KindByte:
+		return "spec.KindByte"
+
+	case model.KindInt16:
+		return "spec.KindInt16"
+	case model.KindInt32:
+		return "spec.KindInt32"
+	case model.KindInt64:
+		return "spec.KindInt64"
+
+	case model.KindUint16:
+		return "spec.KindUint16"
+	case model.KindUint32:
+		return "spec.KindUint32"
+	case model.KindUint64:
+		return "spec.KindUint64"
+
+	case model.KindBin64:
+		return "spec.KindBin64"
+	case model.KindBin128:
+		return "spec.KindBin128"
+	case model.KindBin256:
+		return "spec.KindBin256"
+
+	case model.KindFloat32:
+		return "spec.KindFloat32"
+	case model.KindFloat64:
+		return "spec.KindFloat64"
+
+	case model.KindBytes:
+		return "spec.KindBytes"
+	case model.KindString:
+		return "spec.KindString"
+	case model.KindAnyMessage:
+		return "spec.KindAnyMessage"
+
+	case model.KindList:
+		return "spec.KindList"
+	case model.KindMap:
+		return "spec.KindMap"
+
+	case model.KindEnum:
+		return "spec.KindEnum"
+	case model.KindMessage:
+		return "spec.KindMessage"
+	case model.KindStruct:
+		return "spec.KindStruct"
+	case model.KindService:
+		return "spec.KindService"
+	}
+
+	panic(fmt.Sprintf("unsupported type kind %v", typ.Kind))
+}
+
+// typeDescriptorRef returns a reference to a definition descriptor.
+func typeDescriptorRef(typ *model.Type) string {
+	if typ.Import == nil {
+		return descriptor_name(typ.Ref)
+	}
+
+	switch typ.Kind {
+	case model.KindEnum:
+		return fmt.Sprintf("%v.%v(0).Descriptor()", typ.ImportName, typ.Name)
+	case model.KindMessage,
+		model.KindStruct:
+		return fmt.Sprintf("%v.%v{}.Descriptor()", typ.ImportName, typ.Name)
+	case model.KindService:
+		return fmt.Sprintf("%v.%vDescriptor()", typ.ImportName, typ.Name)
+	}
+
+	panic(fmt.Sprintf("unsupported type kind %v", typ.Kind))
+}
@@ -38,12 +38,16 @@ func (w *enumWriter) enum(def *model.Definition) error {
 	if err := w.string_method(def); err != nil {
 		return err
 	}
+	if err := w.json_methods(def); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (w *enumWriter) def(def *model.Definition) error {
 	w.linef(`// %v`, def.Name)
 	w.line()
+	w.doc(def.Doc, def.Annotations)
 	w.linef("type %v int32", def.Name)
 	w.line()
 	return nil
@@ -55,6 +59,7 @@ func (w *enumWriter) values(def *model.Definition) error {
 	for _, val := range def.Enum.Values {
 		// EnumValue Enum = 1
 		name := enumValueName(val)
+		w.doc(val.Doc, val.Annotations)
 		w.linef("%v %v = %d", name, def.Name, val.Number)
 	}
 
@@ -112,6 +117,26 @@ func (w *enumWriter) string_method(def *model.Definition) error {
 	return nil
 }
 
+func (w *enumWriter) json_methods(def *model.Definition) error {
+	desc := descriptor_name(def)
+
+	w.linef("func (e %v) MarshalJSON() ([]byte, error) {", def.Name)
+	w.linef("return spec.MarshalEnumJSON(%v, int32(e))", desc)
+	w.line("}")
+	w.line()
+
+	w.linef("func (e *%v) UnmarshalJSON(b []byte) error {", def.Name)
+	w.linef("v, err := spec.UnmarshalEnumJSON(%v, b)", desc)
+	w.line(`if err != nil {
+		return err
+	}`)
+	w.linef("*e = %v(v)", def.Name)
+	w.line("return nil")
+	w.line("}")
+	w.line()
+	return nil
+}
+
 func enumValueName(val *model.EnumValue) string {
 	name := toUpperCamelCase(val.Name)
 	return fmt.Sprintf("%v_%v", val.Enum.Def.Name, name)
@@ -22,30 +22,62 @@ func (w *fileWriter) file(file *model.File) error {
 	w.line()
 
 	// Imports
-	w.line("import (")
-	w.line(`"github.com/basecomplextech/baselibrary/alloc"`)
-	w.line(`"github.com/basecomplextech/baselibrary/async"`)
-	w.line(`"github.com/basecomplextech/baselibrary/bin"`)
-	w.line(`"github.com/basecomplextech/baselibrary/buffer"`)
-	w.line(`"github.com/basecomplextech/baselibrary/pools"`)
-	w.line(`"github.com/basecomplextech/baselibrary/ref"`)
-	w.line(`"github.com/basecomplextech/baselibrary/status"`)
-	w.line(`"github.com/basecomplextech/spec"`)
+	imports := []string{
+		"fmt",
+
+		"github.com/basecomplextech/baselibrary/alloc",
+		"github.com/basecomplextech/baselibrary/async",
+		"github.com/basecomplextech/baselibrary/bin",
+		"github.com/basecomplextech/baselibrary/buffer",
+		"github.com/basecomplextech/baselibrary/pools",
+		"github.com/basecomplextech/baselibrary/ref",
+		"github.com/basecomplextech/baselibrary/status",
+		"github.com/basecomplextech/spec",
+	}
 
 	if !w.skipRPC {
-		w.line(`"github.com/basecomplextech/spec/rpc"`)
-		w.line(`"github.com/basecomplextech/spec/proto/prpc"`)
+		imports = append(imports,
+			"github.com/basecomplextech/spec/rpc",
+			"github.com/basecomplextech/spec/proto/prpc",
+		)
+	}
+
+	for _, imp := range file.Imports {
+		imports = append(imports, importPackage(imp))
 	}
 
+	// Existing import names by paths, spec imports are referenced by import names
+	names := make(map[string]string, len(imports))
+	for _, path := range imports {
+		names[path] = goPackageName(path)
+	}
 	for _, imp := range file.Imports {
-		pkg := importPackage(imp)
-		w.linef(`"%v"`, pkg)
+		names[importPackage(imp)] = imp.Name
+	}
+
+	// Custom Go type imports with explicit aliases
+	goImports, err := fileGoImports(file)
+	if err != nil {
+		return err
+	}
+	w.goImports = goImportAliases(goImports, names)
+
+	w.line("import (")
+	for _, path := range imports {
+		w.linef(`"%v"`, path)
+	}
+	for _, path := range goImports {
+		if _, ok := names[path]; ok {
+			continue
+		}
+		w.linef(`%v "%v"`, w.goImports[path], path)
 	}
 	w.line(")")
 	w.line()
 
 	// Empty values for imports
 	w.line(`var (`)
+	w.line(`_ fmt.State`)
 	w.line(`_ alloc.Buffer`)
 	w.line(`_ async.Context`)
 	w.line(`_ bin.Bin128`)
@@ -95,6 +127,10 @@ func (w *fileWriter) definitions(file *model.File) error {
 				return err
 			}
 		}
+
+		if err := w.descriptor(def); err != nil {
+			return err
+		}
 	}
 
 	// Message writers
@@ -107,6 +143,18 @@ func (w *fileWriter) definitions(file *model.File) error {
 		}
 	}
 
+	// Message objects
+	if w.objects || objectsEnabled(file.Package) {
+		for _, def := range file.Definitions {
+			if def.Type != model.DefinitionMessage {
+				continue
+			}
+			if err := w.object(def); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Service impls
 	if !w.skipRPC {
 		for _, def := range file.Definitions {
@@ -130,6 +178,10 @@ func (w *fileWriter) definitions(file *model.File) error {
 	return nil
 }
 
+func (w *fileWriter) descriptor(def *model.Definition) error {
+	return newDescriptorWriter(w.writer).descriptor(def)
+}
+
 func (w *fileWriter) enum(def *model.Definition) error {
 	return newEnumWriter(w.writer).enum(def)
 }
@@ -142,6 +194,10 @@ func (w *fileWriter) messageWriter(def *model.Definition) error {
 	return newMessageWriter(w.writer).messageWriter(def)
 }
 
+func (w *fileWriter) object(def *model.Definition) error {
+	return newObjectWriter(w.writer).object(def)
+}
+
 func (w *fileWriter) struct_(def *model.Definition) error {
 	return newStructWriter(w.writer).struct_(def)
 }
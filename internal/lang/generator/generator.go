@@ -23,16 +23,23 @@ type Generator interface {
 }
 
 // New returns a new generator.
-func New(skipRPC bool) Generator {
-	return newGenerator(skipRPC)
+//
+// When objects is true, the generator emits owned message objects in all packages,
+// otherwise only in packages with the go_objects="true" option.
+func New(skipRPC bool, objects bool) Generator {
+	return newGenerator(skipRPC, objects)
 }
 
 type generator struct {
 	skipRPC bool
+	objects bool
 }
 
-func newGenerator(skipRPC bool) *generator {
-	return &generator{skipRPC: skipRPC}
+func newGenerator(skipRPC bool, objects bool) *generator {
+	return &generator{
+		skipRPC: skipRPC,
+		objects: objects,
+	}
 }
 
 // Package generates a go package.
@@ -47,7 +54,7 @@ func (g *generator) Package(pkg *model.Package, out string) error {
 
 func (g *generator) file(file *model.File, out string) error {
 	// Generate file
-	w := newWriter(g.skipRPC)
+	w := newWriter(g.skipRPC, g.objects)
 	if err := w.file(file); err != nil {
 		return err
 	}
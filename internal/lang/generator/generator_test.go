@@ -16,9 +16,9 @@ func TestGenerator_Package__should_generate_go_package(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	g := newGenerator(false /* do not skip rpc */)
+	g := newGenerator(false /* do not skip rpc */, false /* objects from options */)
 
-	names := []string{"pkg1", "pkg2", "pkg3/pkg3a", "pkg4"}
+	names := []string{"pkg1", "pkg2", "pkg3/pkg3a", "pkg4", "pkg5", "pkg6"}
 	for _, name := range names {
 		pkg1, err := c.Compile("../../tests/" + name)
 		if err != nil {
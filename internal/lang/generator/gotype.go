@@ -0,0 +1,290 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// Custom Go types
+//
+// Message fields can be mapped onto custom Go types with annotations, the wire format
+// does not change. Getters convert schema values with go_from, writer setters convert
+// Go values back with go_to. When a converter is absent, a Go type conversion is used,
+// i.e. ids.UserID(v) and bin.Bin128(v).
+//
+//	user_id bin128 1 [go_type="github.com/x/ids.UserID"];
+//	created int64  2 [go_type="time.Time", go_from="github.com/x/timex.FromNanos", go_to="github.com/x/timex.ToNanos"];
+//
+// Enum and struct definitions can declare the same annotations, they are used
+// for all message fields of the definition type without their own mapping.
+// Strings and bytes are cloned before they are passed to go_from.
+//
+// Custom imports are written with explicit aliases, aliases are package names guessed
+// from import paths with numeric suffixes on conflicts, i.e. ids and ids2.
+
+const (
+	AnnotationGoType = "go_type"
+	AnnotationGoFrom = "go_from"
+	AnnotationGoTo   = "go_to"
+)
+
+// goType is a custom Go type of a message field.
+type goType struct {
+	name string // qualified type name, i.e. "ids.UserID"
+	from string // qualified converter from a schema value or empty
+	to   string // qualified converter to a schema value or empty
+
+	imports []string // import paths
+}
+
+// fieldGoType returns a custom Go type of a message field or nil,
+// qualifies names with import aliases.
+func fieldGoType(field *model.Field, aliases map[string]string) (*goType, error) {
+	annots := field.Annotations
+	if _, ok := annots.Names[AnnotationGoType]; !ok {
+		ref := field.Type.Ref
+		if ref == nil || ref.Annotations == nil {
+			return nil, nil
+		}
+		if _, ok := ref.Annotations.Names[AnnotationGoType]; !ok {
+			return nil, nil
+		}
+		annots = ref.Annotations
+	}
+
+	switch field.Type.Kind {
+	case model.KindAny,
+		model.KindAnyMessage,
+		model.KindList,
+		model.KindMap,
+		model.KindMessage:
+		return nil, fmt.Errorf("%v: %v is not supported for %v fields",
+			field.Name, AnnotationGoType, field.Type.Kind)
+	}
+
+	t, err := newGoType(annots, aliases)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", field.Name, err)
+	}
+	return t, nil
+}
+
+func newGoType(annots *model.Annotations, aliases map[string]string) (*goType, error) {
+	t := &goType{}
+
+	names := []struct {
+		annot string
+		dst   *string
+	}{
+		{AnnotationGoType, &t.name},
+		{AnnotationGoFrom, &t.from},
+		{AnnotationGoTo, &t.to},
+	}
+
+	for _, n := range names {
+		if _, ok := annots.Names[n.annot]; !ok {
+			continue
+		}
+
+		s, ok := annots.String(n.annot)
+		if !ok {
+			return nil, fmt.Errorf("%v must be a string", n.annot)
+		}
+
+		path, ident, err := parseGoName(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v %q: %w", n.annot, s, err)
+		}
+		if path == "" {
+			*n.dst = ident
+			continue
+		}
+
+		t.imports = append(t.imports, path)
+		*n.dst = goImportName(path, aliases) + "." + ident
+	}
+	return t, nil
+}
+
+// convertFrom returns an expression which converts a schema value into a Go value.
+func (t *goType) convertFrom(expr string) string {
+	if t.from != "" {
+		return fmt.Sprintf("%v(%v)", t.from, expr)
+	}
+	return fmt.Sprintf("%v(%v)", t.name, expr)
+}
+
+// convertTo returns an expression which converts a Go value into a schema value.
+func (t *goType) convertTo(expr string, typ *model.Type) string {
+	if t.to != "" {
+		return fmt.Sprintf("%v(%v)", t.to, expr)
+	}
+	return fmt.Sprintf("%v(%v)", inTypeName(typ), expr)
+}
+
+// fileGoImports returns custom Go type import paths of file message fields.
+func fileGoImports(file *model.File) ([]string, error) {
+	var result []string
+	seen := make(map[string]bool)
+
+	for _, def := range file.Definitions {
+		if def.Type != model.DefinitionMessage {
+			continue
+		}
+
+		for _, field := range def.Message.Fields.List {
+			t, err := fieldGoType(field, nil)
+			if err != nil {
+				return nil, fmt.Errorf("%v.%w", def.Name, err)
+			}
+			if t == nil {
+				continue
+			}
+
+			for _, path := range t.imports {
+				if seen[path] {
+					continue
+				}
+				seen[path] = true
+				result = append(result, path)
+			}
+		}
+	}
+	return result, nil
+}
+
+var (
+	goIdent   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	goVersion = regexp.MustCompile(`^v[0-9]+$`)
+)
+
+// goImportAliases returns aliases of custom import paths, reuses names of existing imports
+// and adds numeric suffixes to conflicting names, i.e. "a/ids" and "b/ids" are "ids" and "ids2".
+func goImportAliases(paths []string, existing map[string]string) map[string]string {
+	names := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		names[name] = true
+	}
+
+	aliases := make(map[string]string, len(paths))
+	for _, path := range paths {
+		if name, ok := existing[path]; ok {
+			aliases[path] = name
+			continue
+		}
+
+		base := goPackageName(path)
+		alias := base
+		for i := 2; names[alias]; i++ {
+			alias = fmt.Sprintf("%v%d", base, i)
+		}
+
+		names[alias] = true
+		aliases[path] = alias
+	}
+	return aliases
+}
+
+// goImportName returns an import alias or a package name guessed from an import path.
+func goImportName(path string, aliases map[string]string) string {
+	if alias, ok := aliases[path]; ok {
+		return alias
+	}
+	return goPackageName(path)
+}
+
+// goPackageName guesses a package name from an import path, it is the last path element
+// without major version suffixes, i.e. "github.com/x/ids/v2" and "gopkg.in/ids.v2" are both "ids".
+func goPackageName(path string) string {
+	elems := strings.Split(path, "/")
+	pkg := elems[len(elems)-1]
+	if len(elems) > 1 && goVersion.MatchString(pkg) {
+		pkg = elems[len(elems)-2]
+	}
+	pkg, _, _ = strings.Cut(pkg, ".")
+	pkg = strings.ReplaceAll(pkg, "-", "")
+
+	if !goIdent.MatchString(pkg) {
+		return "pkg"
+	}
+	return pkg
+}
+
+// parseGoName parses a qualified Go name and returns its import path and identifier,
+// i.e. "github.com/x/ids.UserID" is parsed into "github.com/x/ids" and "UserID".
+// Names without a package are returned with an empty path.
+func parseGoName(s string) (path string, ident string, err error) {
+	slash := strings.LastIndex(s, "/")
+	dot := strings.LastIndex(s[slash+1:], ".")
+	if dot < 0 {
+		if !goIdent.MatchString(s) {
+			return "", "", fmt.Errorf("expected an identifier or a qualified name")
+		}
+		return "", s, nil
+	}
+
+	path = s[:slash+1+dot]
+	ident = s[slash+1+dot+1:]
+	if path == "" || !goIdent.MatchString(ident) {
+		return "", "", fmt.Errorf("expected a qualified name, i.e. github.com/x/ids.UserID")
+	}
+	return path, ident, nil
+}
+
+// message
+
+func (w *messageWriter) go_type_field(def *model.Definition, field *model.Field, t *goType) {
+	w.linef(`func (m %v) %v() %v {`, def.Name, messageFieldName(field), t.name)
+	if field.Default != nil {
+		w.linef(`if !m.msg.HasField(%d) {`, field.Tag)
+		w.linef(`return %v`, t.convertFrom(defaultValue(field)))
+		w.line(`}`)
+	}
+	w.linef(`return %v`, t.convertFrom(goTypeFieldValue(field)))
+	w.line(`}`)
+}
+
+func (w *messageWriter) go_type_writer_field(def *model.Definition, field *model.Field, t *goType) {
+	w.linef(`func (w %vWriter) %v(v0 %v) {`, def.Name, messageFieldName(field), t.name)
+	w.writer_field_oneof(def, field)
+	w.linef(`v := %v`, t.convertTo("v0", field.Type))
+	w.writer_field_default(field)
+
+	tag := field.Tag
+	switch field.Type.Kind {
+	case model.KindEnum, model.KindStruct:
+		w.linef(`spec.WriteField(w.w.Field(%d), v, %v)`, tag, typeWriteFunc(field.Type))
+	default:
+		w.linef(`w.w.Field(%d).%v(v)`, tag, goTypeWriteMethod(field.Type))
+	}
+	w.line(`}`)
+}
+
+// goTypeFieldValue returns an expression which reads a message field value,
+// strings and bytes are cloned.
+func goTypeFieldValue(field *model.Field) string {
+	tag := field.Tag
+	typ := field.Type
+
+	switch typ.Kind {
+	case model.KindBytes:
+		return fmt.Sprintf(`m.msg.Bytes(%d).Clone()`, tag)
+	case model.KindString:
+		return fmt.Sprintf(`m.msg.String(%d).Clone()`, tag)
+	case model.KindEnum, model.KindStruct:
+		return fmt.Sprintf(`%v(m.msg.FieldRaw(%d))`, typeNewFunc(typ), tag)
+	}
+	return fmt.Sprintf(`m.msg.%v(%d)`, goTypeWriteMethod(typ), tag)
+}
+
+// goTypeWriteMethod returns a message and field writer method name of a primitive type, i.e. "Int64".
+func goTypeWriteMethod(typ *model.Type) string {
+	return toUpperCamelCase(typ.Kind.String())
+}
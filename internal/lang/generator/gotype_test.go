@@ -0,0 +1,76 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGoName__should_parse_qualified_name(t *testing.T) {
+	path, ident, err := parseGoName("github.com/x/ids.UserID")
+	require.NoError(t, err)
+	assert.Equal(t, "github.com/x/ids", path)
+	assert.Equal(t, "UserID", ident)
+
+	path, ident, err = parseGoName("time.Time")
+	require.NoError(t, err)
+	assert.Equal(t, "time", path)
+	assert.Equal(t, "Time", ident)
+
+	path, ident, err = parseGoName("gopkg.in/ids.v2.UserID")
+	require.NoError(t, err)
+	assert.Equal(t, "gopkg.in/ids.v2", path)
+	assert.Equal(t, "UserID", ident)
+}
+
+func TestParseGoName__should_return_unqualified_name(t *testing.T) {
+	path, ident, err := parseGoName("UserID")
+	require.NoError(t, err)
+	assert.Equal(t, "", path)
+	assert.Equal(t, "UserID", ident)
+}
+
+func TestParseGoName__should_return_error_on_invalid_name(t *testing.T) {
+	_, _, err := parseGoName("github.com/x/ids.")
+	assert.Error(t, err)
+
+	_, _, err = parseGoName("user id")
+	assert.Error(t, err)
+}
+
+// goPackageName
+
+func TestGoPackageName__should_drop_major_version_suffix(t *testing.T) {
+	assert.Equal(t, "ids", goPackageName("github.com/x/ids"))
+	assert.Equal(t, "ids", goPackageName("github.com/x/ids/v2"))
+	assert.Equal(t, "ids", goPackageName("gopkg.in/ids.v2"))
+	assert.Equal(t, "goids", goPackageName("github.com/x/go-ids"))
+}
+
+// goImportAliases
+
+func TestGoImportAliases__should_add_suffixes_to_conflicting_names(t *testing.T) {
+	existing := map[string]string{
+		"github.com/basecomplextech/spec": "spec",
+		"github.com/x/pkg2":               "pkg2",
+	}
+	paths := []string{
+		"github.com/a/ids",
+		"github.com/b/ids",
+		"github.com/x/spec",
+		"github.com/x/pkg2",
+	}
+
+	aliases := goImportAliases(paths, existing)
+	assert.Equal(t, map[string]string{
+		"github.com/a/ids":  "ids",
+		"github.com/b/ids":  "ids2",
+		"github.com/x/spec": "spec2",
+		"github.com/x/pkg2": "pkg2",
+	}, aliases)
+}
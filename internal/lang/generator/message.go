@@ -6,6 +6,8 @@ package generator
 
 import (
 	"fmt"
+	"slices"
+	"strconv"
 
 	"github.com/basecomplextech/spec/internal/lang/model"
 )
@@ -22,6 +24,9 @@ func (w *messageWriter) message(def *model.Definition) error {
 	if err := w.def(def); err != nil {
 		return err
 	}
+	if err := w.oneofs(def); err != nil {
+		return err
+	}
 	if err := w.new_methods(def); err != nil {
 		return err
 	}
@@ -34,15 +39,25 @@ func (w *messageWriter) message(def *model.Definition) error {
 	if err := w.has_fields(def); err != nil {
 		return err
 	}
+	if err := w.which_methods(def); err != nil {
+		return err
+	}
 	if err := w.methods(def); err != nil {
 		return err
 	}
+	if err := w.json_methods(def); err != nil {
+		return err
+	}
+	if err := w.debug_methods(def); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (w *messageWriter) def(def *model.Definition) error {
 	w.linef(`// %v`, def.Name)
 	w.line()
+	w.doc(def.Doc, def.Annotations)
 	w.linef(`type %v struct {`, def.Name)
 	w.line(`msg spec.Message`)
 	w.line(`}`)
@@ -73,7 +88,15 @@ func (w *messageWriter) new_methods(def *model.Definition) error {
 func (w *messageWriter) parse_method(def *model.Definition) error {
 	w.linef(`func Parse%v(b []byte) (_ %v, size int, err error) {`, def.Name, def.Name)
 	w.linef(`msg, size, err := spec.ParseMessage(b)`)
-	w.linef(`return %v{msg}, size, err`, def.Name)
+	w.linef(`if err != nil {`)
+	w.linef(`return %v{}, size, %v.DescribeError(err)`, def.Name, descriptor_name(def))
+	w.linef(`}`)
+	if len(def.Message.Oneofs) > 0 {
+		w.linef(`if err := %v.ValidateOneofs(msg); err != nil {`, descriptor_name(def))
+		w.linef(`return %v{}, size, err`, def.Name)
+		w.linef(`}`)
+	}
+	w.linef(`return %v{msg}, size, nil`, def.Name)
 	w.linef(`}`)
 	w.line()
 	return nil
@@ -100,10 +123,22 @@ func (w *messageWriter) field(def *model.Definition, field *model.Field) error {
 
 	tag := field.Tag
 	kind := field.Type.Kind
+	w.doc(field.Doc, field.Annotations)
+
+	// Custom Go type
+	t, err := fieldGoType(field, w.goImports)
+	switch {
+	case err != nil:
+		return fmt.Errorf("%v.%w", def.Name, err)
+	case t != nil:
+		w.go_type_field(def, field, t)
+		return nil
+	}
 
 	switch kind {
 	default:
 		w.writef(`func (m %v) %v() %v {`, def.Name, fieldName, typeName)
+		w.field_default(field)
 
 		switch kind {
 		case model.KindBool:
@@ -165,6 +200,21 @@ func (w *messageWriter) field(def *model.Definition, field *model.Field) error {
 		w.writef(`}`)
 		w.line()
 
+	case model.KindMap:
+		elem := field.Type.Element
+		decodeKey := typeDecodeRefFunc(field.Type.Key)
+		decodeFunc := typeDecodeRefFunc(elem)
+
+		w.writef(`func (m %v) %v() %v {`, def.Name, fieldName, typeName)
+		if elem.Kind == model.KindMessage {
+			w.writef(`return spec.NewMessageMap(m.msg.Map(%d), %v, %v)`, tag, decodeKey, decodeFunc)
+		} else {
+			w.writef(`return spec.NewValueMap(m.msg.Map(%d), %v, %v)`, tag, decodeKey, decodeFunc)
+		}
+
+		w.writef(`}`)
+		w.line()
+
 	case model.KindMessage:
 		makeFunc := typeMakeMessageFunc(field.Type)
 
@@ -178,13 +228,29 @@ func (w *messageWriter) field(def *model.Definition, field *model.Field) error {
 		newFunc := typeNewFunc(field.Type)
 
 		w.writef(`func (m %v) %v() %v {`, def.Name, fieldName, typeName)
+		w.field_default(field)
 		w.writef(`return %v(m.msg.FieldRaw(%d))`, newFunc, tag)
 		w.writef(`}`)
 		w.line()
 	}
+
+	if field.Default != nil {
+		w.line()
+	}
 	return nil
 }
 
+func (w *messageWriter) field_default(field *model.Field) {
+	if field.Default == nil {
+		return
+	}
+
+	w.line()
+	w.linef(`if !m.msg.HasField(%d) {`, field.Tag)
+	w.linef(`return %v`, defaultValue(field))
+	w.line(`}`)
+}
+
 func (w *messageWriter) has_fields(def *model.Definition) error {
 	fields := def.Message.Fields.List
 
@@ -204,6 +270,7 @@ func (w *messageWriter) has_field(def *model.Definition, field *model.Field) err
 	fieldName := messageFieldName(field)
 	tag := field.Tag
 
+	w.doc("", field.Annotations)
 	w.writef(`func (m %v) Has%v() bool {`, def.Name, fieldName)
 	w.writef(`return m.msg.HasField(%d)`, tag)
 	w.writef(`}`)
@@ -211,6 +278,59 @@ func (w *messageWriter) has_field(def *model.Definition, field *model.Field) err
 	return nil
 }
 
+// oneofs
+
+func (w *messageWriter) oneofs(def *model.Definition) error {
+	for _, oneof := range def.Message.Oneofs {
+		name := oneofTypeName(def, oneof)
+
+		w.linef(`// %v is a %v.%v oneof field, or none.`, name, def.Name, oneof.Name)
+		w.linef(`type %v int32`, name)
+		w.line()
+
+		w.line(`const (`)
+		w.linef(`%v_None %v = 0`, name, name)
+		for _, field := range oneof.Fields {
+			w.linef(`%v %v = %d`, oneofValueName(def, oneof, field), name, field.Tag)
+		}
+		w.line(`)`)
+		w.line()
+
+		w.linef(`func (v %v) String() string {`, name)
+		w.line(`switch v {`)
+		w.linef(`case %v_None:`, name)
+		w.line(`return "none"`)
+		for _, field := range oneof.Fields {
+			w.linef(`case %v:`, oneofValueName(def, oneof, field))
+			w.linef(`return %q`, field.Name)
+		}
+		w.line(`}`)
+		w.line(`return ""`)
+		w.line(`}`)
+		w.line()
+	}
+	return nil
+}
+
+func (w *messageWriter) which_methods(def *model.Definition) error {
+	for _, oneof := range def.Message.Oneofs {
+		name := oneofTypeName(def, oneof)
+
+		w.comment(oneof.Doc)
+		w.linef(`func (m %v) Which%v() %v {`, def.Name, toUpperCamelCase(oneof.Name), name)
+		w.line(`switch {`)
+		for _, field := range oneof.Fields {
+			w.linef(`case m.msg.HasField(%d):`, field.Tag)
+			w.linef(`return %v`, oneofValueName(def, oneof, field))
+		}
+		w.line(`}`)
+		w.linef(`return %v_None`, name)
+		w.line(`}`)
+		w.line()
+	}
+	return nil
+}
+
 func (w *messageWriter) methods(def *model.Definition) error {
 	w.writef(`func (m %v) Clone() %v {`, def.Name, def.Name)
 	w.writef(`return %v{m.msg.Clone()}`, def.Name)
@@ -241,6 +361,56 @@ func (w *messageWriter) methods(def *model.Definition) error {
 	return nil
 }
 
+func (w *messageWriter) json_methods(def *model.Definition) error {
+	desc := descriptor_name(def)
+
+	w.linef(`func (m %v) MarshalJSON() ([]byte, error) {`, def.Name)
+	w.linef(`return spec.MarshalMessageJSON(%v, m.msg)`, desc)
+	w.linef(`}`)
+	w.line()
+
+	w.linef(`func (m *%v) UnmarshalJSON(b []byte) error {`, def.Name)
+	w.linef(`w := New%vWriter()`, def.Name)
+	w.linef(`if err := w.WriteJSON(b); err != nil {
+		return err
+	}`)
+	w.linef(`m1, err := w.Build()`)
+	w.linef(`if err != nil {
+		return err
+	}`)
+	w.linef(`*m = m1`)
+	w.linef(`return nil`)
+	w.linef(`}`)
+	w.line()
+	return nil
+}
+
+// debug_methods writes String and Format methods, skips the methods
+// which conflict with field getters, i.e. for a "string" field.
+func (w *messageWriter) debug_methods(def *model.Definition) error {
+	desc := descriptor_name(def)
+
+	names := make(map[string]bool)
+	for _, field := range def.Message.Fields.List {
+		names[messageFieldName(field)] = true
+	}
+
+	if !names["String"] {
+		w.linef(`func (m %v) String() string {`, def.Name)
+		w.linef(`return spec.MessageString(%v, m.msg)`, desc)
+		w.line(`}`)
+		w.line()
+	}
+
+	if !names["Format"] {
+		w.linef(`func (m %v) Format(f fmt.State, verb rune) {`, def.Name)
+		w.linef(`spec.FormatMessage(f, verb, %v, m.msg)`, desc)
+		w.line(`}`)
+		w.line()
+	}
+	return nil
+}
+
 // writer
 
 func (w *messageWriter) messageWriter(def *model.Definition) error {
@@ -262,8 +432,15 @@ func (w *messageWriter) messageWriter(def *model.Definition) error {
 func (w *messageWriter) writer_def(def *model.Definition) error {
 	w.linef(`// %vWriter`, def.Name)
 	w.line()
+	if len(def.Message.Oneofs) > 0 {
+		w.linef(`// %vWriter writes %v messages. Setters fail the writer when another field`, def.Name, def.Name)
+		w.line(`// of the same oneof is already set, End and Build fail unless exactly one field is set.`)
+	}
 	w.linef(`type %vWriter struct {`, def.Name)
 	w.line(`w spec.MessageWriter`)
+	if messageHasDefaults(def) {
+		w.line(`skipDefaults bool`)
+	}
 	w.line(`}`)
 	w.line()
 	return nil
@@ -272,35 +449,62 @@ func (w *messageWriter) writer_def(def *model.Definition) error {
 func (w *messageWriter) writer_new_method(def *model.Definition) error {
 	w.linef(`func New%vWriter() %vWriter {`, def.Name, def.Name)
 	w.linef(`w := spec.NewMessageWriter()`)
-	w.linef(`return %vWriter{w}`, def.Name)
+	w.linef(`return %vWriter{w: w}`, def.Name)
 	w.linef(`}`)
 	w.line()
 
 	w.linef(`func New%vWriterBuffer(b buffer.Buffer) %vWriter {`, def.Name, def.Name)
 	w.linef(`w := spec.NewMessageWriterBuffer(b)`)
-	w.linef(`return %vWriter{w}`, def.Name)
+	w.linef(`return %vWriter{w: w}`, def.Name)
 	w.linef(`}`)
 	w.line()
 
 	w.linef(`func New%vWriterTo(w spec.MessageWriter) %vWriter {`, def.Name, def.Name)
-	w.linef(`return %vWriter{w}`, def.Name)
+	w.linef(`return %vWriter{w: w}`, def.Name)
 	w.linef(`}`)
 	w.line()
+
+	if messageHasDefaults(def) {
+		w.line(`// SkipDefaults returns a writer which does not write fields equal to their defaults.`)
+		w.line(`// The option is not propagated to nested message writers.`)
+		w.linef(`func (w %vWriter) SkipDefaults() %vWriter {`, def.Name, def.Name)
+		w.line(`w.skipDefaults = true`)
+		w.line(`return w`)
+		w.line(`}`)
+		w.line()
+	}
 	return nil
 }
 
 func (w *messageWriter) writer_end(def *model.Definition) error {
+	w.linef(`func (w %vWriter) WriteJSON(b []byte) error {`, def.Name)
+	w.linef(`return spec.WriteMessageJSON(w.w, %v, b)`, descriptor_name(def))
+	w.linef(`}`)
+	w.line()
+
 	w.linef(`func (w %vWriter) Merge(msg %v) error {`, def.Name, def.Name)
 	w.linef(`return w.w.Merge(msg.Unwrap())`)
 	w.linef(`}`)
 	w.line()
 
+	oneofs := len(def.Message.Oneofs) > 0
+
 	w.linef(`func (w %vWriter) End() error {`, def.Name)
+	if oneofs {
+		w.linef(`if err := %v.ValidateOneofsWriter(w.w); err != nil {`, descriptor_name(def))
+		w.line(`return err`)
+		w.line(`}`)
+	}
 	w.linef(`return w.w.End()`)
 	w.linef(`}`)
 	w.line()
 
 	w.linef(`func (w %vWriter) Build() (_ %v, err error) {`, def.Name, def.Name)
+	if oneofs {
+		w.linef(`if err = %v.ValidateOneofsWriter(w.w); err != nil {`, descriptor_name(def))
+		w.line(`return`)
+		w.line(`}`)
+	}
 	w.linef(`bytes, err := w.w.Build()`)
 	w.linef(`if err != nil {
 		return
@@ -336,10 +540,23 @@ func (w *messageWriter) writer_field(def *model.Definition, field *model.Field)
 
 	tag := field.Tag
 	kind := field.Type.Kind
+	w.doc(field.Doc, field.Annotations)
+
+	// Custom Go type
+	t, err := fieldGoType(field, w.goImports)
+	switch {
+	case err != nil:
+		return fmt.Errorf("%v.%w", def.Name, err)
+	case t != nil:
+		w.go_type_writer_field(def, field, t)
+		return nil
+	}
 
 	switch kind {
 	default:
 		w.writef(`func (w %vWriter) %v(v %v) {`, def.Name, fname, tname)
+		w.writer_field_oneof(def, field)
+		w.writer_field_default(field)
 
 		switch kind {
 		case model.KindBool:
@@ -382,19 +599,25 @@ func (w *messageWriter) writer_field(def *model.Definition, field *model.Field)
 
 	case model.KindAny:
 		w.writef(`func (w %v) %v() spec.FieldWriter {`, wname, fname)
+		w.writer_field_oneof(def, field)
 		w.writef(`return w.w.Field(%d)`, tag)
 		w.linef(`}`)
 
+		w.doc("", field.Annotations)
 		w.writef(`func (w %v) Copy%v(v spec.Value) error {`, wname, fname)
+		w.writer_field_oneof(def, field)
 		w.writef(`return w.w.Field(%d).Any(v)`, tag)
 		w.linef(`}`)
 
 	case model.KindAnyMessage:
 		w.writef(`func (w %v) %v() spec.MessageWriter {`, wname, fname)
+		w.writer_field_oneof(def, field)
 		w.writef(`return w.w.Field(%d).Message()`, tag)
 		w.linef(`}`)
 
+		w.doc("", field.Annotations)
 		w.writef(`func (w %v) Copy%v(v spec.Message) error {`, wname, fname)
+		w.writer_field_oneof(def, field)
 		w.writef(`return w.w.Field(%d).Any(v.Raw())`, tag)
 		w.linef(`}`)
 
@@ -402,6 +625,8 @@ func (w *messageWriter) writer_field(def *model.Definition, field *model.Field)
 		writeFunc := typeWriteFunc(field.Type)
 
 		w.writef(`func (w %v) %v(v %v) {`, wname, fname, tname)
+		w.writer_field_oneof(def, field)
+		w.writer_field_default(field)
 		w.writef(`spec.WriteField(w.w.Field(%d), v, %v)`, tag, writeFunc)
 		w.linef(`}`)
 
@@ -409,6 +634,7 @@ func (w *messageWriter) writer_field(def *model.Definition, field *model.Field)
 		writeFunc := typeWriteFunc(field.Type)
 
 		w.writef(`func (w %v) %v(v %v) {`, wname, fname, tname)
+		w.writer_field_oneof(def, field)
 		w.writef(`spec.WriteField(w.w.Field(%d), v, %v)`, tag, writeFunc)
 		w.linef(`}`)
 
@@ -418,28 +644,115 @@ func (w *messageWriter) writer_field(def *model.Definition, field *model.Field)
 		encodeElement := typeWriteFunc(field.Type.Element)
 
 		w.linef(`func (w %v) %v() %v {`, wname, fname, writer)
+		w.writer_field_oneof(def, field)
 		w.linef(`w1 := w.w.Field(%d).List()`, tag)
 		w.linef(`return %v(w1, %v)`, buildList, encodeElement)
 		w.linef(`}`)
 
+	case model.KindMap:
+		writer := typeWriter(field.Type)
+		buildMap := typeWriteFunc(field.Type)
+		encodeKey := typeWriteFunc(field.Type.Key)
+		encodeElement := typeWriteFunc(field.Type.Element)
+
+		w.linef(`func (w %v) %v() %v {`, wname, fname, writer)
+		w.writer_field_oneof(def, field)
+		w.linef(`w1 := w.w.Field(%d).Map()`, tag)
+		w.linef(`return %v(w1, %v, %v)`, buildMap, encodeKey, encodeElement)
+		w.linef(`}`)
+
 	case model.KindMessage:
 		writer := typeWriter(field.Type)
 		writer_new_method := typeWriteFunc(field.Type)
 		w.linef(`func (w %v) %v() %v {`, wname, fname, writer)
+		w.writer_field_oneof(def, field)
 		w.linef(`w1 := w.w.Field(%d).Message()`, tag)
 		w.linef(`return %v(w1)`, writer_new_method)
 		w.linef(`}`)
 
 		tname := typeName(field.Type)
+		w.doc("", field.Annotations)
 		w.linef(`func (w %v) Copy%v(v %v) error {`, wname, fname, tname)
+		w.writer_field_oneof(def, field)
 		w.linef(`return w.w.Field(%d).Any(v.Unwrap().Raw())`, tag)
 		w.linef(`}`)
 	}
+
+	if field.Default != nil {
+		w.line()
+	}
 	return nil
 }
 
+// writer_field_oneof writes a check which fails the writer when another field
+// of the same oneof is already set.
+func (w *messageWriter) writer_field_oneof(def *model.Definition, field *model.Field) {
+	if field.Oneof == nil {
+		return
+	}
+
+	index := slices.Index(def.Message.Oneofs, field.Oneof)
+	w.linef(`%v.Oneofs[%d].CheckWriter(w.w, %d)`, descriptor_name(def), index, field.Tag)
+}
+
+func (w *messageWriter) writer_field_default(field *model.Field) {
+	if field.Default == nil {
+		return
+	}
+
+	cond := fmt.Sprintf(`v == %v`, defaultValue(field))
+	if v, ok := field.Default.Value.(bool); ok {
+		cond = `v`
+		if !v {
+			cond = `!v`
+		}
+	}
+
+	w.line()
+	w.linef(`if w.skipDefaults && %v {`, cond)
+	w.line(`return`)
+	w.line(`}`)
+}
+
 // util
 
 func messageFieldName(field *model.Field) string {
 	return toUpperCamelCase(field.Name)
 }
+
+// oneofTypeName returns a oneof enum type name, i.e. "MessageBody".
+func oneofTypeName(def *model.Definition, oneof *model.Oneof) string {
+	return def.Name + toUpperCamelCase(oneof.Name)
+}
+
+// oneofValueName returns a oneof enum value name, i.e. "MessageBody_Connect".
+func oneofValueName(def *model.Definition, oneof *model.Oneof, field *model.Field) string {
+	return oneofTypeName(def, oneof) + "_" + messageFieldName(field)
+}
+
+// messageHasDefaults returns true if any message field has a default value.
+func messageHasDefaults(def *model.Definition) bool {
+	for _, field := range def.Message.Fields.List {
+		if field.Default != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultValue returns a field default value literal.
+func defaultValue(field *model.Field) string {
+	d := field.Default
+
+	switch v := d.Value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case *model.EnumValue:
+		name := enumValueName(v)
+		if field.Type.Import != nil {
+			return fmt.Sprintf("%v.%v", field.Type.ImportName, name)
+		}
+		return name
+	}
+	return d.Text
+}
@@ -0,0 +1,404 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package generator
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+const (
+	OptionObjects = "go_objects"
+)
+
+type objectWriter struct {
+	*writer
+}
+
+func newObjectWriter(w *writer) *objectWriter {
+	return &objectWriter{w}
+}
+
+func (w *objectWriter) object(def *model.Definition) error {
+	if err := w.check(def); err != nil {
+		return err
+	}
+	if err := w.def(def); err != nil {
+		return err
+	}
+	if err := w.new_method(def); err != nil {
+		return err
+	}
+	if err := w.unmarshal_method(def); err != nil {
+		return err
+	}
+	if err := w.marshal_methods(def); err != nil {
+		return err
+	}
+	if err := w.debug_methods(def); err != nil {
+		return err
+	}
+	return nil
+}
+
+// check returns an error if a message field cannot be represented by an object.
+func (w *objectWriter) check(def *model.Definition) error {
+	for _, field := range def.Message.Fields.List {
+		typ := field.Type
+
+		switch typ.Kind {
+		case model.KindList, model.KindMap:
+			switch typ.Element.Kind {
+			case model.KindList, model.KindMap:
+				return fmt.Errorf("%v.%v: objects do not support nested lists and maps",
+					def.Name, field.Name)
+			}
+			typ = typ.Element
+		}
+
+		if typ.Kind != model.KindMessage || typ.Import == nil {
+			continue
+		}
+		if w.objects || objectsEnabled(typ.Import.Package) {
+			continue
+		}
+		return fmt.Errorf("%v.%v: imported package %v does not generate objects, add %v option to it",
+			def.Name, field.Name, typ.Import.ID, OptionObjects)
+	}
+	return nil
+}
+
+func (w *objectWriter) def(def *model.Definition) error {
+	name := objectName(def)
+
+	w.linef(`// %v is an owned %v which does not reference its source buffer.`, name, def.Name)
+	w.linef(`type %v struct {`, name)
+
+	oneofs := make(map[*model.Oneof]bool)
+	for _, field := range def.Message.Fields.List {
+		if oneof := field.Oneof; oneof != nil && !oneofs[oneof] {
+			oneofs[oneof] = true
+			w.linef(`%v %v`, toUpperCamelCase(oneof.Name), oneofTypeName(def, oneof))
+		}
+
+		w.doc(field.Doc, field.Annotations)
+		w.linef(`%v %v`, messageFieldName(field), w.objectFieldTypeName(field))
+	}
+
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+func (w *objectWriter) new_method(def *model.Definition) error {
+	name := objectName(def)
+
+	w.linef(`// New%v returns a new object with a copy of a message.`, name)
+	w.linef(`func New%v(m %v) *%v {`, name, def.Name, name)
+	w.linef(`o := &%v{}`, name)
+	w.line(`o.Unmarshal(m)`)
+	w.line(`return o`)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+// unmarshal
+
+func (w *objectWriter) unmarshal_method(def *model.Definition) error {
+	w.line(`// Unmarshal copies a message into the object, the object does not reference the message.`)
+	w.linef(`func (o *%v) Unmarshal(m %v) {`, objectName(def), def.Name)
+
+	for _, oneof := range def.Message.Oneofs {
+		name := toUpperCamelCase(oneof.Name)
+		w.linef(`o.%v = m.Which%v()`, name, name)
+	}
+
+	for _, field := range def.Message.Fields.List {
+		w.unmarshal_field(field)
+	}
+
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+func (w *objectWriter) unmarshal_field(field *model.Field) {
+	name := messageFieldName(field)
+	typ := field.Type
+
+	// Custom Go type getters return owned values
+	if t, _ := fieldGoType(field, w.goImports); t != nil {
+		w.linef(`o.%v = m.%v()`, name, name)
+		return
+	}
+
+	switch typ.Kind {
+	default:
+		w.linef(`o.%v = %v`, name, objectValue(typ, fmt.Sprintf(`m.%v()`, name)))
+
+	case model.KindMessage:
+		w.linef(`o.%v = nil`, name)
+		w.linef(`if m.Has%v() {`, name)
+		w.linef(`o.%v = %v(m.%v())`, name, objectNewFunc(typ), name)
+		w.line(`}`)
+
+	case model.KindList:
+		w.linef(`o.%v = nil`, name)
+		w.linef(`if list := m.%v(); list.Len() > 0 {`, name)
+		w.linef(`o.%v = make(%v, list.Len())`, name, objectTypeName(typ))
+		w.linef(`for i := range o.%v {`, name)
+		w.linef(`o.%v[i] = %v`, name, objectElement(typ.Element, `list.Get(i)`))
+		w.line(`}`)
+		w.line(`}`)
+
+	case model.KindMap:
+		w.linef(`o.%v = nil`, name)
+		w.linef(`if map_ := m.%v(); map_.Len() > 0 {`, name)
+		w.linef(`o.%v = make(%v, map_.Len())`, name, objectTypeName(typ))
+		w.line(`for i := 0; i < map_.Len(); i++ {`)
+		w.linef(`key := %v`, objectValue(typ.Key, `map_.Key(i)`))
+		w.linef(`o.%v[key] = %v`, name, objectElement(typ.Element, `map_.Value(i)`))
+		w.line(`}`)
+		w.line(`}`)
+	}
+}
+
+// marshal
+
+func (w *objectWriter) marshal_methods(def *model.Definition) error {
+	name := objectName(def)
+
+	w.line(`// Marshal writes the object into a new message.`)
+	w.linef(`func (o *%v) Marshal() (%v, error) {`, name, def.Name)
+	w.linef(`w := New%vWriter()`, def.Name)
+	w.linef(`if err := o.MarshalTo(w.Unwrap()); err != nil {`)
+	w.linef(`return %v{}, err`, def.Name)
+	w.line(`}`)
+	w.line(`return w.Build()`)
+	w.line(`}`)
+	w.line()
+
+	w.line(`// MarshalTo writes the object fields to a message writer, does not end the writer.`)
+	w.line(`// Nil messages, empty lists and empty maps are skipped.`)
+	w.linef(`func (o *%v) MarshalTo(w spec.MessageWriter) error {`, name)
+	w.linef(`w1 := New%vWriterTo(w)`, def.Name)
+
+	for _, field := range def.Message.Fields.List {
+		if field.Oneof != nil {
+			continue
+		}
+		w.marshal_field(field)
+	}
+
+	for _, oneof := range def.Message.Oneofs {
+		w.linef(`switch o.%v {`, toUpperCamelCase(oneof.Name))
+		for _, field := range oneof.Fields {
+			w.linef(`case %v:`, oneofValueName(def, oneof, field))
+			w.marshal_field(field)
+		}
+		w.line(`}`)
+	}
+
+	w.line(`return w.Fail(nil)`)
+	w.line(`}`)
+	w.line()
+	return nil
+}
+
+func (w *objectWriter) marshal_field(field *model.Field) {
+	name := messageFieldName(field)
+	typ := field.Type
+
+	switch typ.Kind {
+	default:
+		w.linef(`w1.%v(o.%v)`, name, name)
+
+	case model.KindAny, model.KindAnyMessage:
+		w.linef(`if len(%v) > 0 {`, anyBytes(typ, "o."+name))
+		w.linef(`if err := w1.Copy%v(o.%v); err != nil {`, name, name)
+		w.line(`return err`)
+		w.line(`}`)
+		w.line(`}`)
+
+	case model.KindMessage:
+		// Selected oneof messages are written even when nil to keep the selection
+		if field.Oneof != nil {
+			w.linef(`w2 := w1.%v()`, name)
+			w.linef(`if o.%v != nil {`, name)
+		} else {
+			w.linef(`if o.%v != nil {`, name)
+			w.linef(`w2 := w1.%v()`, name)
+		}
+		w.linef(`if err := o.%v.MarshalTo(w2.Unwrap()); err != nil {`, name)
+		w.line(`return err`)
+		w.line(`}`)
+		if field.Oneof != nil {
+			w.line(`}`)
+		}
+		w.line(`if err := w2.End(); err != nil {`)
+		w.line(`return err`)
+		w.line(`}`)
+		if field.Oneof == nil {
+			w.line(`}`)
+		}
+
+	case model.KindList:
+		w.linef(`if len(o.%v) > 0 {`, name)
+		w.linef(`w2 := w1.%v()`, name)
+		w.linef(`for _, v := range o.%v {`, name)
+		if typ.Element.Kind == model.KindMessage {
+			w.marshal_element(`w2.Add()`)
+		} else {
+			w.line(`w2.Add(v)`)
+		}
+		w.line(`}`)
+		w.line(`if err := w2.End(); err != nil {`)
+		w.line(`return err`)
+		w.line(`}`)
+		w.line(`}`)
+
+	case model.KindMap:
+		w.linef(`if len(o.%v) > 0 {`, name)
+		w.linef(`w2 := w1.%v()`, name)
+		w.linef(`for k, v := range o.%v {`, name)
+		if typ.Element.Kind == model.KindMessage {
+			w.marshal_element(`w2.Put(k)`)
+		} else {
+			w.line(`w2.Put(k, v)`)
+		}
+		w.line(`}`)
+		w.line(`if err := w2.End(); err != nil {`)
+		w.line(`return err`)
+		w.line(`}`)
+		w.line(`}`)
+	}
+}
+
+// marshal_element writes a list or map message element, nil elements are written as empty messages.
+func (w *objectWriter) marshal_element(add string) {
+	w.linef(`w3 := %v`, add)
+	w.line(`if v != nil {`)
+	w.line(`if err := v.MarshalTo(w3.Unwrap()); err != nil {`)
+	w.line(`return err`)
+	w.line(`}`)
+	w.line(`}`)
+	w.line(`if err := w3.End(); err != nil {`)
+	w.line(`return err`)
+	w.line(`}`)
+}
+
+// debug
+
+// debug_methods writes String and Format methods which marshal the object into a message,
+// skips the methods which conflict with fields, i.e. for a "string" field.
+func (w *objectWriter) debug_methods(def *model.Definition) error {
+	name := objectName(def)
+	desc := descriptor_name(def)
+
+	names := make(map[string]bool)
+	for _, field := range def.Message.Fields.List {
+		names[messageFieldName(field)] = true
+	}
+	for _, oneof := range def.Message.Oneofs {
+		names[toUpperCamelCase(oneof.Name)] = true
+	}
+
+	if !names["String"] {
+		w.linef(`func (o %v) String() string {`, name)
+		w.line(`m, err := o.Marshal()`)
+		w.linef(`return spec.ObjectString(%v, m.Unwrap(), err)`, desc)
+		w.line(`}`)
+		w.line()
+	}
+
+	if !names["Format"] {
+		w.linef(`func (o %v) Format(f fmt.State, verb rune) {`, name)
+		w.line(`m, err := o.Marshal()`)
+		w.linef(`spec.FormatObject(f, verb, %v, m.Unwrap(), err)`, desc)
+		w.line(`}`)
+		w.line()
+	}
+	return nil
+}
+
+// util
+
+// objectsEnabled returns true if a package has the go_objects option.
+func objectsEnabled(pkg *model.Package) bool {
+	opt, ok := pkg.OptionNames[OptionObjects]
+	return ok && opt.Value == "true"
+}
+
+// objectName returns a message object name, i.e. "MessageObject".
+func objectName(def *model.Definition) string {
+	return def.Name + "Object"
+}
+
+// objectFieldTypeName returns an object field type name, or a custom Go type name.
+func (w *objectWriter) objectFieldTypeName(field *model.Field) string {
+	if t, _ := fieldGoType(field, w.goImports); t != nil {
+		return t.name
+	}
+	return objectTypeName(field.Type)
+}
+
+// objectTypeName returns an object type name.
+func objectTypeName(typ *model.Type) string {
+	switch typ.Kind {
+	case model.KindMessage:
+		return "*" + typeName(typ) + "Object"
+
+	case model.KindList:
+		elem := objectTypeName(typ.Element)
+		return fmt.Sprintf("[]%v", elem)
+
+	case model.KindMap:
+		key := objectTypeName(typ.Key)
+		elem := objectTypeName(typ.Element)
+		return fmt.Sprintf("map[%v]%v", key, elem)
+	}
+
+	return typeName(typ)
+}
+
+// objectNewFunc returns a message object constructor, i.e. "pkg2.NewSubmessageObject".
+func objectNewFunc(typ *model.Type) string {
+	if typ.Import != nil {
+		return fmt.Sprintf("%v.New%vObject", typ.ImportName, typ.Name)
+	}
+	return fmt.Sprintf("New%vObject", typ.Name)
+}
+
+// objectValue returns an expression which copies a value so that it does not reference a message.
+func objectValue(typ *model.Type, expr string) string {
+	switch typ.Kind {
+	case model.KindBytes,
+		model.KindString,
+		model.KindAnyMessage:
+		return expr + ".Clone()"
+
+	case model.KindAny:
+		return fmt.Sprintf("append(spec.Value(nil), %v...)", expr)
+	}
+	return expr
+}
+
+// objectElement returns an expression which copies a list or map element.
+func objectElement(typ *model.Type, expr string) string {
+	if typ.Kind == model.KindMessage {
+		return fmt.Sprintf("%v(%v)", objectNewFunc(typ), expr)
+	}
+	return objectValue(typ, expr)
+}
+
+// anyBytes returns an expression which returns any or any message bytes.
+func anyBytes(typ *model.Type, expr string) string {
+	if typ.Kind == model.KindAnyMessage {
+		return expr + ".Raw()"
+	}
+	return expr
+}
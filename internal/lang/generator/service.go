@@ -34,6 +34,7 @@ func (w *serviceWriter) service(def *model.Definition) error {
 func (w *serviceWriter) iface(def *model.Definition) error {
 	w.linef(`// %v`, def.Name)
 	w.line()
+	w.doc(def.Doc, def.Annotations)
 	w.linef(`type %v interface {`, def.Name)
 
 	for _, m := range def.Service.Methods {
@@ -48,6 +49,7 @@ func (w *serviceWriter) iface(def *model.Definition) error {
 }
 
 func (w *serviceWriter) method(def *model.Definition, m *model.Method) error {
+	w.doc(m.Doc, m.Annotations)
 	if err := w.method_input(def, m); err != nil {
 		return err
 	}
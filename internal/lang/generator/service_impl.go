@@ -184,6 +184,12 @@ func (w *serviceImplWriter) method(def *model.Definition, m *model.Method) error
 		name, toLowerCameCase(m.Name))
 	w.line(`ref.R[[]byte], status.Status) {`)
 
+	// Mark deprecated
+	if _, ok := m.Annotations.Deprecated(); ok {
+		w.line(`rpc.MarkDeprecated(ch)`)
+		w.line()
+	}
+
 	// Parse input
 	switch {
 	case m.Channel != nil:
@@ -10,6 +10,9 @@ import (
 	"github.com/basecomplextech/spec/internal/lang/model"
 )
 
+// AnnotationWellknown marks structs in the wellknown package.
+const AnnotationWellknown = "wellknown"
+
 type structWriter struct {
 	*writer
 }
@@ -31,12 +34,16 @@ func (w *structWriter) struct_(def *model.Definition) error {
 	if err := w.encode_method(def); err != nil {
 		return err
 	}
+	if err := w.debug_methods(def); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (w *structWriter) def(def *model.Definition) error {
 	w.linef(`// %v`, def.Name)
 	w.line()
+	w.doc(def.Doc, def.Annotations)
 	w.linef("type %v struct {", def.Name)
 
 	fields := def.Struct.Fields.Values()
@@ -44,6 +51,7 @@ func (w *structWriter) def(def *model.Definition) error {
 		name := structFieldName(field)
 		typ := typeName(field.Type)
 		goTag := fmt.Sprintf("`json:\"%v\"`", field.Name)
+		w.doc(field.Doc, field.Annotations)
 		w.linef("%v %v %v", name, typ, goTag)
 	}
 
@@ -144,3 +152,34 @@ func (w *structWriter) encode_method(def *model.Definition) error {
 func structFieldName(field *model.StructField) string {
 	return toUpperCamelCase(field.Name)
 }
+
+// debug_methods writes String and Format methods, skips the methods
+// which conflict with struct fields, i.e. for a "string" field.
+func (w *structWriter) debug_methods(def *model.Definition) error {
+	// Well-known structs implement String in the wellknown package
+	if _, ok := def.Annotations.Names[AnnotationWellknown]; ok {
+		return nil
+	}
+
+	desc := descriptor_name(def)
+
+	names := make(map[string]bool)
+	for _, field := range def.Struct.Fields.Values() {
+		names[structFieldName(field)] = true
+	}
+
+	if !names["String"] {
+		w.linef(`func (s %v) String() string {`, def.Name)
+		w.linef(`return spec.StructString(%v, s, Encode%vTo)`, desc, def.Name)
+		w.line(`}`)
+		w.line()
+	}
+
+	if !names["Format"] {
+		w.linef(`func (s %v) Format(f fmt.State, verb rune) {`, def.Name)
+		w.linef(`spec.FormatStruct(f, verb, %v, s, Encode%vTo)`, desc, def.Name)
+		w.line(`}`)
+		w.line()
+	}
+	return nil
+}
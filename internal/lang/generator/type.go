@@ -63,6 +63,14 @@ func typeName(typ *model.Type) string {
 		}
 		return fmt.Sprintf("spec.ValueList[%v]", elem)
 
+	case model.KindMap:
+		key := typeName(typ.Key)
+		elem := typeName(typ.Element)
+		if typ.Element.Kind == model.KindMessage {
+			return fmt.Sprintf("spec.MessageMap[%v, %v]", key, elem)
+		}
+		return fmt.Sprintf("spec.ValueMap[%v, %v]", key, elem)
+
 	case model.KindEnum,
 		model.KindMessage,
 		model.KindStruct:
@@ -96,6 +104,14 @@ func typeRefName(typ *model.Type) string {
 			return fmt.Sprintf("spec.MessageList[%v]", elem)
 		}
 		return fmt.Sprintf("spec.ValueList[%v]", elem)
+
+	case model.KindMap:
+		key := typeRefName(typ.Key)
+		elem := typeRefName(typ.Element)
+		if typ.Element.Kind == model.KindMessage {
+			return fmt.Sprintf("spec.MessageMap[%v, %v]", key, elem)
+		}
+		return fmt.Sprintf("spec.ValueMap[%v, %v]", key, elem)
 	}
 
 	return typeName(typ)
@@ -224,6 +240,14 @@ func typeDecodeFunc(typ *model.Type) string {
 		}
 		return fmt.Sprintf("spec.OpenValueListErr[%v]", name)
 
+	case model.KindMap:
+		key := typeName(typ.Key)
+		name := typeName(typ.Element)
+		if typ.Element.Kind == model.KindMessage {
+			return fmt.Sprintf("spec.OpenMessageMapErr[%v, %v]", key, name)
+		}
+		return fmt.Sprintf("spec.OpenValueMapErr[%v, %v]", key, name)
+
 	case model.KindEnum,
 		model.KindStruct:
 		if typ.Import != nil {
@@ -316,6 +340,13 @@ func typeWriteFunc(typ *model.Type) string {
 		}
 		return fmt.Sprintf("spec.NewValueListWriter")
 
+	case model.KindMap:
+		elem := typ.Element
+		if elem.Kind == model.KindMessage {
+			return fmt.Sprintf("spec.NewMessageMapWriter")
+		}
+		return fmt.Sprintf("spec.NewValueMapWriter")
+
 	case model.KindMessage:
 		if typ.Import != nil {
 			return fmt.Sprintf("%v.New%vWriterTo", typ.ImportName, typ.Name)
@@ -346,6 +377,17 @@ func typeWriter(typ *model.Type) string {
 		elemName := inTypeName(elem)
 		return fmt.Sprintf("spec.ValueListWriter[%v]", elemName)
 
+	case model.KindMap:
+		keyName := inTypeName(typ.Key)
+		elem := typ.Element
+		if elem.Kind == model.KindMessage {
+			encoder := typeWriter(elem)
+			return fmt.Sprintf("spec.MessageMapWriter[%v, %v]", keyName, encoder)
+		}
+
+		elemName := inTypeName(elem)
+		return fmt.Sprintf("spec.ValueMapWriter[%v, %v]", keyName, elemName)
+
 	case model.KindMessage:
 		if typ.Import != nil {
 			return fmt.Sprintf("%v.%vWriter", typ.ImportName, typ.Name)
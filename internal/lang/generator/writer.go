@@ -16,13 +16,17 @@ type writer struct {
 	b bytes.Buffer
 
 	skipRPC bool
+	objects bool
+
+	goImports map[string]string // custom Go type import aliases by paths
 }
 
-func newWriter(skipRPC bool) *writer {
+func newWriter(skipRPC bool, objects bool) *writer {
 	return &writer{
 		b: bytes.Buffer{},
 
 		skipRPC: skipRPC,
+		objects: objects,
 	}
 }
 
@@ -52,6 +56,39 @@ func (w *writer) writef(format string, args ...interface{}) {
 	w.b.WriteString(s)
 }
 
+// comment writes a doc comment if not empty.
+func (w *writer) comment(doc string) {
+	if doc == "" {
+		return
+	}
+
+	for _, line := range strings.Split(doc, "\n") {
+		if line == "" {
+			w.line("//")
+		} else {
+			w.line("// ", line)
+		}
+	}
+}
+
+// doc writes a doc comment and a deprecation notice when deprecated.
+func (w *writer) doc(doc string, annots *model.Annotations) {
+	w.comment(doc)
+
+	reason, ok := annots.Deprecated()
+	if !ok {
+		return
+	}
+	if reason == "" {
+		reason = "Do not use."
+	}
+
+	if doc != "" {
+		w.line("//")
+	}
+	w.line("// Deprecated: ", reason)
+}
+
 func (w *writer) file(file *model.File) error {
 	return newFileWriter(w).file(file)
 }
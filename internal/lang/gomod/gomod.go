@@ -0,0 +1,139 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package gomod resolves import paths to directories through the local Go module graph.
+//
+// The module graph is loaded with "go list -m -json all" with the proxy disabled,
+// so only the main module, workspace modules, replace directives and modules
+// already in the module cache are available.
+package gomod
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Module is a module in the module graph.
+type Module struct {
+	Path    string // module path, i.e. "github.com/example/schemas"
+	Version string // module version, empty in the main module
+	Dir     string // module directory, empty when the module is not in the module cache
+	Main    bool   // main or workspace module
+}
+
+// Modules is a module graph.
+type Modules struct {
+	list []*Module // sorted by path length in descending order
+}
+
+// Load loads a module graph of a module which contains a directory,
+// returns an empty graph when the directory is not in a module or the go command is not found.
+func Load(dir string) (*Modules, error) {
+	cmd := exec.Command("go", "list", "-m", "-json", "all")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GOPROXY=off",
+		"GOFLAGS=-mod=readonly",
+	)
+
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+
+	out, err := cmd.Output()
+	switch {
+	case errors.Is(err, exec.ErrNotFound):
+		return &Modules{}, nil
+	case err != nil:
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "go.mod file not found") ||
+			strings.Contains(msg, "not using modules") {
+			return &Modules{}, nil
+		}
+		return nil, fmt.Errorf("go list modules in %v: %v", dir, msg)
+	}
+
+	return parseModules(out)
+}
+
+// Resolve returns a directory of an import path, returns false when no module contains it,
+// returns an error when a module contains the path but is not in the module cache.
+func (m *Modules) Resolve(path string) (string, bool, error) {
+	for _, mod := range m.list {
+		rel, ok := relative(mod.Path, path)
+		if !ok {
+			continue
+		}
+
+		if mod.Dir == "" {
+			return "", false, fmt.Errorf("module %v@%v is not in the module cache, run go mod download",
+				mod.Path, mod.Version)
+		}
+		return filepath.Join(mod.Dir, filepath.FromSlash(rel)), true, nil
+	}
+	return "", false, nil
+}
+
+// internal
+
+type listModule struct {
+	Path    string
+	Version string
+	Dir     string
+	Main    bool
+	Replace *listModule
+}
+
+func parseModules(b []byte) (*Modules, error) {
+	var list []*Module
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	for {
+		var lm listModule
+		err := dec.Decode(&lm)
+		switch {
+		case err == io.EOF:
+			sort.SliceStable(list, func(i, j int) bool {
+				return len(list[i].Path) > len(list[j].Path)
+			})
+			return &Modules{list: list}, nil
+		case err != nil:
+			return nil, err
+		}
+
+		mod := &Module{
+			Path:    lm.Path,
+			Version: lm.Version,
+			Dir:     lm.Dir,
+			Main:    lm.Main,
+		}
+
+		// Replaced modules are read from their replacement directories
+		if r := lm.Replace; r != nil {
+			mod.Dir = r.Dir
+			if r.Version != "" {
+				mod.Version = r.Version
+			}
+		}
+		list = append(list, mod)
+	}
+}
+
+// relative returns a path relative to a module path, or false when the module does not contain it.
+func relative(module string, path string) (string, bool) {
+	switch {
+	case path == module:
+		return "", true
+	case strings.HasPrefix(path, module+"/"):
+		return path[len(module)+1:], true
+	}
+	return "", false
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package gomod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testWriteFile(t *testing.T, path string, data string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(data), 0644))
+}
+
+// testModules writes an app module which requires a replaced schemas module.
+func testModules(t *testing.T) (string, string) {
+	root := t.TempDir()
+	app := filepath.Join(root, "app")
+	schemas := filepath.Join(root, "schemas")
+
+	testWriteFile(t, filepath.Join(app, "go.mod"), `module example.com/app
+
+go 1.24
+
+require example.com/schemas v0.0.0
+
+replace example.com/schemas => ../schemas
+`)
+	testWriteFile(t, filepath.Join(schemas, "go.mod"), `module example.com/schemas
+
+go 1.24
+`)
+	return app, schemas
+}
+
+func TestLoad__should_resolve_main_and_replaced_modules(t *testing.T) {
+	app, schemas := testModules(t)
+
+	modules, err := Load(app)
+	require.NoError(t, err)
+
+	dir, ok, err := modules.Resolve("example.com/schemas/common")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(schemas, "common"), dir)
+
+	dir, ok, err = modules.Resolve("example.com/app/api")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join(app, "api"), dir)
+
+	_, ok, err = modules.Resolve("example.com/other")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestLoad__should_return_empty_modules_outside_of_module(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("GOWORK", "off")
+
+	modules, err := Load(dir)
+	require.NoError(t, err)
+
+	_, ok, err := modules.Resolve("example.com/schemas")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestModules_Resolve__should_prefer_longest_module_path(t *testing.T) {
+	modules, err := parseModules([]byte(`
+{"Path": "example.com/schemas", "Dir": "/schemas"}
+{"Path": "example.com/schemas/v2", "Dir": "/schemas-v2"}
+{"Path": "example.com/missing", "Version": "v1.0.0"}
+`))
+	require.NoError(t, err)
+
+	dir, ok, err := modules.Resolve("example.com/schemas/v2/common")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, filepath.Join("/schemas-v2", "common"), dir)
+
+	_, _, err = modules.Resolve("example.com/missing/common")
+	assert.Error(t, err)
+}
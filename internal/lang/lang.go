@@ -5,19 +5,28 @@
 package lang
 
 import (
+	"fmt"
+	"os"
+
+	"github.com/basecomplextech/spec/internal/lang/compat"
 	"github.com/basecomplextech/spec/internal/lang/compiler"
 	"github.com/basecomplextech/spec/internal/lang/generator"
+	"github.com/basecomplextech/spec/internal/lang/lint"
+	"github.com/basecomplextech/spec/lang"
+	"github.com/basecomplextech/spec/plugin"
 )
 
 type Spec struct {
 	importPath []string
 	skipRPC    bool
+	objects    bool
 }
 
-func New(importPath []string, skipRPC bool) *Spec {
+func New(importPath []string, skipRPC bool, objects bool) *Spec {
 	return &Spec{
 		importPath: importPath,
 		skipRPC:    skipRPC,
+		objects:    objects,
 	}
 }
 
@@ -38,6 +47,70 @@ func (s *Spec) Generate(srcPath string, dstPath string) error {
 		return err
 	}
 
-	gen := generator.New(s.skipRPC)
+	// Print warnings
+	for _, warning := range pkg.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", warning)
+	}
+
+	gen := generator.New(s.skipRPC, s.objects)
 	return gen.Package(pkg, dstPath)
 }
+
+// GeneratePlugins compiles a package and runs spec-gen-<name> plugins instead of the Go generator.
+func (s *Spec) GeneratePlugins(srcPath string, dstPath string, plugins []string) error {
+	if dstPath == "" {
+		dstPath = srcPath
+	}
+
+	pkg, err := lang.Compile(srcPath, lang.Options{
+		ImportPaths: s.importPath,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Print warnings
+	for _, warning := range pkg.Warnings {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", warning)
+	}
+
+	for _, name := range plugins {
+		if err := plugin.Exec(name, pkg, dstPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compat compiles an old and a new package and returns breaking wire changes and notes.
+func (s *Spec) Compat(oldPath string, newPath string) ([]compat.Change, error) {
+	compiler, err := compiler.New(compiler.Options{
+		ImportPath: s.importPath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := compiler.Compile(oldPath)
+	if err != nil {
+		return nil, err
+	}
+	new, err := compiler.Compile(newPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return compat.Check(old, new), nil
+}
+
+// Lint compiles a package and returns lint issues.
+func (s *Spec) Lint(srcPath string, config lint.Config) ([]*lint.Issue, error) {
+	pkg, err := lang.Compile(srcPath, lang.Options{
+		ImportPaths: s.importPath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return lint.Lint(pkg, config)
+}
@@ -0,0 +1,10 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package langindex lets the plugin decoder build indexes of public lang packages,
+// which are read-only and do not expose a way to build them.
+package langindex
+
+// Package builds a definition index of a *lang.Package, set by the lang package.
+var Package func(pkg any)
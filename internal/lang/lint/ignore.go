@@ -0,0 +1,121 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/parser"
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+	"github.com/basecomplextech/spec/lang"
+)
+
+const (
+	ignoreDirective     = "lint:ignore"
+	fileIgnoreDirective = "lint:file-ignore"
+)
+
+// ignores are suppressed rules by file paths.
+type ignores map[string]*fileIgnores
+
+type fileIgnores struct {
+	file  map[string]struct{}         // rules ignored in the whole file
+	lines map[int]map[string]struct{} // rules ignored by lines
+}
+
+// readIgnores parses package files and reads ignore comments.
+func readIgnores(pkg *lang.Package) (ignores, error) {
+	p := parser.New()
+	result := make(ignores)
+
+	for _, file := range pkg.Files {
+		pfile, err := p.ParseFile(file.Path)
+		if err != nil {
+			return nil, err
+		}
+		result[file.Path] = parseIgnores(pfile.Comments)
+	}
+	return result, nil
+}
+
+// parseIgnores parses ignore comments in a file.
+func parseIgnores(comments []*syntax.Comment) *fileIgnores {
+	ig := &fileIgnores{
+		file:  make(map[string]struct{}),
+		lines: make(map[int]map[string]struct{}),
+	}
+
+	// Collect comment lines to skip them when looking for the next line
+	commentLines := make(map[int]bool)
+	for _, c := range comments {
+		if c.Trailing {
+			continue
+		}
+		for line := c.Pos.Line; line <= c.EndLine(); line++ {
+			commentLines[line] = true
+		}
+	}
+
+	for _, c := range comments {
+		text := strings.TrimPrefix(c.Text, "//")
+		text = strings.TrimSpace(text)
+
+		switch {
+		case strings.HasPrefix(text, fileIgnoreDirective+" "):
+			for _, rule := range ignoreRules(text[len(fileIgnoreDirective):]) {
+				ig.file[rule] = struct{}{}
+			}
+
+		case strings.HasPrefix(text, ignoreDirective+" "):
+			line := c.Pos.Line
+			if !c.Trailing {
+				line = c.EndLine() + 1
+				for commentLines[line] {
+					line++
+				}
+			}
+
+			rules, ok := ig.lines[line]
+			if !ok {
+				rules = make(map[string]struct{})
+				ig.lines[line] = rules
+			}
+			for _, rule := range ignoreRules(text[len(ignoreDirective):]) {
+				rules[rule] = struct{}{}
+			}
+		}
+	}
+	return ig
+}
+
+// ignoreRules returns comma-separated rule names, the rest of the comment is a reason.
+func ignoreRules(s string) []string {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	var rules []string
+	for _, rule := range strings.Split(fields[0], ",") {
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// ignored returns true if a rule is ignored at a position.
+func (ig ignores) ignored(rule string, pos lang.Position) bool {
+	f, ok := ig[pos.Path]
+	if !ok {
+		return false
+	}
+
+	if _, ok := f.file[rule]; ok {
+		return true
+	}
+	_, ok = f.lines[pos.Line][rule]
+	return ok
+}
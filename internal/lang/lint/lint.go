@@ -0,0 +1,197 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package lint checks compiled packages for style and safety issues which are not errors.
+//
+// Each rule has a default severity which can be overridden in a config, rules with
+// the off severity are skipped. Issues can be suppressed with comments:
+//
+//	// lint:ignore rule1,rule2 optional reason
+//	field int64 1; // lint:ignore field-name
+//
+//	// lint:file-ignore rule
+//
+// A trailing comment suppresses issues on its line, a standalone comment suppresses issues
+// on the next non-comment line, a file-ignore comment suppresses issues in the whole file.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+// Severity is an issue severity.
+type Severity int
+
+const (
+	SeverityOff Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+// ParseSeverity parses a severity name, i.e. "off", "info", "warning" or "error".
+func ParseSeverity(s string) (Severity, error) {
+	switch s {
+	case "off":
+		return SeverityOff, nil
+	case "info":
+		return SeverityInfo, nil
+	case "warning":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	}
+	return SeverityOff, fmt.Errorf("unknown lint severity %q", s)
+}
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityOff:
+		return "off"
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// Rule is a lint rule.
+type Rule struct {
+	Name     string
+	Doc      string
+	Severity Severity // default severity
+
+	check func(r *reporter, pkg *lang.Package)
+}
+
+// Rules returns all registered rules.
+func Rules() []*Rule {
+	return append([]*Rule(nil), registry...)
+}
+
+// LookupRule returns a rule by its name.
+func LookupRule(name string) (*Rule, bool) {
+	for _, rule := range registry {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return nil, false
+}
+
+// Config overrides rule severities.
+type Config struct {
+	Severities map[string]Severity // severities by rule names
+}
+
+// ParseConfig parses "rule=severity" pairs.
+func ParseConfig(pairs []string) (Config, error) {
+	config := Config{Severities: make(map[string]Severity)}
+
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return Config{}, fmt.Errorf("invalid lint rule %q, expected rule=severity", pair)
+		}
+
+		name = strings.TrimSpace(name)
+		if _, ok := LookupRule(name); !ok {
+			return Config{}, fmt.Errorf("unknown lint rule %q", name)
+		}
+
+		severity, err := ParseSeverity(strings.TrimSpace(value))
+		if err != nil {
+			return Config{}, err
+		}
+		config.Severities[name] = severity
+	}
+	return config, nil
+}
+
+// severity returns a rule severity from the config or the rule default severity.
+func (c Config) severity(rule *Rule) Severity {
+	if s, ok := c.Severities[rule.Name]; ok {
+		return s
+	}
+	return rule.Severity
+}
+
+// Issue is a lint issue.
+type Issue struct {
+	Pos      lang.Position
+	Rule     string
+	Severity Severity
+	Msg      string
+}
+
+// String returns "path:line:column: severity: message (rule)".
+func (i *Issue) String() string {
+	return fmt.Sprintf("%v: %v: %v (%v)", i.Pos, i.Severity, i.Msg, i.Rule)
+}
+
+// Lint checks a package and returns issues sorted by positions.
+func Lint(pkg *lang.Package, config Config) ([]*Issue, error) {
+	ignores, err := readIgnores(pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []*Issue
+	for _, rule := range registry {
+		severity := config.severity(rule)
+		if severity == SeverityOff {
+			continue
+		}
+
+		r := &reporter{
+			rule:     rule,
+			severity: severity,
+			ignores:  ignores,
+		}
+		rule.check(r, pkg)
+		issues = append(issues, r.issues...)
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i].Pos, issues[j].Pos
+		switch {
+		case a.Path != b.Path:
+			return a.Path < b.Path
+		case a.Line != b.Line:
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return issues, nil
+}
+
+// internal
+
+type reporter struct {
+	rule     *Rule
+	severity Severity
+	ignores  ignores
+	issues   []*Issue
+}
+
+func (r *reporter) reportf(pos lang.Position, format string, args ...any) {
+	if r.ignores.ignored(r.rule.Name, pos) {
+		return
+	}
+
+	issue := &Issue{
+		Pos:      pos,
+		Rule:     r.rule.Name,
+		Severity: r.severity,
+		Msg:      fmt.Sprintf(format, args...),
+	}
+	r.issues = append(r.issues, issue)
+}
@@ -0,0 +1,196 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testLint compiles a "test" package and lints it with rule=severity pairs.
+func testLint(t *testing.T, src string, rules ...string) []*Issue {
+	dir := filepath.Join(t.TempDir(), "test")
+	require.NoError(t, os.Mkdir(dir, 0755))
+
+	path := filepath.Join(dir, "test.spec")
+	require.NoError(t, os.WriteFile(path, []byte(src), 0644))
+
+	config, err := ParseConfig(rules)
+	require.NoError(t, err)
+
+	pkg, err := lang.Compile(dir, lang.Options{})
+	require.NoError(t, err)
+
+	issues, err := Lint(pkg, config)
+	require.NoError(t, err)
+	return issues
+}
+
+// Rules
+
+func TestLint__should_check_field_and_enum_value_names(t *testing.T) {
+	src := `enum Enum {
+    UNDEFINED = 0;
+    SecondValue = 1;
+}
+
+message Message {
+    enum1 Enum 1;
+    userName string 2;
+}
+
+struct Struct {
+    Key int32;
+}
+`
+	issues := testLint(t, src, "unused=off")
+	require.Len(t, issues, 3)
+
+	assert.Equal(t, "enum-value-name", issues[0].Rule)
+	assert.Equal(t, 3, issues[0].Pos.Line)
+	assert.Equal(t, "field-name", issues[1].Rule)
+	assert.Equal(t, "field Message.userName should be lower_snake_case", issues[1].Msg)
+	assert.Equal(t, "field-name", issues[2].Rule)
+	assert.Equal(t, SeverityWarning, issues[2].Severity)
+}
+
+func TestLint__should_check_unreserved_tag_gaps(t *testing.T) {
+	src := `message Message {
+    a int64 1;
+    b int64 5;
+    c int64 10;
+
+    reserved 6 to 9;
+}
+`
+	issues := testLint(t, src, "unused=off")
+	require.Len(t, issues, 1)
+
+	assert.Equal(t, "tag-gap", issues[0].Rule)
+	assert.Equal(t, 3, issues[0].Pos.Line)
+	assert.Contains(t, issues[0].Msg, "tag gap 2 to 4 before field b")
+}
+
+func TestLint__should_check_big_tags(t *testing.T) {
+	src := `message Message {
+    a int64 1;
+    b int64 256;
+    c int64 257;
+
+    reserved 2 to 255;
+}
+`
+	issues := testLint(t, src, "unused=off")
+	require.Len(t, issues, 1)
+
+	assert.Equal(t, "big-tag", issues[0].Rule)
+	assert.Equal(t, 3, issues[0].Pos.Line)
+}
+
+func TestLint__should_check_method_message_names(t *testing.T) {
+	src := `service Service {
+    create(CreateRequest) CreateResponse;
+    delete(ServiceDeleteRequest) Result;
+    update(id int64 1) (ok bool 1);
+}
+
+message CreateRequest {}
+message CreateResponse {}
+message ServiceDeleteRequest {}
+message Result {}
+`
+	issues := testLint(t, src)
+	require.Len(t, issues, 1)
+
+	assert.Equal(t, "method-message-name", issues[0].Rule)
+	assert.Equal(t, 3, issues[0].Pos.Line)
+	assert.Contains(t, issues[0].Msg, "message Result should be named DeleteResponse or ServiceDeleteResponse")
+}
+
+func TestLint__should_check_unused_definitions(t *testing.T) {
+	src := `message Message {
+    self Message 1;
+    value Value 2;
+}
+
+message Value {}
+`
+	issues := testLint(t, src)
+	require.Len(t, issues, 1)
+
+	assert.Equal(t, "unused", issues[0].Rule)
+	assert.Equal(t, SeverityInfo, issues[0].Severity)
+	assert.Equal(t, "message Message is not used in package test", issues[0].Msg)
+}
+
+// Config
+
+func TestLint__should_override_rule_severities(t *testing.T) {
+	src := `message Message {
+    userName string 1;
+}
+`
+	issues := testLint(t, src, "field-name=error", "unused=off")
+	require.Len(t, issues, 1)
+	assert.Equal(t, SeverityError, issues[0].Severity)
+}
+
+func TestParseConfig__should_return_error_on_unknown_rule_or_severity(t *testing.T) {
+	_, err := ParseConfig([]string{"unknown=error"})
+	assert.Error(t, err)
+
+	_, err = ParseConfig([]string{"tag-gap=fatal"})
+	assert.Error(t, err)
+
+	_, err = ParseConfig([]string{"tag-gap"})
+	assert.Error(t, err)
+}
+
+// Ignore
+
+func TestLint__should_suppress_issues_with_ignore_comments(t *testing.T) {
+	src := `// lint:file-ignore unused
+
+message Message {
+    userName string 1; // lint:ignore field-name legacy name
+
+    // lint:ignore tag-gap,field-name
+    // Doc comment.
+    LastName string 10;
+
+    firstName string 11;
+}
+`
+	issues := testLint(t, src)
+	require.Len(t, issues, 1)
+
+	assert.Equal(t, "field-name", issues[0].Rule)
+	assert.Equal(t, 10, issues[0].Pos.Line)
+}
+
+func TestLint__should_format_issue(t *testing.T) {
+	issue := &Issue{
+		Pos:      lang.Position{Path: "test.spec", Line: 2, Column: 5},
+		Rule:     "field-name",
+		Severity: SeverityWarning,
+		Msg:      "field Message.userName should be lower_snake_case",
+	}
+
+	s := issue.String()
+	assert.Equal(t, "test.spec:2:5: warning: field Message.userName should be lower_snake_case (field-name)", s)
+}
+
+func TestRules__should_have_unique_names(t *testing.T) {
+	names := make(map[string]bool)
+	for _, rule := range Rules() {
+		assert.False(t, names[rule.Name], rule.Name)
+		names[rule.Name] = true
+	}
+}
@@ -0,0 +1,270 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/format"
+	"github.com/basecomplextech/spec/lang"
+)
+
+// registry is a list of all rules in the order they are checked.
+var registry = []*Rule{
+	{
+		Name:     "field-name",
+		Doc:      "message and struct field names are lower_snake_case",
+		Severity: SeverityWarning,
+		check:    checkFieldNames,
+	},
+	{
+		Name:     "enum-value-name",
+		Doc:      "enum value names are UPPER_SNAKE_CASE",
+		Severity: SeverityWarning,
+		check:    checkEnumValueNames,
+	},
+	{
+		Name:     "tag-gap",
+		Doc:      "message tags have no gaps which are not reserved",
+		Severity: SeverityWarning,
+		check:    checkTagGaps,
+	},
+	{
+		Name:     "big-tag",
+		Doc:      "message tags fit into one byte, larger tags force big message tables",
+		Severity: SeverityWarning,
+		check:    checkBigTags,
+	},
+	{
+		Name:     "method-message-name",
+		Doc:      "method request and response messages are named after their methods",
+		Severity: SeverityWarning,
+		check:    checkMethodMessageNames,
+	},
+	{
+		Name:     "unused",
+		Doc:      "definitions are referenced in their package, services are always used",
+		Severity: SeverityInfo,
+		check:    checkUnused,
+	},
+}
+
+var (
+	snakeCase      = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+	upperSnakeCase = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+// field-name
+
+func checkFieldNames(r *reporter, pkg *lang.Package) {
+	for _, def := range pkg.Definitions {
+		switch def.Type {
+		case lang.DefinitionMessage:
+			for _, field := range def.Message.Fields {
+				if !snakeCase.MatchString(field.Name) {
+					r.reportf(field.Pos, "field %v.%v should be lower_snake_case", def.Name, field.Name)
+				}
+			}
+
+		case lang.DefinitionStruct:
+			for _, field := range def.Struct.Fields {
+				if !snakeCase.MatchString(field.Name) {
+					r.reportf(field.Pos, "field %v.%v should be lower_snake_case", def.Name, field.Name)
+				}
+			}
+		}
+	}
+}
+
+// enum-value-name
+
+func checkEnumValueNames(r *reporter, pkg *lang.Package) {
+	for _, def := range pkg.Definitions {
+		if def.Type != lang.DefinitionEnum {
+			continue
+		}
+
+		for _, value := range def.Enum.Values {
+			if !upperSnakeCase.MatchString(value.Name) {
+				r.reportf(value.Pos, "enum value %v.%v should be UPPER_SNAKE_CASE", def.Name, value.Name)
+			}
+		}
+	}
+}
+
+// tag-gap
+
+func checkTagGaps(r *reporter, pkg *lang.Package) {
+	for _, def := range pkg.Definitions {
+		if def.Type != lang.DefinitionMessage {
+			continue
+		}
+
+		msg := def.Message
+		fields := append([]*lang.Field(nil), msg.Fields...)
+		sort.Slice(fields, func(i, j int) bool {
+			return fields[i].Tag < fields[j].Tag
+		})
+
+		prev := 0
+		for _, field := range fields {
+			gaps := unreserved(msg.Reserved, prev+1, field.Tag-1)
+			prev = field.Tag
+
+			if len(gaps) == 0 {
+				continue
+			}
+			r.reportf(field.Pos, "message %v has a tag gap %v before field %v, reserve it or renumber fields",
+				def.Name, strings.Join(gaps, ", "), field.Name)
+		}
+	}
+}
+
+// unreserved returns unreserved tag ranges in [start, end], i.e. "3" or "5 to 9".
+func unreserved(reserved lang.Reserved, start int, end int) []string {
+	var result []string
+
+	for n := start; n <= end; n++ {
+		if reserved.Contains(n) {
+			continue
+		}
+
+		m := n
+		for m < end && !reserved.Contains(m+1) {
+			m++
+		}
+
+		if m == n {
+			result = append(result, fmt.Sprint(n))
+		} else {
+			result = append(result, fmt.Sprintf("%d to %d", n, m))
+		}
+		n = m
+	}
+	return result
+}
+
+// big-tag
+
+func checkBigTags(r *reporter, pkg *lang.Package) {
+	for _, def := range pkg.Definitions {
+		if def.Type != lang.DefinitionMessage {
+			continue
+		}
+
+		for _, field := range def.Message.Fields {
+			fields := []format.MessageField{{Tag: uint16(field.Tag)}}
+			if !format.IsBigMessage(fields) {
+				continue
+			}
+
+			r.reportf(field.Pos, "field %v.%v tag %d > 255 makes %v a big message with a larger field table",
+				def.Name, field.Name, field.Tag, def.Name)
+			break
+		}
+	}
+}
+
+// method-message-name
+
+func checkMethodMessageNames(r *reporter, pkg *lang.Package) {
+	for _, def := range pkg.Definitions {
+		if def.Type != lang.DefinitionService {
+			continue
+		}
+
+		for _, method := range def.Service.Methods {
+			name := toUpperCamelCase(method.Name)
+			checkMethodMessageName(r, def, method, method.Request, name+"Request")
+			checkMethodMessageName(r, def, method, method.Response, name+"Response")
+		}
+	}
+}
+
+// checkMethodMessageName checks that an explicit request or response message is named
+// "MethodRequest" or "ServiceMethodRequest", generated messages are always named correctly.
+func checkMethodMessageName(r *reporter, srv *lang.Definition, method *lang.Method,
+	typ *lang.Type, expected string) {
+
+	if typ == nil || typ.Ref == nil || typ.Ref.Type != lang.DefinitionMessage {
+		return
+	}
+	if typ.Ref.Generated {
+		return
+	}
+
+	name := typ.Ref.Name
+	if name == expected || name == srv.Name+expected {
+		return
+	}
+	r.reportf(method.Pos, "method %v.%v message %v should be named %v or %v%v",
+		srv.Name, method.Name, typ, expected, srv.Name, expected)
+}
+
+// unused
+
+func checkUnused(r *reporter, pkg *lang.Package) {
+	used := make(map[*lang.Definition]bool)
+
+	use := func(from *lang.Definition, typ *lang.Type) {
+		for typ != nil {
+			if typ.Ref != nil && typ.Ref != from {
+				used[typ.Ref] = true
+			}
+			if typ.Key != nil && typ.Key.Ref != nil && typ.Key.Ref != from {
+				used[typ.Key.Ref] = true
+			}
+			typ = typ.Element
+		}
+	}
+
+	for _, def := range pkg.Definitions {
+		switch def.Type {
+		case lang.DefinitionMessage:
+			for _, field := range def.Message.Fields {
+				use(def, field.Type)
+			}
+
+		case lang.DefinitionStruct:
+			for _, field := range def.Struct.Fields {
+				use(def, field.Type)
+			}
+
+		case lang.DefinitionService:
+			for _, m := range def.Service.Methods {
+				use(def, m.Request)
+				use(def, m.Response)
+				use(def, m.Subservice)
+				if m.Channel != nil {
+					use(def, m.Channel.In)
+					use(def, m.Channel.Out)
+				}
+			}
+		}
+	}
+
+	for _, def := range pkg.Definitions {
+		if def.Generated || def.Type == lang.DefinitionService || used[def] {
+			continue
+		}
+		r.reportf(def.Pos, "%v %v is not used in package %v", def.Type, def.Name, pkg.Name)
+	}
+}
+
+// util
+
+func toUpperCamelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+		parts[i] = strings.ToUpper(part[:1]) + strings.ToLower(part[1:])
+	}
+	return strings.Join(parts, "")
+}
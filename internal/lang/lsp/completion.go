@@ -0,0 +1,111 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"strings"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+// builtinTypes are builtin type names offered in completion.
+var builtinTypes = []string{
+	"any",
+	"bool",
+	"byte",
+
+	"int16",
+	"int32",
+	"int64",
+
+	"uint16",
+	"uint32",
+	"uint64",
+
+	"float32",
+	"float64",
+
+	"bin64",
+	"bin128",
+	"bin256",
+
+	"bytes",
+	"string",
+	"message",
+}
+
+// completion returns type names and imported packages,
+// or definitions from an imported package after "pkg.".
+func (s *server) completion(params textDocumentPositionParams) []completionItem {
+	path := uriPath(params.TextDocument.URI)
+	file := s.file(path)
+	if file == nil {
+		return []completionItem{}
+	}
+
+	text, ok := s.source(path)
+	if !ok {
+		return []completionItem{}
+	}
+	prefix := prefixAt(text, params.Position)
+
+	// Imported definitions
+	if name, _, ok := strings.Cut(prefix, "."); ok {
+		imp := fileImport(file, name)
+		if imp == nil || imp.Package == nil {
+			return []completionItem{}
+		}
+		return definitionItems(imp.Package)
+	}
+
+	// Builtin types, local definitions and imports
+	items := make([]completionItem, 0, len(builtinTypes))
+	for _, name := range builtinTypes {
+		items = append(items, completionItem{
+			Label: name,
+			Kind:  completionKindKeyword,
+		})
+	}
+	items = append(items, definitionItems(file.Package)...)
+
+	for _, imp := range file.Imports {
+		items = append(items, completionItem{
+			Label:  imp.Name,
+			Kind:   completionKindModule,
+			Detail: imp.ID,
+		})
+	}
+	return items
+}
+
+// definitionItems returns completion items for package definitions, skips generated ones.
+func definitionItems(pkg *lang.Package) []completionItem {
+	items := []completionItem{}
+	for _, def := range pkg.Definitions {
+		if def.Generated {
+			continue
+		}
+
+		item := completionItem{
+			Label:  def.Name,
+			Kind:   completionKind(def.Type),
+			Detail: string(def.Type),
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func completionKind(typ lang.DefinitionType) int {
+	switch typ {
+	case lang.DefinitionEnum:
+		return completionKindEnum
+	case lang.DefinitionStruct:
+		return completionKindStruct
+	case lang.DefinitionService:
+		return completionKindIface
+	}
+	return completionKindClass
+}
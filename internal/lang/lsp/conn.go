@@ -0,0 +1,134 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// maxContentLength is the maximum message length, larger messages are skipped.
+const maxContentLength = 64 << 20
+
+// conn reads and writes JSON-RPC messages with Content-Length headers.
+type conn struct {
+	r *bufio.Reader
+	w io.Writer
+}
+
+func newConn(r io.Reader, w io.Writer) *conn {
+	return &conn{
+		r: bufio.NewReader(r),
+		w: w,
+	}
+}
+
+// read reads the next message, returns io.EOF when the input is closed.
+func (c *conn) read() (*message, error) {
+	length := -1
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid header %q", line)
+		}
+		if strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid content length %q", value)
+			}
+		}
+	}
+	if length < 0 {
+		return nil, fmt.Errorf("missing content length")
+	}
+	if length > maxContentLength {
+		// Skip the content to read the next message
+		if _, err := io.CopyN(io.Discard, c.r, int64(length)); err != nil {
+			return nil, err
+		}
+
+		msg := fmt.Sprintf("content length %d exceeds maximum %d", length, maxContentLength)
+		return nil, &responseError{Code: codeParseError, Message: msg}
+	}
+
+	b := make([]byte, length)
+	if _, err := io.ReadFull(c.r, b); err != nil {
+		return nil, err
+	}
+
+	msg := &message{}
+	if err := json.Unmarshal(b, msg); err != nil {
+		return nil, &responseError{Code: codeParseError, Message: err.Error()}
+	}
+	return msg, nil
+}
+
+// write writes a message.
+func (c *conn) write(msg *message) error {
+	msg.JSONRPC = jsonrpcVersion
+
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n", len(b)); err != nil {
+		return err
+	}
+	_, err = c.w.Write(b)
+	return err
+}
+
+// reply writes a response to a request.
+func (c *conn) reply(id *json.RawMessage, result any, err error) error {
+	msg := &message{ID: id}
+
+	switch e := err.(type) {
+	case nil:
+		if result == nil {
+			result = json.RawMessage("null")
+		}
+		msg.Result = result
+	case *responseError:
+		msg.Error = e
+	default:
+		msg.Error = &responseError{Code: codeInternalError, Message: err.Error()}
+	}
+	return c.write(msg)
+}
+
+// notify writes a notification.
+func (c *conn) notify(method string, params any) error {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return err
+	}
+
+	msg := &message{
+		Method: method,
+		Params: b,
+	}
+	return c.write(msg)
+}
+
+// responseError
+
+func (e *responseError) Error() string {
+	return e.Message
+}
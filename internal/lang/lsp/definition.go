@@ -0,0 +1,47 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import "github.com/basecomplextech/spec/lang"
+
+// definition returns declaration locations for a reference or an import, or nil.
+func (s *server) definition(params textDocumentPositionParams) []location {
+	t := s.lookup(params)
+	if t == nil {
+		return nil
+	}
+
+	var pos lang.Position
+	switch {
+	case t.imp != nil:
+		p := t.imp.Package
+		if p == nil || len(p.Files) == 0 {
+			return nil
+		}
+		pos = lang.Position{Path: p.Files[0].Path, Line: 1, Column: 1}
+
+	case t.def != nil:
+		pos = t.def.Pos
+	case t.field != nil:
+		pos = t.field.Pos
+	case t.value != nil:
+		pos = t.value.Pos
+	case t.sfield != nil:
+		pos = t.sfield.Pos
+	case t.method != nil:
+		pos = t.method.Pos
+	}
+
+	if !pos.IsValid() || pos.Path == "" {
+		return nil
+	}
+
+	p := position{Line: pos.Line - 1, Character: pos.Column - 1}
+	loc := location{
+		URI:   pathURI(pos.Path),
+		Range: range_{Start: p, End: p},
+	}
+	return []location{loc}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"errors"
+	"path/filepath"
+	"sort"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+// diagnose compiles a package from a directory and publishes diagnostics for its files,
+// clears diagnostics which were published before and are now fixed.
+func (s *server) diagnose(dir string) error {
+	diags := make(map[string][]diagnostic)
+
+	// Clear previous diagnostics, publish empty lists for all package files
+	for _, path := range s.reported[dir] {
+		diags[path] = []diagnostic{}
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.spec"))
+	for _, path := range files {
+		diags[filepath.Clean(path)] = []diagnostic{}
+	}
+
+	// Compile and collect errors
+	_, err := s.compile(dir)
+	if err != nil {
+		for _, e := range compileErrors(err) {
+			// Report errors without paths in the first package file
+			path := e.Pos.Path
+			if path == "" {
+				if len(files) == 0 {
+					continue
+				}
+				path = files[0]
+			}
+			path = filepath.Clean(path)
+
+			d := diagnostic{
+				Range:    errorRange(e.Pos),
+				Severity: severityError,
+				Source:   "spec",
+				Message:  e.Msg,
+			}
+			diags[path] = append(diags[path], d)
+		}
+	}
+
+	// Publish in a stable order
+	paths := make([]string, 0, len(diags))
+	for path := range diags {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var reported []string
+	for _, path := range paths {
+		list := diags[path]
+		if len(list) > 0 {
+			reported = append(reported, path)
+		}
+
+		params := publishDiagnosticsParams{
+			URI:         pathURI(path),
+			Diagnostics: list,
+		}
+		if err := s.conn.notify("textDocument/publishDiagnostics", params); err != nil {
+			return err
+		}
+	}
+
+	s.reported[dir] = reported
+	return nil
+}
+
+// compileErrors returns positioned compile errors, or a single error without a position.
+func compileErrors(err error) lang.Errors {
+	var errs lang.Errors
+	if errors.As(err, &errs) {
+		return errs
+	}
+	return lang.Errors{{Msg: err.Error()}}
+}
+
+// errorRange returns a range which starts at an error position and ends at the next line.
+func errorRange(pos lang.Position) range_ {
+	if !pos.IsValid() {
+		return range_{}
+	}
+
+	start := position{Line: pos.Line - 1, Character: pos.Column - 1}
+	end := position{Line: pos.Line}
+	return range_{Start: start, End: end}
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+// hover returns hover info for a declaration or a reference, or nil.
+func (s *server) hover(params textDocumentPositionParams) *hover {
+	t := s.lookup(params)
+	if t == nil {
+		return nil
+	}
+
+	var decl, detail, doc string
+	switch {
+	case t.imp != nil:
+		decl = fmt.Sprintf("import %q", t.imp.ID)
+		if p := t.imp.Package; p != nil {
+			detail = fmt.Sprintf("Package %v in %v.", p.Name, p.Path)
+		}
+
+	case t.def != nil:
+		def := t.def
+		decl = fmt.Sprintf("%v %v", def.Type, def.Name)
+		detail = fmt.Sprintf("Defined in package %v, %v.", def.Package.Name, def.File.Name)
+		doc = def.Doc
+
+	case t.field != nil:
+		f := t.field
+		decl = fmt.Sprintf("%v %v %d", f.Name, f.Type, f.Tag)
+		detail = fmt.Sprintf("Field of %v, tag %d.", f.Message.Def.Name, f.Tag)
+		if f.Oneof != nil {
+			detail = fmt.Sprintf("Field of %v, oneof %v, tag %d.", f.Message.Def.Name, f.Oneof.Name, f.Tag)
+		}
+		doc = f.Doc
+
+	case t.value != nil:
+		v := t.value
+		decl = fmt.Sprintf("%v = %d", v.Name, v.Number)
+		detail = fmt.Sprintf("Value of %v.", v.Enum.Def.Name)
+		doc = v.Doc
+
+	case t.sfield != nil:
+		f := t.sfield
+		decl = fmt.Sprintf("%v %v", f.Name, f.Type)
+		detail = fmt.Sprintf("Field of %v.", f.Struct.Def.Name)
+		doc = f.Doc
+
+	case t.method != nil:
+		m := t.method
+		decl = methodString(m)
+		detail = fmt.Sprintf("Method of %v.", m.Service.Def.Name)
+		doc = m.Doc
+
+	default:
+		return nil
+	}
+
+	b := strings.Builder{}
+	b.WriteString("```spec\n")
+	b.WriteString(decl)
+	b.WriteString("\n```\n\n")
+	b.WriteString(detail)
+	if doc != "" {
+		b.WriteString("\n\n")
+		b.WriteString(doc)
+	}
+
+	return &hover{
+		Contents: markupContent{
+			Kind:  "markdown",
+			Value: b.String(),
+		},
+	}
+}
+
+// methodString returns a method signature, i.e. "method(Request) Response".
+func methodString(m *lang.Method) string {
+	b := strings.Builder{}
+	b.WriteString(m.Name)
+	b.WriteString("(")
+	if m.Request != nil {
+		b.WriteString(m.Request.String())
+	}
+	b.WriteString(")")
+
+	switch m.Type {
+	case lang.MethodOneway:
+		b.WriteString(" oneway")
+	case lang.MethodChannel:
+		var list []string
+		if ch := m.Channel; ch != nil && ch.In != nil {
+			list = append(list, "<-"+ch.In.String())
+		}
+		if ch := m.Channel; ch != nil && ch.Out != nil {
+			list = append(list, ch.Out.String()+"->")
+		}
+		fmt.Fprintf(&b, " (%v)", strings.Join(list, ", "))
+	case lang.MethodSubservice:
+		if m.Subservice != nil {
+			b.WriteString(" " + m.Subservice.String())
+		}
+	}
+
+	if m.Response != nil {
+		b.WriteString(" " + m.Response.String())
+	}
+	return b.String()
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"os"
+	"strings"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+// target is a declaration or a reference under a cursor, only one field is set.
+type target struct {
+	def    *lang.Definition
+	field  *lang.Field
+	value  *lang.EnumValue
+	sfield *lang.StructField
+	method *lang.Method
+	imp    *lang.Import
+}
+
+// lookup returns a target at a document position or nil.
+func (s *server) lookup(params textDocumentPositionParams) *target {
+	path := uriPath(params.TextDocument.URI)
+	file := s.file(path)
+	if file == nil {
+		return nil
+	}
+
+	text, ok := s.source(path)
+	if !ok {
+		return nil
+	}
+	word, start, ok := wordAt(text, params.Position)
+	if !ok {
+		return nil
+	}
+	line := params.Position.Line + 1
+	column := start + 1
+
+	// Imports
+	for _, imp := range file.Imports {
+		if imp.Pos.Line == line {
+			return &target{imp: imp}
+		}
+	}
+
+	// Declarations
+	for _, def := range file.Definitions {
+		if t := lookupDeclaration(def, word, line, column); t != nil {
+			return t
+		}
+	}
+
+	// References
+	if def := resolve(file, word); def != nil {
+		return &target{def: def}
+	}
+	return nil
+}
+
+// lookupDeclaration returns a definition or its member declared at a position.
+func lookupDeclaration(def *lang.Definition, word string, line, column int) *target {
+	if def.Generated {
+		return nil
+	}
+	if def.Pos.Line == line && def.Name == word {
+		return &target{def: def}
+	}
+
+	match := func(name string, pos lang.Position) bool {
+		return name == word && pos.Line == line && pos.Column == column
+	}
+
+	switch def.Type {
+	case lang.DefinitionEnum:
+		for _, v := range def.Enum.Values {
+			if match(v.Name, v.Pos) {
+				return &target{value: v}
+			}
+		}
+
+	case lang.DefinitionMessage:
+		for _, f := range def.Message.Fields {
+			if match(f.Name, f.Pos) {
+				return &target{field: f}
+			}
+		}
+
+	case lang.DefinitionStruct:
+		for _, f := range def.Struct.Fields {
+			if match(f.Name, f.Pos) {
+				return &target{sfield: f}
+			}
+		}
+
+	case lang.DefinitionService:
+		for _, m := range def.Service.Methods {
+			if match(m.Name, m.Pos) {
+				return &target{method: m}
+			}
+		}
+	}
+	return nil
+}
+
+// resolve resolves a local or an imported definition by its name, i.e. "Type" or "pkg.Type".
+func resolve(file *lang.File, name string) *lang.Definition {
+	impName, defName, ok := strings.Cut(name, ".")
+	if !ok {
+		return file.Package.Definition(name)
+	}
+
+	imp := fileImport(file, impName)
+	if imp == nil || imp.Package == nil {
+		return nil
+	}
+	return imp.Package.Definition(defName)
+}
+
+// fileImport returns a file import by its name or nil.
+func fileImport(file *lang.File, name string) *lang.Import {
+	for _, imp := range file.Imports {
+		if imp.Name == name {
+			return imp
+		}
+	}
+	return nil
+}
+
+// source returns an open document text or reads a file.
+func (s *server) source(path string) (string, bool) {
+	if text, ok := s.text(path); ok {
+		return text, true
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return string(b), true
+}
+
+// text
+
+// lineAt returns a line by its zero-based index as runes.
+func lineAt(text string, line int) ([]rune, bool) {
+	lines := strings.Split(text, "\n")
+	if line < 0 || line >= len(lines) {
+		return nil, false
+	}
+
+	s := strings.TrimSuffix(lines[line], "\r")
+	return []rune(s), true
+}
+
+// wordAt returns an identifier at a position, i.e. "Type" or "pkg.Type",
+// and its zero-based start character.
+func wordAt(text string, pos position) (string, int, bool) {
+	line, ok := lineAt(text, pos.Line)
+	if !ok {
+		return "", 0, false
+	}
+
+	start := min(max(pos.Character, 0), len(line))
+	for start > 0 && isWordChar(line[start-1]) {
+		start--
+	}
+	end := min(max(pos.Character, 0), len(line))
+	for end < len(line) && isWordChar(line[end]) {
+		end++
+	}
+
+	word := strings.Trim(string(line[start:end]), ".")
+	if word == "" {
+		return "", 0, false
+	}
+	return word, start, true
+}
+
+// prefixAt returns an identifier before a position, i.e. "pkg." or "Ty".
+func prefixAt(text string, pos position) string {
+	line, ok := lineAt(text, pos.Line)
+	if !ok {
+		return ""
+	}
+
+	end := min(max(pos.Character, 0), len(line))
+	start := end
+	for start > 0 && isWordChar(line[start-1]) {
+		start--
+	}
+	return string(line[start:end])
+}
+
+func isWordChar(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z',
+		r >= 'A' && r <= 'Z',
+		r >= '0' && r <= '9',
+		r == '_',
+		r == '.':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,321 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package lsp implements a Language Server Protocol server for spec files over stdio.
+//
+// The server compiles packages on open and save, publishes diagnostics and uses
+// the last successfully compiled package for hover, go-to-definition and completion.
+// Document symbols are parsed from unsaved document text.
+//
+// Positions are converted between one-based spec columns and zero-based LSP characters,
+// spec columns count characters, which matches UTF-16 offsets for non-surrogate text.
+package lsp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+// Options specifies server options.
+type Options struct {
+	// ImportPaths are directories to search for imported packages,
+	// the workspace root is added automatically.
+	ImportPaths []string
+}
+
+// Serve serves LSP requests from a reader and writes responses to a writer
+// until an exit notification or the end of input.
+func Serve(r io.Reader, w io.Writer, opts Options) error {
+	s := newServer(r, w, opts)
+	return s.run()
+}
+
+type server struct {
+	conn *conn
+	opts Options
+
+	docs     map[string]*document     // open documents by paths
+	pkgs     map[string]*lang.Package // last compiled packages by directories
+	reported map[string][]string      // paths with diagnostics by directories
+	shutdown bool
+}
+
+type document struct {
+	path string
+	text string
+}
+
+func newServer(r io.Reader, w io.Writer, opts Options) *server {
+	return &server{
+		conn: newConn(r, w),
+		opts: opts,
+
+		docs:     make(map[string]*document),
+		pkgs:     make(map[string]*lang.Package),
+		reported: make(map[string][]string),
+	}
+}
+
+func (s *server) run() error {
+	for {
+		msg, err := s.conn.read()
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			var rerr *responseError
+			if errors.As(err, &rerr) {
+				if err := s.conn.reply(nil, nil, rerr); err != nil {
+					return err
+				}
+				continue
+			}
+			return err
+		}
+
+		if msg.Method == "exit" {
+			return nil
+		}
+		if err := s.handle(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// handle handles a request or a notification, replies to requests.
+func (s *server) handle(msg *message) error {
+	result, err := s.dispatch(msg)
+	if msg.ID == nil {
+		return nil
+	}
+	return s.conn.reply(msg.ID, result, err)
+}
+
+func (s *server) dispatch(msg *message) (any, error) {
+	if s.shutdown {
+		return nil, &responseError{
+			Code:    codeInvalidRequest,
+			Message: "server is shut down",
+		}
+	}
+
+	switch msg.Method {
+	case "initialize":
+		var params initializeParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params)
+
+	case "initialized":
+		return nil, nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didOpen(params)
+
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didChange(params)
+
+	case "textDocument/didSave":
+		var params didSaveParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return nil, s.didSave(params)
+
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		delete(s.docs, uriPath(params.TextDocument.URI))
+		return nil, nil
+
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.hover(params), nil
+
+	case "textDocument/definition":
+		var params textDocumentPositionParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.definition(params), nil
+
+	case "textDocument/completion":
+		var params textDocumentPositionParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.completion(params), nil
+
+	case "textDocument/documentSymbol":
+		var params documentSymbolParams
+		if err := unmarshal(msg.Params, &params); err != nil {
+			return nil, err
+		}
+		return s.documentSymbols(params), nil
+	}
+
+	if msg.ID == nil {
+		return nil, nil
+	}
+	return nil, &responseError{
+		Code:    codeMethodNotFound,
+		Message: fmt.Sprintf("method not found: %v", msg.Method),
+	}
+}
+
+// lifecycle
+
+func (s *server) initialize(params initializeParams) (*initializeResult, error) {
+	paths := append([]string(nil), s.opts.ImportPaths...)
+	paths = append(paths, params.InitializationOptions.ImportPaths...)
+	if params.RootURI != "" {
+		paths = append(paths, uriPath(params.RootURI))
+	}
+	s.opts.ImportPaths = paths
+
+	result := &initializeResult{
+		Capabilities: serverCapabilities{
+			TextDocumentSync: textDocumentSyncOptions{
+				OpenClose: true,
+				Change:    1,
+				Save:      saveOptions{IncludeText: true},
+			},
+			HoverProvider:      true,
+			DefinitionProvider: true,
+			CompletionProvider: completionOptions{
+				TriggerCharacters: []string{"."},
+			},
+			DocumentSymbolProvider: true,
+		},
+		ServerInfo: serverInfo{Name: "spec"},
+	}
+	return result, nil
+}
+
+// documents
+
+func (s *server) didOpen(params didOpenParams) error {
+	path := uriPath(params.TextDocument.URI)
+	s.docs[path] = &document{
+		path: path,
+		text: params.TextDocument.Text,
+	}
+	return s.diagnose(filepath.Dir(path))
+}
+
+func (s *server) didChange(params didChangeParams) error {
+	path := uriPath(params.TextDocument.URI)
+	doc, ok := s.docs[path]
+	if !ok {
+		return nil
+	}
+
+	// Full document sync, the last change contains the whole text
+	if n := len(params.ContentChanges); n > 0 {
+		doc.text = params.ContentChanges[n-1].Text
+	}
+	return nil
+}
+
+func (s *server) didSave(params didSaveParams) error {
+	path := uriPath(params.TextDocument.URI)
+	if doc, ok := s.docs[path]; ok && params.Text != nil {
+		doc.text = *params.Text
+	}
+	return s.diagnose(filepath.Dir(path))
+}
+
+// compile compiles a package from a directory, caches it on success.
+func (s *server) compile(dir string) (*lang.Package, error) {
+	pkg, err := lang.Compile(dir, lang.Options{
+		ImportPaths: s.opts.ImportPaths,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.pkgs[dir] = pkg
+	return pkg, nil
+}
+
+// file returns a compiled file by its path from the last compiled package.
+func (s *server) file(path string) *lang.File {
+	pkg, ok := s.pkgs[filepath.Dir(path)]
+	if !ok {
+		var err error
+		pkg, err = s.compile(filepath.Dir(path))
+		if err != nil {
+			return nil
+		}
+	}
+
+	for _, file := range pkg.Files {
+		if filepath.Clean(file.Path) == path {
+			return file
+		}
+	}
+	return nil
+}
+
+// text returns the text of an open document or nil.
+func (s *server) text(path string) (string, bool) {
+	doc, ok := s.docs[path]
+	if !ok {
+		return "", false
+	}
+	return doc.text, true
+}
+
+// util
+
+func unmarshal(params json.RawMessage, v any) error {
+	if err := json.Unmarshal(params, v); err != nil {
+		return &responseError{Code: codeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+// uriPath returns a clean file path from a file uri.
+func uriPath(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" {
+		return filepath.Clean(uri)
+	}
+	return filepath.Clean(filepath.FromSlash(u.Path))
+}
+
+// pathURI returns a file uri from a file path.
+func pathURI(path string) string {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return ""
+	}
+
+	u := &url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	return u.String()
+}
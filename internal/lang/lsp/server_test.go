@@ -0,0 +1,323 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testResponse struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+type testSession struct {
+	t   *testing.T
+	in  bytes.Buffer
+	id  int
+	out []*testResponse
+}
+
+func newTestSession(t *testing.T) *testSession {
+	s := &testSession{t: t}
+	s.request("initialize", map[string]any{
+		"initializationOptions": map[string]any{
+			"importPaths": []string{"../../tests"},
+		},
+	})
+	s.notify("initialized", struct{}{})
+	return s
+}
+
+func (s *testSession) send(msg map[string]any) {
+	msg["jsonrpc"] = jsonrpcVersion
+
+	b, err := json.Marshal(msg)
+	require.NoError(s.t, err)
+
+	fmt.Fprintf(&s.in, "Content-Length: %d\r\n\r\n", len(b))
+	s.in.Write(b)
+}
+
+func (s *testSession) request(method string, params any) int {
+	s.id++
+	s.send(map[string]any{"id": s.id, "method": method, "params": params})
+	return s.id
+}
+
+func (s *testSession) notify(method string, params any) {
+	s.send(map[string]any{"method": method, "params": params})
+}
+
+// run serves all sent messages and reads responses and notifications.
+func (s *testSession) run() {
+	s.notify("exit", nil)
+
+	out := &bytes.Buffer{}
+	err := Serve(&s.in, out, Options{})
+	require.NoError(s.t, err)
+
+	c := newConn(out, nil)
+	for {
+		msg, err := c.read()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(s.t, err)
+
+		b, err := json.Marshal(msg)
+		require.NoError(s.t, err)
+
+		resp := &testResponse{}
+		require.NoError(s.t, json.Unmarshal(b, resp))
+		s.out = append(s.out, resp)
+	}
+}
+
+func (s *testSession) result(id int, v any) {
+	for _, resp := range s.out {
+		if resp.ID == nil || *resp.ID != id {
+			continue
+		}
+
+		require.Nil(s.t, resp.Error)
+		require.NoError(s.t, json.Unmarshal(resp.Result, v))
+		return
+	}
+	s.t.Fatalf("response %d not found", id)
+}
+
+func (s *testSession) diagnostics(path string) []diagnostic {
+	var result []diagnostic
+	for _, resp := range s.out {
+		if resp.Method != "textDocument/publishDiagnostics" {
+			continue
+		}
+
+		var params publishDiagnosticsParams
+		require.NoError(s.t, json.Unmarshal(resp.Params, &params))
+		if params.URI == pathURI(path) {
+			result = params.Diagnostics
+		}
+	}
+	return result
+}
+
+func (s *testSession) open(path string) string {
+	b, err := os.ReadFile(path)
+	require.NoError(s.t, err)
+
+	uri := pathURI(path)
+	s.notify("textDocument/didOpen", map[string]any{
+		"textDocument": map[string]any{"uri": uri, "text": string(b)},
+	})
+	return uri
+}
+
+func textPosition(uri string, line, char int) map[string]any {
+	return map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"position":     map[string]any{"line": line, "character": char},
+	}
+}
+
+// Tests
+
+func TestServer__should_publish_diagnostics(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.spec")
+	src := "message Message {\n    field Unknown 1;\n}\n"
+	require.NoError(t, os.WriteFile(path, []byte(src), 0644))
+
+	s := newTestSession(t)
+	s.open(path)
+	s.run()
+
+	diags := s.diagnostics(path)
+	require.Len(t, diags, 1)
+	assert.Equal(t, 1, diags[0].Range.Start.Line)
+	assert.Equal(t, 4, diags[0].Range.Start.Character)
+	assert.Contains(t, diags[0].Message, "Unknown")
+}
+
+func TestServer__should_clear_diagnostics_on_save(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.spec")
+	require.NoError(t, os.WriteFile(path, []byte("message Message {\n    field Unknown 1;\n}\n"), 0644))
+
+	s := newTestSession(t)
+	uri := s.open(path)
+
+	fixed := "message Message {\n    field int64 1;\n}\n"
+	require.NoError(t, os.WriteFile(path, []byte(fixed), 0644))
+	s.notify("textDocument/didSave", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+		"text":         fixed,
+	})
+	s.run()
+
+	diags := s.diagnostics(path)
+	assert.NotNil(t, diags)
+	assert.Empty(t, diags)
+}
+
+func TestServer__should_return_hover_with_field_type_and_tag(t *testing.T) {
+	s := newTestSession(t)
+	uri := s.open("../../tests/pkg1/pkg1.spec")
+	id := s.request("textDocument/hover", textPosition(uri, 35, 6))
+	s.run()
+
+	var h hover
+	s.result(id, &h)
+	assert.Contains(t, h.Contents.Value, "submessage1 pkg2.Submessage 63")
+	assert.Contains(t, h.Contents.Value, "Field of Message, tag 63.")
+}
+
+func TestServer__should_return_hover_for_type_reference(t *testing.T) {
+	s := newTestSession(t)
+	uri := s.open("../../tests/pkg1/pkg1.spec")
+	id := s.request("textDocument/hover", textPosition(uri, 35, 20))
+	s.run()
+
+	var h hover
+	s.result(id, &h)
+	assert.Contains(t, h.Contents.Value, "message Submessage")
+	assert.Contains(t, h.Contents.Value, "package pkg2")
+}
+
+func TestServer__should_return_definition_for_reference(t *testing.T) {
+	s := newTestSession(t)
+	uri := s.open("../../tests/pkg1/pkg1.spec")
+	id := s.request("textDocument/definition", textPosition(uri, 35, 20))
+	s.run()
+
+	var locs []location
+	s.result(id, &locs)
+	require.Len(t, locs, 1)
+
+	assert.Equal(t, pathURI("../../tests/pkg2/submessage.spec"), locs[0].URI)
+	assert.Equal(t, 9, locs[0].Range.Start.Line)
+}
+
+func TestServer__should_return_definition_for_import(t *testing.T) {
+	s := newTestSession(t)
+	uri := s.open("../../tests/pkg1/pkg1.spec")
+	id := s.request("textDocument/definition", textPosition(uri, 1, 6))
+	s.run()
+
+	var locs []location
+	s.result(id, &locs)
+	require.Len(t, locs, 1)
+	assert.True(t, strings.HasPrefix(locs[0].URI, pathURI("../../tests/pkg2")))
+}
+
+func TestServer__should_complete_type_names_and_imports(t *testing.T) {
+	s := newTestSession(t)
+	uri := s.open("../../tests/pkg1/pkg1.spec")
+	id := s.request("textDocument/completion", textPosition(uri, 15, 12))
+	s.run()
+
+	var items []completionItem
+	s.result(id, &items)
+
+	labels := make(map[string]int)
+	for _, item := range items {
+		labels[item.Label] = item.Kind
+	}
+	assert.Equal(t, completionKindKeyword, labels["int64"])
+	assert.Equal(t, completionKindClass, labels["Submessage"])
+	assert.Equal(t, completionKindStruct, labels["Struct"])
+	assert.Equal(t, completionKindModule, labels["pkg2"])
+}
+
+func TestServer__should_complete_imported_definitions(t *testing.T) {
+	s := newTestSession(t)
+	uri := s.open("../../tests/pkg1/pkg1.spec")
+	id := s.request("textDocument/completion", textPosition(uri, 35, 21))
+	s.run()
+
+	var items []completionItem
+	s.result(id, &items)
+
+	var labels []string
+	for _, item := range items {
+		labels = append(labels, item.Label)
+	}
+	assert.Contains(t, labels, "Submessage")
+	assert.NotContains(t, labels, "int64")
+}
+
+func TestServer__should_return_document_symbols(t *testing.T) {
+	s := newTestSession(t)
+	uri := s.open("../../tests/pkg1/enum.spec")
+	id := s.request("textDocument/documentSymbol", map[string]any{
+		"textDocument": map[string]any{"uri": uri},
+	})
+	s.run()
+
+	var symbols []documentSymbol
+	s.result(id, &symbols)
+	require.Len(t, symbols, 1)
+
+	sym := symbols[0]
+	assert.Equal(t, "Enum", sym.Name)
+	assert.Equal(t, symbolKindEnum, sym.Kind)
+	assert.Equal(t, 1, sym.Range.Start.Line)
+	assert.Equal(t, 11, sym.Range.End.Line)
+	assert.Equal(t, position{Line: 1, Character: 5}, sym.SelectionRange.Start)
+	require.Len(t, sym.Children, 5)
+	assert.Equal(t, "UNDEFINED", sym.Children[0].Name)
+}
+
+func TestServer__should_return_method_not_found(t *testing.T) {
+	s := newTestSession(t)
+	id := s.request("workspace/unknown", struct{}{})
+	s.run()
+
+	for _, resp := range s.out {
+		if resp.ID != nil && *resp.ID == id {
+			require.NotNil(t, resp.Error)
+			assert.Equal(t, codeMethodNotFound, resp.Error.Code)
+			return
+		}
+	}
+	t.Fatal("response not found")
+}
+
+func TestServer__should_return_parse_error_on_too_large_message(t *testing.T) {
+	s := newTestSession(t)
+
+	fmt.Fprintf(&s.in, "Content-Length: %d\r\n\r\n", maxContentLength+1)
+	s.in.Write(make([]byte, maxContentLength+1))
+
+	id := s.request("workspace/unknown", struct{}{})
+	s.run()
+
+	var parseErr, notFound bool
+	for _, resp := range s.out {
+		switch {
+		case resp.Error == nil:
+		case resp.Error.Code == codeParseError:
+			parseErr = true
+		case resp.ID != nil && *resp.ID == id:
+			notFound = true
+		}
+	}
+	assert.True(t, parseErr)
+	assert.True(t, notFound)
+}
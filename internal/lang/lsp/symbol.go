@@ -0,0 +1,131 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lsp
+
+import (
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/parser"
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+// documentSymbols returns definitions and their members parsed from a document text,
+// returns nil when the document has syntax errors.
+func (s *server) documentSymbols(params documentSymbolParams) []documentSymbol {
+	path := uriPath(params.TextDocument.URI)
+	text, ok := s.source(path)
+	if !ok {
+		return nil
+	}
+
+	file, err := parser.New().Parse(text)
+	if err != nil {
+		return nil
+	}
+
+	symbols := []documentSymbol{}
+	for _, def := range file.Definitions {
+		symbols = append(symbols, definitionSymbol(text, def))
+	}
+	return symbols
+}
+
+func definitionSymbol(text string, def *syntax.Definition) documentSymbol {
+	var kind int
+	var detail string
+	var children []documentSymbol
+
+	switch def.Type {
+	case syntax.DefinitionEnum:
+		kind, detail = symbolKindEnum, "enum"
+		for _, v := range def.Enum.Values {
+			children = append(children, memberSymbol(v.Name, "", symbolKindEnumMember, v.Pos))
+		}
+
+	case syntax.DefinitionMessage:
+		kind, detail = symbolKindClass, "message"
+		for _, f := range def.Message.Fields {
+			children = append(children, memberSymbol(f.Name, f.Type.String(), symbolKindField, f.Pos))
+		}
+
+	case syntax.DefinitionStruct:
+		kind, detail = symbolKindStruct, "struct"
+		for _, f := range def.Struct.Fields {
+			children = append(children, memberSymbol(f.Name, f.Type.String(), symbolKindField, f.Pos))
+		}
+
+	case syntax.DefinitionService:
+		kind, detail = symbolKindInterface, "service"
+		for _, m := range def.Service.Methods {
+			children = append(children, memberSymbol(m.Name, "", symbolKindMethod, m.Pos))
+		}
+	}
+
+	// Range spans from the keyword to the closing brace
+	start := toPosition(def.Pos)
+	end := start
+	if def.End.IsValid() {
+		end = toPosition(def.End)
+		end.Character++
+	}
+
+	// Selection range is the definition name after the keyword
+	name := namePosition(text, def.Name, position{
+		Line:      start.Line,
+		Character: start.Character + len(detail),
+	})
+	selection := range_{
+		Start: name,
+		End:   position{Line: name.Line, Character: name.Character + len([]rune(def.Name))},
+	}
+
+	return documentSymbol{
+		Name:           def.Name,
+		Detail:         detail,
+		Kind:           kind,
+		Range:          range_{Start: start, End: end},
+		SelectionRange: selection,
+		Children:       children,
+	}
+}
+
+func memberSymbol(name string, detail string, kind int, pos syntax.Position) documentSymbol {
+	start := toPosition(pos)
+	end := position{Line: start.Line, Character: start.Character + len([]rune(name))}
+	r := range_{Start: start, End: end}
+
+	return documentSymbol{
+		Name:           name,
+		Detail:         detail,
+		Kind:           kind,
+		Range:          r,
+		SelectionRange: r,
+	}
+}
+
+// namePosition returns a position of a name on the same line after a start position,
+// or the start position when the name is not found.
+func namePosition(text string, name string, start position) position {
+	line, ok := lineAt(text, start.Line)
+	if !ok || start.Character > len(line) {
+		return start
+	}
+
+	i := strings.Index(string(line[start.Character:]), name)
+	if i < 0 {
+		return start
+	}
+
+	n := len([]rune(string(line[start.Character:])[:i]))
+	return position{Line: start.Line, Character: start.Character + n}
+}
+
+// toPosition converts a one-based source position into a zero-based document position.
+func toPosition(pos syntax.Position) position {
+	if !pos.IsValid() {
+		return position{}
+	}
+	return position{Line: pos.Line - 1, Character: pos.Column - 1}
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+// Annotation is a definition, field, enum value or method annotation, i.e. deprecated=true.
+type Annotation struct {
+	Name string
+	Text string // literal text
+
+	// Value is a parsed value, bool, int64, float64 or string.
+	// Identifiers except true and false are parsed as strings.
+	Value any
+}
+
+// Annotations is an ordered set of annotations.
+type Annotations struct {
+	List  []*Annotation
+	Names map[string]*Annotation
+}
+
+func newAnnotations(pannots []*syntax.Annotation) (*Annotations, error) {
+	a := &Annotations{
+		Names: make(map[string]*Annotation, len(pannots)),
+	}
+
+	for _, pannot := range pannots {
+		annot, err := newAnnotation(pannot)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := a.Names[annot.Name]; ok {
+			return nil, fmt.Errorf("duplicate annotation %q", annot.Name)
+		}
+
+		a.List = append(a.List, annot)
+		a.Names[annot.Name] = annot
+	}
+	return a, nil
+}
+
+func newAnnotation(pannot *syntax.Annotation) (*Annotation, error) {
+	pval := pannot.Value
+	text := pval.Text
+
+	var value any
+	var err error
+
+	switch pval.Kind {
+	case syntax.ValueInteger:
+		value, err = strconv.ParseInt(text, 10, 64)
+	case syntax.ValueFloat:
+		value, err = strconv.ParseFloat(text, 64)
+	case syntax.ValueString:
+		value, err = strconv.Unquote(text)
+	case syntax.ValueIdent:
+		switch text {
+		case "true":
+			value = true
+		case "false":
+			value = false
+		default:
+			value = text
+		}
+	default:
+		err = fmt.Errorf("unsupported value")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid annotation %q: invalid value %v", pannot.Name, text)
+	}
+
+	// Deprecated must be a bool or a reason
+	if pannot.Name == "deprecated" {
+		switch value.(type) {
+		case bool, string:
+		default:
+			return nil, fmt.Errorf("invalid annotation %q: must be a bool or a string, got %v",
+				pannot.Name, text)
+		}
+	}
+
+	// Sensitive must be a bool
+	if pannot.Name == "sensitive" {
+		if _, ok := value.(bool); !ok {
+			return nil, fmt.Errorf("invalid annotation %q: must be a bool, got %v", pannot.Name, text)
+		}
+	}
+
+	a := &Annotation{
+		Name:  pannot.Name,
+		Text:  text,
+		Value: value,
+	}
+	return a, nil
+}
+
+// Get returns an annotation by its name or nil.
+func (a *Annotations) Get(name string) *Annotation {
+	return a.Names[name]
+}
+
+// Bool returns true if a bool annotation is present and true.
+func (a *Annotations) Bool(name string) bool {
+	annot, ok := a.Names[name]
+	if !ok {
+		return false
+	}
+
+	v, _ := annot.Value.(bool)
+	return v
+}
+
+// String returns a string annotation value, or false when absent or not a string.
+func (a *Annotations) String(name string) (string, bool) {
+	annot, ok := a.Names[name]
+	if !ok {
+		return "", false
+	}
+
+	v, ok := annot.Value.(string)
+	return v, ok
+}
+
+// Deprecated returns a deprecation reason and true when marked as deprecated,
+// i.e. deprecated=true or deprecated="use another method".
+func (a *Annotations) Deprecated() (string, bool) {
+	if a == nil {
+		return "", false
+	}
+
+	annot, ok := a.Names["deprecated"]
+	if !ok {
+		return "", false
+	}
+
+	switch v := annot.Value.(type) {
+	case bool:
+		return "", v
+	case string:
+		return v, true
+	}
+	return "", false
+}
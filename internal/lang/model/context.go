@@ -9,15 +9,26 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/basecomplextech/spec/internal/lang/gomod"
 	"github.com/basecomplextech/spec/internal/lang/parser"
 	"github.com/basecomplextech/spec/internal/lang/syntax"
 )
 
+// goPackageOption is a Go import path option, defaults to an import id in packages
+// resolved through Go modules.
+const goPackageOption = "go_package"
+
 type Context struct {
 	Parser      parser.Parser
 	ImportPaths []string // import paths
 
+	// ModuleDir is a directory whose Go module graph is used to resolve imports
+	// not found in import paths, empty disables resolving imports through Go modules.
+	ModuleDir string
+
 	Packages map[string]*Package // compiled packages by ids
+
+	modules *gomod.Modules // lazily loaded module graph
 }
 
 // NewContext returns a new package context.
@@ -61,9 +72,63 @@ func (x *Context) getPackage(id string) (*Package, error) {
 		return x.compile(id, p)
 	}
 
+	// Try to find package in Go modules
+	p, ok, err := x.resolveModule(id)
+	switch {
+	case err != nil:
+		return nil, err
+	case ok:
+		return x.compileModule(id, p)
+	}
+
 	return nil, fmt.Errorf("package not found: %v", id)
 }
 
+// resolveModule returns a package directory from the Go module graph.
+func (x *Context) resolveModule(id string) (string, bool, error) {
+	if x.ModuleDir == "" {
+		return "", false, nil
+	}
+
+	if x.modules == nil {
+		modules, err := gomod.Load(x.ModuleDir)
+		if err != nil {
+			return "", false, err
+		}
+		x.modules = modules
+	}
+
+	p, ok, err := x.modules.Resolve(id)
+	if err != nil || !ok {
+		return "", false, err
+	}
+
+	_, err = os.Stat(p)
+	switch {
+	case os.IsNotExist(err):
+		return "", false, nil
+	case err != nil:
+		return "", false, err
+	}
+	return p, true, nil
+}
+
+// compileModule compiles a package resolved through Go modules,
+// maps its import id to the go_package option when the option is absent.
+func (x *Context) compileModule(id string, path string) (*Package, error) {
+	pkg, err := x.compile(id, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := pkg.OptionNames[goPackageOption]; !ok {
+		opt := &Option{Name: goPackageOption, Value: id}
+		pkg.Options = append(pkg.Options, opt)
+		pkg.OptionNames[opt.Name] = opt
+	}
+	return pkg, nil
+}
+
 // compile
 
 func (x *Context) compile(id string, path string) (*Package, error) {
@@ -76,7 +141,9 @@ func (x *Context) compile(id string, path string) (*Package, error) {
 	// Parse directory files
 	files, err := x.Parser.ParseDirectory(path)
 	if err != nil {
-		return nil, err
+		var errs errorList
+		errs.add(syntax.Position{}, err)
+		return nil, errs.err("")
 	}
 	return x.compileFiles(id, path, files)
 }
@@ -92,17 +159,26 @@ func (x *Context) compileFiles(id string, path string, files []*syntax.File) (*P
 		return nil, fmt.Errorf("%v: duplicate package %q", path, id)
 	}
 
-	// Parse package
+	// Parse package, continue on errors to resolve valid definitions
+	var errs errorList
 	pkg, err := parsePackage(x, id, path, files)
-	if err != nil {
+	switch {
+	case pkg == nil:
 		return nil, err
+	case err != nil:
+		errs.add(syntax.Position{}, err)
 	}
 	x.Packages[id] = pkg
 
-	// Resolve, compile, validate
+	// Resolve, return parse and resolve errors together
 	if err := pkg.resolve(); err != nil {
+		errs.add(syntax.Position{}, err)
+	}
+	if err := errs.err(""); err != nil {
 		return nil, err
 	}
+
+	// Compile, validate
 	if err := pkg.compile(); err != nil {
 		return nil, err
 	}
@@ -16,6 +16,10 @@ type Definition struct {
 
 	Name string
 	Type DefinitionType
+	Doc  string          // doc comment
+	Pos  syntax.Position // source position
+
+	Annotations *Annotations
 
 	Enum    *Enum
 	Message *Message
@@ -29,12 +33,21 @@ func parseDefinition(pkg *Package, file *File, pdef *syntax.Definition) (*Defini
 		return nil, err
 	}
 
+	annots, err := newAnnotations(pdef.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
 	def := &Definition{
 		Package: pkg,
 		File:    file,
 
 		Name: pdef.Name,
 		Type: typ,
+		Doc:  pdef.Doc,
+		Pos:  pdef.Pos,
+
+		Annotations: annots,
 	}
 
 	if err := def.parse(pdef); err != nil {
@@ -18,6 +18,7 @@ type Enum struct {
 	Values       []*EnumValue
 	ValueNames   map[string]*EnumValue
 	ValueNumbers map[int]*EnumValue
+	Reserved     *Reserved
 }
 
 func parseEnum(pkg *Package, file *File, def *Definition, penum *syntax.Enum) (*Enum, error) {
@@ -30,6 +31,13 @@ func parseEnum(pkg *Package, file *File, def *Definition, penum *syntax.Enum) (*
 		ValueNumbers: make(map[int]*EnumValue),
 	}
 
+	// Parse reserved
+	var err error
+	e.Reserved, err = newReserved(penum.Reserved)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %w", def.Name, err)
+	}
+
 	// Parse values
 	if err := e.parseValues(penum); err != nil {
 		return nil, err
@@ -46,12 +54,13 @@ func parseEnum(pkg *Package, file *File, def *Definition, penum *syntax.Enum) (*
 // parse
 
 func (e *Enum) parseValues(penum *syntax.Enum) error {
+	var errs errorList
 	for _, pval := range penum.Values {
 		if err := e.parseValue(pval); err != nil {
-			return err
+			errs.add(pval.Pos, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 func (e *Enum) parseValue(pval *syntax.EnumValue) error {
@@ -66,6 +75,15 @@ func (e *Enum) parseValue(pval *syntax.EnumValue) error {
 		return fmt.Errorf("%v.%v: duplicate enum value", e.Def.Name, val.Name)
 	}
 
+	// Check reserved
+	if e.Reserved.ContainsName(val.Name) {
+		return fmt.Errorf("%v.%v: enum value name is reserved", e.Def.Name, val.Name)
+	}
+	if e.Reserved.Contains(val.Number) {
+		return fmt.Errorf("%v.%v: enum value number is reserved, number=%v",
+			e.Def.Name, val.Name, val.Number)
+	}
+
 	// Check number
 	_, ok = e.ValueNumbers[val.Number]
 	if ok {
@@ -11,13 +11,26 @@ type EnumValue struct {
 
 	Name   string
 	Number int
+	Doc    string          // doc comment
+	Pos    syntax.Position // source position
+
+	Annotations *Annotations
 }
 
 func parseEnumValue(enum *Enum, pval *syntax.EnumValue) (*EnumValue, error) {
+	annots, err := newAnnotations(pval.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
 	v := &EnumValue{
 		Enum:   enum,
 		Name:   pval.Name,
 		Number: pval.Value,
+		Doc:    pval.Doc,
+		Pos:    pval.Pos,
+
+		Annotations: annots,
 	}
 	return v, nil
 }
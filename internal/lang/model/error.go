@@ -0,0 +1,113 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/parser"
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+// Error is a compile error with a source position.
+type Error struct {
+	Path string          // file path
+	Pos  syntax.Position // source position, can be invalid
+	Msg  string
+}
+
+// Error returns "path:line:column: msg", omits unknown parts.
+func (e *Error) Error() string {
+	switch {
+	case e.Path == "" && !e.Pos.IsValid():
+		return e.Msg
+	case e.Path == "":
+		return fmt.Sprintf("%v: %v", e.Pos, e.Msg)
+	case !e.Pos.IsValid():
+		return fmt.Sprintf("%v: %v", e.Path, e.Msg)
+	}
+	return fmt.Sprintf("%v:%v: %v", e.Path, e.Pos, e.Msg)
+}
+
+// Errors is a list of compile errors, returned when a compilation fails.
+type Errors []*Error
+
+// Error returns errors separated by newlines.
+func (e Errors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// internal
+
+func newErrorf(path string, pos syntax.Position, format string, a ...any) *Error {
+	return &Error{
+		Path: path,
+		Pos:  pos,
+		Msg:  fmt.Sprintf(format, a...),
+	}
+}
+
+// errorList collects compile errors, so that all errors are reported in one pass.
+type errorList struct {
+	list Errors
+}
+
+// add adds an error at a position, nested compile errors keep their own positions.
+// Syntax errors are converted into compile errors, joined errors are added one by one.
+func (l *errorList) add(pos syntax.Position, err error) {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, err1 := range joined.Unwrap() {
+			l.add(pos, err1)
+		}
+		return
+	}
+
+	var list Errors
+	if errors.As(err, &list) {
+		l.list = append(l.list, list...)
+		return
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		l.list = append(l.list, e)
+		return
+	}
+
+	var perr *parser.Error
+	if errors.As(err, &perr) {
+		l.list = append(l.list, &Error{Path: perr.Path, Pos: perr.Pos, Msg: perr.Msg})
+		return
+	}
+
+	l.list = append(l.list, &Error{Pos: pos, Msg: err.Error()})
+}
+
+// addf formats and adds an error at a position.
+func (l *errorList) addf(pos syntax.Position, format string, a ...any) {
+	l.add(pos, fmt.Errorf(format, a...))
+}
+
+// err returns collected errors or nil, sets a file path on errors without one.
+func (l *errorList) err(path string) error {
+	if len(l.list) == 0 {
+		return nil
+	}
+
+	if path != "" {
+		for _, e := range l.list {
+			if e.Path == "" {
+				e.Path = path
+			}
+		}
+	}
+	return l.list
+}
@@ -24,6 +24,8 @@ type File struct {
 
 	Definitions     []*Definition
 	DefinitionNames map[string]*Definition
+
+	importErrors bool // imports failed to parse or resolve, types are not resolved
 }
 
 func newFile(pkg *Package, pfile *syntax.File) (*File, error) {
@@ -40,16 +42,18 @@ func newFile(pkg *Package, pfile *syntax.File) (*File, error) {
 		DefinitionNames: make(map[string]*Definition),
 	}
 
+	var errs errorList
 	if err := f.parseImports(pfile); err != nil {
-		return nil, err
+		errs.add(syntax.Position{}, err)
+		f.importErrors = true
 	}
 	if err := f.parseOptions(pfile); err != nil {
-		return nil, err
+		errs.add(syntax.Position{}, err)
 	}
 	if err := f.parseDefinitions(pfile); err != nil {
-		return nil, err
+		errs.add(syntax.Position{}, err)
 	}
-	return f, nil
+	return f, errs.err(f.Path)
 }
 
 func (f *File) lookupImport(name string) (*Import, bool) {
@@ -65,23 +69,24 @@ func (f *File) lookupType(name string) (*Definition, bool) {
 // parse imports
 
 func (f *File) parseImports(pfile *syntax.File) error {
+	var errs errorList
 	for _, pimp := range pfile.Imports {
 		if err := f.parseImport(pimp); err != nil {
-			return err
+			errs.add(pimp.Pos, err)
 		}
 	}
-	return nil
+	return errs.err(f.Path)
 }
 
 func (f *File) parseImport(pimp *syntax.Import) error {
 	imp, err := newImport(f, pimp)
 	if err != nil {
-		return fmt.Errorf("%v: %w", f.Path, err)
+		return err
 	}
 
 	_, ok := f.ImportMap[imp.Name]
 	if ok {
-		return fmt.Errorf("%v: duplicate import %q", f.Path, imp.Name)
+		return fmt.Errorf("duplicate import %q", imp.Name)
 	}
 
 	f.Imports = append(f.Imports, imp)
@@ -92,23 +97,24 @@ func (f *File) parseImport(pimp *syntax.Import) error {
 // parse options
 
 func (f *File) parseOptions(pfile *syntax.File) error {
+	var errs errorList
 	for _, popt := range pfile.Options {
 		if err := f.parseOption(popt); err != nil {
-			return err
+			errs.add(popt.Pos, err)
 		}
 	}
-	return nil
+	return errs.err(f.Path)
 }
 
 func (f *File) parseOption(popt *syntax.Option) error {
 	opt, err := newOption(popt)
 	if err != nil {
-		return fmt.Errorf("%v: %w", f.Path, err)
+		return err
 	}
 
 	_, ok := f.OptionMap[opt.Name]
 	if ok {
-		return fmt.Errorf("%v: duplicate option %q", f.Path, opt.Name)
+		return fmt.Errorf("duplicate option %q", opt.Name)
 	}
 
 	f.Options = append(f.Options, opt)
@@ -119,18 +125,20 @@ func (f *File) parseOption(popt *syntax.Option) error {
 // parse definitions
 
 func (f *File) parseDefinitions(pfile *syntax.File) error {
+	var errs errorList
 	for _, pdef := range pfile.Definitions {
 		if err := f.parseDefinition(pdef); err != nil {
-			return err
+			errs.add(pdef.Pos, err)
 		}
 	}
-	return nil
+	return errs.err(f.Path)
 }
 
 func (f *File) parseDefinition(pdef *syntax.Definition) error {
 	def, err := parseDefinition(f.Package, f, pdef)
 	if err != nil {
-		return fmt.Errorf("%v: %w", f.Path, err)
+		f.Package.invalidate(pdef.Name)
+		return err
 	}
 
 	return f.add(def)
@@ -139,43 +147,48 @@ func (f *File) parseDefinition(pdef *syntax.Definition) error {
 // resolve
 
 func (f *File) resolveImports() error {
+	var errs errorList
 	for _, imp := range f.Imports {
 		if err := imp.resolve(); err != nil {
-			return fmt.Errorf("%v: %w", f.Name, err)
+			errs.add(imp.Pos, err)
+			f.importErrors = true
 		}
 	}
-	return nil
+	return errs.err(f.Path)
 }
 
 func (f *File) resolve() error {
+	var errs errorList
 	for _, def := range f.Definitions {
 		if err := def.resolve(f); err != nil {
-			return fmt.Errorf("%v: %w", f.Name, err)
+			errs.add(def.Pos, err)
 		}
 	}
-	return nil
+	return errs.err(f.Path)
 }
 
 // compile
 
 func (f *File) compile() error {
+	var errs errorList
 	for _, def := range f.Definitions {
 		if err := def.compile(); err != nil {
-			return fmt.Errorf("%v: %w", f.Path, err)
+			errs.add(def.Pos, err)
 		}
 	}
-	return nil
+	return errs.err(f.Path)
 }
 
 // validate
 
 func (f *File) validate() error {
+	var errs errorList
 	for _, def := range f.Definitions {
 		if err := def.validate(); err != nil {
-			return fmt.Errorf("%v: %w", f.Path, err)
+			errs.add(def.Pos, err)
 		}
 	}
-	return nil
+	return errs.err(f.Path)
 }
 
 // add
@@ -183,7 +196,7 @@ func (f *File) validate() error {
 func (f *File) add(def *Definition) error {
 	_, ok := f.DefinitionNames[def.Name]
 	if ok {
-		return fmt.Errorf("%v: duplicate definition %q", f.Path, def.Name)
+		return fmt.Errorf("duplicate definition %q", def.Name)
 	}
 
 	f.Definitions = append(f.Definitions, def)
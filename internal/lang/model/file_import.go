@@ -15,9 +15,10 @@ import (
 type Import struct {
 	File *File
 
-	ID      string   // full id
-	Name    string   // name or alias
-	Package *Package // resolved imported package
+	ID      string          // full id
+	Name    string          // name or alias
+	Package *Package        // resolved imported package
+	Pos     syntax.Position // source position
 
 	Resolved bool
 }
@@ -37,6 +38,7 @@ func newImport(file *File, pimp *syntax.Import) (*Import, error) {
 		File: file,
 		ID:   pimp.ID,
 		Name: name,
+		Pos:  pimp.Pos,
 	}
 	return imp, nil
 }
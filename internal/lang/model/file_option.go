@@ -9,12 +9,14 @@ import "github.com/basecomplextech/spec/internal/lang/syntax"
 type Option struct {
 	Name  string
 	Value string
+	Pos   syntax.Position // source position
 }
 
 func newOption(popt *syntax.Option) (*Option, error) {
 	opt := &Option{
 		Name:  popt.Name,
 		Value: popt.Value,
+		Pos:   popt.Pos,
 	}
 	return opt, nil
 }
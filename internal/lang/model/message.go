@@ -14,6 +14,8 @@ type Message struct {
 	Def     *Definition
 
 	Fields    *Fields
+	Oneofs    []*Oneof
+	Reserved  *Reserved
 	Generated bool // Auto-generated message, i.e. request/response
 }
 
@@ -25,11 +27,23 @@ func parseMessage(pkg *Package, file *File, def *Definition, pmsg *syntax.Messag
 	}
 
 	var err error
+	msg.Reserved, err = newReserved(pmsg.Reserved)
+	if err != nil {
+		return nil, err
+	}
+
 	msg.Fields, err = newFields(pmsg.Fields)
 	if err != nil {
 		return nil, err
 	}
+	if err := msg.Fields.checkReserved(msg.Reserved); err != nil {
+		return nil, err
+	}
 
+	msg.Oneofs, err = newOneofs(msg.Fields, pmsg)
+	if err != nil {
+		return nil, err
+	}
 	return msg, nil
 }
 
@@ -40,6 +54,8 @@ func generateMessageDef(pkg *Package, file *File, name string, fields *Fields) (
 
 		Name: name,
 		Type: DefinitionMessage,
+
+		Annotations: &Annotations{},
 	}
 
 	// Generate message
@@ -63,6 +79,7 @@ func generateMessage(pkg *Package, file *File, def *Definition, fields *Fields)
 		Def:     def,
 
 		Fields:    fields,
+		Reserved:  &Reserved{},
 		Generated: true,
 	}
 	return msg, nil
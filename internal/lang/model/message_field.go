@@ -11,9 +11,17 @@ import (
 )
 
 type Field struct {
-	Name string
-	Tag  int
-	Type *Type
+	Name    string
+	Tag     int
+	Type    *Type
+	Oneof   *Oneof          // oneof or nil
+	Default *Default        // default value or nil, parsed on compile
+	Doc     string          // doc comment
+	Pos     syntax.Position // source position
+
+	Annotations *Annotations
+
+	pdefault *syntax.Value
 }
 
 func newField(pfield *syntax.Field) (*Field, error) {
@@ -27,10 +35,21 @@ func newField(pfield *syntax.Field) (*Field, error) {
 		return nil, err
 	}
 
+	annots, err := newAnnotations(pfield.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
 	f := &Field{
 		Name: pfield.Name,
 		Tag:  pfield.Tag,
 		Type: type_,
+		Doc:  pfield.Doc,
+		Pos:  pfield.Pos,
+
+		Annotations: annots,
+
+		pdefault: pfield.Default,
 	}
 	return f, nil
 }
@@ -43,7 +62,18 @@ func (f *Field) resolve(file *File) error {
 }
 
 func (f *Field) resolved() error {
+	if f.pdefault != nil {
+		d, err := parseDefault(f.Type, f.pdefault)
+		if err != nil {
+			return fmt.Errorf("invalid field %q: %w", f.Name, err)
+		}
+		f.Default = d
+	}
+
 	ref := f.Type.Ref
+	if f.Type.Kind == KindMap {
+		ref = f.Type.Element.Ref
+	}
 	if ref == nil {
 		return nil
 	}
@@ -68,20 +98,24 @@ func newFields(pfields []*syntax.Field) (*Fields, error) {
 	}
 
 	// Create fields
+	var errs errorList
 	for _, pfield := range pfields {
 		field, err := newField(pfield)
 		if err != nil {
-			return nil, fmt.Errorf("invalid field %q: %w", pfield.Name, err)
+			errs.addf(pfield.Pos, "invalid field %q: %w", pfield.Name, err)
+			continue
 		}
 
 		_, ok := fields.Tags[field.Tag]
 		if ok {
-			return nil, fmt.Errorf("invalid field %q: duplicate tag %d", field.Name, field.Tag)
+			errs.addf(field.Pos, "invalid field %q: duplicate tag %d", field.Name, field.Tag)
+			continue
 		}
 
 		_, ok = fields.Names[field.Name]
 		if ok {
-			return nil, fmt.Errorf("duplicate field %q", field.Name)
+			errs.addf(field.Pos, "duplicate field %q", field.Name)
+			continue
 		}
 
 		fields.List = append(fields.List, field)
@@ -89,9 +123,26 @@ func newFields(pfields []*syntax.Field) (*Fields, error) {
 		fields.Tags[field.Tag] = field
 	}
 
+	if err := errs.err(""); err != nil {
+		return nil, err
+	}
 	return fields, nil
 }
 
+// checkReserved returns an error if a field uses a reserved tag or name.
+func (f *Fields) checkReserved(r *Reserved) error {
+	var errs errorList
+	for _, field := range f.List {
+		if r.Contains(field.Tag) {
+			errs.addf(field.Pos, "invalid field %q: tag %d is reserved", field.Name, field.Tag)
+		}
+		if r.ContainsName(field.Name) {
+			errs.addf(field.Pos, "invalid field %q: name is reserved", field.Name)
+		}
+	}
+	return errs.err("")
+}
+
 func (f *Fields) Get(name string) *Field {
 	return f.Names[name]
 }
@@ -103,19 +154,21 @@ func (f *Fields) GetByTag(tag int) *Field {
 // internal
 
 func (f *Fields) resolve(file *File) error {
+	var errs errorList
 	for _, field := range f.List {
 		if err := field.resolve(file); err != nil {
-			return err
+			errs.add(field.Pos, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 func (f *Fields) compile() error {
+	var errs errorList
 	for _, field := range f.List {
 		if err := field.resolved(); err != nil {
-			return err
+			errs.add(field.Pos, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
@@ -0,0 +1,130 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+// Default is a field default value.
+type Default struct {
+	Text string // literal text
+
+	// Value is a parsed value, bool, int64, uint64, float64, string or *EnumValue.
+	Value any
+}
+
+func parseDefault(typ *Type, pval *syntax.Value) (*Default, error) {
+	value, err := parseDefaultValue(typ, pval)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Default{
+		Text:  pval.Text,
+		Value: value,
+	}
+	return d, nil
+}
+
+func parseDefaultValue(typ *Type, pval *syntax.Value) (any, error) {
+	kind := typ.Kind
+	text := pval.Text
+
+	switch kind {
+	case KindBool:
+		if pval.Kind == syntax.ValueIdent {
+			switch text {
+			case "true":
+				return true, nil
+			case "false":
+				return false, nil
+			}
+		}
+
+	case KindByte, KindUint16, KindUint32, KindUint64:
+		if pval.Kind == syntax.ValueInteger {
+			v, err := strconv.ParseUint(text, 10, uintBits(kind))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %v default %v", kind, text)
+			}
+			return v, nil
+		}
+
+	case KindInt16, KindInt32, KindInt64:
+		if pval.Kind == syntax.ValueInteger {
+			v, err := strconv.ParseInt(text, 10, intBits(kind))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %v default %v", kind, text)
+			}
+			return v, nil
+		}
+
+	case KindFloat32, KindFloat64:
+		if pval.Kind == syntax.ValueInteger || pval.Kind == syntax.ValueFloat {
+			bits := 64
+			if kind == KindFloat32 {
+				bits = 32
+			}
+
+			v, err := strconv.ParseFloat(text, bits)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %v default %v", kind, text)
+			}
+			return v, nil
+		}
+
+	case KindString:
+		if pval.Kind == syntax.ValueString {
+			v, err := strconv.Unquote(text)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string default %v", text)
+			}
+			return v, nil
+		}
+
+	case KindEnum:
+		if pval.Kind == syntax.ValueIdent {
+			enum := typ.Ref.Enum
+			v, ok := enum.ValueNames[text]
+			if !ok {
+				return nil, fmt.Errorf("invalid enum default %v, value not found in %v", text, typ.Name)
+			}
+			return v, nil
+		}
+
+	default:
+		return nil, fmt.Errorf("default values not supported for %v type", kind)
+	}
+
+	return nil, fmt.Errorf("invalid %v default %v", kind, text)
+}
+
+// util
+
+func intBits(kind Kind) int {
+	switch kind {
+	case KindInt16:
+		return 16
+	case KindInt32:
+		return 32
+	}
+	return 64
+}
+
+func uintBits(kind Kind) int {
+	switch kind {
+	case KindByte:
+		return 8
+	case KindUint16:
+		return 16
+	case KindUint32:
+		return 32
+	}
+	return 64
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+// Oneof is a set of mutually exclusive message fields.
+type Oneof struct {
+	Name   string
+	Doc    string          // doc comment
+	Pos    syntax.Position // source position
+	Fields []*Field
+}
+
+func newOneofs(fields *Fields, pmsg *syntax.Message) ([]*Oneof, error) {
+	if len(pmsg.Oneofs) == 0 {
+		return nil, nil
+	}
+
+	oneofs := make([]*Oneof, 0, len(pmsg.Oneofs))
+	names := make(map[string]*Oneof, len(pmsg.Oneofs))
+
+	// Create oneofs
+	var errs errorList
+	for _, poneof := range pmsg.Oneofs {
+		name := poneof.Name
+
+		if _, ok := names[name]; ok {
+			errs.addf(poneof.Pos, "duplicate oneof %q", name)
+			continue
+		}
+		if _, ok := fields.Names[name]; ok {
+			errs.addf(poneof.Pos, "invalid oneof %q: name conflicts with field", name)
+			continue
+		}
+		if len(poneof.Fields) == 0 {
+			errs.addf(poneof.Pos, "invalid oneof %q: no fields", name)
+			continue
+		}
+
+		oneof := &Oneof{Name: name, Doc: poneof.Doc, Pos: poneof.Pos}
+		oneofs = append(oneofs, oneof)
+		names[name] = oneof
+	}
+
+	// Add fields in the schema order
+	for _, pfield := range pmsg.Fields {
+		if pfield.Oneof == "" {
+			continue
+		}
+
+		if pfield.Default != nil {
+			errs.addf(pfield.Pos, "invalid field %q: oneof fields cannot have defaults", pfield.Name)
+			continue
+		}
+
+		oneof, ok := names[pfield.Oneof]
+		if !ok {
+			continue
+		}
+		field := fields.Names[pfield.Name]
+
+		field.Oneof = oneof
+		oneof.Fields = append(oneof.Fields, field)
+	}
+
+	if err := errs.err(""); err != nil {
+		return nil, err
+	}
+	return oneofs, nil
+}
@@ -27,7 +27,11 @@ type Method struct {
 
 	Name   string
 	Type   MethodType
-	Oneway bool // Oneway method
+	Oneway bool            // Oneway method
+	Doc    string          // doc comment
+	Pos    syntax.Position // source position
+
+	Annotations *Annotations
 
 	Request    *Type // Message type
 	Response   *Type // Message type
@@ -41,6 +45,11 @@ type Method struct {
 }
 
 func parseMethod(pkg *Package, file *File, service *Service, pm *syntax.Method) (*Method, error) {
+	annots, err := newAnnotations(pm.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
 	m := &Method{
 		Package: pkg,
 		File:    file,
@@ -48,6 +57,10 @@ func parseMethod(pkg *Package, file *File, service *Service, pm *syntax.Method)
 
 		Name:   pm.Name,
 		Oneway: pm.Oneway,
+		Doc:    pm.Doc,
+		Pos:    pm.Pos,
+
+		Annotations: annots,
 	}
 
 	if err := m.parseInput(pm); err != nil {
@@ -42,6 +42,10 @@ func newMethodChannel(p *syntax.MethodChannel) (*MethodChannel, error) {
 		}
 	}
 
+	if (in != nil && in.Kind == KindMap) || (out != nil && out.Kind == KindMap) {
+		return nil, fmt.Errorf("map channel types not supported")
+	}
+
 	ch := &MethodChannel{
 		In:  in,
 		Out: out,
@@ -27,6 +27,14 @@ type Package struct {
 	Definitions     []*Definition
 	DefinitionNames map[string]*Definition
 
+	// Warnings are compile warnings, i.e. references to deprecated imported definitions.
+	Warnings []string
+	warned   map[string]struct{}
+
+	// invalid are names of definitions which failed to parse,
+	// references to them are not reported as missing types.
+	invalid map[string]struct{}
+
 	Compiling bool
 }
 
@@ -50,16 +58,19 @@ func parsePackage(ctx *Context, id string, path string, pfiles []*syntax.File) (
 		Compiling: true,
 	}
 
+	// Return a partially parsed package on errors,
+	// so that types are still resolved in valid definitions.
+	var errs errorList
 	if err := pkg.parseFiles(pfiles); err != nil {
-		return nil, err
+		errs.add(syntax.Position{}, err)
 	}
 	if err := pkg.parseOptions(); err != nil {
-		return nil, err
+		errs.add(syntax.Position{}, err)
 	}
 	if err := pkg.parseDefinitions(); err != nil {
-		return nil, err
+		errs.add(syntax.Position{}, err)
 	}
-	return pkg, nil
+	return pkg, errs.err("")
 }
 
 func (p *Package) lookupType(name string) (*Definition, bool) {
@@ -67,106 +78,143 @@ func (p *Package) lookupType(name string) (*Definition, bool) {
 	return def, ok
 }
 
+// invalidate marks a definition name as failed to parse.
+func (p *Package) invalidate(name string) {
+	if p.invalid == nil {
+		p.invalid = make(map[string]struct{})
+	}
+	p.invalid[name] = struct{}{}
+}
+
+// isInvalid returns true if a definition failed to parse.
+func (p *Package) isInvalid(name string) bool {
+	_, ok := p.invalid[name]
+	return ok
+}
+
+// warn adds a compile warning, skips duplicates.
+func (p *Package) warn(msg string) {
+	if _, ok := p.warned[msg]; ok {
+		return
+	}
+
+	if p.warned == nil {
+		p.warned = make(map[string]struct{})
+	}
+	p.warned[msg] = struct{}{}
+	p.Warnings = append(p.Warnings, msg)
+}
+
 // parse
 
 func (p *Package) parseFiles(pfiles []*syntax.File) error {
+	var errs errorList
 	for _, pfile := range pfiles {
 		if err := p.parseFile(pfile); err != nil {
-			return err
+			errs.add(syntax.Position{}, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 func (p *Package) parseFile(pfile *syntax.File) error {
 	f, err := newFile(p, pfile)
-	if err != nil {
-		return err
-	}
-
 	p.Files = append(p.Files, f)
 	p.FileNames[f.Name] = f
-	return nil
+	return err
 }
 
 func (p *Package) parseOptions() error {
+	var errs errorList
 	for _, file := range p.Files {
 		for _, opt := range file.Options {
 			_, ok := p.OptionNames[opt.Name]
 			if ok {
-				return fmt.Errorf("%v: duplicate option %q", file.Path, opt.Name)
+				errs.add(opt.Pos, newErrorf(file.Path, opt.Pos, "duplicate option %q", opt.Name))
+				continue
 			}
 
 			p.Options = append(p.Options, opt)
 			p.OptionNames[opt.Name] = opt
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 func (p *Package) parseDefinitions() error {
+	var errs errorList
 	for _, file := range p.Files {
 		for _, def := range file.Definitions {
 			_, ok := p.DefinitionNames[def.Name]
 			if ok {
-				return fmt.Errorf("%v: duplicate definition %q", file.Path, def.Name)
+				errs.add(def.Pos, newErrorf(file.Path, def.Pos, "duplicate definition %q", def.Name))
+				continue
 			}
 
 			p.Definitions = append(p.Definitions, def)
 			p.DefinitionNames[def.Name] = def
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 // resolve
 
 func (p *Package) resolve() error {
+	var errs errorList
 	if err := p.resolveImports(); err != nil {
-		return err
+		errs.add(syntax.Position{}, err)
 	}
 	if err := p.resolveTypes(); err != nil {
-		return err
+		errs.add(syntax.Position{}, err)
 	}
-	return nil
+	return errs.err("")
 }
 
 func (p *Package) resolveImports() error {
+	var errs errorList
 	for _, file := range p.Files {
 		if err := file.resolveImports(); err != nil {
-			return fmt.Errorf("%v: %w", p.Name, err)
+			errs.add(syntax.Position{}, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 func (p *Package) resolveTypes() error {
+	var errs errorList
 	for _, file := range p.Files {
+		// Skip files with import errors, their types cannot be resolved
+		if file.importErrors {
+			continue
+		}
 		if err := file.resolve(); err != nil {
-			return fmt.Errorf("%v: %w", p.Name, err)
+			errs.add(syntax.Position{}, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 // compile
 
 func (p *Package) compile() error {
+	var errs errorList
 	for _, file := range p.Files {
 		if err := file.compile(); err != nil {
-			return fmt.Errorf("%v: %w", p.Name, err)
+			errs.add(syntax.Position{}, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 // validate
 
 func (p *Package) validate() error {
+	var errs errorList
 	for _, file := range p.Files {
 		if err := file.validate(); err != nil {
-			return fmt.Errorf("%v: %w", p.Name, err)
+			errs.add(syntax.Position{}, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
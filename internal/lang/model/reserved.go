@@ -0,0 +1,84 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+// Reserved is a set of reserved message tags or enum numbers and names,
+// which cannot be used by fields or enum values.
+type Reserved struct {
+	Ranges []ReservedRange
+	Names  []string
+}
+
+// ReservedRange is an inclusive range of reserved tags or enum numbers.
+type ReservedRange struct {
+	Start int
+	End   int
+}
+
+func newReserved(preserved syntax.Reserved) (*Reserved, error) {
+	r := &Reserved{}
+
+	for _, prange := range preserved.Ranges {
+		if prange.Start > prange.End {
+			return nil, fmt.Errorf("invalid reserved range %d to %d", prange.Start, prange.End)
+		}
+
+		rng := ReservedRange{
+			Start: prange.Start,
+			End:   prange.End,
+		}
+		r.Ranges = append(r.Ranges, rng)
+	}
+
+	names := make(map[string]struct{}, len(preserved.Names))
+	for _, name := range preserved.Names {
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("duplicate reserved name %q", name)
+		}
+
+		names[name] = struct{}{}
+		r.Names = append(r.Names, name)
+	}
+	return r, nil
+}
+
+// Empty returns true if no tags or names are reserved.
+func (r *Reserved) Empty() bool {
+	return len(r.Ranges) == 0 && len(r.Names) == 0
+}
+
+// Contains returns true if a tag or an enum number is reserved.
+func (r *Reserved) Contains(n int) bool {
+	for _, rng := range r.Ranges {
+		if n >= rng.Start && n <= rng.End {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsName returns true if a name is reserved.
+func (r *Reserved) ContainsName(name string) bool {
+	for _, n := range r.Names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a reserved range string, i.e. "7" or "7 to 9".
+func (r ReservedRange) String() string {
+	if r.Start == r.End {
+		return fmt.Sprintf("%d", r.Start)
+	}
+	return fmt.Sprintf("%d to %d", r.Start, r.End)
+}
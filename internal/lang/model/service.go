@@ -39,12 +39,13 @@ func newService(pkg *Package, file *File, def *Definition, ps *syntax.Service) (
 // parse
 
 func (s *Service) parseMethods(ps *syntax.Service) error {
+	var errs errorList
 	for _, pm := range ps.Methods {
 		if err := s.parseMethod(pm); err != nil {
-			return err
+			errs.add(pm.Pos, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 func (s *Service) parseMethod(pm *syntax.Method) error {
@@ -66,21 +67,23 @@ func (s *Service) parseMethod(pm *syntax.Method) error {
 // resolve
 
 func (s *Service) resolve(file *File) error {
+	var errs errorList
 	for _, m := range s.Methods {
 		if err := m.resolve(file); err != nil {
-			return fmt.Errorf("%v.%v: %w", s.Def.Name, m.Name, err)
+			errs.addf(m.Pos, "%v.%v: %w", s.Def.Name, m.Name, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 // compile
 
 func (s *Service) compile() error {
+	var errs errorList
 	for _, m := range s.Methods {
 		if err := m.compile(); err != nil {
-			return fmt.Errorf("%v.%v: %w", s.Def.Name, m.Name, err)
+			errs.addf(m.Pos, "%v.%v: %w", s.Def.Name, m.Name, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
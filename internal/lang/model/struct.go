@@ -37,12 +37,13 @@ func parseStruct(pkg *Package, file *File, def *Definition, ps *syntax.Struct) (
 // parse
 
 func (s *Struct) parseFields(ps *syntax.Struct) error {
+	var errs errorList
 	for _, pfield := range ps.Fields {
 		if err := s.parseField(pfield); err != nil {
-			return err
+			errs.add(pfield.Pos, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 func (s *Struct) parseField(pfield *syntax.StructField) error {
@@ -56,39 +57,42 @@ func (s *Struct) parseField(pfield *syntax.StructField) error {
 		return fmt.Errorf("%v.%v: duplicate field", s.Def.Name, field.Name)
 	}
 
-	s.Fields.Set(field.Name, field)
+	s.Fields.Put(field.Name, field)
 	return nil
 }
 
 // resolve
 
 func (s *Struct) resolve(file *File) error {
+	var errs errorList
 	for _, field := range s.Fields.Values() {
 		if err := field.resolve(file); err != nil {
-			return err
+			errs.add(field.Pos, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 // compile
 
 func (s *Struct) compile() error {
+	var errs errorList
 	for _, field := range s.Fields.Values() {
 		if err := field.compile(); err != nil {
-			return err
+			errs.add(field.Pos, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
 
 // validate
 
 func (s *Struct) validate() error {
+	var errs errorList
 	for _, field := range s.Fields.Values() {
 		if err := field.validate(); err != nil {
-			return fmt.Errorf("%v: %w", s.Def.Name, err)
+			errs.addf(field.Pos, "%v: %w", s.Def.Name, err)
 		}
 	}
-	return nil
+	return errs.err("")
 }
@@ -14,6 +14,10 @@ type StructField struct {
 	Struct *Struct
 	Name   string
 	Type   *Type
+	Doc    string          // doc comment
+	Pos    syntax.Position // source position
+
+	Annotations *Annotations
 }
 
 func parseStructField(str *Struct, pfield *syntax.StructField) (*StructField, error) {
@@ -22,10 +26,19 @@ func parseStructField(str *Struct, pfield *syntax.StructField) (*StructField, er
 		return nil, err
 	}
 
+	annots, err := newAnnotations(pfield.Annotations)
+	if err != nil {
+		return nil, err
+	}
+
 	f := &StructField{
 		Struct: str,
 		Name:   pfield.Name,
 		Type:   typ,
+		Doc:    pfield.Doc,
+		Pos:    pfield.Pos,
+
+		Annotations: annots,
 	}
 	return f, nil
 }
@@ -36,6 +36,23 @@ var builtin = map[Kind]*Type{
 	KindAnyMessage: newBuiltinType(KindAnyMessage),
 }
 
+// mapKeys are kinds which can be used as map keys.
+var mapKeys = map[Kind]struct{}{
+	KindInt16: {},
+	KindInt32: {},
+	KindInt64: {},
+
+	KindUint16: {},
+	KindUint32: {},
+	KindUint64: {},
+
+	KindBin64:  {},
+	KindBin128: {},
+	KindBin256: {},
+
+	KindString: {},
+}
+
 var primitive = map[Kind]struct{}{
 	KindBool: {},
 	KindByte: {},
@@ -59,7 +76,8 @@ var primitive = map[Kind]struct{}{
 type Type struct {
 	Kind       Kind
 	Name       string
-	Element    *Type  // element type in list, reference and nullable types
+	Key        *Type  // key type in map
+	Element    *Type  // element type in list and map, reference and nullable types
 	ImportName string // imported package name, "pkg" in "pkg.Type"
 
 	// Resolved
@@ -92,6 +110,27 @@ func newType(ptype *syntax.Type) (*Type, error) {
 		}
 		return type_, nil
 
+	case KindMap:
+		key, err := newType(ptype.Key)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := mapKeys[key.Kind]; !ok {
+			return nil, fmt.Errorf("invalid map key type %v, only integer, string and bin keys are supported", key.Name)
+		}
+
+		elem, err := newType(ptype.Element)
+		if err != nil {
+			return nil, err
+		}
+		type_ := &Type{
+			Kind:    KindMap,
+			Name:    "map",
+			Key:     key,
+			Element: elem,
+		}
+		return type_, nil
+
 	case KindReference:
 		type_ := &Type{
 			Kind:       KindReference,
@@ -135,7 +174,7 @@ func (t *Type) primitive() bool {
 
 func (t *Type) resolve(file *File) error {
 	switch t.Kind {
-	case KindList:
+	case KindList, KindMap:
 		return t.Element.resolve(file)
 
 	case KindReference:
@@ -144,7 +183,11 @@ func (t *Type) resolve(file *File) error {
 
 			pkg := file.Package
 			def, ok := pkg.lookupType(t.Name)
-			if !ok {
+			switch {
+			case !ok && pkg.isInvalid(t.Name):
+				// Already reported as a parse error
+				return nil
+			case !ok:
 				return fmt.Errorf("type not found: %v", t.Name)
 			}
 			t._resolve(def, nil)
@@ -161,6 +204,15 @@ func (t *Type) resolve(file *File) error {
 				return fmt.Errorf("type not found: %v.%v", t.ImportName, t.Name)
 			}
 			t._resolve(def, imp)
+
+			// Warn on deprecated imported types
+			if reason, ok := def.Annotations.Deprecated(); ok {
+				msg := fmt.Sprintf("%v: %v.%v is deprecated", file.Path, t.ImportName, t.Name)
+				if reason != "" {
+					msg += ": " + reason
+				}
+				file.Package.warn(msg)
+			}
 		}
 	}
 	return nil
@@ -39,9 +39,10 @@ const (
 	KindString
 	KindAnyMessage
 
-	// List
+	// List/map
 
 	KindList
+	KindMap
 
 	// Resolved
 
@@ -103,6 +104,8 @@ func parseKind(pkind syntax.Kind) (Kind, error) {
 
 	case syntax.KindList:
 		return KindList, nil
+	case syntax.KindMap:
+		return KindMap, nil
 
 	case syntax.KindReference:
 		return KindReference, nil
@@ -156,6 +159,8 @@ func (k Kind) String() string {
 
 	case KindList:
 		return "list"
+	case KindMap:
+		return "map"
 
 	case KindEnum:
 		return "enum"
@@ -0,0 +1,26 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package parser
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+// Error is a syntax error with a source position.
+type Error struct {
+	Path string          // file path, empty when parsing a string
+	Pos  syntax.Position // source position
+	Msg  string
+}
+
+// Error returns "path:line:column: msg" or "line:column: msg" without a path.
+func (e *Error) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%v: %v", e.Pos, e.Msg)
+	}
+	return fmt.Sprintf("%v:%v: %v", e.Path, e.Pos, e.Msg)
+}
@@ -16,6 +16,8 @@ type yySymType struct {
 	bool    bool
 	integer int
 	string  string
+	doc     string          // leading doc comment of a token
+	pos     syntax.Position // token position
 
 	// Type
 	type_ *syntax.Type
@@ -33,12 +35,24 @@ type yySymType struct {
 	definitions []*syntax.Definition
 
 	// Enum
-	enum_value  *syntax.EnumValue
-	enum_values []*syntax.EnumValue
+	enum       *syntax.Enum
+	enum_value *syntax.EnumValue
+
+	// Reserved
+	reserved *syntax.Reserved
+
+	// Message
+	message *syntax.Message
+	oneof   *syntax.Oneof
 
 	// Field
 	field  *syntax.Field
 	fields syntax.Fields
+	value  *syntax.Value
+
+	// Annotation
+	annotation  *syntax.Annotation
+	annotations []*syntax.Annotation
 
 	// Struct
 	struct_field  *syntax.StructField
@@ -58,16 +72,20 @@ type yySymType struct {
 const ANY = 57346
 const ENUM = 57347
 const IMPORT = 57348
-const MESSAGE = 57349
-const ONEWAY = 57350
-const OPTIONS = 57351
-const STRUCT = 57352
-const SERVICE = 57353
-const SUBSERVICE = 57354
-const IDENT = 57355
-const INTEGER = 57356
-const STRING = 57357
-const METHOD_OUTPUT = 57358
+const MAP = 57349
+const MESSAGE = 57350
+const ONEOF = 57351
+const ONEWAY = 57352
+const OPTIONS = 57353
+const RESERVED = 57354
+const STRUCT = 57355
+const SERVICE = 57356
+const SUBSERVICE = 57357
+const IDENT = 57358
+const INTEGER = 57359
+const FLOAT = 57360
+const STRING = 57361
+const METHOD_OUTPUT = 57362
 
 var yyToknames = [...]string{
 	"$end",
@@ -76,28 +94,32 @@ var yyToknames = [...]string{
 	"ANY",
 	"ENUM",
 	"IMPORT",
+	"MAP",
 	"MESSAGE",
+	"ONEOF",
 	"ONEWAY",
 	"OPTIONS",
+	"RESERVED",
 	"STRUCT",
 	"SERVICE",
 	"SUBSERVICE",
 	"IDENT",
 	"INTEGER",
+	"FLOAT",
 	"STRING",
 	"METHOD_OUTPUT",
+	"'['",
 	"'('",
 	"')'",
 	"'='",
-	"'['",
 	"']'",
 	"'.'",
-	"'{'",
 	"'}'",
+	"'{'",
 	"';'",
 	"','",
-	"'<'",
 	"'-'",
+	"'<'",
 	"'>'",
 }
 
@@ -107,137 +129,181 @@ const yyEofCode = 1
 const yyErrCode = 2
 const yyInitialStackSize = 16
 
-var yyExca = [...]int8{
+var yyExca = [...]int16{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 98,
-	18, 24,
-	27, 24,
-	28, 24,
+	-1, 7,
+	1, 12,
+	-2, 0,
+	-1, 146,
+	23, 27,
+	31, 27,
+	32, 27,
 	-2, 1,
-	-1, 99,
-	18, 26,
-	27, 26,
-	28, 26,
+	-1, 147,
+	23, 29,
+	31, 29,
+	32, 29,
 	-2, 3,
-	-1, 100,
-	18, 27,
-	27, 27,
-	28, 27,
-	-2, 5,
+	-1, 148,
+	23, 30,
+	31, 30,
+	32, 30,
+	-2, 6,
 }
 
 const yyPrivate = 57344
 
-const yyLast = 212
+const yyLast = 324
 
 var yyAct = [...]uint8{
-	63, 112, 111, 102, 64, 91, 110, 103, 43, 99,
-	55, 48, 100, 139, 50, 51, 52, 53, 98, 67,
-	130, 129, 68, 128, 129, 65, 138, 127, 66, 128,
-	125, 67, 113, 115, 68, 65, 119, 123, 126, 121,
-	66, 106, 105, 115, 44, 124, 104, 65, 67, 60,
-	56, 68, 93, 88, 113, 62, 77, 66, 39, 38,
-	37, 95, 71, 74, 74, 36, 35, 81, 80, 89,
-	44, 78, 82, 47, 67, 48, 49, 68, 50, 51,
-	52, 53, 45, 66, 76, 86, 40, 109, 94, 96,
-	142, 97, 141, 75, 33, 107, 114, 94, 108, 31,
-	47, 122, 48, 49, 120, 50, 51, 52, 53, 45,
-	47, 117, 48, 49, 84, 50, 51, 52, 53, 45,
-	72, 116, 8, 131, 6, 134, 133, 136, 137, 135,
-	69, 140, 47, 67, 48, 49, 68, 50, 51, 52,
-	53, 45, 66, 5, 3, 57, 34, 132, 47, 65,
-	48, 49, 58, 50, 51, 52, 53, 45, 99, 85,
-	48, 100, 79, 50, 51, 52, 53, 98, 25, 16,
-	24, 17, 87, 23, 18, 19, 20, 67, 30, 29,
-	68, 28, 27, 26, 118, 61, 66, 1, 101, 92,
-	90, 83, 73, 15, 14, 54, 70, 13, 42, 12,
-	41, 59, 11, 7, 10, 4, 21, 32, 2, 9,
-	22, 46,
+	114, 151, 170, 150, 87, 171, 37, 80, 169, 65,
+	139, 106, 74, 46, 70, 50, 115, 51, 52, 53,
+	212, 198, 54, 55, 56, 57, 58, 48, 81, 83,
+	84, 197, 196, 196, 197, 211, 39, 40, 41, 42,
+	47, 195, 82, 128, 129, 193, 191, 147, 179, 51,
+	175, 148, 194, 192, 54, 55, 56, 57, 58, 146,
+	111, 66, 112, 205, 116, 188, 76, 187, 47, 85,
+	92, 97, 101, 101, 174, 172, 186, 71, 185, 181,
+	79, 88, 163, 162, 124, 122, 113, 103, 119, 135,
+	62, 117, 120, 61, 60, 121, 59, 91, 123, 50,
+	118, 51, 52, 53, 93, 116, 54, 77, 56, 57,
+	58, 48, 134, 44, 203, 27, 172, 183, 38, 132,
+	104, 156, 86, 78, 90, 67, 26, 43, 215, 25,
+	136, 214, 137, 24, 152, 145, 190, 92, 155, 177,
+	161, 153, 142, 144, 173, 164, 165, 166, 35, 157,
+	158, 167, 180, 176, 38, 33, 126, 142, 85, 178,
+	8, 6, 133, 107, 182, 108, 100, 63, 50, 36,
+	51, 52, 53, 200, 189, 54, 55, 56, 57, 58,
+	48, 159, 119, 199, 109, 110, 120, 92, 154, 201,
+	204, 102, 131, 207, 118, 209, 210, 208, 206, 213,
+	202, 100, 127, 50, 130, 51, 52, 53, 32, 31,
+	54, 55, 56, 57, 58, 48, 30, 29, 96, 28,
+	50, 5, 51, 52, 53, 3, 98, 54, 55, 56,
+	57, 58, 48, 184, 1, 75, 149, 50, 140, 51,
+	52, 53, 138, 94, 54, 77, 56, 57, 58, 48,
+	119, 119, 125, 117, 120, 120, 99, 141, 16, 15,
+	72, 119, 118, 118, 69, 120, 95, 116, 38, 143,
+	14, 119, 45, 118, 117, 120, 160, 174, 38, 168,
+	89, 68, 50, 118, 51, 52, 53, 13, 116, 54,
+	55, 56, 57, 58, 48, 147, 105, 51, 52, 148,
+	64, 73, 54, 55, 56, 57, 58, 146, 11, 12,
+	7, 17, 10, 4, 18, 22, 34, 2, 9, 19,
+	20, 21, 23, 49,
 }
 
 var yyPact = [...]int16{
-	138, -1000, 134, 107, -1000, 105, -1000, 164, -1000, 155,
-	-1000, -1000, -1000, -1000, -1000, -1000, 170, 169, 168, 166,
-	165, 81, -1000, -1000, -1000, 131, 43, 42, 37, 36,
-	35, -1000, -1000, 67, -1000, -1000, 144, -1000, -1000, -1000,
-	130, 128, 30, -1000, 129, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, 106, 96, 69, -1000, -1000, -1000,
-	65, 32, 144, 148, -1000, 47, 45, -1000, -1000, -1000,
-	-1000, 129, -1000, -1000, 97, -1000, 145, -1000, -1000, -1000,
-	173, 159, 28, 44, 154, 21, -1000, -1000, -1000, -1000,
-	17, 16, 70, -1000, -1000, 5, 103, 93, 45, -1000,
-	-1000, 10, -1000, 129, -1000, -1000, -1000, -1000, 14, 144,
-	83, 19, 12, -1, -4, -9, -1000, -1000, -1000, 144,
-	133, -1000, -1000, -1000, 15, -1000, 27, 129, -2, -16,
-	129, -1000, -1000, 74, -7, 72, 2, -1000, -1000, -1000,
-	-1000, -1000, -1000,
+	219, -1000, 210, 139, -1000, 138, -1000, 306, -1000, 110,
+	-1000, 88, -1000, -1000, -1000, -1000, -1000, 203, 201, 200,
+	193, 192, 132, -1000, -1000, -1000, 150, -1000, 133, 133,
+	133, 133, 133, -1000, -1000, 103, -1000, 85, 278, 68,
+	66, 65, 62, 148, -1000, 31, -1000, 101, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, 233, 98, 278, 11, 95, 216,
+	199, 164, -1000, -1000, -1000, 58, 96, 146, -1000, -1000,
+	-1000, -1000, 167, -1000, -1000, -1000, -1000, 33, -1000, -1000,
+	-1000, 57, 267, 278, -1000, -1000, 56, 267, -1000, -1000,
+	55, 134, -1000, -1000, 185, 14, -1000, 188, -1000, -1000,
+	-1000, -1000, -1000, -1000, 175, -1000, 94, 141, 86, -1000,
+	-1000, 61, -1000, 133, -1000, 247, 291, 133, -1000, 146,
+	171, 97, 178, 178, 165, 278, 54, 53, 133, 133,
+	257, -1000, -1000, 43, 130, 116, 86, -1000, -1000, 18,
+	-1000, 267, 50, -1000, -1000, -1000, 11, -1000, 92, -1000,
+	49, -1000, -1000, -1000, 47, 38, 36, 133, 278, 113,
+	23, 22, 10, 0, -12, 141, -1000, -1000, -1000, 278,
+	156, -1000, 133, 178, 87, 278, -1000, -1000, -1000, 34,
+	-1000, -1000, 246, -1000, 84, 267, 4, -13, 267, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, 108, 3, 105, 1,
+	-1000, -1000, -1000, -1000, -1000, -1000,
 }
 
-var yyPgo = [...]uint8{
-	0, 211, 7, 210, 209, 208, 207, 206, 205, 0,
-	4, 204, 203, 202, 201, 200, 199, 8, 198, 197,
-	196, 195, 194, 193, 10, 192, 191, 190, 5, 189,
-	2, 1, 3, 188, 6, 187, 185, 184,
+var yyPgo = [...]int16{
+	0, 323, 1, 322, 318, 317, 316, 315, 313, 0,
+	16, 312, 310, 309, 301, 300, 12, 296, 11, 287,
+	281, 280, 4, 276, 7, 13, 272, 6, 270, 266,
+	264, 259, 258, 14, 256, 252, 242, 10, 238, 2,
+	5, 3, 236, 8, 234, 233, 9,
 }
 
 var yyR1 = [...]int8{
 	0, 2, 2, 1, 1, 1, 1, 1, 1, 1,
-	35, 3, 3, 4, 4, 5, 5, 8, 8, 7,
-	7, 6, 9, 9, 10, 10, 10, 10, 11, 11,
-	11, 11, 11, 12, 12, 13, 14, 15, 15, 16,
-	17, 18, 18, 18, 19, 20, 21, 21, 22, 23,
-	24, 24, 25, 25, 25, 25, 25, 26, 26, 27,
-	28, 28, 29, 29, 29, 29, 30, 30, 31, 31,
-	34, 33, 33, 33, 32, 37, 37, 36, 36,
+	1, 1, 44, 3, 3, 4, 4, 5, 5, 8,
+	8, 7, 7, 6, 9, 9, 9, 10, 10, 10,
+	10, 11, 11, 11, 11, 11, 12, 12, 12, 13,
+	14, 15, 15, 15, 15, 16, 17, 17, 18, 18,
+	18, 19, 19, 20, 20, 20, 20, 20, 20, 21,
+	22, 22, 24, 24, 24, 24, 24, 24, 27, 27,
+	26, 26, 25, 23, 23, 23, 28, 29, 30, 30,
+	30, 31, 32, 33, 33, 33, 34, 34, 34, 34,
+	34, 35, 35, 36, 37, 37, 38, 38, 38, 38,
+	39, 39, 40, 40, 43, 42, 42, 42, 41, 46,
+	46, 45, 45,
 }
 
 var yyR2 = [...]int8{
 	0, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	3, 1, 2, 0, 2, 0, 4, 0, 4, 0,
-	2, 3, 1, 3, 1, 3, 1, 1, 1, 1,
-	1, 1, 1, 0, 2, 5, 4, 0, 2, 6,
-	3, 0, 1, 3, 5, 3, 0, 2, 5, 5,
-	0, 2, 3, 4, 4, 4, 5, 3, 3, 1,
-	1, 3, 3, 3, 5, 5, 3, 3, 3, 3,
-	2, 0, 1, 3, 3, 0, 1, 0, 1,
+	1, 1, 3, 1, 2, 0, 2, 0, 4, 0,
+	4, 0, 2, 3, 1, 3, 5, 1, 3, 1,
+	1, 1, 1, 1, 1, 1, 0, 2, 3, 6,
+	5, 0, 2, 2, 3, 3, 1, 3, 1, 3,
+	1, 6, 7, 0, 3, 2, 2, 2, 3, 6,
+	4, 6, 1, 2, 1, 2, 1, 1, 0, 4,
+	1, 3, 3, 0, 1, 3, 6, 4, 0, 2,
+	3, 6, 6, 0, 2, 3, 4, 5, 5, 5,
+	6, 3, 3, 1, 1, 3, 3, 3, 5, 5,
+	3, 3, 3, 3, 2, 0, 1, 3, 3, 0,
+	1, 0, 1,
 }
 
 var yyChk = [...]int16{
-	-1000, -35, -5, 6, -8, 9, 17, -12, 17, -4,
-	-11, -13, -16, -19, -22, -23, 5, 7, 10, 11,
-	12, -7, -3, 18, 15, 13, 13, 13, 13, 13,
-	13, 18, -6, 13, 15, 23, 23, 23, 23, 23,
-	19, -15, -18, -17, -2, 13, -1, 4, 6, 7,
-	9, 10, 11, 12, -21, -24, -24, 15, 24, -14,
-	-2, -36, 25, -9, -10, 20, 13, 4, 7, 24,
-	-20, -2, 24, -25, -2, 24, 19, 24, -17, 14,
-	21, 22, -9, -26, 17, 14, -10, 13, 25, 25,
-	-27, -28, -29, 8, -10, 17, -10, -34, 13, 4,
-	7, -33, -32, -2, 25, 25, 25, 25, -28, 17,
-	-34, -30, -31, 27, -9, 28, 18, 18, -37, 26,
-	-9, 25, 18, 18, 26, 18, 26, 28, 27, 28,
-	29, -32, 14, -31, -9, -30, -9, -9, 28, 29,
-	-9, 18, 18,
+	-1000, -44, -5, 6, -8, 11, 22, -12, 22, -4,
+	-11, 2, -13, -19, -28, -31, -32, 5, 8, 13,
+	14, 15, -7, -3, 23, 19, 16, 27, 16, 16,
+	16, 16, 16, 23, -6, 16, 19, -27, 21, -27,
+	-27, -27, -27, 24, 28, -26, -25, -2, 16, -1,
+	4, 6, 7, 8, 11, 12, 13, 14, 15, 28,
+	28, 28, 28, 19, -15, -46, 30, 24, -20, -30,
+	-33, -33, 27, -14, -16, 2, -2, 12, 25, -25,
+	-24, 17, 31, 18, 19, -2, 27, -22, -16, -21,
+	29, 2, -2, 9, 27, -29, 2, -2, 27, -34,
+	2, -2, 27, 29, 24, -17, -18, 17, 19, 17,
+	18, 27, 29, 29, -9, -10, 21, 7, 16, 4,
+	8, -2, 29, -9, 29, -35, 22, 17, 29, 30,
+	16, 17, 25, 21, 26, 28, -27, -27, -36, -37,
+	-38, 10, -10, 22, -10, -43, 16, 4, 8, -42,
+	-41, -2, -27, -18, 17, -27, 24, -10, -10, 16,
+	-23, -22, 29, 29, -27, -27, -27, -37, 22, -43,
+	-39, -40, 32, -9, 31, 7, 23, 23, -46, 30,
+	-9, 29, -24, 25, -45, 29, 29, 29, 29, -27,
+	23, 23, 30, 23, 30, 31, 32, 31, 33, -41,
+	17, -27, -10, 27, -22, 29, -40, -9, -39, -9,
+	-9, 31, 33, -9, 23, 23,
 }
 
 var yyDef = [...]int8{
-	15, -2, 17, 0, 33, 0, 13, 10, 19, 0,
-	34, 28, 29, 30, 31, 32, 0, 0, 0, 0,
-	0, 0, 14, 16, 11, 0, 0, 0, 0, 0,
-	0, 18, 20, 0, 12, 37, 41, 46, 50, 50,
-	0, 0, 77, 42, 0, 1, 2, 3, 4, 5,
-	6, 7, 8, 9, 0, 0, 0, 21, 35, 38,
-	0, 0, 78, 0, 22, 0, 24, 26, 27, 44,
-	47, 0, 48, 51, 0, 49, 0, 39, 43, 40,
-	0, 0, 0, 0, 71, 0, 23, 25, 45, 52,
-	0, 0, 0, 59, 60, 71, 0, 0, -2, -2,
-	-2, 75, 72, 0, 36, 53, 54, 55, 0, 71,
-	0, 0, 0, 0, 0, 0, 57, 58, 70, 76,
-	0, 56, 61, 62, 0, 63, 0, 0, 0, 0,
-	0, 73, 74, 0, 0, 0, 0, 66, 67, 68,
-	69, 64, 65,
+	17, -2, 19, 0, 36, 0, 15, -2, 21, 0,
+	37, 0, 31, 32, 33, 34, 35, 0, 0, 0,
+	0, 0, 0, 16, 18, 13, 0, 38, 68, 68,
+	68, 68, 68, 20, 22, 0, 14, 0, 0, 0,
+	0, 0, 0, 0, 41, 109, 70, 0, 1, 2,
+	3, 4, 5, 6, 7, 8, 9, 10, 11, 53,
+	78, 83, 83, 23, 0, 0, 110, 0, 0, 0,
+	0, 0, 39, 42, 43, 0, 0, 8, 69, 71,
+	72, 62, 0, 64, 66, 67, 51, 0, 55, 56,
+	57, 0, 0, 0, 76, 79, 0, 0, 81, 84,
+	0, 0, 82, 44, 0, 0, 46, 48, 50, 63,
+	65, 52, 54, 58, 0, 24, 0, 0, 27, 29,
+	30, 0, 80, 68, 85, 68, 105, 68, 45, 0,
+	0, 68, 0, 0, 0, 73, 0, 0, 68, 68,
+	68, 93, 94, 105, 0, 0, -2, -2, -2, 109,
+	106, 0, 0, 47, 49, 60, 0, 25, 0, 28,
+	111, 74, 77, 86, 0, 0, 0, 68, 105, 0,
+	0, 0, 0, 0, 0, 5, 91, 92, 104, 110,
+	0, 40, 68, 0, 0, 112, 87, 88, 89, 0,
+	95, 96, 0, 97, 0, 0, 0, 0, 0, 107,
+	108, 61, 26, 59, 75, 90, 0, 0, 0, 0,
+	100, 101, 102, 103, 98, 99,
 }
 
 var yyTok1 = [...]int8{
@@ -245,20 +311,20 @@ var yyTok1 = [...]int8{
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	17, 18, 3, 3, 26, 28, 22, 3, 3, 3,
-	3, 3, 3, 3, 3, 3, 3, 3, 3, 25,
-	27, 19, 29, 3, 3, 3, 3, 3, 3, 3,
+	22, 23, 3, 3, 30, 31, 26, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 29,
+	32, 24, 33, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 20, 3, 21, 3, 3, 3, 3, 3, 3,
+	3, 21, 3, 25, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
 	3, 3, 3, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 23, 3, 24,
+	3, 3, 3, 28, 3, 27,
 }
 
 var yyTok2 = [...]int8{
 	2, 3, 4, 5, 6, 7, 8, 9, 10, 11,
-	12, 13, 14, 15, 16,
+	12, 13, 14, 15, 16, 17, 18, 19, 20,
 }
 
 var yyTok3 = [...]int8{
@@ -623,29 +689,39 @@ yydefault:
 	case 5:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.ident = "message"
+			yyVAL.ident = "map"
 		}
 	case 6:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.ident = "options"
+			yyVAL.ident = "message"
 		}
 	case 7:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.ident = "struct"
+			yyVAL.ident = "options"
 		}
 	case 8:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.ident = "service"
+			yyVAL.ident = "reserved"
 		}
 	case 9:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
-			yyVAL.ident = "subservice"
+			yyVAL.ident = "struct"
 		}
 	case 10:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.ident = "service"
+		}
+	case 11:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.ident = "subservice"
+		}
+	case 12:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			file := &syntax.File{
@@ -655,17 +731,18 @@ yydefault:
 			}
 			setLexerResult(yylex, file)
 		}
-	case 11:
+	case 13:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("import ", yyDollar[1].string)
 			}
 			yyVAL.import_ = &syntax.Import{
-				ID: trimString(yyDollar[1].string),
+				ID:  trimString(yyDollar[1].string),
+				Pos: yyDollar[1].pos,
 			}
 		}
-	case 12:
+	case 14:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -674,14 +751,15 @@ yydefault:
 			yyVAL.import_ = &syntax.Import{
 				Alias: yyDollar[1].ident,
 				ID:    trimString(yyDollar[2].string),
+				Pos:   yyDollar[1].pos,
 			}
 		}
-	case 13:
+	case 15:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.imports = nil
 		}
-	case 14:
+	case 16:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -689,12 +767,12 @@ yydefault:
 			}
 			yyVAL.imports = append(yyVAL.imports, yyDollar[2].import_)
 		}
-	case 15:
+	case 17:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.imports = nil
 		}
-	case 16:
+	case 18:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if debugParser {
@@ -702,12 +780,12 @@ yydefault:
 			}
 			yyVAL.imports = append(yyVAL.imports, yyDollar[3].imports...)
 		}
-	case 17:
+	case 19:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.options = nil
 		}
-	case 18:
+	case 20:
 		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if debugParser {
@@ -715,12 +793,12 @@ yydefault:
 			}
 			yyVAL.options = append(yyVAL.options, yyDollar[3].options...)
 		}
-	case 19:
+	case 21:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.options = nil
 		}
-	case 20:
+	case 22:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -728,7 +806,7 @@ yydefault:
 			}
 			yyVAL.options = append(yyVAL.options, yyDollar[2].option)
 		}
-	case 21:
+	case 23:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -737,9 +815,10 @@ yydefault:
 			yyVAL.option = &syntax.Option{
 				Name:  yyDollar[1].ident,
 				Value: trimString(yyDollar[3].string),
+				Pos:   yyDollar[1].pos,
 			}
 		}
-	case 22:
+	case 24:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -747,7 +826,7 @@ yydefault:
 			}
 			yyVAL.type_ = yyDollar[1].type_
 		}
-	case 23:
+	case 25:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -758,7 +837,19 @@ yydefault:
 				Element: yyDollar[3].type_,
 			}
 		}
-	case 24:
+	case 26:
+		yyDollar = yyS[yypt-5 : yypt+1]
+		{
+			if debugParser {
+				fmt.Printf("type map[%v]%v\n", yyDollar[3].type_, yyDollar[5].type_)
+			}
+			yyVAL.type_ = &syntax.Type{
+				Kind:    syntax.KindMap,
+				Key:     yyDollar[3].type_,
+				Element: yyDollar[5].type_,
+			}
+		}
+	case 27:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -769,7 +860,7 @@ yydefault:
 				Name: yyDollar[1].ident,
 			}
 		}
-	case 25:
+	case 28:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -781,7 +872,7 @@ yydefault:
 				Import: yyDollar[1].ident,
 			}
 		}
-	case 26:
+	case 29:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -792,7 +883,7 @@ yydefault:
 				Name: "any",
 			}
 		}
-	case 27:
+	case 30:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -803,12 +894,12 @@ yydefault:
 				Name: "message",
 			}
 		}
-	case 33:
+	case 36:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.definitions = nil
 		}
-	case 34:
+	case 37:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -816,78 +907,313 @@ yydefault:
 			}
 			yyVAL.definitions = append(yyVAL.definitions, yyDollar[2].definition)
 		}
-	case 35:
-		yyDollar = yyS[yypt-5 : yypt+1]
+	case 38:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			// Skip an invalid definition, continue parsing
+			yyVAL.definitions = yyDollar[1].definitions
+		}
+	case 39:
+		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			if debugParser {
-				fmt.Println("enum", yyDollar[2].ident, yyDollar[4].enum_values)
+				fmt.Println("enum", yyDollar[2].ident, yyDollar[5].enum)
 			}
 			yyVAL.definition = &syntax.Definition{
 				Type: syntax.DefinitionEnum,
 				Name: yyDollar[2].ident,
+				Doc:  yyDollar[1].doc,
+				Pos:  yyDollar[1].pos,
+				End:  yyDollar[6].pos,
 
-				Enum: &syntax.Enum{
-					Values: yyDollar[4].enum_values,
-				},
+				Annotations: yyDollar[3].annotations,
+
+				Enum: yyDollar[5].enum,
 			}
 		}
-	case 36:
-		yyDollar = yyS[yypt-4 : yypt+1]
+	case 40:
+		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("enum value", yyDollar[1].ident, yyDollar[3].integer)
 			}
 			yyVAL.enum_value = &syntax.EnumValue{
-				Name:  yyDollar[1].ident,
-				Value: yyDollar[3].integer,
+				Name:        yyDollar[1].ident,
+				Doc:         yyDollar[1].doc,
+				Pos:         yyDollar[1].pos,
+				Value:       yyDollar[3].integer,
+				Annotations: yyDollar[4].annotations,
 			}
 		}
-	case 37:
+	case 41:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
-			yyVAL.enum_values = nil
+			yyVAL.enum = &syntax.Enum{}
 		}
-	case 38:
+	case 42:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
-				fmt.Println("enum values", yyDollar[1].enum_values, yyDollar[2].enum_value)
+				fmt.Println("enum values", yyDollar[1].enum, yyDollar[2].enum_value)
 			}
-			yyVAL.enum_values = append(yyVAL.enum_values, yyDollar[2].enum_value)
+			yyDollar[1].enum.Values = append(yyDollar[1].enum.Values, yyDollar[2].enum_value)
+			yyVAL.enum = yyDollar[1].enum
 		}
-	case 39:
+	case 43:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyDollar[1].enum.Reserved.Add(yyDollar[2].reserved)
+			yyDollar[1].enum.ReservedList = append(yyDollar[1].enum.ReservedList, yyDollar[2].reserved)
+			yyVAL.enum = yyDollar[1].enum
+		}
+	case 44:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			// Skip an invalid enum value, continue parsing
+			yyVAL.enum = yyDollar[1].enum
+		}
+	case 45:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			if debugParser {
+				fmt.Println("reserved", yyDollar[2].reserved)
+			}
+			yyDollar[2].reserved.Pos = yyDollar[1].pos
+			yyVAL.reserved = yyDollar[2].reserved
+		}
+	case 46:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.reserved = yyDollar[1].reserved
+		}
+	case 47:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyDollar[1].reserved.Add(yyDollar[3].reserved)
+			yyVAL.reserved = yyDollar[1].reserved
+		}
+	case 48:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.reserved = &syntax.Reserved{
+				Ranges: []syntax.ReservedRange{{Start: yyDollar[1].integer, End: yyDollar[1].integer}},
+			}
+		}
+	case 49:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			if yyDollar[2].ident != "to" {
+				return yyLexErrorf(yylex, "invalid reserved range, expected %v to %v, got %v %v %v",
+					yyDollar[1].integer, yyDollar[3].integer, yyDollar[1].integer, yyDollar[2].ident, yyDollar[3].integer)
+			}
+			yyVAL.reserved = &syntax.Reserved{
+				Ranges: []syntax.ReservedRange{{Start: yyDollar[1].integer, End: yyDollar[3].integer}},
+			}
+		}
+	case 50:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.reserved = &syntax.Reserved{
+				Names: []string{trimString(yyDollar[1].string)},
+			}
+		}
+	case 51:
 		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			if debugParser {
-				fmt.Println("message", yyDollar[2].ident, yyDollar[4].fields)
+				fmt.Println("message", yyDollar[2].ident, yyDollar[5].message)
 			}
 			yyVAL.definition = &syntax.Definition{
 				Type: syntax.DefinitionMessage,
 				Name: yyDollar[2].ident,
+				Doc:  yyDollar[1].doc,
+				Pos:  yyDollar[1].pos,
+				End:  yyDollar[6].pos,
 
-				Message: &syntax.Message{
-					Fields: yyDollar[4].fields,
-				},
+				Annotations: yyDollar[3].annotations,
+
+				Message: yyDollar[5].message,
 			}
 		}
-	case 40:
+	case 52:
+		yyDollar = yyS[yypt-7 : yypt+1]
+		{
+			if debugParser {
+				fmt.Println("message", yyDollar[2].ident, yyDollar[5].message, yyDollar[6].field)
+			}
+			yyDollar[5].message.Fields = append(yyDollar[5].message.Fields, yyDollar[6].field)
+			yyVAL.definition = &syntax.Definition{
+				Type: syntax.DefinitionMessage,
+				Name: yyDollar[2].ident,
+				Doc:  yyDollar[1].doc,
+				Pos:  yyDollar[1].pos,
+				End:  yyDollar[7].pos,
+
+				Annotations: yyDollar[3].annotations,
+
+				Message: yyDollar[5].message,
+			}
+		}
+	case 53:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.message = &syntax.Message{}
+		}
+	case 54:
 		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			if debugParser {
+				fmt.Println("message items", yyDollar[1].message, yyDollar[2].field)
+			}
+			yyDollar[1].message.Fields = append(yyDollar[1].message.Fields, yyDollar[2].field)
+			yyVAL.message = yyDollar[1].message
+		}
+	case 55:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyDollar[1].message.Reserved.Add(yyDollar[2].reserved)
+			yyDollar[1].message.ReservedList = append(yyDollar[1].message.ReservedList, yyDollar[2].reserved)
+			yyVAL.message = yyDollar[1].message
+		}
+	case 56:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			if debugParser {
+				fmt.Println("message items", yyDollar[1].message, yyDollar[2].oneof)
+			}
+			yyDollar[1].message.Fields = append(yyDollar[1].message.Fields, yyDollar[2].oneof.Fields...)
+			yyDollar[1].message.Oneofs = append(yyDollar[1].message.Oneofs, yyDollar[2].oneof)
+			yyVAL.message = yyDollar[1].message
+		}
+	case 57:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.message = yyDollar[1].message
+		}
+	case 58:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			// Skip an invalid field, continue parsing
+			yyVAL.message = yyDollar[1].message
+		}
+	case 59:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			if debugParser {
+				fmt.Println("oneof", yyDollar[2].ident, yyDollar[4].fields)
+			}
+			for _, field := range yyDollar[4].fields {
+				field.Oneof = yyDollar[2].ident
+			}
+			yyVAL.oneof = &syntax.Oneof{
+				Name:   yyDollar[2].ident,
+				Doc:    yyDollar[1].doc,
+				Pos:    yyDollar[1].pos,
+				End:    yyDollar[6].pos,
+				Fields: yyDollar[4].fields,
+			}
+		}
+	case 60:
+		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("message field", yyDollar[1].ident, yyDollar[2].type_, yyDollar[3].integer)
 			}
 			yyVAL.field = &syntax.Field{
-				Name: yyDollar[1].ident,
-				Type: yyDollar[2].type_,
-				Tag:  yyDollar[3].integer,
+				Name:        yyDollar[1].ident,
+				Doc:         yyDollar[1].doc,
+				Pos:         yyDollar[1].pos,
+				Type:        yyDollar[2].type_,
+				Tag:         yyDollar[3].integer,
+				Annotations: yyDollar[4].annotations,
 			}
 		}
-	case 41:
+	case 61:
+		yyDollar = yyS[yypt-6 : yypt+1]
+		{
+			if debugParser {
+				fmt.Println("message field", yyDollar[1].ident, yyDollar[2].type_, yyDollar[3].integer, yyDollar[5].value)
+			}
+			yyVAL.field = &syntax.Field{
+				Name:        yyDollar[1].ident,
+				Doc:         yyDollar[1].doc,
+				Pos:         yyDollar[1].pos,
+				Type:        yyDollar[2].type_,
+				Tag:         yyDollar[3].integer,
+				Default:     yyDollar[5].value,
+				Annotations: yyDollar[6].annotations,
+			}
+		}
+	case 62:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.value = &syntax.Value{Kind: syntax.ValueInteger, Text: yyDollar[1].string}
+		}
+	case 63:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.value = &syntax.Value{Kind: syntax.ValueInteger, Text: "-" + yyDollar[2].string}
+		}
+	case 64:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.value = &syntax.Value{Kind: syntax.ValueFloat, Text: yyDollar[1].string}
+		}
+	case 65:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		{
+			yyVAL.value = &syntax.Value{Kind: syntax.ValueFloat, Text: "-" + yyDollar[2].string}
+		}
+	case 66:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.value = &syntax.Value{Kind: syntax.ValueString, Text: yyDollar[1].string}
+		}
+	case 67:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.value = &syntax.Value{Kind: syntax.ValueIdent, Text: yyDollar[1].ident}
+		}
+	case 68:
+		yyDollar = yyS[yypt-0 : yypt+1]
+		{
+			yyVAL.annotations = nil
+		}
+	case 69:
+		yyDollar = yyS[yypt-4 : yypt+1]
+		{
+			if debugParser {
+				fmt.Println("annotations", yyDollar[2].annotations)
+			}
+			yyVAL.annotations = yyDollar[2].annotations
+		}
+	case 70:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		{
+			yyVAL.annotations = []*syntax.Annotation{yyDollar[1].annotation}
+		}
+	case 71:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			yyVAL.annotations = append(yyDollar[1].annotations, yyDollar[3].annotation)
+		}
+	case 72:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			if debugParser {
+				fmt.Println("annotation", yyDollar[1].ident, yyDollar[3].value)
+			}
+			yyVAL.annotation = &syntax.Annotation{
+				Name:  yyDollar[1].ident,
+				Value: yyDollar[3].value,
+			}
+		}
+	case 73:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.fields = nil
 		}
-	case 42:
+	case 74:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -895,7 +1221,7 @@ yydefault:
 			}
 			yyVAL.fields = []*syntax.Field{yyDollar[1].field}
 		}
-	case 43:
+	case 75:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -903,38 +1229,46 @@ yydefault:
 			}
 			yyVAL.fields = append(yyVAL.fields, yyDollar[3].field)
 		}
-	case 44:
-		yyDollar = yyS[yypt-5 : yypt+1]
+	case 76:
+		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			if debugParser {
-				fmt.Println("struct", yyDollar[2].ident, yyDollar[4].struct_fields)
+				fmt.Println("struct", yyDollar[2].ident, yyDollar[5].struct_fields)
 			}
 			yyVAL.definition = &syntax.Definition{
 				Type: syntax.DefinitionStruct,
 				Name: yyDollar[2].ident,
+				Doc:  yyDollar[1].doc,
+				Pos:  yyDollar[1].pos,
+				End:  yyDollar[6].pos,
+
+				Annotations: yyDollar[3].annotations,
 
 				Struct: &syntax.Struct{
-					Fields: yyDollar[4].struct_fields,
+					Fields: yyDollar[5].struct_fields,
 				},
 			}
 		}
-	case 45:
-		yyDollar = yyS[yypt-3 : yypt+1]
+	case 77:
+		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("struct field", yyDollar[1].ident, yyDollar[2].type_)
 			}
 			yyVAL.struct_field = &syntax.StructField{
-				Name: yyDollar[1].ident,
-				Type: yyDollar[2].type_,
+				Name:        yyDollar[1].ident,
+				Doc:         yyDollar[1].doc,
+				Pos:         yyDollar[1].pos,
+				Type:        yyDollar[2].type_,
+				Annotations: yyDollar[3].annotations,
 			}
 		}
-	case 46:
+	case 78:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.struct_fields = nil
 		}
-	case 47:
+	case 79:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -942,108 +1276,145 @@ yydefault:
 			}
 			yyVAL.struct_fields = append(yyVAL.struct_fields, yyDollar[2].struct_field)
 		}
-	case 48:
-		yyDollar = yyS[yypt-5 : yypt+1]
+	case 80:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			// Skip an invalid struct field, continue parsing
+			yyVAL.struct_fields = yyDollar[1].struct_fields
+		}
+	case 81:
+		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			if debugParser {
-				fmt.Println("service", yyDollar[2].ident, yyDollar[4].methods)
+				fmt.Println("service", yyDollar[2].ident, yyDollar[5].methods)
 			}
 			yyVAL.definition = &syntax.Definition{
 				Type: syntax.DefinitionService,
 				Name: yyDollar[2].ident,
+				Doc:  yyDollar[1].doc,
+				Pos:  yyDollar[1].pos,
+				End:  yyDollar[6].pos,
+
+				Annotations: yyDollar[3].annotations,
 
 				Service: &syntax.Service{
-					Methods: yyDollar[4].methods,
+					Methods: yyDollar[5].methods,
 				},
 			}
 		}
-	case 49:
-		yyDollar = yyS[yypt-5 : yypt+1]
+	case 82:
+		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			if debugParser {
-				fmt.Println("subservice", yyDollar[2].ident, yyDollar[4].methods)
+				fmt.Println("subservice", yyDollar[2].ident, yyDollar[5].methods)
 			}
 			yyVAL.definition = &syntax.Definition{
 				Type: syntax.DefinitionService,
 				Name: yyDollar[2].ident,
+				Doc:  yyDollar[1].doc,
+				Pos:  yyDollar[1].pos,
+				End:  yyDollar[6].pos,
+
+				Annotations: yyDollar[3].annotations,
 
 				Service: &syntax.Service{
 					Sub:     true,
-					Methods: yyDollar[4].methods,
+					Methods: yyDollar[5].methods,
 				},
 			}
 		}
-	case 50:
+	case 83:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.methods = nil
 		}
-	case 51:
+	case 84:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			yyVAL.methods = append(yyDollar[1].methods, yyDollar[2].method)
 		}
-	case 52:
+	case 85:
 		yyDollar = yyS[yypt-3 : yypt+1]
+		{
+			// Skip an invalid method, continue parsing
+			yyVAL.methods = yyDollar[1].methods
+		}
+	case 86:
+		yyDollar = yyS[yypt-4 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("method", yyDollar[1].ident, yyDollar[2].method_input)
 			}
 			yyVAL.method = &syntax.Method{
-				Name:  yyDollar[1].ident,
-				Input: yyDollar[2].method_input,
+				Name:        yyDollar[1].ident,
+				Doc:         yyDollar[1].doc,
+				Pos:         yyDollar[1].pos,
+				Input:       yyDollar[2].method_input,
+				Annotations: yyDollar[3].annotations,
 			}
 		}
-	case 53:
-		yyDollar = yyS[yypt-4 : yypt+1]
+	case 87:
+		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("method", yyDollar[1].ident, yyDollar[2].method_input, yyDollar[3].bool)
 			}
 			yyVAL.method = &syntax.Method{
-				Name:   yyDollar[1].ident,
-				Input:  yyDollar[2].method_input,
-				Oneway: true,
+				Name:        yyDollar[1].ident,
+				Doc:         yyDollar[1].doc,
+				Pos:         yyDollar[1].pos,
+				Input:       yyDollar[2].method_input,
+				Oneway:      true,
+				Annotations: yyDollar[4].annotations,
 			}
 		}
-	case 54:
-		yyDollar = yyS[yypt-4 : yypt+1]
+	case 88:
+		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("method", yyDollar[1].ident, yyDollar[2].method_input, yyDollar[3].method_output)
 			}
 			yyVAL.method = &syntax.Method{
-				Name:   yyDollar[1].ident,
-				Input:  yyDollar[2].method_input,
-				Output: yyDollar[3].method_output,
+				Name:        yyDollar[1].ident,
+				Doc:         yyDollar[1].doc,
+				Pos:         yyDollar[1].pos,
+				Input:       yyDollar[2].method_input,
+				Output:      yyDollar[3].method_output,
+				Annotations: yyDollar[4].annotations,
 			}
 		}
-	case 55:
-		yyDollar = yyS[yypt-4 : yypt+1]
+	case 89:
+		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("method", yyDollar[1].ident, yyDollar[2].method_input, yyDollar[3].method_channel)
 			}
 			yyVAL.method = &syntax.Method{
-				Name:    yyDollar[1].ident,
-				Input:   yyDollar[2].method_input,
-				Channel: yyDollar[3].method_channel,
+				Name:        yyDollar[1].ident,
+				Doc:         yyDollar[1].doc,
+				Pos:         yyDollar[1].pos,
+				Input:       yyDollar[2].method_input,
+				Channel:     yyDollar[3].method_channel,
+				Annotations: yyDollar[4].annotations,
 			}
 		}
-	case 56:
-		yyDollar = yyS[yypt-5 : yypt+1]
+	case 90:
+		yyDollar = yyS[yypt-6 : yypt+1]
 		{
 			if debugParser {
 				fmt.Println("method", yyDollar[1].ident, yyDollar[2].method_input, yyDollar[3].method_channel, yyDollar[4].method_output)
 			}
 			yyVAL.method = &syntax.Method{
-				Name:    yyDollar[1].ident,
-				Input:   yyDollar[2].method_input,
-				Channel: yyDollar[3].method_channel,
-				Output:  yyDollar[4].method_output,
+				Name:        yyDollar[1].ident,
+				Doc:         yyDollar[1].doc,
+				Pos:         yyDollar[1].pos,
+				Input:       yyDollar[2].method_input,
+				Channel:     yyDollar[3].method_channel,
+				Output:      yyDollar[4].method_output,
+				Annotations: yyDollar[5].annotations,
 			}
 		}
-	case 57:
+	case 91:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1051,7 +1422,7 @@ yydefault:
 			}
 			yyVAL.method_input = yyDollar[2].type_
 		}
-	case 58:
+	case 92:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1059,7 +1430,7 @@ yydefault:
 			}
 			yyVAL.method_input = yyDollar[2].fields
 		}
-	case 59:
+	case 93:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -1067,7 +1438,7 @@ yydefault:
 			}
 			yyVAL.bool = true
 		}
-	case 60:
+	case 94:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -1075,7 +1446,7 @@ yydefault:
 			}
 			yyVAL.method_output = yyDollar[1].type_
 		}
-	case 61:
+	case 95:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1083,7 +1454,7 @@ yydefault:
 			}
 			yyVAL.method_output = yyDollar[2].fields
 		}
-	case 62:
+	case 96:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1094,7 +1465,7 @@ yydefault:
 				In: yyDollar[2].type_,
 			}
 		}
-	case 63:
+	case 97:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1105,7 +1476,7 @@ yydefault:
 				Out: yyDollar[2].type_,
 			}
 		}
-	case 64:
+	case 98:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			if debugParser {
@@ -1117,38 +1488,38 @@ yydefault:
 				Out: yyDollar[4].type_,
 			}
 		}
-	case 65:
+	case 99:
 		yyDollar = yyS[yypt-5 : yypt+1]
 		{
 			return yyLexErrorf(yylex,
 				"invalid channel syntax, expected (<-%v, %v->), got (%v->, <-%v)",
 				yyDollar[4].type_, yyDollar[2].type_, yyDollar[2].type_, yyDollar[4].type_)
 		}
-	case 66:
+	case 100:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.type_ = yyDollar[3].type_
 		}
-	case 67:
+	case 101:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			return yyLexErrorf(yylex,
 				"invalid channel in syntax, expected <-%v, got %v<-",
 				yyDollar[1].type_, yyDollar[1].type_)
 		}
-	case 68:
+	case 102:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			yyVAL.type_ = yyDollar[1].type_
 		}
-	case 69:
+	case 103:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			return yyLexErrorf(yylex,
 				"invalid channel out syntax, expected %v->, got ->%v",
 				yyDollar[3].type_, yyDollar[3].type_)
 		}
-	case 70:
+	case 104:
 		yyDollar = yyS[yypt-2 : yypt+1]
 		{
 			if debugParser {
@@ -1156,12 +1527,12 @@ yydefault:
 			}
 			yyVAL.fields = yyDollar[1].fields
 		}
-	case 71:
+	case 105:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 			yyVAL.fields = nil
 		}
-	case 72:
+	case 106:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 			if debugParser {
@@ -1169,7 +1540,7 @@ yydefault:
 			}
 			yyVAL.fields = []*syntax.Field{yyDollar[1].field}
 		}
-	case 73:
+	case 107:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1177,7 +1548,7 @@ yydefault:
 			}
 			yyVAL.fields = append(yyDollar[1].fields, yyDollar[3].field)
 		}
-	case 74:
+	case 108:
 		yyDollar = yyS[yypt-3 : yypt+1]
 		{
 			if debugParser {
@@ -1185,23 +1556,25 @@ yydefault:
 			}
 			yyVAL.field = &syntax.Field{
 				Name: yyDollar[1].ident,
+				Doc:  yyDollar[1].doc,
+				Pos:  yyDollar[1].pos,
 				Type: yyDollar[2].type_,
 				Tag:  yyDollar[3].integer,
 			}
 		}
-	case 75:
+	case 109:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 		}
-	case 76:
+	case 110:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 		}
-	case 77:
+	case 111:
 		yyDollar = yyS[yypt-0 : yypt+1]
 		{
 		}
-	case 78:
+	case 112:
 		yyDollar = yyS[yypt-1 : yypt+1]
 		{
 		}
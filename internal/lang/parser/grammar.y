@@ -16,6 +16,8 @@ import (
 	bool	bool
 	integer int
 	string  string
+	doc     string          // leading doc comment of a token
+	pos     syntax.Position // token position
 
     // Type
 	type_ *syntax.Type
@@ -33,12 +35,24 @@ import (
 	definitions []*syntax.Definition
 
 	// Enum
+	enum        *syntax.Enum
 	enum_value  *syntax.EnumValue
-	enum_values []*syntax.EnumValue
+
+	// Reserved
+	reserved *syntax.Reserved
+
+	// Message
+	message *syntax.Message
+	oneof   *syntax.Oneof
 
 	// Field
 	field  *syntax.Field
 	fields syntax.Fields
+	value  *syntax.Value
+
+	// Annotation
+	annotation  *syntax.Annotation
+	annotations []*syntax.Annotation
 
 	// Struct
 	struct_field  *syntax.StructField
@@ -59,9 +73,12 @@ import (
 %token ANY
 %token ENUM
 %token IMPORT
+%token MAP
 %token MESSAGE
+%token ONEOF
 %token ONEWAY
 %token OPTIONS
+%token RESERVED
 %token STRUCT
 %token SERVICE
 %token SUBSERVICE
@@ -69,6 +86,7 @@ import (
 // general
 %token <ident>      IDENT
 %token <integer>    INTEGER
+%token <string>     FLOAT
 %token <string>     STRING
 %token <ident>      MESSAGE
 %token <ident>      ONEWAY
@@ -97,14 +115,27 @@ import (
 // enum
 %type <definition>  enum
 %type <enum_value>  enum_value
-%type <enum_values> enum_values
+%type <enum>        enum_items
+
+// reserved
+%type <reserved> reserved
+%type <reserved> reserved_items
+%type <reserved> reserved_item
 
 // message
 %type <definition>	message
+%type <message>		message_items
+%type <oneof>		oneof
 
 // field
 %type <field>	field
 %type <fields> 	fields
+%type <value>	value
+
+// annotation
+%type <annotation>	annotation
+%type <annotations>	annotation_list
+%type <annotations>	annotations_opt
 
 // struct
 %type <definition>      struct
@@ -128,6 +159,10 @@ import (
 
 %left METHOD_OUTPUT
 
+// map followed by '[' starts a map type, otherwise it is a field name.
+%nonassoc MAP
+%nonassoc '['
+
 // start
 %start file
 
@@ -154,6 +189,10 @@ keyword:
     {
         $$ = "import"
     }
+	| MAP
+	{
+		$$ = "map"
+	}
 	| MESSAGE
     {
         $$ = "message"
@@ -162,6 +201,10 @@ keyword:
     {
         $$ = "options"
     }
+	| RESERVED
+	{
+		$$ = "reserved"
+	}
 	| STRUCT
     {
         $$ = "struct"
@@ -196,7 +239,8 @@ import:
 			fmt.Println("import ", $1)
 		}
 		$$ = &syntax.Import{
-			ID: trimString($1),
+			ID:  trimString($1),
+			Pos: $<pos>1,
 		}
 	}
 	| IDENT STRING
@@ -207,6 +251,7 @@ import:
 		$$ = &syntax.Import{
 			Alias: $1,
 			ID:    trimString($2),
+			Pos:   $<pos>1,
 		}
 	};
 
@@ -273,6 +318,7 @@ option:
 		$$ = &syntax.Option{
 			Name:  $1,
 			Value: trimString($3),
+			Pos:   $<pos>1,
 		}
 	};
 
@@ -295,6 +341,17 @@ type:
 			Kind:    syntax.KindList,
 			Element: $3,
 		}
+	}
+	| MAP '[' base_type ']' base_type
+	{
+		if debugParser {
+			fmt.Printf("type map[%v]%v\n", $3, $5)
+		}
+		$$ = &syntax.Type{
+			Kind:    syntax.KindMap,
+			Key:     $3,
+			Element: $5,
+		}
 	};
 
 base_type:
@@ -361,77 +418,306 @@ definitions:
 			fmt.Println("definitions", $1, $2)
 		}
 		$$ = append($$, $2)
+	}
+	| definitions error '}'
+	{
+		// Skip an invalid definition, continue parsing
+		$$ = $1
 	};
 
 
 // enum
 
-enum: ENUM IDENT '{' enum_values '}'
+enum: ENUM IDENT annotations_opt '{' enum_items '}'
 	{
 		if debugParser {
-			fmt.Println("enum", $2, $4)
+			fmt.Println("enum", $2, $5)
 		}
 		$$ = &syntax.Definition{
 			Type: syntax.DefinitionEnum,
 			Name: $2,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
+			End: $<pos>6,
 
-			Enum: &syntax.Enum{
-				Values: $4,
-			},
+			Annotations: $3,
+
+			Enum: $5,
 		}
 	};
 
-enum_value: field_name '=' INTEGER ';'
+enum_value: field_name '=' INTEGER annotations_opt ';'
 	{
 		if debugParser {
 			fmt.Println("enum value", $1, $3)
 		}
 		$$ = &syntax.EnumValue{
 			Name: $1,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
 			Value: $3,
+			Annotations: $4,
 		}
 	};
 
-enum_values:
+// enum_items are enum values and reserved numbers and names.
+enum_items:
 	// Empty
 	{
-		$$ = nil
+		$$ = &syntax.Enum{}
 	}
-	| enum_values enum_value
+	| enum_items enum_value
 	{
 		if debugParser {
 			fmt.Println("enum values", $1, $2)
 		}
-		$$ = append($$, $2)
+		$1.Values = append($1.Values, $2)
+		$$ = $1
+	}
+	| enum_items reserved
+	{
+		$1.Reserved.Add($2)
+		$1.ReservedList = append($1.ReservedList, $2)
+		$$ = $1
+	}
+	| enum_items error ';'
+	{
+		// Skip an invalid enum value, continue parsing
+		$$ = $1
+	};
+
+// reserved
+
+reserved: RESERVED reserved_items ';'
+	{
+		if debugParser {
+			fmt.Println("reserved", $2)
+		}
+		$2.Pos = $<pos>1
+		$$ = $2
+	};
+
+reserved_items:
+	reserved_item
+	{
+		$$ = $1
+	}
+	| reserved_items ',' reserved_item
+	{
+		$1.Add($3)
+		$$ = $1
+	};
+
+reserved_item:
+	INTEGER
+	{
+		$$ = &syntax.Reserved{
+			Ranges: []syntax.ReservedRange{{Start: $1, End: $1}},
+		}
+	}
+	| INTEGER IDENT INTEGER
+	{
+		if $2 != "to" {
+			return yyLexErrorf(yylex, "invalid reserved range, expected %v to %v, got %v %v %v",
+				$1, $3, $1, $2, $3)
+		}
+		$$ = &syntax.Reserved{
+			Ranges: []syntax.ReservedRange{{Start: $1, End: $3}},
+		}
+	}
+	| STRING
+	{
+		$$ = &syntax.Reserved{
+			Names: []string{trimString($1)},
+		}
 	};
 
 
 // message
 
-message: MESSAGE IDENT '{' fields semi_opt '}' 
+message: MESSAGE IDENT annotations_opt '{' message_items '}' 
 	{ 
 		if debugParser {
-			fmt.Println("message", $2, $4)
+			fmt.Println("message", $2, $5)
 		}
 		$$ = &syntax.Definition{
 			Type: syntax.DefinitionMessage,
 			Name: $2,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
+			End: $<pos>6,
 
-			Message: &syntax.Message{
-				Fields: $4,
-			},
+			Annotations: $3,
+
+			Message: $5,
+		}
+	}
+	| MESSAGE IDENT annotations_opt '{' message_items field '}' 
+	{ 
+		if debugParser {
+			fmt.Println("message", $2, $5, $6)
+		}
+		$5.Fields = append($5.Fields, $6)
+		$$ = &syntax.Definition{
+			Type: syntax.DefinitionMessage,
+			Name: $2,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
+			End: $<pos>7,
+
+			Annotations: $3,
+
+			Message: $5,
 		}
 	};
 
-field: field_name type INTEGER
+// message_items are message fields, oneofs and reserved tags and names,
+// the last field may omit a semicolon.
+message_items:
+	// Empty
+	{
+		$$ = &syntax.Message{}
+	}
+	| message_items field ';'
+	{
+		if debugParser {
+			fmt.Println("message items", $1, $2)
+		}
+		$1.Fields = append($1.Fields, $2)
+		$$ = $1
+	}
+	| message_items reserved
+	{
+		$1.Reserved.Add($2)
+		$1.ReservedList = append($1.ReservedList, $2)
+		$$ = $1
+	}
+	| message_items oneof
+	{
+		if debugParser {
+			fmt.Println("message items", $1, $2)
+		}
+		$1.Fields = append($1.Fields, $2.Fields...)
+		$1.Oneofs = append($1.Oneofs, $2)
+		$$ = $1
+	}
+	| message_items ';'
+	{
+		$$ = $1
+	}
+	| message_items error ';'
+	{
+		// Skip an invalid field, continue parsing
+		$$ = $1
+	};
+
+oneof: ONEOF field_name '{' fields semi_opt '}'
+	{
+		if debugParser {
+			fmt.Println("oneof", $2, $4)
+		}
+		for _, field := range $4 {
+			field.Oneof = $2
+		}
+		$$ = &syntax.Oneof{
+			Name:   $2,
+			Doc:    $<doc>1,
+			Pos:    $<pos>1,
+			End:    $<pos>6,
+			Fields: $4,
+		}
+	};
+
+field: field_name type INTEGER annotations_opt
 	{
 		if debugParser {
 			fmt.Println("message field", $1, $2, $3)
 		}
 		$$ = &syntax.Field{
 			Name: $1,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
+			Type: $2,
+			Tag: $3,
+			Annotations: $4,
+		}
+	}
+	| field_name type INTEGER '=' value annotations_opt
+	{
+		if debugParser {
+			fmt.Println("message field", $1, $2, $3, $5)
+		}
+		$$ = &syntax.Field{
+			Name: $1,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
 			Type: $2,
 			Tag: $3,
+			Default: $5,
+			Annotations: $6,
+		}
+	};
+
+// value is a literal value.
+value:
+	INTEGER
+	{
+		$$ = &syntax.Value{Kind: syntax.ValueInteger, Text: $<string>1}
+	}
+	| '-' INTEGER
+	{
+		$$ = &syntax.Value{Kind: syntax.ValueInteger, Text: "-" + $<string>2}
+	}
+	| FLOAT
+	{
+		$$ = &syntax.Value{Kind: syntax.ValueFloat, Text: $1}
+	}
+	| '-' FLOAT
+	{
+		$$ = &syntax.Value{Kind: syntax.ValueFloat, Text: "-" + $2}
+	}
+	| STRING
+	{
+		$$ = &syntax.Value{Kind: syntax.ValueString, Text: $1}
+	}
+	| field_name
+	{
+		$$ = &syntax.Value{Kind: syntax.ValueIdent, Text: $1}
+	};
+
+// annotations
+
+annotations_opt:
+	// Empty
+	{
+		$$ = nil
+	}
+	| '[' annotation_list comma_opt ']'
+	{
+		if debugParser {
+			fmt.Println("annotations", $2)
+		}
+		$$ = $2
+	};
+
+annotation_list:
+	annotation
+	{
+		$$ = []*syntax.Annotation{$1}
+	}
+	| annotation_list ',' annotation
+	{
+		$$ = append($1, $3)
+	};
+
+annotation: field_name '=' value
+	{
+		if debugParser {
+			fmt.Println("annotation", $1, $3)
+		}
+		$$ = &syntax.Annotation{
+			Name: $1,
+			Value: $3,
 		}
 	};
 
@@ -458,29 +744,37 @@ fields:
 
 // struct
 
-struct: STRUCT IDENT '{' struct_fields '}' 
+struct: STRUCT IDENT annotations_opt '{' struct_fields '}' 
 	{ 
 		if debugParser {
-			fmt.Println("struct", $2, $4)
+			fmt.Println("struct", $2, $5)
 		}
 		$$ = &syntax.Definition{
 			Type: syntax.DefinitionStruct,
 			Name: $2,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
+			End: $<pos>6,
+
+			Annotations: $3,
 
 			Struct: &syntax.Struct{
-				Fields: $4,
+				Fields: $5,
 			},
 		}
 	};
 
-struct_field: field_name type ';'
+struct_field: field_name type annotations_opt ';'
 	{
 		if debugParser {
 			fmt.Println("struct field", $1, $2)
 		}
 		$$ = &syntax.StructField{
 			Name: $1,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
 			Type: $2,
+			Annotations: $3,
 		}
 	};
 
@@ -495,38 +789,53 @@ struct_fields:
 			fmt.Println("struct fields", $1, $2)
 		}
 		$$ = append($$, $2)
+	}
+	| struct_fields error ';'
+	{
+		// Skip an invalid struct field, continue parsing
+		$$ = $1
 	};
 
 // service
 
-service: SERVICE IDENT '{' methods '}'
+service: SERVICE IDENT annotations_opt '{' methods '}'
 	{
 		if debugParser {
-			fmt.Println("service", $2, $4)
+			fmt.Println("service", $2, $5)
 		}
 		$$ = &syntax.Definition{
 			Type: syntax.DefinitionService,
 			Name: $2,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
+			End: $<pos>6,
+
+			Annotations: $3,
 
 			Service: &syntax.Service{
-				Methods: $4,
+				Methods: $5,
 			},
 		}
 	}
 	;
 
-subservice: SUBSERVICE IDENT '{' methods '}'
+subservice: SUBSERVICE IDENT annotations_opt '{' methods '}'
 	{
 		if debugParser {
-			fmt.Println("subservice", $2, $4)
+			fmt.Println("subservice", $2, $5)
 		}
 		$$ = &syntax.Definition{
 			Type: syntax.DefinitionService,
 			Name: $2,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
+			End: $<pos>6,
+
+			Annotations: $3,
 
 			Service: &syntax.Service{
 				Sub: true,
-				Methods: $4,
+				Methods: $5,
 			},
 		}
 	}
@@ -543,62 +852,82 @@ methods:
 	| methods method
 	{
 		$$ = append($1, $2)
+	}
+	| methods error ';'
+	{
+		// Skip an invalid method, continue parsing
+		$$ = $1
 	};
 
 method:
-	field_name method_input ';'
+	field_name method_input annotations_opt ';'
 	{
 		if debugParser {
 			fmt.Println("method", $1, $2)
 		}
 		$$ = &syntax.Method{
 			Name: $1,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
 			Input: $2,
+			Annotations: $3,
 		}
 	}
-	| field_name method_input method_oneway ';'
+	| field_name method_input method_oneway annotations_opt ';'
 	{
 		if debugParser {
 			fmt.Println("method", $1, $2, $3)
 		}
 		$$ = &syntax.Method{
 			Name: $1,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
 			Input: $2,
 			Oneway: true,
+			Annotations: $4,
 		}
 	}
-	| field_name method_input method_output ';'
+	| field_name method_input method_output annotations_opt ';'
 	{
 		if debugParser {
 			fmt.Println("method", $1, $2, $3)
 		}
 		$$ = &syntax.Method{
 			Name: $1,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
 			Input: $2,
 			Output: $3,
+			Annotations: $4,
 		}
 	}
-	| field_name method_input method_channel ';'
+	| field_name method_input method_channel annotations_opt ';'
 	{
 		if debugParser {
 			fmt.Println("method", $1, $2, $3)
 		}
 		$$ = &syntax.Method{
 			Name: $1,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
 			Input: $2,
 			Channel: $3,
+			Annotations: $4,
 		}
 	}
-	| field_name method_input method_channel method_output ';'
+	| field_name method_input method_channel method_output annotations_opt ';'
 	{
 		if debugParser {
 			fmt.Println("method", $1, $2, $3, $4)
 		}
 		$$ = &syntax.Method{
 			Name: $1,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
 			Input: $2,
 			Channel: $3,
 			Output: $4,
+			Annotations: $5,
 		}
 	};
 
@@ -745,6 +1074,8 @@ method_field:
 		}
 		$$ = &syntax.Field{
 			Name: $1,
+			Doc: $<doc>1,
+			Pos: $<pos>1,
 			Type: $2,
 			Tag: $3,
 		}
@@ -8,9 +8,12 @@ var keywords = map[string]int{
 	"any":        ANY,
 	"enum":       ENUM,
 	"import":     IMPORT,
+	"map":        MAP,
 	"message":    MESSAGE,
+	"oneof":      ONEOF,
 	"oneway":     ONEWAY,
 	"options":    OPTIONS,
+	"reserved":   RESERVED,
 	"struct":     STRUCT,
 	"service":    SERVICE,
 	"subservice": SUBSERVICE,
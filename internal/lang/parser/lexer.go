@@ -25,13 +25,19 @@ type lexer struct {
 	s *scanner.Scanner
 
 	file *syntax.File // used by yyParser to return result
-	err  error        // parse error
+	errs []error      // parse errors, parser recovers from syntax errors
+
+	line     int               // last token line
+	doc      []string          // pending doc comment lines
+	docLine  int               // pending doc comment last line
+	comments []*syntax.Comment // all comments
 }
 
 func newLexer(filename string, src io.Reader) *lexer {
 	s := &scanner.Scanner{}
 	s.Init(src)
 	s.Filename = filename
+	s.Mode &^= scanner.SkipComments
 	return &lexer{s: s}
 }
 
@@ -52,6 +58,21 @@ func (l *lexer) Lex(lval *yySymType) int {
 			return EOF
 		}
 
+		// Collect comments, attach doc comments to next tokens
+		if token == scanner.Comment {
+			if debugLexer {
+				fmt.Printf("COMMENT %v %v %v\n", l.s.Position, token, text)
+			}
+
+			l.comment(text)
+			continue
+		}
+		lval.doc = l.takeDoc()
+		lval.pos = syntax.Position{
+			Line:   l.s.Position.Line,
+			Column: l.s.Position.Column,
+		}
+
 		switch token {
 		case scanner.Ident:
 			keyword, ok := keywords[text]
@@ -78,6 +99,7 @@ func (l *lexer) Lex(lval *yySymType) int {
 			v, _ := strconv.ParseInt(text, 10, 64)
 			lval.yys = INTEGER
 			lval.integer = int(v)
+			lval.string = text
 
 			if debugLexer {
 				fmt.Printf("INTEGER %v %v %v\n", l.s.Position, token, text)
@@ -85,7 +107,7 @@ func (l *lexer) Lex(lval *yySymType) int {
 			return lval.yys
 
 		case scanner.Float:
-			lval.yys = int(token)
+			lval.yys = FLOAT
 			lval.string = text
 
 			if debugLexer {
@@ -102,12 +124,6 @@ func (l *lexer) Lex(lval *yySymType) int {
 			}
 			return lval.yys
 
-		case scanner.Comment:
-			if debugLexer {
-				fmt.Printf("COMMENT %v %v %v\n", l.s.Position, token, text)
-			}
-			continue
-
 		default:
 			lval.yys = int(token)
 			lval.string = text
@@ -120,13 +136,56 @@ func (l *lexer) Lex(lval *yySymType) int {
 	}
 }
 
+// comment records a comment and adds it to the pending doc comment,
+// skips trailing comments and resets the doc comment on blank lines.
+func (l *lexer) comment(text string) {
+	line := l.s.Position.Line
+	trailing := line == l.line
+
+	l.comments = append(l.comments, &syntax.Comment{
+		Text: text,
+		Pos: syntax.Position{
+			Line:   line,
+			Column: l.s.Position.Column,
+		},
+		Trailing: trailing,
+	})
+	if trailing {
+		return
+	}
+	if len(l.doc) > 0 && line != l.docLine+1 {
+		l.doc = l.doc[:0]
+	}
+
+	lines := commentLines(text)
+	l.doc = append(l.doc, lines...)
+	l.docLine = line + strings.Count(text, "\n")
+}
+
+// takeDoc returns a doc comment directly preceding the current token and clears it.
+func (l *lexer) takeDoc() string {
+	line := l.s.Position.Line
+	l.line = line
+
+	if len(l.doc) == 0 {
+		return ""
+	}
+
+	var doc string
+	if l.docLine == line-1 {
+		doc = strings.Join(l.doc, "\n")
+	}
+	l.doc = l.doc[:0]
+	return doc
+}
+
 func (l *lexer) Error(s string) {
-	l.err = fmt.Errorf("%v %v", l.s.Position, s)
+	l.errs = append(l.errs, l.error(s))
 }
 
 func yyLexError(l yyLexer, err error) int {
 	ll := l.(*lexer)
-	ll.err = fmt.Errorf("%v %w", ll.s.Position, err)
+	ll.errs = append(ll.errs, ll.error(err.Error()))
 	return ERROR
 }
 
@@ -135,8 +194,37 @@ func yyLexErrorf(l yyLexer, format string, a ...any) int {
 	return yyLexError(l, err)
 }
 
+// error returns a syntax error at the current position.
+func (l *lexer) error(msg string) *Error {
+	return &Error{
+		Path: l.s.Position.Filename,
+		Pos: syntax.Position{
+			Line:   l.s.Position.Line,
+			Column: l.s.Position.Column,
+		},
+		Msg: msg,
+	}
+}
+
 // util
 
 func trimString(s string) string {
 	return strings.Trim(s, "\"")
 }
+
+// commentLines returns comment text lines without comment markers.
+func commentLines(text string) []string {
+	if strings.HasPrefix(text, "//") {
+		s := strings.TrimPrefix(text, "//")
+		s = strings.TrimPrefix(s, " ")
+		return []string{strings.TrimRight(s, " \t\r")}
+	}
+
+	s := strings.TrimPrefix(text, "/*")
+	s = strings.TrimSuffix(s, "*/")
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return lines
+}
@@ -8,6 +8,7 @@ package parser
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -68,17 +69,22 @@ func (p *parser) ParseDirectory(path string) ([]*syntax.File, error) {
 		return nil, err
 	}
 
-	// Parse files
+	// Parse files, report errors from all files
+	var errs []error
 	files := make([]*syntax.File, 0, len(filepaths))
 	for _, filepath := range filepaths {
 		file, err := p.ParseFile(filepath)
 		if err != nil {
-			return nil, err
+			errs = append(errs, err)
+			continue
 		}
 
 		files = append(files, file)
 	}
 
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
 	return files, nil
 }
 
@@ -89,11 +95,12 @@ func (p *parser) parse(filename string, src io.Reader) (*syntax.File, error) {
 	parser := yyNewParser()
 	parser.Parse(lexer)
 
-	if err := lexer.err; err != nil {
-		return nil, err
+	if len(lexer.errs) > 0 {
+		return nil, errors.Join(lexer.errs...)
 	}
 
 	file := lexer.file
 	file.Path = filename
+	file.Comments = lexer.comments
 	return file, nil
 }
@@ -6,6 +6,8 @@ package parser
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/basecomplextech/spec/internal/lang/syntax"
@@ -156,6 +158,26 @@ enum TestEnum {
 	assert.Equal(t, 1, value1.Value)
 }
 
+func TestParser_Parse__should_parse_enum_reserved(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`
+enum TestEnum {
+	UNDEFINED = 0;
+	reserved 1 to 3, "ONE";
+	FOUR = 4;
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enum := file.Definitions[0].Enum
+	require.Len(t, enum.Values, 2)
+
+	assert.Equal(t, []syntax.ReservedRange{{Start: 1, End: 3}}, enum.Reserved.Ranges)
+	assert.Equal(t, []string{"ONE"}, enum.Reserved.Names)
+}
+
 func TestParser_Parse__should_parse_empty_enum(t *testing.T) {
 	p := newParser()
 
@@ -219,6 +241,223 @@ func TestParser_Parse__should_parse_empty_message(t *testing.T) {
 	assert.Len(t, def.Message.Fields, 0)
 }
 
+func TestParser_Parse__should_parse_message_oneof(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`
+message TestMessage {
+	field1	int32	1;
+	oneof body {
+		field2	string	2;
+		field3	int64	3;
+	}
+	field4	bool	4;
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := file.Definitions[0]
+	require.Len(t, def.Message.Fields, 4)
+	require.Len(t, def.Message.Oneofs, 1)
+
+	oneof := def.Message.Oneofs[0]
+	assert.Equal(t, "body", oneof.Name)
+	require.Len(t, oneof.Fields, 2)
+	assert.Equal(t, "field2", oneof.Fields[0].Name)
+	assert.Equal(t, "field3", oneof.Fields[1].Name)
+
+	assert.Equal(t, "", def.Message.Fields[0].Oneof)
+	assert.Equal(t, "body", def.Message.Fields[1].Oneof)
+	assert.Equal(t, "body", def.Message.Fields[2].Oneof)
+	assert.Equal(t, "", def.Message.Fields[3].Oneof)
+}
+
+func TestParser_Parse__should_parse_field_defaults(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`
+message TestMessage {
+	field1	int32	1 = -100;
+	field2	float64	2 = 1.5;
+	field3	string	3 = "hello";
+	field4	bool	4 = true;
+	field5	Enum	5 = ONE;
+	field6	int32	6;
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := file.Definitions[0].Message.Fields
+	require.Len(t, fields, 6)
+
+	assert.Equal(t, &syntax.Value{Kind: syntax.ValueInteger, Text: "-100"}, fields[0].Default)
+	assert.Equal(t, &syntax.Value{Kind: syntax.ValueFloat, Text: "1.5"}, fields[1].Default)
+	assert.Equal(t, &syntax.Value{Kind: syntax.ValueString, Text: `"hello"`}, fields[2].Default)
+	assert.Equal(t, &syntax.Value{Kind: syntax.ValueIdent, Text: "true"}, fields[3].Default)
+	assert.Equal(t, &syntax.Value{Kind: syntax.ValueIdent, Text: "ONE"}, fields[4].Default)
+	assert.Nil(t, fields[5].Default)
+}
+
+func TestParser_Parse__should_parse_message_reserved(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`
+message TestMessage {
+	field1		int32	1;
+	reserved	4, 7 to 9, "old_name";
+	reserved	int32	2;
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := file.Definitions[0].Message
+	require.Len(t, msg.Fields, 2)
+	assert.Equal(t, "reserved", msg.Fields[1].Name)
+
+	assert.Equal(t, []syntax.ReservedRange{{Start: 4, End: 4}, {Start: 7, End: 9}}, msg.Reserved.Ranges)
+	assert.Equal(t, []string{"old_name"}, msg.Reserved.Names)
+}
+
+func TestParser_Parse__should_return_error_when_invalid_reserved_range(t *testing.T) {
+	p := newParser()
+
+	_, err := p.Parse(`
+message TestMessage {
+	reserved 7 until 9;
+}`)
+	assert.ErrorContains(t, err, "invalid reserved range")
+}
+
+func TestParser_Parse__should_parse_doc_comments(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`
+// Section comment
+
+// TestMessage is a test message.
+// It has two lines.
+message TestMessage {
+	// Field1 doc comment.
+	field1	int32	1; // Field1 trailing comment.
+	field2	string	2;
+
+	/* Field3 block comment. */
+	field3	string	3;
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := file.Definitions[0]
+	assert.Equal(t, "TestMessage is a test message.\nIt has two lines.", def.Doc)
+
+	fields := def.Message.Fields
+	assert.Equal(t, "Field1 doc comment.", fields[0].Doc)
+	assert.Equal(t, "", fields[1].Doc)
+	assert.Equal(t, "Field3 block comment.", fields[2].Doc)
+}
+
+func TestParser_Parse__should_parse_positions(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`import (
+	"pkg"
+)
+
+// TestMessage doc comment.
+message TestMessage {
+	field1	int32	1;
+  field2	string	2;
+}
+
+service Service {
+	method(a int32 1);
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, syntax.Position{Line: 2, Column: 2}, file.Imports[0].Pos)
+
+	def := file.Definitions[0]
+	assert.Equal(t, syntax.Position{Line: 6, Column: 1}, def.Pos)
+
+	fields := def.Message.Fields
+	assert.Equal(t, syntax.Position{Line: 7, Column: 2}, fields[0].Pos)
+	assert.Equal(t, syntax.Position{Line: 8, Column: 3}, fields[1].Pos)
+
+	method := file.Definitions[1].Service.Methods[0]
+	assert.Equal(t, syntax.Position{Line: 12, Column: 2}, method.Pos)
+	assert.Equal(t, "12:2", method.Pos.String())
+}
+
+func TestParser_Parse__should_parse_comments(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`// Message doc comment.
+message Message {
+	field int32 1; // trailing
+	reserved 2;
+	/* block */
+	reserved "old";
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	require.Len(t, file.Comments, 3)
+	assert.Equal(t, "// Message doc comment.", file.Comments[0].Text)
+	assert.False(t, file.Comments[0].Trailing)
+	assert.Equal(t, "// trailing", file.Comments[1].Text)
+	assert.True(t, file.Comments[1].Trailing)
+	assert.Equal(t, syntax.Position{Line: 5, Column: 2}, file.Comments[2].Pos)
+
+	def := file.Definitions[0]
+	assert.Equal(t, syntax.Position{Line: 7, Column: 1}, def.End)
+
+	list := def.Message.ReservedList
+	require.Len(t, list, 2)
+	assert.Equal(t, 4, list[0].Pos.Line)
+	assert.Equal(t, []string{"old"}, list[1].Names)
+}
+
+func TestParser_Parse__should_parse_annotations(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`
+message TestMessage [deprecated=true] {
+	field1	int32	1 [deprecated=true, go_type="x.Y"];
+	field2	int32	2 = 10 [min=-1, ratio=0.5,];
+	field3	int32	3;
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := file.Definitions[0]
+	require.Len(t, def.Annotations, 1)
+	assert.Equal(t, "deprecated", def.Annotations[0].Name)
+
+	fields := def.Message.Fields
+	require.Len(t, fields[0].Annotations, 2)
+	assert.Equal(t, &syntax.Annotation{
+		Name:  "deprecated",
+		Value: &syntax.Value{Kind: syntax.ValueIdent, Text: "true"},
+	}, fields[0].Annotations[0])
+	assert.Equal(t, &syntax.Annotation{
+		Name:  "go_type",
+		Value: &syntax.Value{Kind: syntax.ValueString, Text: `"x.Y"`},
+	}, fields[0].Annotations[1])
+
+	require.Len(t, fields[1].Annotations, 2)
+	assert.Equal(t, "10", fields[1].Default.Text)
+	assert.Equal(t, "-1", fields[1].Annotations[0].Value.Text)
+	assert.Nil(t, fields[2].Annotations)
+}
+
 // struct
 
 func TestParser_Parse__should_parse_struct(t *testing.T) {
@@ -327,6 +566,47 @@ message TestMessage {
 	assert.Equal(t, "pkg", type_.Element.Import)
 }
 
+func TestParser_Parse__should_parse_map_type(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`
+message TestMessage {
+	field1	map[string]pkg.Message	1;
+	map		int32					2;
+	field3	map [string]int32		3;
+}
+
+message TestMessage2 {
+	map		[]int32					1;
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := file.Definitions[0]
+	type_ := def.Message.Fields[0].Type
+
+	assert.Equal(t, syntax.KindMap, type_.Kind)
+	require.NotNil(t, type_.Key)
+	require.NotNil(t, type_.Element)
+
+	assert.Equal(t, syntax.KindString, type_.Key.Kind)
+	assert.Equal(t, syntax.KindReference, type_.Element.Kind)
+	assert.Equal(t, "Message", type_.Element.Name)
+	assert.Equal(t, "pkg", type_.Element.Import)
+
+	field := def.Message.Fields[1]
+	assert.Equal(t, "map", field.Name)
+	assert.Equal(t, syntax.KindInt32, field.Type.Kind)
+
+	field = def.Message.Fields[2]
+	assert.Equal(t, syntax.KindMap, field.Type.Kind)
+
+	field = file.Definitions[1].Message.Fields[0]
+	assert.Equal(t, "map", field.Name)
+	assert.Equal(t, syntax.KindList, field.Type.Kind)
+}
+
 func TestParser_Parse__should_parse_imported_type(t *testing.T) {
 	p := newParser()
 
@@ -378,6 +658,29 @@ func TestParser_Parse__should_parse_service_file(t *testing.T) {
 	require.NotNil(t, file)
 }
 
+func TestParser_Parse__should_parse_method_annotations(t *testing.T) {
+	p := newParser()
+
+	file, err := p.Parse(`
+service Service [version=1] {
+	method(a int32 1) (b int32 1) [idempotent=true];
+	method1() oneway [deprecated=true];
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	def := file.Definitions[0]
+	require.Len(t, def.Annotations, 1)
+
+	methods := def.Service.Methods
+	require.Len(t, methods, 2)
+	require.Len(t, methods[0].Annotations, 1)
+	assert.Equal(t, "idempotent", methods[0].Annotations[0].Name)
+	require.Len(t, methods[1].Annotations, 1)
+	assert.Equal(t, "deprecated", methods[1].Annotations[0].Name)
+}
+
 func TestParser_Parse__should_parse_empty_service(t *testing.T) {
 	p := newParser()
 	s := `service Service {}`
@@ -567,3 +870,65 @@ func TestParser_Parse__should_return_error_when_invalid_channel_syntax(t *testin
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), `invalid channel out syntax, expected Msg->, got ->Msg`)
 }
+
+// errors
+
+func TestParser_Parse__should_recover_from_syntax_errors(t *testing.T) {
+	s := `message Message {
+    field1 int32;
+    field2 int32 2;
+    field3 string;
+}
+
+enum Enum {
+    ONE = ;
+    TWO = 2;
+}
+
+struct Struct {
+    a int32 1;
+    b int64;
+}
+
+service Service {
+    method(;
+    method1();
+}
+`
+
+	p := newParser()
+	_, err := p.Parse(s)
+	require.Error(t, err)
+
+	var lines []int
+	for _, err := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var perr *Error
+		require.ErrorAs(t, err, &perr)
+		lines = append(lines, perr.Pos.Line)
+	}
+	assert.Equal(t, []int{2, 4, 8, 13, 18}, lines)
+}
+
+// directory
+
+func TestParser_ParseDirectory__should_return_errors_from_all_files(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.spec": "message A {\n    field int32;\n}\n",
+		"b.spec": "enum B {\n    ONE = ;\n}\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := newParser()
+	_, err := p.ParseDirectory(dir)
+	require.Error(t, err)
+
+	lines := strings.Split(err.Error(), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], filepath.Join(dir, "a.spec")+":2:")
+	assert.Contains(t, lines[1], filepath.Join(dir, "b.spec")+":2:")
+}
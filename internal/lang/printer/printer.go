@@ -0,0 +1,563 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package printer prints spec files in the canonical format.
+package printer
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/basecomplextech/spec/internal/lang/parser"
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+const indent = "    "
+
+// Format parses a spec source and returns it in the canonical format.
+func Format(src []byte) ([]byte, error) {
+	file, err := parser.New().Parse(string(src))
+	if err != nil {
+		return nil, err
+	}
+	return Print(file), nil
+}
+
+// Print prints a file in the canonical format, comments are printed from file comments.
+func Print(file *syntax.File) []byte {
+	p := newPrinter(file.Comments)
+	p.file(file)
+	return p.bytes()
+}
+
+type printer struct {
+	buf bytes.Buffer
+	w   *tabwriter.Writer
+
+	comments []*syntax.Comment
+	next     int // next comment index
+
+	depth int  // indentation depth
+	last  int  // last printed source line
+	open  bool // block has just been opened, skip blank lines
+	sep   bool // next line must be separated by a blank line
+}
+
+func newPrinter(comments []*syntax.Comment) *printer {
+	p := &printer{
+		comments: comments,
+		open:     true,
+	}
+	p.w = tabwriter.NewWriter(&p.buf, 0, 4, 1, ' ', tabwriter.StripEscape)
+	return p
+}
+
+func (p *printer) bytes() []byte {
+	p.w.Flush()
+	return p.buf.Bytes()
+}
+
+// file
+
+func (p *printer) file(file *syntax.File) {
+	if len(file.Imports) > 0 {
+		p.imports(file.Imports)
+	}
+	if len(file.Options) > 0 {
+		p.options(file.Options)
+	}
+	for _, def := range file.Definitions {
+		p.definition(def)
+	}
+
+	// Print remaining comments at the end of the file
+	p.sep = true
+	p.flush(int(^uint(0) >> 1))
+}
+
+func (p *printer) imports(imports []*syntax.Import) {
+	p.section(imports[0].Pos.Line)
+	p.line("import (")
+	p.begin()
+
+	for _, imp := range imports {
+		p.leading(imp.Pos.Line)
+
+		s := strconv.Quote(imp.ID)
+		if imp.Alias != "" {
+			s = imp.Alias + " " + s
+		}
+		p.item(imp.Pos.Line, s)
+	}
+
+	p.end(")", 0)
+}
+
+func (p *printer) options(options []*syntax.Option) {
+	p.section(options[0].Pos.Line)
+	p.line("options (")
+	p.begin()
+
+	for _, opt := range options {
+		p.leading(opt.Pos.Line)
+		p.item(opt.Pos.Line, opt.Name+`="`+opt.Value+`"`)
+	}
+
+	p.end(")", 0)
+}
+
+// definitions
+
+func (p *printer) definition(def *syntax.Definition) {
+	p.section(def.Pos.Line)
+
+	var keyword string
+	switch def.Type {
+	case syntax.DefinitionEnum:
+		keyword = "enum"
+	case syntax.DefinitionMessage:
+		keyword = "message"
+	case syntax.DefinitionStruct:
+		keyword = "struct"
+	case syntax.DefinitionService:
+		keyword = "service"
+		if def.Service.Sub {
+			keyword = "subservice"
+		}
+	}
+
+	header := keyword + " " + def.Name + annotations(def.Annotations)
+	if p.empty(def) {
+		p.item(def.Pos.Line, header+" {}")
+		p.last = def.End.Line
+		return
+	}
+
+	p.item(def.Pos.Line, header+" {")
+	p.begin()
+
+	switch def.Type {
+	case syntax.DefinitionEnum:
+		p.enum(def.Enum)
+	case syntax.DefinitionMessage:
+		p.message(def.Message)
+	case syntax.DefinitionStruct:
+		p.struct_(def.Struct)
+	case syntax.DefinitionService:
+		p.service(def.Service)
+	}
+
+	p.flush(def.End.Line)
+	p.end("}", def.End.Line)
+}
+
+// empty returns true if a definition has no items and no inner comments.
+func (p *printer) empty(def *syntax.Definition) bool {
+	switch def.Type {
+	case syntax.DefinitionEnum:
+		if len(def.Enum.Values) > 0 || len(def.Enum.ReservedList) > 0 {
+			return false
+		}
+	case syntax.DefinitionMessage:
+		if len(def.Message.Fields) > 0 || len(def.Message.ReservedList) > 0 {
+			return false
+		}
+	case syntax.DefinitionStruct:
+		if len(def.Struct.Fields) > 0 {
+			return false
+		}
+	case syntax.DefinitionService:
+		if len(def.Service.Methods) > 0 {
+			return false
+		}
+	}
+
+	for i := p.next; i < len(p.comments); i++ {
+		c := p.comments[i]
+		if c.Pos.Line > def.End.Line {
+			break
+		}
+		if !c.Trailing || c.Pos.Line != def.Pos.Line {
+			return false
+		}
+	}
+	return true
+}
+
+// enum
+
+func (p *printer) enum(enum *syntax.Enum) {
+	type item struct {
+		pos      syntax.Position
+		value    *syntax.EnumValue
+		reserved *syntax.Reserved
+	}
+
+	items := make([]item, 0, len(enum.Values)+len(enum.ReservedList))
+	for _, val := range enum.Values {
+		items = append(items, item{pos: val.Pos, value: val})
+	}
+	for _, r := range enum.ReservedList {
+		items = append(items, item{pos: r.Pos, reserved: r})
+	}
+	sortItems(items, func(i int) syntax.Position { return items[i].pos })
+
+	for _, it := range items {
+		p.leading(it.pos.Line)
+
+		if it.reserved != nil {
+			p.reserved(it.reserved)
+			continue
+		}
+
+		val := it.value
+		s := val.Name + " = " + strconv.Itoa(val.Value) + annotations(val.Annotations) + ";"
+		p.item(val.Pos.Line, s)
+	}
+}
+
+// message
+
+func (p *printer) message(msg *syntax.Message) {
+	type item struct {
+		pos      syntax.Position
+		field    *syntax.Field
+		oneof    *syntax.Oneof
+		reserved *syntax.Reserved
+	}
+
+	items := make([]item, 0, len(msg.Fields)+len(msg.ReservedList))
+	for _, field := range msg.Fields {
+		if field.Oneof != "" {
+			continue
+		}
+		items = append(items, item{pos: field.Pos, field: field})
+	}
+	for _, oneof := range msg.Oneofs {
+		items = append(items, item{pos: oneof.Pos, oneof: oneof})
+	}
+	for _, r := range msg.ReservedList {
+		items = append(items, item{pos: r.Pos, reserved: r})
+	}
+	sortItems(items, func(i int) syntax.Position { return items[i].pos })
+
+	for _, it := range items {
+		p.leading(it.pos.Line)
+
+		switch {
+		case it.field != nil:
+			p.field(it.field)
+		case it.oneof != nil:
+			p.oneof(it.oneof)
+		case it.reserved != nil:
+			p.reserved(it.reserved)
+		}
+	}
+}
+
+func (p *printer) oneof(oneof *syntax.Oneof) {
+	p.item(oneof.Pos.Line, "oneof "+oneof.Name+" {")
+	p.begin()
+
+	for _, field := range oneof.Fields {
+		p.leading(field.Pos.Line)
+		p.field(field)
+	}
+
+	p.flush(oneof.End.Line)
+	p.end("}", oneof.End.Line)
+}
+
+func (p *printer) field(field *syntax.Field) {
+	tag := strconv.Itoa(field.Tag)
+	if field.Default != nil {
+		tag += " = " + field.Default.Text
+	}
+	tag += annotations(field.Annotations) + ";"
+
+	p.row(field.Pos.Line, field.Name, field.Type.String(), tag)
+}
+
+func (p *printer) reserved(r *syntax.Reserved) {
+	items := make([]string, 0, len(r.Ranges)+len(r.Names))
+	for _, rng := range r.Ranges {
+		if rng.Start == rng.End {
+			items = append(items, strconv.Itoa(rng.Start))
+		} else {
+			items = append(items, strconv.Itoa(rng.Start)+" to "+strconv.Itoa(rng.End))
+		}
+	}
+	for _, name := range r.Names {
+		items = append(items, strconv.Quote(name))
+	}
+
+	p.item(r.Pos.Line, "reserved "+strings.Join(items, ", ")+";")
+}
+
+// struct
+
+func (p *printer) struct_(str *syntax.Struct) {
+	for _, field := range str.Fields {
+		p.leading(field.Pos.Line)
+
+		typ := field.Type.String() + annotations(field.Annotations) + ";"
+		p.row(field.Pos.Line, field.Name, typ)
+	}
+}
+
+// service
+
+func (p *printer) service(srv *syntax.Service) {
+	for _, m := range srv.Methods {
+		p.leading(m.Pos.Line)
+		p.method(m)
+	}
+}
+
+func (p *printer) method(m *syntax.Method) {
+	var b strings.Builder
+	b.WriteString(m.Name)
+
+	// Input
+	line := m.Pos.Line
+	var input syntax.Fields
+
+	switch in := m.Input.(type) {
+	case *syntax.Type:
+		b.WriteString("(" + in.String() + ")")
+	case syntax.Fields:
+		if multiline(in, line) {
+			input = in
+			b.WriteString("(")
+		} else {
+			b.WriteString("(" + fieldList(in) + ")")
+		}
+		if len(in) > 0 {
+			line = in[len(in)-1].Pos.Line
+		}
+		if input != nil {
+			line++ // closing parenthesis line
+		}
+	}
+
+	// Print multiline input, continue with the rest of the signature after ")"
+	if input != nil {
+		p.item(m.Pos.Line, b.String())
+		p.params(input)
+		b.Reset()
+		b.WriteString(")")
+	}
+
+	// Oneway, channel
+	if m.Oneway {
+		b.WriteString(" oneway")
+	}
+	if ch := m.Channel; ch != nil {
+		switch {
+		case ch.In != nil && ch.Out != nil:
+			b.WriteString(" (<-" + ch.In.String() + ", " + ch.Out.String() + "->)")
+		case ch.In != nil:
+			b.WriteString(" (<-" + ch.In.String() + ")")
+		case ch.Out != nil:
+			b.WriteString(" (" + ch.Out.String() + "->)")
+		}
+	}
+
+	// Output
+	var output syntax.Fields
+	switch out := m.Output.(type) {
+	case *syntax.Type:
+		b.WriteString(" " + out.String())
+	case syntax.Fields:
+		if multiline(out, line) {
+			output = out
+			b.WriteString(" (")
+		} else {
+			b.WriteString(" (" + fieldList(out) + ")")
+		}
+	}
+
+	if output != nil {
+		p.line(b.String())
+		p.params(output)
+		b.Reset()
+		b.WriteString(")")
+	}
+
+	b.WriteString(annotations(m.Annotations) + ";")
+	if input == nil && output == nil {
+		p.item(m.Pos.Line, b.String())
+	} else {
+		p.line(b.String())
+	}
+}
+
+// params prints multiline method fields.
+func (p *printer) params(fields syntax.Fields) {
+	p.begin()
+
+	for _, field := range fields {
+		p.leading(field.Pos.Line)
+		p.row(field.Pos.Line, field.Name, field.Type.String(), strconv.Itoa(field.Tag)+",")
+	}
+
+	// Closing parenthesis line
+	p.depth--
+	p.open = false
+	p.last++
+}
+
+// lines
+
+// section starts a top-level section separated by a blank line.
+func (p *printer) section(line int) {
+	p.sep = true
+	p.leading(line)
+}
+
+// begin begins an indented block.
+func (p *printer) begin() {
+	p.depth++
+	p.open = true
+}
+
+// end ends an indented block with a closing line.
+func (p *printer) end(s string, line int) {
+	p.depth--
+	p.open = false
+	p.item(line, s)
+
+	if line > p.last {
+		p.last = line
+	}
+}
+
+// leading prints comments before a source line and a blank line if the source has one.
+func (p *printer) leading(line int) {
+	p.flush(line)
+	p.blank(line)
+}
+
+// comments_ prints comments before a source line, keeps single blank lines between groups.
+func (p *printer) flush(line int) {
+	for p.next < len(p.comments) {
+		c := p.comments[p.next]
+		if c.Pos.Line >= line {
+			break
+		}
+		p.next++
+
+		p.blank(c.Pos.Line)
+		p.line(escape(c.Text))
+		p.last = c.EndLine()
+	}
+}
+
+// blank writes a blank line if required or if the source has one before a line.
+func (p *printer) blank(line int) {
+	switch {
+	case p.open:
+	case p.sep || (p.last > 0 && line > p.last+1):
+		p.w.Write([]byte("\n"))
+	}
+
+	p.open = false
+	p.sep = false
+	if line > p.last {
+		p.last = line
+	}
+}
+
+// item writes a single cell line with a trailing comment.
+func (p *printer) item(line int, s string) {
+	if c := p.trailingComment(line); c != "" {
+		s += " " + c
+	}
+	p.line(s)
+}
+
+// row writes an aligned line with a trailing comment.
+func (p *printer) row(line int, cells ...string) {
+	for i, cell := range cells {
+		cells[i] = escape(cell)
+	}
+
+	s := strings.Join(cells, "\t")
+	if c := p.trailingComment(line); c != "" {
+		s += "\t" + c
+	}
+	p.line(s)
+}
+
+// trailingComment returns trailing comments on a source line.
+func (p *printer) trailingComment(line int) string {
+	var comments []string
+	for p.next < len(p.comments) {
+		c := p.comments[p.next]
+		if !c.Trailing || c.Pos.Line != line {
+			break
+		}
+
+		comments = append(comments, c.Text)
+		p.next++
+	}
+	if len(comments) == 0 {
+		return ""
+	}
+	return escape(strings.Join(comments, " "))
+}
+
+// line writes an indented line.
+func (p *printer) line(s string) {
+	p.w.Write([]byte(strings.Repeat(indent, p.depth) + s + "\n"))
+}
+
+// util
+
+func annotations(annots []*syntax.Annotation) string {
+	if len(annots) == 0 {
+		return ""
+	}
+
+	items := make([]string, 0, len(annots))
+	for _, a := range annots {
+		items = append(items, a.Name+"="+a.Value.Text)
+	}
+	return " [" + strings.Join(items, ", ") + "]"
+}
+
+func fieldList(fields syntax.Fields) string {
+	items := make([]string, 0, len(fields))
+	for _, field := range fields {
+		items = append(items, field.Name+" "+field.Type.String()+" "+strconv.Itoa(field.Tag))
+	}
+	return strings.Join(items, ", ")
+}
+
+// multiline returns true if fields start on a new line after a source line.
+func multiline(fields syntax.Fields, line int) bool {
+	return len(fields) > 0 && fields[0].Pos.Line > line
+}
+
+func sortItems[T any](items []T, pos func(i int) syntax.Position) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := pos(i), pos(j)
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+}
+
+// escape escapes text with tabs or newlines, so that it is not split into cells.
+func escape(s string) string {
+	if !strings.ContainsAny(s, "\t\n") {
+		return s
+	}
+	esc := string([]byte{tabwriter.Escape})
+	return esc + s + esc
+}
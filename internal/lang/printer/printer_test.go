@@ -0,0 +1,170 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package printer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFormat(t *testing.T, src string) string {
+	b, err := Format([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestFormat__should_align_fields(t *testing.T) {
+	src := `message Message {
+  id int64 1;
+      name   string 2 [min=1,max=10];
+
+
+  list []int32 3 = 1
+}
+struct Struct { key int32; value    string; }
+`
+	exp := `message Message {
+    id   int64  1;
+    name string 2 [min=1, max=10];
+
+    list []int32 3 = 1;
+}
+
+struct Struct {
+    key   int32;
+    value string;
+}
+`
+	assert.Equal(t, exp, testFormat(t, src))
+}
+
+func TestFormat__should_format_imports_and_options(t *testing.T) {
+	src := `import ( "pkg1"
+alias "pkg2" )
+options (go_package="example.com/test")
+enum Enum { NONE=0; ONE = 1 [alias=one]; reserved 2 to 5, 7, "TWO"; }`
+
+	exp := `import (
+    "pkg1"
+    alias "pkg2"
+)
+
+options (
+    go_package="example.com/test"
+)
+
+enum Enum {
+    NONE = 0;
+    ONE = 1 [alias=one];
+    reserved 2 to 5, 7, "TWO";
+}
+`
+	assert.Equal(t, exp, testFormat(t, src))
+}
+
+func TestFormat__should_normalize_methods(t *testing.T) {
+	src := `service Service {
+    method1 ( a int64 1 , b string 2 ) ( ok bool 1 ) ;
+    method2(Request)Response [idempotent=true];
+    method3(Request)oneway;
+    method4(Request)(<-In,Out->)Response;
+    method5(
+        a int64 1,
+        long_name string 2
+    ) (ok bool 1);
+}`
+
+	exp := `service Service {
+    method1(a int64 1, b string 2) (ok bool 1);
+    method2(Request) Response [idempotent=true];
+    method3(Request) oneway;
+    method4(Request) (<-In, Out->) Response;
+    method5(
+        a         int64  1,
+        long_name string 2,
+    ) (ok bool 1);
+}
+`
+	assert.Equal(t, exp, testFormat(t, src))
+}
+
+func TestFormat__should_preserve_comments(t *testing.T) {
+	src := `// File comment.
+
+// Message doc.
+message Message { // header comment
+    // Field doc.
+    id int64 1; // trailing
+    name string 2;
+
+    oneof body {
+        a int32 10;
+        // Last oneof comment.
+    }
+
+    // Last comment.
+}
+message Empty {}
+/* Block
+   comment. */
+struct Struct {
+    key int32;
+}
+
+// Final comment.
+`
+	exp := `// File comment.
+
+// Message doc.
+message Message { // header comment
+    // Field doc.
+    id   int64  1; // trailing
+    name string 2;
+
+    oneof body {
+        a int32 10;
+        // Last oneof comment.
+    }
+
+    // Last comment.
+}
+
+message Empty {}
+
+/* Block
+   comment. */
+struct Struct {
+    key int32;
+}
+
+// Final comment.
+`
+	assert.Equal(t, exp, testFormat(t, src))
+}
+
+func TestFormat__should_be_idempotent(t *testing.T) {
+	paths, err := filepath.Glob("../../tests/*/*.spec")
+	require.NoError(t, err)
+	paths = append(paths, "../parser/test.spec", "../parser/test_service.spec")
+
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		out := testFormat(t, string(src))
+		assert.Equal(t, out, testFormat(t, out), path)
+	}
+}
+
+func TestFormat__should_return_syntax_error(t *testing.T) {
+	_, err := Format([]byte("message Message { field int32; }"))
+	assert.Error(t, err)
+}
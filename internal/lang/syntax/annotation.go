@@ -0,0 +1,11 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package syntax
+
+// Annotation is a definition, field, enum value or method annotation, i.e. deprecated=true.
+type Annotation struct {
+	Name  string
+	Value *Value
+}
@@ -0,0 +1,19 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package syntax
+
+import "strings"
+
+// Comment is a source comment, doc comments are also attached to definitions as text.
+type Comment struct {
+	Text     string   // comment text with markers, i.e. "// text" or "/* text */"
+	Pos      Position // source position
+	Trailing bool     // comment follows a token on the same line
+}
+
+// EndLine returns the last line of the comment.
+func (c *Comment) EndLine() int {
+	return c.Pos.Line + strings.Count(c.Text, "\n")
+}
@@ -17,6 +17,11 @@ const (
 type Definition struct {
 	Type DefinitionType
 	Name string
+	Doc  string   // doc comment
+	Pos  Position // source position
+	End  Position // closing brace position
+
+	Annotations []*Annotation
 
 	Enum    *Enum
 	Message *Message
@@ -5,10 +5,16 @@
 package syntax
 
 type Enum struct {
-	Values []*EnumValue
+	Values       []*EnumValue
+	Reserved     Reserved
+	ReservedList []*Reserved // reserved statements in source order
 }
 
 type EnumValue struct {
 	Name  string
 	Value int
+	Doc   string   // doc comment
+	Pos   Position // source position
+
+	Annotations []*Annotation
 }
@@ -5,9 +5,15 @@
 package syntax
 
 type Field struct {
-	Name string
-	Type *Type
-	Tag  int
+	Name    string
+	Type    *Type
+	Tag     int
+	Oneof   string   // oneof name or empty
+	Default *Value   // default value or nil
+	Doc     string   // doc comment
+	Pos     Position // source position
+
+	Annotations []*Annotation
 }
 
 type Fields []*Field
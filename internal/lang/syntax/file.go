@@ -9,6 +9,7 @@ type File struct {
 	Imports     []*Import
 	Options     []*Option
 	Definitions []*Definition
+	Comments    []*Comment // all comments in source order
 }
 
 // Import
@@ -16,6 +17,7 @@ type File struct {
 type Import struct {
 	ID    string
 	Alias string
+	Pos   Position // source position
 }
 
 // Option
@@ -23,4 +25,5 @@ type Import struct {
 type Option struct {
 	Name  string
 	Value string
+	Pos   Position // source position
 }
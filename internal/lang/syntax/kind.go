@@ -35,6 +35,7 @@ const (
 	// Element-based
 
 	KindList
+	KindMap
 	KindReference
 )
 
@@ -133,6 +134,8 @@ func (k Kind) String() string {
 
 	case KindList:
 		return "list"
+	case KindMap:
+		return "map"
 	case KindReference:
 		return "ref"
 	}
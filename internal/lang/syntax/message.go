@@ -5,5 +5,17 @@
 package syntax
 
 type Message struct {
+	Fields       []*Field // all fields including oneof fields
+	Oneofs       []*Oneof
+	Reserved     Reserved
+	ReservedList []*Reserved // reserved statements in source order
+}
+
+// Oneof is a set of mutually exclusive message fields.
+type Oneof struct {
+	Name   string
+	Doc    string   // doc comment
+	Pos    Position // source position
+	End    Position // closing brace position
 	Fields []*Field
 }
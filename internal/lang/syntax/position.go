@@ -0,0 +1,26 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package syntax
+
+import "fmt"
+
+// Position is a source position, lines and columns start at 1.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// IsValid returns true if the position is known.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns "line:column" or "-" when unknown.
+func (p Position) String() string {
+	if !p.IsValid() {
+		return "-"
+	}
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
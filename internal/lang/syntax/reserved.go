@@ -0,0 +1,24 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package syntax
+
+// Reserved is a set of reserved tags or enum numbers and names.
+type Reserved struct {
+	Ranges []ReservedRange
+	Names  []string
+	Pos    Position // reserved statement position, unset when merged
+}
+
+// ReservedRange is an inclusive range of reserved tags or enum numbers.
+type ReservedRange struct {
+	Start int
+	End   int
+}
+
+// Add adds other reserved tags and names.
+func (r *Reserved) Add(other *Reserved) {
+	r.Ranges = append(r.Ranges, other.Ranges...)
+	r.Names = append(r.Names, other.Names...)
+}
@@ -11,11 +11,15 @@ type Service struct {
 
 type Method struct {
 	Name string
+	Doc  string   // doc comment
+	Pos  Position // source position
 
 	Input   MethodInput
 	Output  MethodOutput
 	Channel *MethodChannel
 	Oneway  bool
+
+	Annotations []*Annotation
 }
 
 // MethodInput is a union type for method inputs.
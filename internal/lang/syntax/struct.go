@@ -11,4 +11,8 @@ type Struct struct {
 type StructField struct {
 	Name string
 	Type *Type
+	Doc  string   // doc comment
+	Pos  Position // source position
+
+	Annotations []*Annotation
 }
@@ -8,7 +8,8 @@ type Type struct {
 	Kind    Kind
 	Name    string
 	Import  string // package name in imported type, "pkg" in "pkg.Name"
-	Element *Type  // element type in list and nullable types
+	Key     *Type  // key type in map types
+	Element *Type  // element type in list and nullable types, value type in map types
 }
 
 func (t *Type) String() string {
@@ -20,6 +21,8 @@ func (t *Type) String() string {
 		return t.Name
 	case KindList:
 		return "[]" + t.Element.String()
+	case KindMap:
+		return "map[" + t.Key.String() + "]" + t.Element.String()
 	}
 	return t.Kind.String()
 }
@@ -0,0 +1,21 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package syntax
+
+// Value is a literal value, i.e. a field default value.
+type Value struct {
+	Kind ValueKind
+	Text string // literal text, strings are quoted
+}
+
+type ValueKind int
+
+const (
+	ValueUndefined ValueKind = iota
+	ValueInteger             // 100 or -100
+	ValueFloat               // 1.5 or -1.5
+	ValueString              // "text"
+	ValueIdent               // true, false or an enum value
+)
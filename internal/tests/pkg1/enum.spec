@@ -1,8 +1,12 @@
+// Enum is a test enum.
 enum Enum {
     UNDEFINED = 0;
 
+    /* One is the first value. */
     ONE = 1;
     TWO = 2;
-    THREE = 3;
-    TEN = 10;
+    THREE = 3 [deprecated="use TEN"];
+    TEN = 10 [alias=ten];
+
+    reserved 4 to 9, "FOUR";
 }
@@ -0,0 +1,106 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package pkg1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMessage(t *testing.T) Message {
+	o := TestObject(t)
+
+	m, err := o.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestMessage_MarshalJSON__should_marshal_message_to_canonical_json(t *testing.T) {
+	m := testMessage(t)
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal(b, &obj); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, true, obj["bool"])
+	assert.Equal(t, "hello, world", obj["string"])
+	assert.Equal(t, "Z29vZGJ5ZSwgd29ybGQ=", obj["bytes1"])
+	assert.Equal(t, "ONE", obj["enum1"])
+	assert.Equal(t, m.Bin128().String(), obj["bin128"])
+	assert.Equal(t, map[string]any{"key": 1.0, "value": -1.0}, obj["struct1"])
+
+	message1 := obj["message1"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "int32", "value": 1.0}, message1["1"])
+
+	counts := obj["counts"].(map[string]any)
+	assert.Equal(t, 3.0, counts["key 003"])
+
+	subs := obj["submessage_map"].(map[string]any)
+	assert.Equal(t, map[string]any{"value": "value 001"}, subs["1"])
+}
+
+func TestMessage_UnmarshalJSON__should_unmarshal_message_from_canonical_json(t *testing.T) {
+	m := testMessage(t)
+
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m1 Message
+	if err := json.Unmarshal(b, &m1); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, m.Int64(), m1.Int64())
+	assert.Equal(t, m.Uint64(), m1.Uint64())
+	assert.Equal(t, m.Float64(), m1.Float64())
+	assert.Equal(t, m.Bin256(), m1.Bin256())
+	assert.Equal(t, m.Bytes1().Unwrap(), m1.Bytes1().Unwrap())
+	assert.Equal(t, m.Enum1(), m1.Enum1())
+	assert.Equal(t, m.Struct1(), m1.Struct1())
+	assert.Equal(t, m.Submessage().Value().Unwrap(), m1.Submessage().Value().Unwrap())
+	assert.Equal(t, m.Message1().Field(2).Int32(), m1.Message1().Field(2).Int32())
+	assert.Equal(t, m.Strings().Len(), m1.Strings().Len())
+	assert.Equal(t, m.Counts().Len(), m1.Counts().Len())
+	assert.Equal(t, m.SubmessageMap().Len(), m1.SubmessageMap().Len())
+
+	b1, err := json.Marshal(m1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, string(b), string(b1))
+}
+
+func TestMessage_UnmarshalJSON__should_return_error_on_unknown_field(t *testing.T) {
+	var m Message
+	err := json.Unmarshal([]byte(`{"unknown": 1}`), &m)
+	require.Error(t, err)
+}
+
+func TestEnum_MarshalJSON__should_marshal_enum_by_name(t *testing.T) {
+	b, err := json.Marshal(Enum_Ten)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, `"TEN"`, string(b))
+
+	var e Enum
+	if err := json.Unmarshal(b, &e); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, Enum_Ten, e)
+}
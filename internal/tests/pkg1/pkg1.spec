@@ -4,6 +4,7 @@ import (
 
 options (
     go_package="github.com/basecomplextech/spec/internal/tests/pkg1"
+    go_objects="true"
 )
 
 message Message {
@@ -41,15 +42,56 @@ message Message {
     submessages1    []pkg2.Submessage   75;
 
     any any 80;
+
+    counts          map[string]int32        81;
+    submessage_map  map[int32]Submessage    82;
+
+    reserved 3 to 9, 91, "deleted";
+}
+
+// Union is a message with a oneof.
+message Union [version=2] {
+    id  int64   1 [min=1, label="identifier", ratio=0.5];
+
+    // Body is a union body.
+    oneof body {
+        // Number is a numeric body.
+        number      int64       10;
+        text        string      11;
+        submessage  Submessage  12;
+    }
+}
+
+// Defaults is a message with field default values.
+message Defaults {
+    bool    bool    1 = true;
+    int32   int32   2 = -100;
+    uint64  uint64  3 = 100;
+    float32 float32 4 = 0.5;
+    float64 float64 5 = -1.5;
+    string  string  6 = "hello\tworld";
+    enum1   Enum    7 = ONE;
+    plain   int32   8;
+}
+
+// Legacy is a message with deprecated fields.
+message Legacy {
+    value   int32               1;
+
+    // Old is an old submessage.
+    old     pkg2.OldSubmessage  2 [deprecated=true];
 }
 
 struct Struct {
-    key     int32;
+    // Key is a struct key.
+    key     int32 [primary=true];
     value   int32;
 }
 
+// Submessage is a recursive message.
 message Submessage {
-    value   string      1;
+    // Value is a submessage value.
+    value   string      1;  // trailing comment
     next    Submessage  2;
 }
 
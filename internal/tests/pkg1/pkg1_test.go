@@ -5,18 +5,22 @@
 package pkg1
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"math"
 	"testing"
 
 	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/spec"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestWriteMessage__should_write_message(t *testing.T) {
 	o := TestObject(t)
 
-	m, err := o.Write(NewMessageWriter())
+	m, err := o.Marshal()
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -89,12 +93,40 @@ func TestWriteMessage__should_write_message(t *testing.T) {
 		list := m.Submessages1()
 		assert.Equal(t, 10, list.Len())
 	}
+
+	{
+		counts := m.Counts()
+		assert.Equal(t, 10, counts.Len())
+
+		for i := 0; i < 10; i++ {
+			key := spec.String(fmt.Sprintf("key %03d", i))
+			v, ok := counts.Get(key)
+			assert.True(t, ok)
+			assert.Equal(t, int32(i), v)
+		}
+
+		_, ok := counts.Get("missing")
+		assert.False(t, ok)
+	}
+
+	{
+		subs := m.SubmessageMap()
+		assert.Equal(t, 10, subs.Len())
+
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, int32(i), subs.Key(i))
+
+			sub, ok := subs.Get(int32(i))
+			require.True(t, ok)
+			assert.Equal(t, fmt.Sprintf("value %03d", i), sub.Value().Unwrap())
+		}
+	}
 }
 
 func TestParseMessage__should_parse_message(t *testing.T) {
 	o := TestObject(t)
 
-	m, err := o.Write(NewMessageWriter())
+	m, err := o.Marshal()
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -108,3 +140,294 @@ func TestParseMessage__should_parse_message(t *testing.T) {
 	assert.Equal(t, len(b), n)
 	assert.Equal(t, m, m1)
 }
+
+func TestParseMessage__should_return_decode_error_with_field_names(t *testing.T) {
+	o := TestObject(t)
+
+	m, err := o.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := bytes.Clone(m.Unwrap().Raw())
+
+	// Corrupt the submessage string type
+	offset := bytes.Index(b, []byte("value 004"))
+	for b[offset] != byte(spec.TypeString) {
+		offset++
+	}
+	b[offset] = 0xff
+
+	_, _, err = ParseMessage(b)
+
+	var e *spec.DecodeError
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, offset, e.Offset)
+	assert.Equal(t, "submessages -> list[4] -> value", e.PathString())
+}
+
+// Object
+
+func TestMessageObject__should_marshal_and_unmarshal_message(t *testing.T) {
+	o := TestObject(t)
+
+	m, err := o.Marshal()
+	require.NoError(t, err)
+
+	o1 := NewMessageObject(m)
+	assert.Equal(t, o, o1)
+}
+
+func TestMessageObject__should_not_reference_message_buffer(t *testing.T) {
+	o := TestObject(t)
+
+	m, err := o.Marshal()
+	require.NoError(t, err)
+	b := bytes.Clone(m.Unwrap().Raw())
+
+	o1 := NewMessageObject(OpenMessage(b))
+	clear(b)
+
+	assert.Equal(t, "hello, world", o1.String)
+	assert.Equal(t, []byte("goodbye, world"), o1.Bytes1)
+	assert.Equal(t, "value 000", o1.Submessage.Value)
+	assert.Equal(t, "key 000", o1.Submessages1[0].Key)
+	assert.Equal(t, int32(1), o1.Message1.Field(1).Int32())
+}
+
+func TestUnionObject__should_marshal_selected_oneof_field(t *testing.T) {
+	o := &UnionObject{
+		Id:     1,
+		Body:   UnionBody_Submessage,
+		Number: 10,
+		Text:   "hello",
+	}
+
+	u, err := o.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, UnionBody_Submessage, u.WhichBody())
+	assert.False(t, u.HasNumber())
+	assert.False(t, u.HasText())
+
+	o1 := NewUnionObject(u)
+	assert.Equal(t, UnionBody_Submessage, o1.Body)
+	assert.NotNil(t, o1.Submessage)
+}
+
+// Oneof
+
+func TestUnion__should_write_and_read_oneof_field(t *testing.T) {
+	w := NewUnionWriter()
+	w.Id(1)
+	w.Text("hello")
+
+	u, err := w.Build()
+	require.NoError(t, err)
+	assert.Equal(t, UnionBody_Text, u.WhichBody())
+	assert.Equal(t, "text", u.WhichBody().String())
+	assert.Equal(t, "hello", u.Text().Unwrap())
+
+	_, _, err = ParseUnion(u.Unwrap().Raw())
+	assert.NoError(t, err)
+}
+
+func TestUnion__should_return_none_when_no_oneof_field(t *testing.T) {
+	w := spec.NewMessageWriter()
+	w.Field(1).Int64(1)
+
+	b, err := w.Build()
+	require.NoError(t, err)
+
+	u := OpenUnion(b)
+	assert.Equal(t, UnionBody_None, u.WhichBody())
+}
+
+func TestUnion__should_accept_no_oneof_field_when_parsing(t *testing.T) {
+	w := spec.NewMessageWriter()
+	w.Field(1).Int64(1)
+	b, err := w.Build()
+	require.NoError(t, err)
+
+	// Newer schemas may write oneof fields unknown to this one
+	u, _, err := ParseUnion(b)
+	require.NoError(t, err)
+	assert.Equal(t, UnionBody_None, u.WhichBody())
+}
+
+func TestUnion__should_reject_no_oneof_field_when_writing(t *testing.T) {
+	// Rebuild via JSON
+	var u Union
+	err := u.UnmarshalJSON([]byte(`{"id": 1}`))
+	assert.ErrorContains(t, err, "oneof body: no field set")
+
+	// Rebuild via object
+	obj := &UnionObject{Id: 1}
+	_, err = obj.Marshal()
+	assert.ErrorContains(t, err, "oneof body: no field set")
+}
+
+func TestUnionWriter__should_return_error_when_multiple_oneof_fields(t *testing.T) {
+	w := NewUnionWriter()
+	w.Number(1)
+	w.Text("hello")
+
+	_, err := w.Build()
+	assert.ErrorContains(t, err, "oneof body: multiple fields set, number and text")
+}
+
+func TestUnionWriter__should_fail_when_setting_second_oneof_field(t *testing.T) {
+	w := NewUnionWriter()
+	w.Id(1)
+	w.Number(1)
+	require.NoError(t, w.Unwrap().Fail(nil))
+
+	w.Text("hello")
+	err := w.Unwrap().Fail(nil)
+	assert.ErrorContains(t, err, "oneof body: multiple fields set, number and text")
+}
+
+func TestUnionWriter__should_return_error_when_no_oneof_field(t *testing.T) {
+	w := NewUnionWriter()
+	w.Id(1)
+
+	_, err := w.Build()
+	assert.ErrorContains(t, err, "oneof body: no field set")
+}
+
+func TestParseUnion__should_return_error_when_multiple_oneof_fields(t *testing.T) {
+	w := spec.NewMessageWriter()
+	w.Field(10).Int64(1)
+	w.Field(11).String("hello")
+
+	b, err := w.Build()
+	require.NoError(t, err)
+
+	_, _, err = ParseUnion(b)
+	assert.Error(t, err)
+}
+
+// Defaults
+
+func TestDefaults__should_return_defaults_when_fields_not_set(t *testing.T) {
+	w := NewDefaultsWriter()
+	w.Plain(1)
+
+	msg, err := w.Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, true, msg.Bool())
+	assert.Equal(t, int32(-100), msg.Int32())
+	assert.Equal(t, uint64(100), msg.Uint64())
+	assert.Equal(t, float32(0.5), msg.Float32())
+	assert.Equal(t, -1.5, msg.Float64())
+	assert.Equal(t, "hello\tworld", msg.String().Unwrap())
+	assert.Equal(t, Enum_One, msg.Enum1())
+	assert.Equal(t, int32(1), msg.Plain())
+}
+
+func TestDefaults__should_return_field_values_when_set(t *testing.T) {
+	w := NewDefaultsWriter()
+	w.Bool(false)
+	w.Int32(0)
+	w.String("")
+	w.Enum1(Enum_Undefined)
+
+	msg, err := w.Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, false, msg.Bool())
+	assert.Equal(t, int32(0), msg.Int32())
+	assert.Equal(t, "", msg.String().Unwrap())
+	assert.Equal(t, Enum_Undefined, msg.Enum1())
+}
+
+func TestDefaultsWriter_SkipDefaults__should_skip_fields_equal_to_defaults(t *testing.T) {
+	w := NewDefaultsWriter().SkipDefaults()
+	w.Bool(true)
+	w.Int32(-100)
+	w.Uint64(100)
+	w.Float32(0.5)
+	w.Float64(-1.5)
+	w.String("hello\tworld")
+	w.Enum1(Enum_One)
+	w.Plain(0)
+
+	msg, err := w.Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, msg.Unwrap().Fields())
+	assert.True(t, msg.HasPlain())
+	assert.False(t, msg.HasInt32())
+	assert.Equal(t, int32(-100), msg.Int32())
+}
+
+// Annotations
+
+func TestDescriptor__should_include_annotations(t *testing.T) {
+	desc := Union{}.Descriptor()
+	version, ok := desc.Annotations.Int("version")
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), version)
+
+	field := desc.Field("id")
+	label, ok := field.Annotations.String("label")
+	assert.True(t, ok)
+	assert.Equal(t, "identifier", label)
+	assert.Equal(t, 0.5, field.Annotations["ratio"])
+
+	assert.True(t, Struct{}.Descriptor().Field("key").Annotations.Bool("primary"))
+	assert.Nil(t, desc.Field("number").Annotations)
+}
+
+// Debug
+
+func TestUnion_String__should_print_field_names_and_values(t *testing.T) {
+	w := NewUnionWriter()
+	w.Id(1)
+	w.Text("hello")
+
+	u, err := w.Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, `Union{id: 1, text: "hello"}`, u.String())
+	assert.Equal(t, `Union{id: 1, text: "hello"}`, fmt.Sprintf("%v", u))
+}
+
+func TestSubmessage_String__should_print_nested_messages(t *testing.T) {
+	w := NewSubmessageWriter()
+	w.Value("a")
+	next := w.Next()
+	next.Value("b")
+	require.NoError(t, next.End())
+
+	sub, err := w.Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, `Submessage{value: "a", next: Submessage{value: "b"}}`, sub.String())
+}
+
+func TestMessage_Format__should_format_message_with_string_field(t *testing.T) {
+	w := NewMessageWriter()
+	w.String("hello")
+	w.Enum1(Enum_One)
+	w.Struct1(Struct{Key: 1, Value: 2})
+
+	ints := w.Ints()
+	ints.Add(1)
+	ints.Add(2)
+	require.NoError(t, ints.End())
+
+	m, err := w.Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, `Message{string: "hello", enum1: ONE, struct1: Struct{key: 1, value: 2}, ints: [1, 2]}`,
+		fmt.Sprintf("%v", m))
+	assert.Equal(t, "[1, 2]", m.Ints().String())
+}
+
+func TestStruct_String__should_print_struct_fields(t *testing.T) {
+	s := Struct{Key: 1, Value: 2}
+	assert.Equal(t, `Struct{key: 1, value: 2}`, s.String())
+
+	c := ComplexStruct{String: "hello"}
+	assert.Contains(t, fmt.Sprintf("%v", c), `string: "hello"`)
+}
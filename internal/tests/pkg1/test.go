@@ -10,15 +10,21 @@ import (
 
 	"github.com/basecomplextech/baselibrary/bin"
 	"github.com/basecomplextech/baselibrary/tests"
+	"github.com/basecomplextech/spec"
+	"github.com/basecomplextech/spec/internal/tests/pkg2"
 	"github.com/basecomplextech/spec/internal/tests/pkg3/pkg3a"
 )
 
-func TestObject(t tests.T) *Object {
-	message := map[uint16]int32{
-		1: 1,
-		2: 2,
-		3: 3,
+func TestObject(t tests.T) *MessageObject {
+	w := spec.NewMessageWriter()
+	for i := 1; i <= 3; i++ {
+		w.Field(uint16(i)).Int32(int32(i))
 	}
+	b, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	message := spec.OpenMessage(b)
 
 	ints := make([]int64, 0, 10)
 	for i := 0; i < 10; i++ {
@@ -40,17 +46,28 @@ func TestObject(t tests.T) *Object {
 		structs = append(structs, s)
 	}
 
-	subObjects := make([]*Subobject, 0, 10)
+	submessages := make([]*SubmessageObject, 0, 10)
+	for i := 0; i < 10; i++ {
+		submessages = append(submessages, TestSubmessageObject(i))
+	}
+
+	submessages1 := make([]*pkg2.SubmessageObject, 0, 10)
 	for i := 0; i < 10; i++ {
-		subObjects = append(subObjects, TestSubobject(i))
+		submessages1 = append(submessages1, TestSubmessageObject1(i))
 	}
 
-	subObjects1 := make([]*Subobject1, 0, 10)
+	counts := make(map[string]int32, 10)
 	for i := 0; i < 10; i++ {
-		subObjects1 = append(subObjects1, TestSubobject1(i))
+		key := fmt.Sprintf("key %03d", i)
+		counts[key] = int32(i)
 	}
 
-	return &Object{
+	submessageMap := make(map[int32]*SubmessageObject, 10)
+	for i := 0; i < 10; i++ {
+		submessageMap[int32(i)] = TestSubmessageObject(i)
+	}
+
+	return &MessageObject{
 		Bool: true,
 		Byte: 255,
 
@@ -73,27 +90,30 @@ func TestObject(t tests.T) *Object {
 		Bytes1:   []byte("goodbye, world"),
 		Message1: message,
 
-		Enum1:      Enum_One,
-		Struct1:    TestStruct(),
-		Subobject:  TestSubobject(0),
-		Subobject1: TestSubobject1(0),
+		Enum1:       Enum_One,
+		Struct1:     TestStruct(),
+		Submessage:  TestSubmessageObject(0),
+		Submessage1: TestSubmessageObject1(0),
+
+		Ints:         ints,
+		Strings:      strings,
+		Structs:      structs,
+		Submessages:  submessages,
+		Submessages1: submessages1,
 
-		Ints:        ints,
-		Strings:     strings,
-		Structs:     structs,
-		Subobjects:  subObjects,
-		Subobjects1: subObjects1,
+		Counts:        counts,
+		SubmessageMap: submessageMap,
 	}
 }
 
-func TestSubobject(i int) *Subobject {
-	return &Subobject{
+func TestSubmessageObject(i int) *SubmessageObject {
+	return &SubmessageObject{
 		Value: fmt.Sprintf("value %03d", i),
 	}
 }
 
-func TestSubobject1(i int) *Subobject1 {
-	return &Subobject1{
+func TestSubmessageObject1(i int) *pkg2.SubmessageObject {
+	return &pkg2.SubmessageObject{
 		Key: fmt.Sprintf("key %03d", i),
 		Value: pkg3a.Value{
 			X: int32(i),
@@ -4,9 +4,15 @@ import (
 
 options (
     go_package="github.com/basecomplextech/spec/internal/tests/pkg2"
+    go_objects="true"
 )
 
 message Submessage {
     key     string      1;
     value   pkg3a.Value 2;
 }
+
+// OldSubmessage is replaced by Submessage.
+message OldSubmessage [deprecated="use Submessage"] {
+    key     string      1;
+}
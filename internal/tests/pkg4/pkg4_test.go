@@ -345,3 +345,40 @@ func TestService_Subservice(t *testing.T) {
 
 	assert.Equal(t, "hello", resp.Unwrap().Msg().Unwrap())
 }
+
+// Deprecated
+
+func TestService_DeprecatedCalls(t *testing.T) {
+	ctx := async.NoContext()
+	logger := logging.TestLogger(t)
+	service := newTestService()
+	server := testServer(t, logger, service)
+	client := testClient(t, logger, server)
+
+	for i := 0; i < 2; i++ {
+		st := client.Method(ctx)
+		if !st.OK() {
+			t.Fatal(st)
+		}
+	}
+
+	w := NewServiceMethod1RequestWriter()
+	w.Msg("hello")
+	req, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	st := client.Method1(ctx, req)
+	if !st.OK() {
+		t.Fatal(st)
+	}
+
+	counter, ok := server.(rpc.DeprecatedCounter)
+	if !ok {
+		t.Fatal("server does not count deprecated calls")
+	}
+
+	calls := counter.DeprecatedCalls()
+	assert.Equal(t, map[string]int64{"method": 2}, calls)
+}
@@ -12,13 +12,13 @@ service Service {
     subservice(id bin128 1) Subservice;
 
     // Method doc comment.
-    method();
+    method() [deprecated=true];
 
     // Method0 is a one-way method.
     method0(msg string 1) oneway;
 
     // Method1 doc comment.
-    method1(msg string 1);
+    method1(msg string 1) [idempotent=true];
 
     // Method2 doc comment.
     method2(a int64 1, b float64 2, c bool 3) (a int64 1, b float64 2, c bool 3);
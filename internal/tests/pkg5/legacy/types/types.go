@@ -0,0 +1,9 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package legacytypes contains custom Go types in a directory with the same name as types,
+// the package name differs from the directory name.
+package legacytypes
+
+type Email string
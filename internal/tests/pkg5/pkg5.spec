@@ -0,0 +1,27 @@
+options (
+    go_package="github.com/basecomplextech/spec/internal/tests/pkg5"
+    go_objects="true"
+)
+
+// Status is mapped onto a Go type in all message fields.
+enum Status [go_type="github.com/basecomplextech/spec/internal/tests/pkg5/types.Status"] {
+    UNKNOWN = 0;
+    ACTIVE  = 1;
+}
+
+// User is a message with custom Go type fields.
+message User {
+    id      bin128  1 [go_type="github.com/basecomplextech/spec/internal/tests/pkg5/types.UserID"];
+    name    string  2 [go_type="github.com/basecomplextech/spec/internal/tests/pkg5/types.Name"];
+    status  Status  3;
+    timeout int64   4 = 100 [go_type="time.Duration"];
+
+    created int64   5 [
+        go_type="time.Time",
+        go_from="github.com/basecomplextech/spec/internal/tests/pkg5/types.TimeFromNanos",
+        go_to="github.com/basecomplextech/spec/internal/tests/pkg5/types.TimeToNanos"
+    ];
+
+    token   string  6 [sensitive=true];
+    email   string  7 [go_type="github.com/basecomplextech/spec/internal/tests/pkg5/legacy/types.Email"];
+}
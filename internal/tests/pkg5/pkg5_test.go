@@ -0,0 +1,98 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package pkg5
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/spec/internal/tests/pkg5/legacy/types"
+	"github.com/basecomplextech/spec/internal/tests/pkg5/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testUser(t *testing.T) User {
+	w := NewUserWriter()
+	w.Id(types.UserID(bin.Int128(1, 2)))
+	w.Name("alice")
+	w.Status(types.Status(Status_Active))
+	w.Timeout(5 * time.Second)
+	w.Created(time.Unix(100, 200).UTC())
+	w.Token("secret")
+	w.Email("alice@example.com")
+
+	user, err := w.Build()
+	require.NoError(t, err)
+	return user
+}
+
+func TestUser__should_read_and_write_custom_go_types(t *testing.T) {
+	user := testUser(t)
+
+	assert.Equal(t, types.UserID(bin.Int128(1, 2)), user.Id())
+	assert.Equal(t, types.Name("alice"), user.Name())
+	assert.Equal(t, types.Status(Status_Active), user.Status())
+	assert.Equal(t, 5*time.Second, user.Timeout())
+	assert.Equal(t, time.Unix(100, 200).UTC(), user.Created())
+	assert.Equal(t, legacytypes.Email("alice@example.com"), user.Email())
+}
+
+func TestUser__should_not_change_wire_format(t *testing.T) {
+	user := testUser(t)
+	msg := user.Unwrap()
+
+	assert.Equal(t, bin.Int128(1, 2), msg.Bin128(1))
+	assert.Equal(t, "alice", msg.String(2).Unwrap())
+	assert.Equal(t, int32(Status_Active), msg.Int32(3))
+	assert.Equal(t, int64(5*time.Second), msg.Int64(4))
+	assert.Equal(t, time.Unix(100, 200).UnixNano(), msg.Int64(5))
+}
+
+func TestUser__should_convert_default_values(t *testing.T) {
+	w := NewUserWriter()
+	user, err := w.Build()
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Duration(100), user.Timeout())
+}
+
+func TestUserObject__should_use_custom_go_types(t *testing.T) {
+	user := testUser(t)
+
+	obj := NewUserObject(user)
+	assert.Equal(t, types.Name("alice"), obj.Name)
+	assert.Equal(t, 5*time.Second, obj.Timeout)
+
+	user1, err := obj.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, user.Unwrap().Raw(), user1.Unwrap().Raw())
+}
+
+func TestUser_String__should_redact_sensitive_fields(t *testing.T) {
+	w := NewUserWriter()
+	w.Name("alice")
+	w.Token("secret")
+
+	user, err := w.Build()
+	require.NoError(t, err)
+
+	s := user.String()
+	assert.Equal(t, `User{name: "alice", token: <redacted>}`, s)
+	assert.NotContains(t, fmt.Sprintf("%v", user), "secret")
+}
+
+func TestUserObject_String__should_redact_sensitive_fields(t *testing.T) {
+	obj := NewUserObject(testUser(t))
+
+	s := obj.String()
+	assert.Contains(t, s, `name: "alice"`)
+	assert.Contains(t, s, `token: <redacted>`)
+	assert.NotContains(t, s, "secret")
+	assert.NotContains(t, fmt.Sprintf("%v", *obj), "secret")
+	assert.NotContains(t, fmt.Sprintf("%+v", obj), "secret")
+}
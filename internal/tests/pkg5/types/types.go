@@ -0,0 +1,26 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package types contains custom Go types for pkg5 message fields.
+package types
+
+import (
+	"time"
+
+	"github.com/basecomplextech/baselibrary/bin"
+)
+
+type (
+	UserID bin.Bin128
+	Name   string
+	Status int32
+)
+
+func TimeFromNanos(v int64) time.Time {
+	return time.Unix(0, v).UTC()
+}
+
+func TimeToNanos(t time.Time) int64 {
+	return t.UnixNano()
+}
@@ -0,0 +1,18 @@
+import (
+    "github.com/basecomplextech/spec/wellknown"
+)
+
+options (
+    go_package="github.com/basecomplextech/spec/internal/tests/pkg6"
+    go_objects="true"
+)
+
+// Order is a message with well-known type fields.
+message Order {
+    id          wellknown.UUID          1;
+    created     wellknown.Timestamp     2;
+    timeout     wellknown.Duration      3;
+    price       wellknown.Decimal       4;
+
+    history     []wellknown.Timestamp   10;
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package pkg6
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/basecomplextech/spec/wellknown"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOrder(t *testing.T) Order {
+	id, err := wellknown.ParseUUID("01234567-89ab-cdef-0123-456789abcdef")
+	require.NoError(t, err)
+
+	w := NewOrderWriter()
+	w.Id(id)
+	w.Created(time.Date(2025, 1, 2, 3, 4, 5, 500_000_000, time.UTC))
+	w.Timeout(1500 * time.Millisecond)
+	w.Price(wellknown.NewDecimal(-1234500, 4))
+
+	history := w.History()
+	history.Add(wellknown.FromTime(time.Unix(0, 0)))
+	require.NoError(t, history.End())
+
+	order, err := w.Build()
+	require.NoError(t, err)
+	return order
+}
+
+func TestOrder__should_map_wellknown_types(t *testing.T) {
+	order := testOrder(t)
+
+	assert.Equal(t, "01234567-89ab-cdef-0123-456789abcdef", order.Id().String())
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 500_000_000, time.UTC), order.Created())
+	assert.Equal(t, 1500*time.Millisecond, order.Timeout())
+	assert.Equal(t, "-123.4500", order.Price().String())
+	assert.Equal(t, time.Unix(0, 0).UTC(), order.History().Get(0).Time())
+}
+
+func TestOrder__should_marshal_canonical_json(t *testing.T) {
+	order := testOrder(t)
+
+	b, err := json.Marshal(order)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "01234567-89ab-cdef-0123-456789abcdef",
+		"created": "2025-01-02T03:04:05.5Z",
+		"timeout": "1.5s",
+		"price": "-123.4500",
+		"history": ["1970-01-01T00:00:00Z"]
+	}`, string(b))
+
+	var order1 Order
+	err = json.Unmarshal(b, &order1)
+	require.NoError(t, err)
+	assert.Equal(t, order.Unwrap().Raw(), order1.Unwrap().Raw())
+}
+
+func TestOrder__should_unmarshal_json_in_any_offset(t *testing.T) {
+	var order Order
+	err := json.Unmarshal([]byte(`{
+		"created": "2025-01-02T05:04:05+02:00",
+		"timeout": "1h30m"
+	}`), &order)
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), order.Created())
+	assert.Equal(t, 90*time.Minute, order.Timeout())
+}
+
+func TestOrder__should_return_error_on_invalid_json(t *testing.T) {
+	var order Order
+	err := json.Unmarshal([]byte(`{"price": "1.2.3"}`), &order)
+	assert.ErrorContains(t, err, "invalid decimal")
+
+	err = json.Unmarshal([]byte(`{"id": {"value": "0000000000000000-0000000000000000"}}`), &order)
+	assert.ErrorContains(t, err, "expected uuid string")
+}
+
+func TestOrderObject__should_use_wellknown_go_types(t *testing.T) {
+	order := testOrder(t)
+
+	obj := NewOrderObject(order)
+	assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 500_000_000, time.UTC), obj.Created)
+	assert.Equal(t, 1500*time.Millisecond, obj.Timeout)
+
+	order1, err := obj.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, order.Unwrap().Raw(), order1.Unwrap().Raw())
+}
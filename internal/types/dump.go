@@ -0,0 +1,289 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package types
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/format"
+)
+
+// dumpMaxBytes is the max number of bytes printed for bytes, strings and structs.
+const dumpMaxBytes = 64
+
+// Dump writes a schema-less tree representation of a value to a writer.
+//
+// Each line contains a value type, its byte range in the input, size, and a decoded value,
+// messages are printed with field tags, lists and maps with element indices. Decoding errors are
+// printed inline with their byte offsets, the method returns the first decoding error.
+func Dump(b []byte, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	d := &dumper{w: bw}
+	d.value(b, 0, 0, "")
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	return d.err
+}
+
+// Format formats a value as a tree for %v and %s verbs, as hex for %x and %X verbs,
+// and as a byte slice for %#v.
+func (v Value) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprintf(f, "%#v", []byte(v))
+	case verb == 'x':
+		fmt.Fprintf(f, "%x", []byte(v))
+	case verb == 'X':
+		fmt.Fprintf(f, "%X", []byte(v))
+	default:
+		Dump(v, f)
+	}
+}
+
+// internal
+
+type dumper struct {
+	w   *bufio.Writer
+	err error // first decoding error
+}
+
+// value dumps a value which ends at the end of b, base is the absolute offset of b.
+func (d *dumper) value(b []byte, base int, depth int, label string) {
+	typ, size, err := decode.DecodeTypeSize(b)
+	if err != nil {
+		d.fail(depth, label, base+len(b)-1, err)
+		return
+	}
+	if size == 0 {
+		d.line(depth, label, "empty")
+		return
+	}
+
+	start := len(b) - size
+	v := b[start:]
+	header := fmt.Sprintf("%v [%d:%d] size=%d", typ, base+start, base+len(b), size)
+
+	switch typ {
+	case format.TypeList, format.TypeBigList:
+		d.list(v, base+start, depth, label, header)
+		return
+	case format.TypeMap, format.TypeBigMap:
+		d.map_(v, base+start, depth, label, header)
+		return
+	case format.TypeMessage, format.TypeBigMessage:
+		d.message(v, base+start, depth, label, header)
+		return
+	}
+
+	s, err := dumpPrimitive(typ, v)
+	if err != nil {
+		d.fail(depth, label, base+len(b)-1, err)
+		return
+	}
+	d.line(depth, label, header+" "+s)
+}
+
+func (d *dumper) list(b []byte, base int, depth int, label string, header string) {
+	table, size, err := decode.DecodeListTable(b)
+	if err != nil {
+		d.fail(depth, label, base+len(b)-1, err)
+		return
+	}
+
+	n := table.Len()
+	d.line(depth, label, fmt.Sprintf("%v len=%d", header, n))
+
+	body := b[len(b)-size:]
+	data := int(table.DataSize())
+
+	for i := 0; i < n; i++ {
+		label1 := fmt.Sprintf("[%d]", i)
+
+		start, end := table.Offset(i)
+		if start < 0 || end > data || start > end {
+			err := fmt.Errorf("decode list: invalid element offset, start=%d, end=%d", start, end)
+			d.fail(depth+1, label1, base, err)
+			continue
+		}
+
+		d.value(body[start:end], base+start, depth+1, label1)
+	}
+}
+
+func (d *dumper) map_(b []byte, base int, depth int, label string, header string) {
+	table, size, err := decode.DecodeMapTable(b)
+	if err != nil {
+		d.fail(depth, label, base+len(b)-1, err)
+		return
+	}
+
+	n := table.Len()
+	d.line(depth, label, fmt.Sprintf("%v len=%d", header, n))
+
+	body := b[len(b)-size:]
+	data := table.DataSize()
+
+	for i := 0; i < n; i++ {
+		entry, ok := table.Entry(i)
+		if !ok {
+			continue
+		}
+
+		label1 := fmt.Sprintf("key[%d]", i)
+		label2 := fmt.Sprintf("value[%d]", i)
+		if entry.KeyOffset > data || entry.Offset > data {
+			err := fmt.Errorf("decode map: invalid entry offset, key=%d, value=%d",
+				entry.KeyOffset, entry.Offset)
+			d.fail(depth+1, label1, base, err)
+			continue
+		}
+
+		d.value(body[:entry.KeyOffset], base, depth+1, label1)
+		d.value(body[:entry.Offset], base, depth+1, label2)
+	}
+}
+
+func (d *dumper) message(b []byte, base int, depth int, label string, header string) {
+	table, size, err := decode.DecodeMessageTable(b)
+	if err != nil {
+		d.fail(depth, label, base+len(b)-1, err)
+		return
+	}
+
+	n := table.Len()
+	d.line(depth, label, fmt.Sprintf("%v fields=%d", header, n))
+
+	body := b[len(b)-size:]
+	data := int(table.DataSize())
+
+	for i := 0; i < n; i++ {
+		field, ok := table.Field(i)
+		if !ok {
+			continue
+		}
+
+		label1 := fmt.Sprintf("field %d", field.Tag)
+		end := int(field.Offset)
+		if end > data {
+			err := fmt.Errorf("decode message: invalid field offset, offset=%d", end)
+			d.fail(depth+1, label1, base, err)
+			continue
+		}
+
+		d.value(body[:end], base, depth+1, label1)
+	}
+}
+
+// line
+
+func (d *dumper) line(depth int, label string, s string) {
+	d.w.WriteString(strings.Repeat("  ", depth))
+	if label != "" {
+		d.w.WriteString(label)
+		d.w.WriteString(": ")
+	}
+	d.w.WriteString(s)
+	d.w.WriteByte('\n')
+}
+
+func (d *dumper) fail(depth int, label string, offset int, err error) {
+	if offset < 0 {
+		offset = 0
+	}
+	if d.err == nil {
+		d.err = fmt.Errorf("offset %d: %w", offset, err)
+	}
+
+	s := fmt.Sprintf("error at offset %d: %v", offset, err)
+	d.line(depth, label, s)
+}
+
+// primitive
+
+func dumpPrimitive(typ format.Type, b []byte) (string, error) {
+	v := Value(b)
+
+	switch typ {
+	case format.TypeTrue:
+		return "value=true", nil
+	case format.TypeFalse:
+		return "value=false", nil
+	case format.TypeByte:
+		p, err := v.ByteErr()
+		return "value=" + strconv.Itoa(int(p)), err
+
+	case format.TypeInt16:
+		p, err := v.Int16Err()
+		return "value=" + strconv.FormatInt(int64(p), 10), err
+	case format.TypeInt32:
+		p, err := v.Int32Err()
+		return "value=" + strconv.FormatInt(int64(p), 10), err
+	case format.TypeInt64:
+		p, err := v.Int64Err()
+		return "value=" + strconv.FormatInt(p, 10), err
+
+	case format.TypeUint16:
+		p, err := v.Uint16Err()
+		return "value=" + strconv.FormatUint(uint64(p), 10), err
+	case format.TypeUint32:
+		p, err := v.Uint32Err()
+		return "value=" + strconv.FormatUint(uint64(p), 10), err
+	case format.TypeUint64:
+		p, err := v.Uint64Err()
+		return "value=" + strconv.FormatUint(p, 10), err
+
+	case format.TypeFloat32:
+		p, err := v.Float32Err()
+		return "value=" + strconv.FormatFloat(float64(p), 'g', -1, 32), err
+	case format.TypeFloat64:
+		p, err := v.Float64Err()
+		return "value=" + strconv.FormatFloat(p, 'g', -1, 64), err
+
+	case format.TypeBin64:
+		p, err := v.Bin64Err()
+		return "value=" + p.String(), err
+	case format.TypeBin128:
+		p, err := v.Bin128Err()
+		return "value=" + p.String(), err
+	case format.TypeBin256:
+		p, err := v.Bin256Err()
+		return "value=" + p.String(), err
+
+	case format.TypeBytes:
+		p, err := v.BytesErr()
+		return fmt.Sprintf("len=%d value=%v", len(p), dumpHex(p)), err
+
+	case format.TypeString:
+		p, err := v.StringErr()
+		s := string(p)
+		if len(s) > dumpMaxBytes {
+			s = s[:dumpMaxBytes]
+			return fmt.Sprintf("len=%d value=%q...", len(p), s), err
+		}
+		return fmt.Sprintf("len=%d value=%q", len(p), s), err
+
+	case format.TypeStruct:
+		dataSize, _, err := decode.DecodeStruct(b)
+		return fmt.Sprintf("data=%d bytes=%v", dataSize, dumpHex(b)), err
+	}
+
+	return "", fmt.Errorf("unsupported type %d", typ)
+}
+
+func dumpHex(b []byte) string {
+	if len(b) <= dumpMaxBytes {
+		return hex.EncodeToString(b)
+	}
+	return hex.EncodeToString(b[:dumpMaxBytes]) + "..."
+}
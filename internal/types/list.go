@@ -33,23 +33,7 @@ func OpenListErr(b []byte) (List, error) {
 
 // ParseList recursively parses and returns a list.
 func ParseList(b []byte) (l List, size int, err error) {
-	l, size, err = decodeList(b)
-	if err != nil {
-		return List{}, 0, err
-	}
-
-	ln := l.Len()
-	for i := 0; i < ln; i++ {
-		b1 := l.GetBytes(i)
-		if len(b1) == 0 {
-			continue
-		}
-
-		if _, _, err = ParseValue(b1); err != nil {
-			return
-		}
-	}
-	return l, size, nil
+	return ParseListOpts(b, ParseOptions{})
 }
 
 func decodeList(b []byte) (l List, size int, err error) {
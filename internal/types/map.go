@@ -0,0 +1,141 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package types
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/format"
+)
+
+// Map is a raw map of entries sorted by keys.
+type Map struct {
+	table format.MapTable
+	bytes []byte
+}
+
+// OpenMap opens and returns a map from bytes, or an empty map on error.
+// The method decodes the map table, but not the entries.
+func OpenMap(b []byte) Map {
+	m, _, _ := decodeMap(b)
+	return m
+}
+
+// OpenMapErr opens and returns a map from bytes, or an error.
+// The method decodes the map table, but not the entries.
+func OpenMapErr(b []byte) (Map, error) {
+	m, _, err := decodeMap(b)
+	return m, err
+}
+
+// ParseMap recursively parses and returns a map.
+func ParseMap(b []byte) (m Map, size int, err error) {
+	return ParseMapOpts(b, ParseOptions{})
+}
+
+func decodeMap(b []byte) (m Map, size int, err error) {
+	table, size, err := decode.DecodeMapTable(b)
+	if err != nil {
+		return Map{}, 0, err
+	}
+	bytes := b[len(b)-size:]
+
+	m = Map{
+		table: table,
+		bytes: bytes,
+	}
+	return m, size, nil
+}
+
+// Len returns the number of entries in the map.
+func (m Map) Len() int {
+	return m.table.Len()
+}
+
+// Empty returns true if bytes are empty or map has no entries.
+func (m Map) Empty() bool {
+	return len(m.bytes) == 0 || m.table.Len() == 0
+}
+
+// Raw returns the underlying map bytes.
+func (m Map) Raw() []byte {
+	return m.bytes
+}
+
+// Entries
+
+// Key returns a key at index i, panics on out of range.
+func (m Map) Key(i int) Value {
+	entry, ok := m.table.Entry(i)
+	if !ok {
+		panic(fmt.Sprintf("index out of range: %d", i))
+	}
+	return m.value(entry.KeyOffset)
+}
+
+// Value returns a value at index i, panics on out of range.
+func (m Map) Value(i int) Value {
+	entry, ok := m.table.Entry(i)
+	if !ok {
+		panic(fmt.Sprintf("index out of range: %d", i))
+	}
+	return m.value(entry.Offset)
+}
+
+// Get returns a value by an encoded key, or false.
+func (m Map) Get(key []byte) (Value, bool) {
+	i := m.Search(func(k Value) (int, error) {
+		return decode.CompareKeys(k, key)
+	})
+	if i < 0 {
+		return nil, false
+	}
+	return m.Value(i), true
+}
+
+// Search binary searches the map and returns an entry index or -1.
+// The compare function must return the result of comparing an entry key with a target key,
+// the method returns -1 when the compare function fails, i.e. on a mismatched key type.
+func (m Map) Search(compare func(key Value) (int, error)) int {
+	left, right := 0, m.table.Len()-1
+	for left <= right {
+		middle := int(uint(left+right) >> 1) // avoid overflow
+
+		c, err := compare(m.Key(middle))
+		switch {
+		case err != nil:
+			return -1
+		case c < 0:
+			left = middle + 1
+		case c > 0:
+			right = middle - 1
+		default:
+			return middle
+		}
+	}
+	return -1
+}
+
+// Clone
+
+// Clone returns a map clone.
+func (m Map) Clone() Map {
+	b := make([]byte, len(m.bytes))
+	copy(b, m.bytes)
+	return OpenMap(b)
+}
+
+// internal
+
+func (m Map) value(end uint32) Value {
+	size := m.table.DataSize()
+	if end > size {
+		return nil
+	}
+
+	b := m.bytes[:end]
+	return OpenValue(b)
+}
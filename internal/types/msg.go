@@ -56,29 +56,7 @@ func OpenMessageErr(b []byte) (Message, error) {
 
 // ParseMessage recursively parses and returns a message.
 func ParseMessage(b []byte) (_ Message, size int, err error) {
-	table, size, err := decode.DecodeMessageTable(b)
-	if err != nil {
-		return Message{}, 0, err
-	}
-	bytes := b[len(b)-size:]
-
-	m := Message{
-		table: table,
-		bytes: bytes,
-	}
-
-	num := m.Fields()
-	for i := 0; i < num; i++ {
-		b1 := m.fieldAt(i)
-		if len(b1) == 0 {
-			continue
-		}
-
-		if _, _, err = ParseValue(b1); err != nil {
-			return
-		}
-	}
-	return m, size, nil
+	return ParseMessageOpts(b, ParseOptions{})
 }
 
 // Empty returns true if bytes are empty or message has no fields.
@@ -435,7 +413,7 @@ func (m Message) StringErr(tag uint16) (format.String, error) {
 	return format.String(p), err
 }
 
-// List/message
+// List/map/message
 
 // List decodes and returns a list or an empty list.
 func (m Message) List(tag uint16) List {
@@ -443,6 +421,12 @@ func (m Message) List(tag uint16) List {
 	return OpenList(b)
 }
 
+// Map decodes and returns a map or an empty map.
+func (m Message) Map(tag uint16) Map {
+	b := m.field(tag)
+	return OpenMap(b)
+}
+
 // Message decodes and returns a message or an empty message.
 func (m Message) Message(tag uint16) Message {
 	b := m.field(tag)
@@ -455,6 +439,12 @@ func (m Message) ListErr(tag uint16) (List, error) {
 	return OpenListErr(b)
 }
 
+// MapErr decodes and returns a map or an error.
+func (m Message) MapErr(tag uint16) (Map, error) {
+	b := m.field(tag)
+	return OpenMapErr(b)
+}
+
 // MessageErr decodes and returns a message or an error.
 func (m Message) MessageErr(tag uint16) (Message, error) {
 	b := m.field(tag)
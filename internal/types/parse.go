@@ -0,0 +1,314 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/basecomplextech/spec/internal/format"
+)
+
+// ErrLimitExceeded is returned when a parsed value exceeds parse limits.
+var ErrLimitExceeded = errors.New("parse limit exceeded")
+
+// ParseOptions specifies limits for recursive parsing, zero values mean no limits.
+type ParseOptions struct {
+	// MaxDepth is a max nesting depth of lists and messages.
+	MaxDepth int `json:"max_depth"`
+
+	// MaxSize is a max total size of a parsed value in bytes.
+	MaxSize int `json:"max_size"`
+
+	// MaxListLen is a max number of elements in a list or entries in a map.
+	MaxListLen int `json:"max_list_len"`
+
+	// MaxMessageFields is a max number of fields in a message.
+	MaxMessageFields int `json:"max_message_fields"`
+
+	// MaxBytesLen is a max length of a string or bytes value.
+	MaxBytesLen int `json:"max_bytes_len"`
+}
+
+// ParseValueOpts recursively parses and returns a value, checks the parse limits.
+func ParseValueOpts(b []byte, opts ParseOptions) (_ Value, n int, err error) {
+	p := parser{opts: opts}
+	if err := p.checkSize(b); err != nil {
+		return nil, 0, err
+	}
+	return p.value(b, 0)
+}
+
+// ParseListOpts recursively parses and returns a list, checks the parse limits.
+func ParseListOpts(b []byte, opts ParseOptions) (_ List, size int, err error) {
+	p := parser{opts: opts}
+	if err := p.checkSize(b); err != nil {
+		return List{}, 0, err
+	}
+	return p.list(b, 0)
+}
+
+// ParseMapOpts recursively parses and returns a map, checks the parse limits.
+func ParseMapOpts(b []byte, opts ParseOptions) (_ Map, size int, err error) {
+	p := parser{opts: opts}
+	if err := p.checkSize(b); err != nil {
+		return Map{}, 0, err
+	}
+	return p.map_(b, 0)
+}
+
+// ParseMessageOpts recursively parses and returns a message, checks the parse limits.
+func ParseMessageOpts(b []byte, opts ParseOptions) (_ Message, size int, err error) {
+	p := parser{opts: opts}
+	if err := p.checkSize(b); err != nil {
+		return Message{}, 0, err
+	}
+	return p.message(b, 0)
+}
+
+// internal
+
+type parser struct {
+	opts ParseOptions
+}
+
+func (p parser) value(b []byte, depth int) (_ Value, n int, err error) {
+	typ, n, err := decode.DecodeType(b)
+	if err != nil {
+		return nil, n, decode.WrapError(err, len(b)-1)
+	}
+
+	switch typ {
+	case format.TypeTrue, format.TypeFalse:
+		// Pass
+
+	case format.TypeByte:
+		_, n, err = decode.DecodeByte(b)
+
+	case format.TypeInt16:
+		_, n, err = decode.DecodeInt16(b)
+	case format.TypeInt32:
+		_, n, err = decode.DecodeInt32(b)
+	case format.TypeInt64:
+		_, n, err = decode.DecodeInt64(b)
+
+	case format.TypeUint16:
+		_, n, err = decode.DecodeUint16(b)
+	case format.TypeUint32:
+		_, n, err = decode.DecodeUint32(b)
+	case format.TypeUint64:
+		_, n, err = decode.DecodeUint64(b)
+
+	case format.TypeBin64:
+		_, n, err = decode.DecodeBin64(b)
+	case format.TypeBin128:
+		_, n, err = decode.DecodeBin128(b)
+	case format.TypeBin256:
+		_, n, err = decode.DecodeBin256(b)
+
+	case format.TypeFloat32:
+		_, n, err = decode.DecodeFloat32(b)
+	case format.TypeFloat64:
+		_, n, err = decode.DecodeFloat64(b)
+
+	case format.TypeBytes:
+		var v format.Bytes
+		v, n, err = decode.DecodeBytes(b)
+		if err == nil {
+			err = p.checkBytesLen("bytes", len(v))
+		}
+	case format.TypeString:
+		var v format.String
+		v, n, err = decode.DecodeString(b)
+		if err == nil {
+			err = p.checkBytesLen("string", len(v))
+		}
+
+	case format.TypeList, format.TypeBigList:
+		_, n, err = p.list(b, depth)
+
+	case format.TypeMap, format.TypeBigMap:
+		_, n, err = p.map_(b, depth)
+
+	case format.TypeMessage, format.TypeBigMessage:
+		_, n, err = p.message(b, depth)
+
+	case format.TypeStruct:
+		_, n, err = decode.DecodeStruct(b)
+
+	default:
+		n, err = 0, &decode.Error{
+			Err:    fmt.Errorf("unsupported type %d", typ),
+			Offset: len(b) - 1,
+			Found:  typ,
+		}
+	}
+	if err != nil {
+		return nil, n, decode.WrapError(err, len(b)-1)
+	}
+
+	return b[len(b)-n:], n, nil
+}
+
+func (p parser) list(b []byte, depth int) (l List, size int, err error) {
+	if err := p.checkDepth(depth); err != nil {
+		return List{}, 0, decode.WrapError(err, len(b)-1)
+	}
+
+	l, size, err = decodeList(b)
+	if err != nil {
+		return List{}, 0, decode.WrapError(err, len(b)-1)
+	}
+
+	ln := l.Len()
+	if max := p.opts.MaxListLen; max > 0 && ln > max {
+		err := fmt.Errorf("parse list: %w, len=%d, max=%d", ErrLimitExceeded, ln, max)
+		return List{}, 0, decode.WrapError(err, len(b)-1)
+	}
+
+	// Element offsets are relative to the list bytes
+	base := len(b) - size
+	data := int(l.table.DataSize())
+
+	for i := 0; i < ln; i++ {
+		start, end := l.table.Offset(i)
+		if end > data || start == end {
+			continue
+		}
+
+		b1 := l.bytes[start:end]
+		if _, _, err := p.value(b1, depth+1); err != nil {
+			return List{}, 0, decode.WrapElementError(err, i, base+start)
+		}
+	}
+	return l, size, nil
+}
+
+func (p parser) map_(b []byte, depth int) (m Map, size int, err error) {
+	if err := p.checkDepth(depth); err != nil {
+		return Map{}, 0, decode.WrapError(err, len(b)-1)
+	}
+
+	m, size, err = decodeMap(b)
+	if err != nil {
+		return Map{}, 0, decode.WrapError(err, len(b)-1)
+	}
+
+	ln := m.Len()
+	if max := p.opts.MaxListLen; max > 0 && ln > max {
+		err := fmt.Errorf("parse map: %w, len=%d, max=%d", ErrLimitExceeded, ln, max)
+		return Map{}, 0, decode.WrapError(err, len(b)-1)
+	}
+
+	// Entry offsets are relative to the map bytes
+	base := len(b) - size
+	data := m.table.DataSize()
+
+	var prev []byte
+	for i := 0; i < ln; i++ {
+		entry, _ := m.table.Entry(i)
+		if entry.KeyOffset > data || entry.Offset > data {
+			err := errors.New("parse map: invalid entry offset")
+			return Map{}, 0, decode.WrapEntryError(decode.WrapError(err, 0), i, base)
+		}
+
+		// Parse key
+		key := m.bytes[:entry.KeyOffset]
+		if _, _, err := p.value(key, depth+1); err != nil {
+			return Map{}, 0, decode.WrapEntryError(err, i, base)
+		}
+		key = OpenValue(key)
+
+		// Check keys are sorted
+		if prev != nil {
+			c, err := decode.CompareKeys(prev, key)
+			switch {
+			case err != nil:
+				return Map{}, 0, decode.WrapEntryError(decode.WrapError(err, len(key)-1), i, base)
+			case c >= 0:
+				err := errors.New("parse map: keys not sorted or not unique")
+				return Map{}, 0, decode.WrapEntryError(decode.WrapError(err, len(key)-1), i, base)
+			}
+		}
+		prev = key
+
+		// Parse value
+		value := m.bytes[:entry.Offset]
+		if len(value) == 0 {
+			continue
+		}
+		if _, _, err := p.value(value, depth+1); err != nil {
+			return Map{}, 0, decode.WrapEntryError(err, i, base)
+		}
+	}
+	return m, size, nil
+}
+
+func (p parser) message(b []byte, depth int) (_ Message, size int, err error) {
+	if err := p.checkDepth(depth); err != nil {
+		return Message{}, 0, decode.WrapError(err, len(b)-1)
+	}
+
+	table, size, err := decode.DecodeMessageTable(b)
+	if err != nil {
+		return Message{}, 0, decode.WrapError(err, len(b)-1)
+	}
+	bytes := b[len(b)-size:]
+
+	m := Message{
+		table: table,
+		bytes: bytes,
+	}
+
+	num := m.Fields()
+	if max := p.opts.MaxMessageFields; max > 0 && num > max {
+		err := fmt.Errorf("parse message: %w, fields=%d, max=%d", ErrLimitExceeded, num, max)
+		return Message{}, 0, decode.WrapError(err, len(b)-1)
+	}
+
+	// Field offsets are relative to the message bytes
+	base := len(b) - size
+
+	for i := 0; i < num; i++ {
+		b1 := m.fieldAt(i)
+		if len(b1) == 0 {
+			continue
+		}
+
+		if _, _, err := p.value(b1, depth+1); err != nil {
+			tag, _ := m.TagAt(i)
+			return Message{}, 0, decode.WrapFieldError(err, tag, base)
+		}
+	}
+	return m, size, nil
+}
+
+// limits
+
+func (p parser) checkSize(b []byte) error {
+	max := p.opts.MaxSize
+	if max <= 0 || len(b) <= max {
+		return nil
+	}
+	err := fmt.Errorf("parse: %w, size=%d, max=%d", ErrLimitExceeded, len(b), max)
+	return decode.WrapError(err, len(b)-1)
+}
+
+func (p parser) checkDepth(depth int) error {
+	max := p.opts.MaxDepth
+	if max <= 0 || depth < max {
+		return nil
+	}
+	return fmt.Errorf("parse: %w, depth=%d, max=%d", ErrLimitExceeded, depth+1, max)
+}
+
+func (p parser) checkBytesLen(name string, n int) error {
+	max := p.opts.MaxBytesLen
+	if max <= 0 || n <= max {
+		return nil
+	}
+	return fmt.Errorf("parse %v: %w, len=%d, max=%d", name, ErrLimitExceeded, n, max)
+}
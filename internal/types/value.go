@@ -5,8 +5,6 @@
 package types
 
 import (
-	"fmt"
-
 	"github.com/basecomplextech/baselibrary/bin"
 	"github.com/basecomplextech/spec/internal/decode"
 	"github.com/basecomplextech/spec/internal/format"
@@ -47,66 +45,7 @@ func OpenValueErr(b []byte) (Value, error) {
 
 // ParseValue recursively parses and returns a value.
 func ParseValue(b []byte) (_ Value, n int, err error) {
-	typ, n, err := decode.DecodeType(b)
-	if err != nil {
-		return
-	}
-
-	switch typ {
-	case format.TypeTrue, format.TypeFalse:
-		// Pass
-
-	case format.TypeByte:
-		_, n, err = decode.DecodeByte(b)
-
-	case format.TypeInt16:
-		_, n, err = decode.DecodeInt16(b)
-	case format.TypeInt32:
-		_, n, err = decode.DecodeInt32(b)
-	case format.TypeInt64:
-		_, n, err = decode.DecodeInt64(b)
-
-	case format.TypeUint16:
-		_, n, err = decode.DecodeUint16(b)
-	case format.TypeUint32:
-		_, n, err = decode.DecodeUint32(b)
-	case format.TypeUint64:
-		_, n, err = decode.DecodeUint64(b)
-
-	case format.TypeBin64:
-		_, n, err = decode.DecodeBin64(b)
-	case format.TypeBin128:
-		_, n, err = decode.DecodeBin128(b)
-	case format.TypeBin256:
-		_, n, err = decode.DecodeBin256(b)
-
-	case format.TypeFloat32:
-		_, n, err = decode.DecodeFloat32(b)
-	case format.TypeFloat64:
-		_, n, err = decode.DecodeFloat64(b)
-
-	case format.TypeBytes:
-		_, n, err = decode.DecodeBytes(b)
-	case format.TypeString:
-		_, n, err = decode.DecodeString(b)
-
-	case format.TypeList, format.TypeBigList:
-		_, n, err = ParseList(b)
-
-	case format.TypeMessage, format.TypeBigMessage:
-		_, n, err = ParseMessage(b)
-
-	case format.TypeStruct:
-		_, n, err = decode.DecodeStruct(b)
-
-	default:
-		n, err = 0, fmt.Errorf("unsupported type %d", typ)
-	}
-	if err != nil {
-		return nil, n, err
-	}
-
-	return b[len(b)-n:], n, nil
+	return ParseValueOpts(b, ParseOptions{})
 }
 
 // Types
@@ -319,6 +258,16 @@ func (v Value) ListErr() (List, error) {
 	return OpenListErr(v)
 }
 
+// Map decodes and returns a map or an empty map.
+func (v Value) Map() Map {
+	return OpenMap(v)
+}
+
+// MapErr decodes and returns a map or an error.
+func (v Value) MapErr() (Map, error) {
+	return OpenMapErr(v)
+}
+
 // Message decodes and returns a message or an empty message.
 func (v Value) Message() Message {
 	return OpenMessage(v)
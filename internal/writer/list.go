@@ -165,13 +165,18 @@ func (l ListWriter) String(v string) error {
 	return l.w.element()
 }
 
-// List/message
+// List/map/message
 
 func (l ListWriter) List() ListWriter {
 	l.w.beginElement()
 	return l.w.List()
 }
 
+func (l ListWriter) Map() MapWriter {
+	l.w.beginElement()
+	return l.w.Map()
+}
+
 func (l ListWriter) Message() MessageWriter {
 	l.w.beginElement()
 	return l.w.Message()
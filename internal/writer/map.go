@@ -0,0 +1,198 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package writer
+
+import "github.com/basecomplextech/baselibrary/bin"
+
+// MapWriter writes a map of entries.
+//
+// Keys and values are written alternately, i.e. key0, value0, key1, value1, etc.
+// Entries are sorted by keys on end, duplicate keys are an error.
+type MapWriter struct {
+	w *writer
+}
+
+// Err returns the current write error.
+func (m MapWriter) Err() error {
+	return m.w.err
+}
+
+// Len returns the number of written entries.
+// The method is only valid when there is no pending entry.
+func (m MapWriter) Len() int {
+	return m.w.mapLen()
+}
+
+// Build ends the map and returns its bytes.
+func (m MapWriter) Build() ([]byte, error) {
+	return m.w.end()
+}
+
+// End ends the map.
+func (m MapWriter) End() error {
+	_, err := m.w.end()
+	return err
+}
+
+// WriteEntry writes a generic map entry using the given write functions.
+func WriteEntry[K, V any](w MapWriter, key K, value V, writeKey WriteFunc[K], writeValue WriteFunc[V]) error {
+	if err := WriteKey(w, key, writeKey); err != nil {
+		return err
+	}
+	if err := WriteValue(w.w, value, writeValue); err != nil {
+		return err
+	}
+	return w.w.element()
+}
+
+// WriteKey writes a generic map key using the given write function.
+// The key must be followed by a value.
+func WriteKey[K any](w MapWriter, key K, write WriteFunc[K]) error {
+	if err := WriteValue(w.w, key, write); err != nil {
+		return err
+	}
+	return w.w.element()
+}
+
+// Keys/values
+
+func (m MapWriter) Any(v []byte) error {
+	if err := m.w.Value().Any(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+func (m MapWriter) Bool(v bool) error {
+	if err := m.w.Value().Bool(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+func (m MapWriter) Byte(v byte) error {
+	if err := m.w.Value().Byte(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+// Int
+
+func (m MapWriter) Int16(v int16) error {
+	if err := m.w.Value().Int16(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+func (m MapWriter) Int32(v int32) error {
+	if err := m.w.Value().Int32(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+func (m MapWriter) Int64(v int64) error {
+	if err := m.w.Value().Int64(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+// Uint
+
+func (m MapWriter) Uint16(v uint16) error {
+	if err := m.w.Value().Uint16(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+func (m MapWriter) Uint32(v uint32) error {
+	if err := m.w.Value().Uint32(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+func (m MapWriter) Uint64(v uint64) error {
+	if err := m.w.Value().Uint64(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+// Float
+
+func (m MapWriter) Float32(v float32) error {
+	if err := m.w.Value().Float32(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+func (m MapWriter) Float64(v float64) error {
+	if err := m.w.Value().Float64(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+// Bin
+
+func (m MapWriter) Bin64(v bin.Bin64) error {
+	if err := m.w.Value().Bin64(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+func (m MapWriter) Bin128(v bin.Bin128) error {
+	if err := m.w.Value().Bin128(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+func (m MapWriter) Bin256(v bin.Bin256) error {
+	if err := m.w.Value().Bin256(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+// Bytes/string
+
+func (m MapWriter) Bytes(v []byte) error {
+	if err := m.w.Value().Bytes(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+func (m MapWriter) String(v string) error {
+	if err := m.w.Value().String(v); err != nil {
+		return err
+	}
+	return m.w.element()
+}
+
+// List/map/message
+
+func (m MapWriter) List() ListWriter {
+	m.w.beginElement()
+	return m.w.List()
+}
+
+func (m MapWriter) Map() MapWriter {
+	m.w.beginElement()
+	return m.w.Map()
+}
+
+func (m MapWriter) Message() MessageWriter {
+	m.w.beginElement()
+	return m.w.Message()
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package writer
+
+import (
+	"testing"
+
+	"github.com/basecomplextech/spec/internal/decode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapWriter__should_write_map_sorted_by_keys(t *testing.T) {
+	w := testWriter()
+
+	m := w.Map()
+	m.String("c")
+	m.Int32(3)
+	m.String("a")
+	m.Int32(1)
+	m.String("b")
+	m.Int32(2)
+	assert.Equal(t, 3, m.Len())
+
+	b, err := m.Build()
+	require.NoError(t, err)
+
+	table, n, err := decode.DecodeMapTable(b)
+	require.NoError(t, err)
+	assert.Equal(t, len(b), n)
+	require.Equal(t, 3, table.Len())
+
+	body := b[len(b)-n:]
+	for i, expected := range []string{"a", "b", "c"} {
+		entry, ok := table.Entry(i)
+		require.True(t, ok)
+
+		key, _, err := decode.DecodeString(body[:entry.KeyOffset])
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(key))
+
+		value, _, err := decode.DecodeInt32(body[:entry.Offset])
+		require.NoError(t, err)
+		assert.Equal(t, int32(i+1), value)
+	}
+}
+
+func TestMapWriter__should_write_message_values(t *testing.T) {
+	w := testWriter()
+
+	m := w.Map()
+	m.Int64(2)
+	msg := m.Message()
+	msg.Field(1).String("two")
+	require.NoError(t, msg.End())
+
+	m.Int64(1)
+	msg = m.Message()
+	msg.Field(1).String("one")
+	require.NoError(t, msg.End())
+
+	b, err := m.Build()
+	require.NoError(t, err)
+
+	table, _, err := decode.DecodeMapTable(b)
+	require.NoError(t, err)
+	assert.Equal(t, 2, table.Len())
+}
+
+func TestMapWriter__should_return_error_on_duplicate_keys(t *testing.T) {
+	w := testWriter()
+
+	m := w.Map()
+	m.Int32(1)
+	m.String("a")
+	m.Int32(1)
+	m.String("b")
+
+	_, err := m.Build()
+	assert.Error(t, err)
+}
+
+func TestMapWriter__should_return_error_on_key_without_value(t *testing.T) {
+	w := testWriter()
+
+	m := w.Map()
+	m.Int32(1)
+
+	_, err := m.Build()
+	assert.Error(t, err)
+}
@@ -25,6 +25,14 @@ func (m MessageWriter) HasField(field uint16) bool {
 	return m.w.hasField(field)
 }
 
+// Fail fails the writer with an error and returns it, subsequent writes return the error.
+func (m MessageWriter) Fail(err error) error {
+	if err == nil {
+		return m.w.err
+	}
+	return m.w.fail(err)
+}
+
 // Copy copies absent fields from the given message.
 func (m MessageWriter) Copy(src types.Message) error {
 	n := src.Fields()
@@ -214,13 +222,18 @@ func (f FieldWriter) String(v string) error {
 	return f.w.field(f.tag)
 }
 
-// List/message
+// List/map/message
 
 func (f FieldWriter) List() ListWriter {
 	f.w.beginField(f.tag)
 	return f.w.List()
 }
 
+func (f FieldWriter) Map() MapWriter {
+	f.w.beginField(f.tag)
+	return f.w.Map()
+}
+
 func (f FieldWriter) Message() MessageWriter {
 	f.w.beginField(f.tag)
 	return f.w.Message()
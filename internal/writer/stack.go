@@ -13,6 +13,7 @@ const (
 	entryElement
 	entryMessage
 	entryField
+	entryMap
 )
 
 type stackEntry struct {
@@ -114,6 +115,15 @@ func (s *stack) pushMessage(start int, tableStart int) {
 	s.stack = append(s.stack, e)
 }
 
+func (s *stack) pushMap(start int, tableStart int) {
+	e := stackEntry{
+		type_:      entryMap,
+		start:      start,
+		tableStart: tableStart,
+	}
+	s.stack = append(s.stack, e)
+}
+
 func (s *stack) pushField(start int, tag uint16) {
 	e := stackEntry{
 		type_:      entryField,
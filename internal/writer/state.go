@@ -17,7 +17,7 @@ type writerState struct {
 	releaseWriter bool // whether to release the writer on close
 
 	stack    stack
-	elements listStack    // buffer for list element tables
+	elements listStack    // buffer for list element and map entry tables
 	fields   messageStack // buffer for message field tables
 
 	// Preallocated
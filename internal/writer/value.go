@@ -234,12 +234,16 @@ func (w ValueWriter) String(v string) error {
 	return w.w.pushData(start, end)
 }
 
-// List/Message
+// List/Map/Message
 
 func (w ValueWriter) List() ListWriter {
 	return w.w.List()
 }
 
+func (w ValueWriter) Map() MapWriter {
+	return w.w.Map()
+}
+
 func (w ValueWriter) Message() MessageWriter {
 	return w.w.Message()
 }
@@ -7,6 +7,7 @@ package writer
 import (
 	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/basecomplextech/baselibrary/buffer"
 	"github.com/basecomplextech/baselibrary/pools"
@@ -28,6 +29,9 @@ type Writer interface {
 	// List begins a new list and returns a list writer.
 	List() ListWriter
 
+	// Map begins a new map and returns a map writer.
+	Map() MapWriter
+
 	// Value returns a value writer.
 	Value() ValueWriter
 
@@ -115,6 +119,12 @@ func (w *writer) List() ListWriter {
 	return ListWriter{w}
 }
 
+// Map begins a new map and returns a map writer.
+func (w *writer) Map() MapWriter {
+	w.beginMap()
+	return MapWriter{w}
+}
+
 // Value returns a value writer.
 func (w *writer) Value() ValueWriter {
 	return ValueWriter{w}
@@ -168,6 +178,12 @@ func (w *writer) end() (result []byte, err error) {
 			return nil, err
 		}
 
+	case entryMap:
+		result, err = w.endMap()
+		if err != nil {
+			return nil, err
+		}
+
 	default:
 		return nil, w.failf("end: cannot end object, invalid entry type: %v", entry.type_)
 	}
@@ -236,12 +252,12 @@ func (w *writer) beginElement() error {
 		return w.err
 	}
 
-	// Check list
+	// Check list or map
 	list, ok := w.stack.peek()
 	switch {
 	case !ok:
 		return w.failf("begin element: cannot begin element, parent not list")
-	case list.type_ != entryList:
+	case list.type_ != entryList && list.type_ != entryMap:
 		return w.failf("begin element: cannot begin element, parent not list")
 	}
 
@@ -262,12 +278,12 @@ func (w *writer) element() error {
 		return w.fail(err)
 	}
 
-	// Check list
+	// Check list or map
 	list, ok := w.stack.peek()
 	switch {
 	case !ok:
 		return w.failf("element: cannot encode element, parent not list")
-	case list.type_ != entryList:
+	case list.type_ != entryList && list.type_ != entryMap:
 		return w.failf("element: cannot encode element, parent not list")
 	}
 
@@ -316,12 +332,12 @@ func (w *writer) endElement() ([]byte, error) {
 		return nil, w.failf("end element: not element")
 	}
 
-	// Check list
+	// Check list or map
 	list, ok := w.stack.peek()
 	switch {
 	case !ok:
 		return nil, w.failf("end element: parent not list")
-	case list.type_ != entryList:
+	case list.type_ != entryList && list.type_ != entryMap:
 		return nil, w.failf("end element: parent not list")
 	}
 
@@ -371,6 +387,89 @@ func (w *writer) endList() ([]byte, error) {
 	return b, nil
 }
 
+// map
+
+func (w *writer) beginMap() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	// Push map, keys and values are stored as list elements
+	start := w.buf.Len()
+	tableStart := w.elements.offset()
+
+	w.stack.pushMap(start, tableStart)
+	return nil
+}
+
+func (w *writer) mapLen() int {
+	if w.err != nil {
+		return 0
+	}
+
+	// Check map
+	m, ok := w.stack.peek()
+	switch {
+	case !ok:
+		return 0
+	case m.type_ != entryMap:
+		return 0
+	}
+
+	return w.elements.len(m.tableStart) / 2
+}
+
+func (w *writer) endMap() ([]byte, error) {
+	if w.err != nil {
+		return nil, w.err
+	}
+
+	// Pop map
+	m, ok := w.stack.pop()
+	switch {
+	case !ok:
+		return nil, w.failf("end map: not map")
+	case m.type_ != entryMap:
+		return nil, w.failf("end map: not map")
+	}
+
+	bodySize := w.buf.Len() - m.start
+	elements := w.elements.pop(m.tableStart)
+	if len(elements)%2 != 0 {
+		return nil, w.failf("end map: key without value")
+	}
+
+	// Make map entries from the key/value table
+	entries0 := mapEntriesPool.New()
+	defer releaseMapEntries(entries0)
+
+	*entries0 = appendMapEntries(*entries0, elements)
+	entries := *entries0
+
+	// Sort entries by keys
+	body := w.buf.Bytes()[m.start:]
+	if err := sortMapEntries(body, entries); err != nil {
+		return nil, w.fail(err)
+	}
+
+	// Encode map
+	if _, err := encode.EncodeMapTable(w.buf, bodySize, entries); err != nil {
+		return nil, w.fail(err)
+	}
+
+	// Push data entry
+	start := m.start
+	end := w.buf.Len()
+	if err := w.pushData(start, end); err != nil {
+		return nil, err
+	}
+
+	// Return data
+	b := w.buf.Bytes()
+	b = b[start:end]
+	return b, nil
+}
+
 // message
 
 func (w *writer) beginMessage() error {
@@ -653,8 +752,62 @@ func (w *writer) reset() {
 	w.err = nil
 }
 
+// util
+
+// appendMapEntries appends map entries from alternating key/value elements.
+func appendMapEntries(entries []format.MapEntry, elements []format.ListElement) []format.MapEntry {
+	for i := 0; i < len(elements); i += 2 {
+		entry := format.MapEntry{
+			KeyOffset: elements[i].Offset,
+			Offset:    elements[i+1].Offset,
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// sortMapEntries sorts map entries by keys, returns an error on duplicate keys.
+func sortMapEntries(body []byte, entries []format.MapEntry) error {
+	var err error
+	slices.SortFunc(entries, func(a, b format.MapEntry) int {
+		c, err1 := decode.CompareKeys(body[:a.KeyOffset], body[:b.KeyOffset])
+		if err1 != nil && err == nil {
+			err = err1
+		}
+		return c
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := 1; i < len(entries); i++ {
+		prev := body[:entries[i-1].KeyOffset]
+		key := body[:entries[i].KeyOffset]
+
+		c, err := decode.CompareKeys(prev, key)
+		if err != nil {
+			return err
+		}
+		if c == 0 {
+			return errors.New("end map: duplicate key")
+		}
+	}
+	return nil
+}
+
 // pool
 
+var mapEntriesPool = pools.NewPoolFunc(
+	func() *[]format.MapEntry {
+		return &[]format.MapEntry{}
+	},
+)
+
+func releaseMapEntries(entries *[]format.MapEntry) {
+	*entries = (*entries)[:0]
+	mapEntriesPool.Put(entries)
+}
+
 var writerPool = pools.NewPoolFunc(
 	func() *writer {
 		s := acquireWriterState()
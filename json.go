@@ -0,0 +1,539 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Canonical JSON
+//
+// Messages and structs are encoded as objects with field names in the schema order,
+// absent message fields are omitted. Enums are encoded by their names, bin64/bin128/bin256
+// as hex strings, bytes as base64 strings, NaN and infinite floats as "NaN", "Infinity"
+// and "-Infinity" strings.
+//
+// Schema-less values (any and message fields) are encoded using their embedded types.
+// Any values are encoded as {"type": "int32", "value": 123} objects, any messages
+// as objects with field tags as keys and any values as values, any maps as arrays
+// of {"key": any, "value": any} objects.
+//
+// Maps are encoded as objects with keys in the map order, non-string keys are quoted.
+//
+// Well-known structs are encoded as strings, see json_wellknown.go.
+
+// MarshalMessageJSON returns a canonical JSON representation of a message.
+func MarshalMessageJSON(desc *MessageDescriptor, msg Message) ([]byte, error) {
+	e := jsonEncoder{}
+	if err := e.message(desc, msg); err != nil {
+		return nil, err
+	}
+	return e.b, nil
+}
+
+// MarshalValueJSON returns a schema-less JSON representation of a value.
+func MarshalValueJSON(v Value) ([]byte, error) {
+	e := jsonEncoder{}
+	if err := e.any(v); err != nil {
+		return nil, err
+	}
+	return e.b, nil
+}
+
+// MarshalEnumJSON returns an enum value name as a JSON string, or its number if unknown.
+func MarshalEnumJSON(desc *EnumDescriptor, v int32) ([]byte, error) {
+	e := jsonEncoder{}
+	e.enum(desc, v)
+	return e.b, nil
+}
+
+// internal
+
+type jsonEncoder struct {
+	b []byte
+}
+
+func (e *jsonEncoder) message(desc *MessageDescriptor, msg Message) error {
+	if desc == nil {
+		return e.anyMessage(msg)
+	}
+
+	e.b = append(e.b, '{')
+	first := true
+
+	for _, field := range desc.Fields {
+		v := msg.Field(field.Tag)
+		if v == nil {
+			continue
+		}
+
+		if !first {
+			e.b = append(e.b, ',')
+		}
+		first = false
+
+		e.string(field.Name)
+		e.b = append(e.b, ':')
+
+		if err := e.value(field.Type, v); err != nil {
+			return fmt.Errorf("%v.%v: %w", desc.Name, field.Name, err)
+		}
+	}
+
+	e.b = append(e.b, '}')
+	return nil
+}
+
+func (e *jsonEncoder) value(typ *TypeDescriptor, v Value) error {
+	switch typ.Kind {
+	case KindAny:
+		return e.any(v)
+
+	case KindBool:
+		b, err := v.BoolErr()
+		if err != nil {
+			return err
+		}
+		e.b = strconv.AppendBool(e.b, b)
+
+	case KindByte:
+		b, err := v.ByteErr()
+		if err != nil {
+			return err
+		}
+		e.b = strconv.AppendUint(e.b, uint64(b), 10)
+
+	case KindInt16:
+		i, err := v.Int16Err()
+		if err != nil {
+			return err
+		}
+		e.b = strconv.AppendInt(e.b, int64(i), 10)
+
+	case KindInt32:
+		i, err := v.Int32Err()
+		if err != nil {
+			return err
+		}
+		e.b = strconv.AppendInt(e.b, int64(i), 10)
+
+	case KindInt64:
+		i, err := v.Int64Err()
+		if err != nil {
+			return err
+		}
+		e.b = strconv.AppendInt(e.b, i, 10)
+
+	case KindUint16:
+		u, err := v.Uint16Err()
+		if err != nil {
+			return err
+		}
+		e.b = strconv.AppendUint(e.b, uint64(u), 10)
+
+	case KindUint32:
+		u, err := v.Uint32Err()
+		if err != nil {
+			return err
+		}
+		e.b = strconv.AppendUint(e.b, uint64(u), 10)
+
+	case KindUint64:
+		u, err := v.Uint64Err()
+		if err != nil {
+			return err
+		}
+		e.b = strconv.AppendUint(e.b, u, 10)
+
+	case KindBin64:
+		b, err := v.Bin64Err()
+		if err != nil {
+			return err
+		}
+		e.string(b.String())
+
+	case KindBin128:
+		b, err := v.Bin128Err()
+		if err != nil {
+			return err
+		}
+		e.string(b.String())
+
+	case KindBin256:
+		b, err := v.Bin256Err()
+		if err != nil {
+			return err
+		}
+		e.string(b.String())
+
+	case KindFloat32:
+		f, err := v.Float32Err()
+		if err != nil {
+			return err
+		}
+		e.float(float64(f), 32)
+
+	case KindFloat64:
+		f, err := v.Float64Err()
+		if err != nil {
+			return err
+		}
+		e.float(f, 64)
+
+	case KindBytes:
+		b, err := v.BytesErr()
+		if err != nil {
+			return err
+		}
+		e.bytes(b)
+
+	case KindString:
+		s, err := v.StringErr()
+		if err != nil {
+			return err
+		}
+		e.string(s.Unwrap())
+
+	case KindAnyMessage:
+		msg, err := v.MessageErr()
+		if err != nil {
+			return err
+		}
+		return e.anyMessage(msg)
+
+	case KindList:
+		return e.list(typ.Element, v)
+
+	case KindMap:
+		return e.map_(typ, v)
+
+	case KindEnum:
+		i, err := v.Int32Err()
+		if err != nil {
+			return err
+		}
+		e.enum(typ.Enum, i)
+
+	case KindMessage:
+		msg, err := v.MessageErr()
+		if err != nil {
+			return err
+		}
+		return e.message(typ.Message, msg)
+
+	case KindStruct:
+		return e.struct_(typ.Struct, v)
+
+	default:
+		return fmt.Errorf("unsupported kind %v", typ.Kind)
+	}
+	return nil
+}
+
+func (e *jsonEncoder) list(elem *TypeDescriptor, v Value) error {
+	list, err := v.ListErr()
+	if err != nil {
+		return err
+	}
+
+	e.b = append(e.b, '[')
+	for i := 0; i < list.Len(); i++ {
+		if i > 0 {
+			e.b = append(e.b, ',')
+		}
+
+		v1 := list.Get(i)
+		if err := e.value(elem, v1); err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+	}
+	e.b = append(e.b, ']')
+	return nil
+}
+
+func (e *jsonEncoder) map_(typ *TypeDescriptor, v Value) error {
+	m, err := v.MapErr()
+	if err != nil {
+		return err
+	}
+
+	e.b = append(e.b, '{')
+	for i := 0; i < m.Len(); i++ {
+		if i > 0 {
+			e.b = append(e.b, ',')
+		}
+
+		// Quote non-string keys
+		start := len(e.b)
+		if err := e.value(typ.Key, m.Key(i)); err != nil {
+			return fmt.Errorf("map[%d]: %w", i, err)
+		}
+		if e.b[start] != '"' {
+			e.b = append(e.b, 0)
+			copy(e.b[start+1:], e.b[start:])
+			e.b[start] = '"'
+			e.b = append(e.b, '"')
+		}
+		e.b = append(e.b, ':')
+
+		if err := e.value(typ.Element, m.Value(i)); err != nil {
+			return fmt.Errorf("map[%d]: %w", i, err)
+		}
+	}
+	e.b = append(e.b, '}')
+	return nil
+}
+
+func (e *jsonEncoder) struct_(desc *StructDescriptor, v Value) error {
+	if desc == nil {
+		e.bytes(v)
+		return nil
+	}
+
+	values, err := structFieldValues(desc, v)
+	if err != nil {
+		return err
+	}
+
+	if kind, ok := jsonWellknown(desc); ok {
+		return e.wellknown(desc, kind, values)
+	}
+
+	e.b = append(e.b, '{')
+	for i, field := range desc.Fields {
+		if i > 0 {
+			e.b = append(e.b, ',')
+		}
+
+		e.string(field.Name)
+		e.b = append(e.b, ':')
+
+		if err := e.value(field.Type, values[i]); err != nil {
+			return fmt.Errorf("%v.%v: %w", desc.Name, field.Name, err)
+		}
+	}
+	e.b = append(e.b, '}')
+	return nil
+}
+
+func (e *jsonEncoder) enum(desc *EnumDescriptor, v int32) {
+	if desc != nil {
+		if val := desc.ValueByNumber(v); val != nil {
+			e.string(val.Name)
+			return
+		}
+	}
+
+	e.b = strconv.AppendInt(e.b, int64(v), 10)
+}
+
+// any
+
+func (e *jsonEncoder) any(v Value) error {
+	typ, _, err := DecodeType(v)
+	if err != nil {
+		return err
+	}
+
+	e.b = append(e.b, `{"type":`...)
+	e.string(jsonTypeName(typ))
+	e.b = append(e.b, `,"value":`...)
+
+	switch typ {
+	case TypeTrue, TypeFalse:
+		err = e.value(jsonBuiltin[KindBool], v)
+	case TypeByte:
+		err = e.value(jsonBuiltin[KindByte], v)
+
+	case TypeInt16:
+		err = e.value(jsonBuiltin[KindInt16], v)
+	case TypeInt32:
+		err = e.value(jsonBuiltin[KindInt32], v)
+	case TypeInt64:
+		err = e.value(jsonBuiltin[KindInt64], v)
+
+	case TypeUint16:
+		err = e.value(jsonBuiltin[KindUint16], v)
+	case TypeUint32:
+		err = e.value(jsonBuiltin[KindUint32], v)
+	case TypeUint64:
+		err = e.value(jsonBuiltin[KindUint64], v)
+
+	case TypeBin64:
+		err = e.value(jsonBuiltin[KindBin64], v)
+	case TypeBin128:
+		err = e.value(jsonBuiltin[KindBin128], v)
+	case TypeBin256:
+		err = e.value(jsonBuiltin[KindBin256], v)
+
+	case TypeFloat32:
+		err = e.value(jsonBuiltin[KindFloat32], v)
+	case TypeFloat64:
+		err = e.value(jsonBuiltin[KindFloat64], v)
+
+	case TypeBytes:
+		err = e.value(jsonBuiltin[KindBytes], v)
+	case TypeString:
+		err = e.value(jsonBuiltin[KindString], v)
+
+	case TypeList, TypeBigList:
+		err = e.list(jsonBuiltin[KindAny], v)
+
+	case TypeMap, TypeBigMap:
+		err = e.anyMap(v)
+
+	case TypeMessage, TypeBigMessage:
+		err = e.value(jsonBuiltin[KindAnyMessage], v)
+
+	case TypeStruct:
+		e.bytes(v)
+
+	default:
+		err = fmt.Errorf("unsupported type %v", typ)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.b = append(e.b, '}')
+	return nil
+}
+
+func (e *jsonEncoder) anyMessage(msg Message) error {
+	e.b = append(e.b, '{')
+
+	n := msg.Fields()
+	first := true
+	for i := 0; i < n; i++ {
+		tag, ok := msg.TagAt(i)
+		if !ok {
+			continue
+		}
+
+		if !first {
+			e.b = append(e.b, ',')
+		}
+		first = false
+
+		e.b = append(e.b, '"')
+		e.b = strconv.AppendUint(e.b, uint64(tag), 10)
+		e.b = append(e.b, '"', ':')
+
+		v := msg.FieldAt(i)
+		if err := e.any(v); err != nil {
+			return fmt.Errorf("%d: %w", tag, err)
+		}
+	}
+
+	e.b = append(e.b, '}')
+	return nil
+}
+
+func (e *jsonEncoder) anyMap(v Value) error {
+	m, err := v.MapErr()
+	if err != nil {
+		return err
+	}
+
+	e.b = append(e.b, '[')
+	for i := 0; i < m.Len(); i++ {
+		if i > 0 {
+			e.b = append(e.b, ',')
+		}
+
+		e.b = append(e.b, `{"key":`...)
+		if err := e.any(m.Key(i)); err != nil {
+			return fmt.Errorf("map[%d]: %w", i, err)
+		}
+		e.b = append(e.b, `,"value":`...)
+		if err := e.any(m.Value(i)); err != nil {
+			return fmt.Errorf("map[%d]: %w", i, err)
+		}
+		e.b = append(e.b, '}')
+	}
+	e.b = append(e.b, ']')
+	return nil
+}
+
+// primitives
+
+func (e *jsonEncoder) string(s string) {
+	b, _ := json.Marshal(s)
+	e.b = append(e.b, b...)
+}
+
+func (e *jsonEncoder) bytes(b []byte) {
+	e.b = append(e.b, '"')
+	e.b = base64.StdEncoding.AppendEncode(e.b, b)
+	e.b = append(e.b, '"')
+}
+
+func (e *jsonEncoder) float(f float64, bits int) {
+	switch {
+	case math.IsNaN(f):
+		e.string("NaN")
+	case math.IsInf(f, 1):
+		e.string("Infinity")
+	case math.IsInf(f, -1):
+		e.string("-Infinity")
+	default:
+		e.b = strconv.AppendFloat(e.b, f, 'g', -1, bits)
+	}
+}
+
+// util
+
+// structFieldValues decodes struct field values in the schema order.
+func structFieldValues(desc *StructDescriptor, v Value) ([]Value, error) {
+	dataSize, size, err := DecodeStruct(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode fields in reverse order
+	b := v[len(v)-size:]
+	off := len(b) - (size - dataSize)
+
+	values := make([]Value, len(desc.Fields))
+	for i := len(desc.Fields) - 1; i >= 0; i-- {
+		v1, err := OpenValueErr(b[:off])
+		if err != nil {
+			return nil, err
+		}
+
+		values[i] = v1
+		off -= len(v1)
+	}
+	return values, nil
+}
+
+var jsonBuiltin = func() map[Kind]*TypeDescriptor {
+	m := make(map[Kind]*TypeDescriptor)
+	for k := KindAny; k <= KindAnyMessage; k++ {
+		m[k] = &TypeDescriptor{Kind: k, Name: k.String()}
+	}
+	return m
+}()
+
+// jsonTypeName returns a type name for schema-less any values.
+func jsonTypeName(typ Type) string {
+	switch typ {
+	case TypeTrue, TypeFalse:
+		return "bool"
+	case TypeByte:
+		return "byte"
+	case TypeList, TypeBigList:
+		return "list"
+	case TypeMap, TypeBigMap:
+		return "map"
+	case TypeMessage, TypeBigMessage:
+		return "message"
+	}
+	return typ.String()
+}
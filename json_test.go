@@ -0,0 +1,125 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"math"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/stretchr/testify/assert"
+)
+
+func testJSONDescriptor() *MessageDescriptor {
+	return &MessageDescriptor{
+		Name: "Test",
+		Fields: []*FieldDescriptor{
+			{Name: "float", Tag: 1, Type: &TypeDescriptor{Kind: KindFloat64, Name: "float64"}},
+			{Name: "any", Tag: 2, Type: &TypeDescriptor{Kind: KindAny, Name: "any"}},
+			{Name: "message", Tag: 3, Type: &TypeDescriptor{Kind: KindAnyMessage, Name: "message"}},
+		},
+	}
+}
+
+func TestMarshalMessageJSON__should_marshal_any_values_with_types(t *testing.T) {
+	w := NewMessageWriter()
+	w.Field(1).Float64(math.Inf(-1))
+
+	list := w.Field(2).List()
+	list.Int64(1)
+	list.Bin64(bin.Int64(2))
+	if err := list.End(); err != nil {
+		t.Fatal(err)
+	}
+
+	sub := w.Field(3).Message()
+	sub.Field(1).String("hello")
+	sub.Field(2).Bool(true)
+	if err := sub.End(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := OpenMessage(b)
+
+	desc := testJSONDescriptor()
+	js, err := MarshalMessageJSON(desc, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{"float":"-Infinity",` +
+		`"any":{"type":"list","value":[{"type":"int64","value":1},{"type":"bin64","value":"0000000000000002"}]},` +
+		`"message":{"1":{"type":"string","value":"hello"},"2":{"type":"bool","value":true}}}`
+	assert.Equal(t, expected, string(js))
+}
+
+func TestWriteMessageJSON__should_write_any_values_with_types(t *testing.T) {
+	desc := testJSONDescriptor()
+	js := `{"float":"NaN",` +
+		`"any":{"type":"list","value":[{"type":"int64","value":1},{"type":"uint16","value":2}]},` +
+		`"message":{"2":{"type":"bool","value":true},"1":{"type":"string","value":"hello"}}}`
+
+	w := NewMessageWriter()
+	if err := WriteMessageJSON(w, desc, []byte(js)); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := OpenMessage(b)
+
+	assert.True(t, math.IsNaN(msg.Float64(1)))
+
+	list := msg.List(2)
+	assert.Equal(t, 2, list.Len())
+	assert.Equal(t, TypeInt64, list.Get(0).Type())
+	assert.Equal(t, uint16(2), list.Get(1).Uint16())
+
+	sub := msg.Message(3)
+	assert.Equal(t, "hello", sub.String(1).Unwrap())
+	assert.Equal(t, true, sub.Bool(2))
+}
+
+func TestWriteMessageJSON__should_return_error_on_invalid_value(t *testing.T) {
+	desc := testJSONDescriptor()
+
+	w := NewMessageWriter()
+	err := WriteMessageJSON(w, desc, []byte(`{"float": "hello"}`))
+	assert.Error(t, err)
+}
+
+func TestWriteMessageJSON__should_write_any_message_tags_with_leading_zeros(t *testing.T) {
+	desc := testJSONDescriptor()
+	js := `{"message":{"007":{"type":"string","value":"hello"}}}`
+
+	w := NewMessageWriter()
+	if err := WriteMessageJSON(w, desc, []byte(js)); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := OpenMessage(b)
+
+	sub := msg.Message(3)
+	assert.Equal(t, "hello", sub.String(7).Unwrap())
+}
+
+func TestWriteMessageJSON__should_return_error_on_duplicate_any_message_tags(t *testing.T) {
+	desc := testJSONDescriptor()
+	js := `{"message":{"7":{"type":"int32","value":1},"07":{"type":"int32","value":2}}}`
+
+	w := NewMessageWriter()
+	err := WriteMessageJSON(w, desc, []byte(js))
+	assert.ErrorContains(t, err, "duplicate message field tag 7")
+}
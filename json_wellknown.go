@@ -0,0 +1,161 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/basecomplextech/spec/internal/canonical"
+)
+
+// Well-known types
+//
+// Structs with a wellknown annotation are encoded in canonical JSON as strings in their
+// canonical text forms, see the wellknown package. Known kinds and their struct fields:
+//
+//	timestamp  seconds int64, nanos int32
+//	duration   nanos int64
+//	decimal    value int64, scale int32
+//	uuid       value bin128
+
+const annotationWellknown = "wellknown"
+
+// jsonWellknown returns a well-known kind of a struct or false.
+func jsonWellknown(desc *StructDescriptor) (string, bool) {
+	if desc == nil {
+		return "", false
+	}
+	return desc.Annotations.String(annotationWellknown)
+}
+
+// encode
+
+func (e *jsonEncoder) wellknown(desc *StructDescriptor, kind string, values []Value) error {
+	s, err := jsonFormatWellknown(kind, values)
+	if err != nil {
+		return fmt.Errorf("%v: %w", desc.Name, err)
+	}
+
+	e.string(s)
+	return nil
+}
+
+func jsonFormatWellknown(kind string, values []Value) (string, error) {
+	switch kind {
+	case "timestamp":
+		if len(values) != 2 {
+			break
+		}
+		seconds, err := values[0].Int64Err()
+		if err != nil {
+			return "", err
+		}
+		nanos, err := values[1].Int32Err()
+		if err != nil {
+			return "", err
+		}
+		return canonical.FormatTimestamp(seconds, nanos), nil
+
+	case "duration":
+		if len(values) != 1 {
+			break
+		}
+		nanos, err := values[0].Int64Err()
+		if err != nil {
+			return "", err
+		}
+		return canonical.FormatDuration(nanos), nil
+
+	case "decimal":
+		if len(values) != 2 {
+			break
+		}
+		value, err := values[0].Int64Err()
+		if err != nil {
+			return "", err
+		}
+		scale, err := values[1].Int32Err()
+		if err != nil {
+			return "", err
+		}
+		return canonical.FormatDecimal(value, scale), nil
+
+	case "uuid":
+		if len(values) != 1 {
+			break
+		}
+		v, err := values[0].Bin128Err()
+		if err != nil {
+			return "", err
+		}
+		return canonical.FormatUUID(v), nil
+
+	default:
+		return "", fmt.Errorf("unknown well-known type %q", kind)
+	}
+
+	return "", fmt.Errorf("invalid %v struct fields", kind)
+}
+
+// decode
+
+// jsonParseWellknown parses a canonical string into a struct object
+// with fields in their JSON forms.
+func jsonParseWellknown(desc *StructDescriptor, kind string, v any) (map[string]any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid %v value, expected %v string, got %T", desc.Name, kind, v)
+	}
+
+	var values []any
+	switch kind {
+	case "timestamp":
+		seconds, nanos, err := canonical.ParseTimestamp(s)
+		if err != nil {
+			return nil, err
+		}
+		values = []any{jsonNumber(seconds), jsonNumber(int64(nanos))}
+
+	case "duration":
+		nanos, err := canonical.ParseDuration(s)
+		if err != nil {
+			return nil, err
+		}
+		values = []any{jsonNumber(nanos)}
+
+	case "decimal":
+		value, scale, err := canonical.ParseDecimal(s)
+		if err != nil {
+			return nil, err
+		}
+		values = []any{jsonNumber(value), jsonNumber(int64(scale))}
+
+	case "uuid":
+		u, err := canonical.ParseUUID(s)
+		if err != nil {
+			return nil, err
+		}
+		values = []any{u.String()}
+
+	default:
+		return nil, fmt.Errorf("%v: unknown well-known type %q", desc.Name, kind)
+	}
+
+	if len(values) != len(desc.Fields) {
+		return nil, fmt.Errorf("%v: invalid %v struct fields", desc.Name, kind)
+	}
+
+	obj := make(map[string]any, len(values))
+	for i, field := range desc.Fields {
+		obj[field.Name] = values[i]
+	}
+	return obj, nil
+}
+
+func jsonNumber(v int64) json.Number {
+	return json.Number(strconv.FormatInt(v, 10))
+}
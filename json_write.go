@@ -0,0 +1,673 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+)
+
+// WriteMessageJSON parses a canonical JSON object and writes its fields into a message writer,
+// see [MarshalMessageJSON]. The method does not end the message.
+func WriteMessageJSON(w MessageWriter, desc *MessageDescriptor, b []byte) error {
+	obj, err := jsonDecodeObject(b)
+	if err != nil {
+		return err
+	}
+	return jsonWriteFields(w, desc, obj)
+}
+
+// UnmarshalEnumJSON parses an enum value from a JSON name or number.
+func UnmarshalEnumJSON(desc *EnumDescriptor, b []byte) (int32, error) {
+	v, err := jsonDecode(b)
+	if err != nil {
+		return 0, err
+	}
+	return jsonEnum(desc, v)
+}
+
+// internal
+
+func jsonWriteFields(w MessageWriter, desc *MessageDescriptor, obj map[string]any) error {
+	if desc == nil {
+		return jsonWriteAnyFields(w, obj)
+	}
+
+	for _, field := range desc.Fields {
+		v, ok := obj[field.Name]
+		if !ok || v == nil {
+			continue
+		}
+
+		fw := w.Field(field.Tag)
+		if err := jsonWriteValue(fw, field.Type, v); err != nil {
+			return fmt.Errorf("%v.%v: %w", desc.Name, field.Name, err)
+		}
+	}
+
+	for name := range obj {
+		if desc.Field(name) == nil {
+			return fmt.Errorf("%v: unknown field %q", desc.Name, name)
+		}
+	}
+	return nil
+}
+
+func jsonWriteValue(w valueWriter, typ *TypeDescriptor, v any) error {
+	switch typ.Kind {
+	case KindAny:
+		return jsonWriteAny(w, v)
+
+	case KindBool:
+		b, ok := v.(bool)
+		if !ok {
+			return jsonInvalid(typ, v)
+		}
+		return w.Bool(b)
+
+	case KindByte:
+		u, err := jsonUint(v, 8)
+		if err != nil {
+			return err
+		}
+		return w.Byte(byte(u))
+
+	case KindInt16:
+		i, err := jsonInt(v, 16)
+		if err != nil {
+			return err
+		}
+		return w.Int16(int16(i))
+
+	case KindInt32:
+		i, err := jsonInt(v, 32)
+		if err != nil {
+			return err
+		}
+		return w.Int32(int32(i))
+
+	case KindInt64:
+		i, err := jsonInt(v, 64)
+		if err != nil {
+			return err
+		}
+		return w.Int64(i)
+
+	case KindUint16:
+		u, err := jsonUint(v, 16)
+		if err != nil {
+			return err
+		}
+		return w.Uint16(uint16(u))
+
+	case KindUint32:
+		u, err := jsonUint(v, 32)
+		if err != nil {
+			return err
+		}
+		return w.Uint32(uint32(u))
+
+	case KindUint64:
+		u, err := jsonUint(v, 64)
+		if err != nil {
+			return err
+		}
+		return w.Uint64(u)
+
+	case KindBin64:
+		s, ok := v.(string)
+		if !ok {
+			return jsonInvalid(typ, v)
+		}
+		b, err := bin.ParseString64(s)
+		if err != nil {
+			return err
+		}
+		return w.Bin64(b)
+
+	case KindBin128:
+		s, ok := v.(string)
+		if !ok {
+			return jsonInvalid(typ, v)
+		}
+		b, err := bin.ParseString128(s)
+		if err != nil {
+			return err
+		}
+		return w.Bin128(b)
+
+	case KindBin256:
+		s, ok := v.(string)
+		if !ok {
+			return jsonInvalid(typ, v)
+		}
+		b, err := bin.ParseString256(s)
+		if err != nil {
+			return err
+		}
+		return w.Bin256(b)
+
+	case KindFloat32:
+		f, err := jsonFloat(v, 32)
+		if err != nil {
+			return err
+		}
+		return w.Float32(float32(f))
+
+	case KindFloat64:
+		f, err := jsonFloat(v, 64)
+		if err != nil {
+			return err
+		}
+		return w.Float64(f)
+
+	case KindBytes:
+		b, err := jsonBytes(v)
+		if err != nil {
+			return err
+		}
+		return w.Bytes(b)
+
+	case KindString:
+		s, ok := v.(string)
+		if !ok {
+			return jsonInvalid(typ, v)
+		}
+		return w.String(s)
+
+	case KindAnyMessage:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return jsonInvalid(typ, v)
+		}
+
+		mw := w.Message()
+		if err := jsonWriteAnyFields(mw, obj); err != nil {
+			return err
+		}
+		return mw.End()
+
+	case KindList:
+		arr, ok := v.([]any)
+		if !ok {
+			return jsonInvalid(typ, v)
+		}
+
+		lw := w.List()
+		for i, v1 := range arr {
+			if err := jsonWriteValue(lw, typ.Element, v1); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		return lw.End()
+
+	case KindMap:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return jsonInvalid(typ, v)
+		}
+
+		mw := w.Map()
+		for key, v1 := range obj {
+			if err := jsonWriteValue(mw, typ.Key, jsonMapKey(typ.Key, key)); err != nil {
+				return fmt.Errorf("map[%q]: %w", key, err)
+			}
+			if err := jsonWriteValue(mw, typ.Element, v1); err != nil {
+				return fmt.Errorf("map[%q]: %w", key, err)
+			}
+		}
+		return mw.End()
+
+	case KindEnum:
+		i, err := jsonEnum(typ.Enum, v)
+		if err != nil {
+			return err
+		}
+		return w.Int32(i)
+
+	case KindMessage:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return jsonInvalid(typ, v)
+		}
+
+		mw := w.Message()
+		if err := jsonWriteFields(mw, typ.Message, obj); err != nil {
+			return err
+		}
+		return mw.End()
+
+	case KindStruct:
+		b, err := jsonEncodeStruct(typ.Struct, v)
+		if err != nil {
+			return err
+		}
+		return w.Any(b)
+	}
+
+	return fmt.Errorf("unsupported kind %v", typ.Kind)
+}
+
+// any
+
+func jsonWriteAny(w valueWriter, v any) error {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return fmt.Errorf("invalid any value, expected object with type and value, got %T", v)
+	}
+
+	name, ok := obj["type"].(string)
+	if !ok {
+		return fmt.Errorf("invalid any value, missing type")
+	}
+	v1, ok := obj["value"]
+	if !ok {
+		return fmt.Errorf("invalid any value, missing value")
+	}
+
+	switch name {
+	case "list":
+		arr, ok := v1.([]any)
+		if !ok {
+			return fmt.Errorf("invalid any list, got %T", v1)
+		}
+
+		lw := w.List()
+		for i, v2 := range arr {
+			if err := jsonWriteAny(lw, v2); err != nil {
+				return fmt.Errorf("[%d]: %w", i, err)
+			}
+		}
+		return lw.End()
+
+	case "map":
+		arr, ok := v1.([]any)
+		if !ok {
+			return fmt.Errorf("invalid any map, got %T", v1)
+		}
+
+		mw := w.Map()
+		for i, v2 := range arr {
+			entry, ok := v2.(map[string]any)
+			if !ok {
+				return fmt.Errorf("map[%d]: invalid any map entry, got %T", i, v2)
+			}
+			if err := jsonWriteAny(mw, entry["key"]); err != nil {
+				return fmt.Errorf("map[%d]: %w", i, err)
+			}
+			if err := jsonWriteAny(mw, entry["value"]); err != nil {
+				return fmt.Errorf("map[%d]: %w", i, err)
+			}
+		}
+		return mw.End()
+
+	case "struct":
+		b, err := jsonBytes(v1)
+		if err != nil {
+			return err
+		}
+		return w.Any(b)
+	}
+
+	kind, ok := jsonAnyKinds[name]
+	if !ok {
+		return fmt.Errorf("invalid any value, unknown type %q", name)
+	}
+	return jsonWriteValue(w, jsonBuiltin[kind], v1)
+}
+
+func jsonWriteAnyFields(w MessageWriter, obj map[string]any) error {
+	keys := make(map[uint16]string, len(obj)) // tags to original keys, i.e. "007"
+	tags := make([]uint16, 0, len(obj))
+
+	for key := range obj {
+		tag, err := strconv.ParseUint(key, 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid message field tag %q", key)
+		}
+
+		tag1 := uint16(tag)
+		if key1, ok := keys[tag1]; ok {
+			return fmt.Errorf("duplicate message field tag %d, %q and %q", tag1, key1, key)
+		}
+
+		keys[tag1] = key
+		tags = append(tags, tag1)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
+
+	for _, tag := range tags {
+		v := obj[keys[tag]]
+		if v == nil {
+			continue
+		}
+
+		fw := w.Field(tag)
+		if err := jsonWriteAny(fw, v); err != nil {
+			return fmt.Errorf("%d: %w", tag, err)
+		}
+	}
+	return nil
+}
+
+var jsonAnyKinds = map[string]Kind{
+	"bool": KindBool,
+	"byte": KindByte,
+
+	"int16": KindInt16,
+	"int32": KindInt32,
+	"int64": KindInt64,
+
+	"uint16": KindUint16,
+	"uint32": KindUint32,
+	"uint64": KindUint64,
+
+	"bin64":  KindBin64,
+	"bin128": KindBin128,
+	"bin256": KindBin256,
+
+	"float32": KindFloat32,
+	"float64": KindFloat64,
+
+	"bytes":   KindBytes,
+	"string":  KindString,
+	"message": KindAnyMessage,
+}
+
+// struct
+
+func jsonEncodeStruct(desc *StructDescriptor, v any) ([]byte, error) {
+	if desc == nil {
+		return jsonBytes(v)
+	}
+
+	var obj map[string]any
+	if kind, ok := jsonWellknown(desc); ok {
+		var err error
+		obj, err = jsonParseWellknown(desc, kind, v)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		obj, ok = v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid %v value, expected object, got %T", desc.Name, v)
+		}
+	}
+
+	buf := buffer.New()
+	dataSize := 0
+
+	for _, field := range desc.Fields {
+		v1 := obj[field.Name]
+
+		n, err := jsonEncodeStructField(buf, field.Type, v1)
+		if err != nil {
+			return nil, fmt.Errorf("%v.%v: %w", desc.Name, field.Name, err)
+		}
+		dataSize += n
+	}
+
+	if _, err := EncodeStruct(buf, dataSize); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonEncodeStructField encodes a struct field, absent fields are encoded as zero values.
+func jsonEncodeStructField(b buffer.Buffer, typ *TypeDescriptor, v any) (int, error) {
+	switch typ.Kind {
+	case KindBool:
+		b1, _ := v.(bool)
+		return EncodeBool(b, b1)
+	case KindByte:
+		u, err := jsonUintOrZero(v, 8)
+		if err != nil {
+			return 0, err
+		}
+		return EncodeByte(b, byte(u))
+
+	case KindInt16:
+		i, err := jsonIntOrZero(v, 16)
+		if err != nil {
+			return 0, err
+		}
+		return EncodeInt16(b, int16(i))
+	case KindInt32:
+		i, err := jsonIntOrZero(v, 32)
+		if err != nil {
+			return 0, err
+		}
+		return EncodeInt32(b, int32(i))
+	case KindInt64:
+		i, err := jsonIntOrZero(v, 64)
+		if err != nil {
+			return 0, err
+		}
+		return EncodeInt64(b, i)
+
+	case KindUint16:
+		u, err := jsonUintOrZero(v, 16)
+		if err != nil {
+			return 0, err
+		}
+		return EncodeUint16(b, uint16(u))
+	case KindUint32:
+		u, err := jsonUintOrZero(v, 32)
+		if err != nil {
+			return 0, err
+		}
+		return EncodeUint32(b, uint32(u))
+	case KindUint64:
+		u, err := jsonUintOrZero(v, 64)
+		if err != nil {
+			return 0, err
+		}
+		return EncodeUint64(b, u)
+
+	case KindFloat32:
+		if v == nil {
+			return EncodeFloat32(b, 0)
+		}
+		f, err := jsonFloat(v, 32)
+		if err != nil {
+			return 0, err
+		}
+		return EncodeFloat32(b, float32(f))
+	case KindFloat64:
+		if v == nil {
+			return EncodeFloat64(b, 0)
+		}
+		f, err := jsonFloat(v, 64)
+		if err != nil {
+			return 0, err
+		}
+		return EncodeFloat64(b, f)
+
+	case KindBin64:
+		var b1 bin.Bin64
+		if s, ok := v.(string); ok {
+			var err error
+			if b1, err = bin.ParseString64(s); err != nil {
+				return 0, err
+			}
+		}
+		return EncodeBin64(b, b1)
+	case KindBin128:
+		var b1 bin.Bin128
+		if s, ok := v.(string); ok {
+			var err error
+			if b1, err = bin.ParseString128(s); err != nil {
+				return 0, err
+			}
+		}
+		return EncodeBin128(b, b1)
+	case KindBin256:
+		var b1 bin.Bin256
+		if s, ok := v.(string); ok {
+			var err error
+			if b1, err = bin.ParseString256(s); err != nil {
+				return 0, err
+			}
+		}
+		return EncodeBin256(b, b1)
+
+	case KindBytes:
+		var p []byte
+		if v != nil {
+			var err error
+			if p, err = jsonBytes(v); err != nil {
+				return 0, err
+			}
+		}
+		return EncodeBytes(b, p)
+	case KindString:
+		s, _ := v.(string)
+		return EncodeString(b, s)
+
+	case KindEnum:
+		var i int32
+		if v != nil {
+			var err error
+			if i, err = jsonEnum(typ.Enum, v); err != nil {
+				return 0, err
+			}
+		}
+		return EncodeInt32(b, i)
+
+	case KindStruct:
+		p, err := jsonEncodeStruct(typ.Struct, v)
+		if err != nil {
+			return 0, err
+		}
+		b.Write(p)
+		return len(p), nil
+	}
+
+	return 0, fmt.Errorf("unsupported struct field kind %v", typ.Kind)
+}
+
+// decode
+
+func jsonDecode(b []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func jsonDecodeObject(b []byte) (map[string]any, error) {
+	v, err := jsonDecode(b)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("invalid message, expected object, got %T", v)
+	}
+	return obj, nil
+}
+
+// primitives
+
+func jsonInt(v any, bits int) (int64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("invalid int%d, got %T", bits, v)
+	}
+	return strconv.ParseInt(n.String(), 10, bits)
+}
+
+func jsonIntOrZero(v any, bits int) (int64, error) {
+	if v == nil {
+		return 0, nil
+	}
+	return jsonInt(v, bits)
+}
+
+func jsonUint(v any, bits int) (uint64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("invalid uint%d, got %T", bits, v)
+	}
+	return strconv.ParseUint(n.String(), 10, bits)
+}
+
+func jsonUintOrZero(v any, bits int) (uint64, error) {
+	if v == nil {
+		return 0, nil
+	}
+	return jsonUint(v, bits)
+}
+
+func jsonFloat(v any, bits int) (float64, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return strconv.ParseFloat(v.String(), bits)
+
+	case string:
+		switch v {
+		case "NaN":
+			return math.NaN(), nil
+		case "Infinity":
+			return math.Inf(1), nil
+		case "-Infinity":
+			return math.Inf(-1), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid float%d, got %v", bits, v)
+}
+
+func jsonBytes(v any) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid bytes, expected base64 string, got %T", v)
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+func jsonEnum(desc *EnumDescriptor, v any) (int32, error) {
+	switch v := v.(type) {
+	case json.Number:
+		i, err := strconv.ParseInt(v.String(), 10, 32)
+		return int32(i), err
+
+	case string:
+		if desc != nil {
+			if val := desc.Value(v); val != nil {
+				return val.Number, nil
+			}
+		}
+		return 0, fmt.Errorf("unknown enum value %q", v)
+	}
+	return 0, fmt.Errorf("invalid enum value, got %T", v)
+}
+
+// jsonMapKey converts a quoted JSON object key into a number for integer key types.
+func jsonMapKey(typ *TypeDescriptor, key string) any {
+	switch typ.Kind {
+	case KindInt16, KindInt32, KindInt64,
+		KindUint16, KindUint32, KindUint64:
+		return json.Number(key)
+	}
+	return key
+}
+
+func jsonInvalid(typ *TypeDescriptor, v any) error {
+	return fmt.Errorf("invalid %v value, got %T", typ.Name, v)
+}
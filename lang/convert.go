@@ -0,0 +1,410 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec"
+	"github.com/basecomplextech/spec/internal/lang/model"
+	"github.com/basecomplextech/spec/internal/lang/syntax"
+)
+
+// converter converts compiled internal packages into public read-only packages.
+type converter struct {
+	pkgs   map[*model.Package]*Package
+	defs   map[*model.Definition]*Definition
+	values map[*model.EnumValue]*EnumValue
+}
+
+func newConverter() *converter {
+	return &converter{
+		pkgs:   make(map[*model.Package]*Package),
+		defs:   make(map[*model.Definition]*Definition),
+		values: make(map[*model.EnumValue]*EnumValue),
+	}
+}
+
+// package
+
+func (c *converter) pkg(mpkg *model.Package) *Package {
+	if pkg, ok := c.pkgs[mpkg]; ok {
+		return pkg
+	}
+
+	pkg := &Package{
+		ID:       mpkg.ID,
+		Name:     mpkg.Name,
+		Path:     mpkg.Path,
+		Warnings: append([]string(nil), mpkg.Warnings...),
+
+		defs: make(map[string]*Definition),
+	}
+	c.pkgs[mpkg] = pkg
+
+	// Make files and definitions first, types can reference any of them
+	for _, mfile := range mpkg.Files {
+		file := c.file(pkg, mfile)
+		pkg.Files = append(pkg.Files, file)
+		pkg.Options = append(pkg.Options, file.Options...)
+	}
+
+	// Make imports, compile imported packages
+	for i, mfile := range mpkg.Files {
+		file := pkg.Files[i]
+
+		for _, mimp := range mfile.Imports {
+			imp := &Import{
+				File:    file,
+				ID:      mimp.ID,
+				Name:    mimp.Name,
+				Package: c.pkg(mimp.Package),
+				Pos:     position(mfile, mimp.Pos),
+			}
+			file.Imports = append(file.Imports, imp)
+		}
+	}
+
+	// Fill enums first, field defaults can reference enum values
+	for _, mfile := range mpkg.Files {
+		for _, mdef := range mfile.Definitions {
+			if mdef.Type == model.DefinitionEnum {
+				c.fill(mdef)
+			}
+		}
+	}
+	for _, mfile := range mpkg.Files {
+		for _, mdef := range mfile.Definitions {
+			if mdef.Type != model.DefinitionEnum {
+				c.fill(mdef)
+			}
+		}
+	}
+	return pkg
+}
+
+func (c *converter) file(pkg *Package, mfile *model.File) *File {
+	file := &File{
+		Package: pkg,
+		Name:    mfile.Name,
+		Path:    mfile.Path,
+	}
+
+	for _, mopt := range mfile.Options {
+		opt := &Option{
+			Name:  mopt.Name,
+			Value: mopt.Value,
+			Pos:   position(mfile, mopt.Pos),
+		}
+		file.Options = append(file.Options, opt)
+	}
+
+	for _, mdef := range mfile.Definitions {
+		def := &Definition{
+			Package: pkg,
+			File:    file,
+
+			Name: mdef.Name,
+			Type: DefinitionType(mdef.Type),
+			Doc:  mdef.Doc,
+			Pos:  position(mfile, mdef.Pos),
+
+			Generated: mdef.Message != nil && mdef.Message.Generated,
+
+			Annotations: annotations(mdef.Annotations),
+		}
+		c.defs[mdef] = def
+
+		file.Definitions = append(file.Definitions, def)
+		pkg.Definitions = append(pkg.Definitions, def)
+		pkg.defs[def.Name] = def
+	}
+	return file
+}
+
+// definitions
+
+func (c *converter) fill(mdef *model.Definition) {
+	def := c.defs[mdef]
+
+	switch mdef.Type {
+	case model.DefinitionEnum:
+		def.Enum = c.enum(def, mdef.Enum)
+	case model.DefinitionMessage:
+		def.Message = c.message(def, mdef.Message)
+	case model.DefinitionStruct:
+		def.Struct = c.struct_(def, mdef.Struct)
+	case model.DefinitionService:
+		def.Service = c.service(def, mdef.Service)
+	default:
+		panic(fmt.Sprintf("unsupported definition type %q", mdef.Type))
+	}
+}
+
+func (c *converter) enum(def *Definition, menum *model.Enum) *Enum {
+	e := &Enum{
+		Def:      def,
+		Reserved: reserved(menum.Reserved),
+	}
+
+	for _, mval := range menum.Values {
+		val := &EnumValue{
+			Enum: e,
+
+			Name:   mval.Name,
+			Number: mval.Number,
+			Doc:    mval.Doc,
+			Pos:    position(menum.File, mval.Pos),
+
+			Annotations: annotations(mval.Annotations),
+		}
+		c.values[mval] = val
+
+		e.Values = append(e.Values, val)
+	}
+	return e
+}
+
+func (c *converter) message(def *Definition, mmsg *model.Message) *Message {
+	msg := &Message{
+		Def:      def,
+		Reserved: reserved(mmsg.Reserved),
+	}
+
+	fields := make(map[*model.Field]*Field, len(mmsg.Fields.List))
+	for _, mfield := range mmsg.Fields.List {
+		field := &Field{
+			Message: msg,
+
+			Name: mfield.Name,
+			Tag:  mfield.Tag,
+			Type: c.type_(mfield.Type),
+			Doc:  mfield.Doc,
+			Pos:  position(mmsg.File, mfield.Pos),
+
+			Annotations: annotations(mfield.Annotations),
+		}
+		if d := mfield.Default; d != nil {
+			field.Default = c.default_(d)
+		}
+
+		fields[mfield] = field
+		msg.Fields = append(msg.Fields, field)
+	}
+
+	for _, moneof := range mmsg.Oneofs {
+		oneof := &Oneof{
+			Name: moneof.Name,
+			Doc:  moneof.Doc,
+			Pos:  position(mmsg.File, moneof.Pos),
+		}
+
+		for _, mfield := range moneof.Fields {
+			field := fields[mfield]
+			field.Oneof = oneof
+			oneof.Fields = append(oneof.Fields, field)
+		}
+		msg.Oneofs = append(msg.Oneofs, oneof)
+	}
+	return msg
+}
+
+func (c *converter) default_(d *model.Default) *Default {
+	value := d.Value
+	if mval, ok := value.(*model.EnumValue); ok {
+		value = c.values[mval]
+	}
+
+	return &Default{
+		Text:  d.Text,
+		Value: value,
+	}
+}
+
+func (c *converter) struct_(def *Definition, mstr *model.Struct) *Struct {
+	str := &Struct{Def: def}
+
+	for _, mfield := range mstr.Fields.Values() {
+		field := &StructField{
+			Struct: str,
+
+			Name: mfield.Name,
+			Type: c.type_(mfield.Type),
+			Doc:  mfield.Doc,
+			Pos:  position(mstr.File, mfield.Pos),
+
+			Annotations: annotations(mfield.Annotations),
+		}
+		str.Fields = append(str.Fields, field)
+	}
+	return str
+}
+
+func (c *converter) service(def *Definition, msrv *model.Service) *Service {
+	srv := &Service{
+		Def: def,
+		Sub: msrv.Sub,
+	}
+
+	for _, mm := range msrv.Methods {
+		m := &Method{
+			Service: srv,
+
+			Name: mm.Name,
+			Type: MethodType(mm.Type),
+			Doc:  mm.Doc,
+			Pos:  position(msrv.File, mm.Pos),
+
+			Annotations: annotations(mm.Annotations),
+
+			Request:    c.typeOrNil(mm.Request),
+			Response:   c.typeOrNil(mm.Response),
+			Subservice: c.typeOrNil(mm.Subservice),
+		}
+
+		if ch := mm.Channel; ch != nil {
+			m.Channel = &Channel{
+				In:  c.typeOrNil(ch.In),
+				Out: c.typeOrNil(ch.Out),
+			}
+		}
+		srv.Methods = append(srv.Methods, m)
+	}
+	return srv
+}
+
+// types
+
+func (c *converter) type_(mtype *model.Type) *Type {
+	t := &Type{
+		Kind: kind(mtype.Kind),
+		Name: mtype.Name,
+	}
+
+	switch mtype.Kind {
+	case model.KindList:
+		t.Name = ""
+		t.Element = c.type_(mtype.Element)
+
+	case model.KindMap:
+		t.Name = ""
+		t.Key = c.type_(mtype.Key)
+		t.Element = c.type_(mtype.Element)
+
+	case model.KindEnum, model.KindMessage, model.KindStruct, model.KindService:
+		t.Ref = c.defs[mtype.Ref]
+		if mtype.Import != nil {
+			t.Import = mtype.Import.Name
+		}
+	}
+	return t
+}
+
+func (c *converter) typeOrNil(mtype *model.Type) *Type {
+	if mtype == nil {
+		return nil
+	}
+	return c.type_(mtype)
+}
+
+// util
+
+func position(mfile *model.File, pos syntax.Position) Position {
+	if !pos.IsValid() {
+		return Position{}
+	}
+
+	return Position{
+		Path:   mfile.Path,
+		Line:   pos.Line,
+		Column: pos.Column,
+	}
+}
+
+func annotations(a *model.Annotations) spec.Annotations {
+	if a == nil || len(a.List) == 0 {
+		return nil
+	}
+
+	result := make(spec.Annotations, len(a.List))
+	for _, annot := range a.List {
+		result[annot.Name] = annot.Value
+	}
+	return result
+}
+
+func reserved(r *model.Reserved) Reserved {
+	if r == nil {
+		return Reserved{}
+	}
+
+	result := Reserved{
+		Names: append([]string(nil), r.Names...),
+	}
+	for _, rng := range r.Ranges {
+		result.Ranges = append(result.Ranges, ReservedRange{Start: rng.Start, End: rng.End})
+	}
+	return result
+}
+
+func kind(k model.Kind) spec.Kind {
+	switch k {
+	case model.KindAny:
+		return spec.KindAny
+
+	case model.KindBool:
+		return spec.KindBool
+	case model.KindByte:
+		return spec.KindByte
+
+	case model.KindInt16:
+		return spec.KindInt16
+	case model.KindInt32:
+		return spec.KindInt32
+	case model.KindInt64:
+		return spec.KindInt64
+
+	case model.KindUint16:
+		return spec.KindUint16
+	case model.KindUint32:
+		return spec.KindUint32
+	case model.KindUint64:
+		return spec.KindUint64
+
+	case model.KindBin64:
+		return spec.KindBin64
+	case model.KindBin128:
+		return spec.KindBin128
+	case model.KindBin256:
+		return spec.KindBin256
+
+	case model.KindFloat32:
+		return spec.KindFloat32
+	case model.KindFloat64:
+		return spec.KindFloat64
+
+	case model.KindBytes:
+		return spec.KindBytes
+	case model.KindString:
+		return spec.KindString
+	case model.KindAnyMessage:
+		return spec.KindAnyMessage
+
+	case model.KindList:
+		return spec.KindList
+	case model.KindMap:
+		return spec.KindMap
+
+	case model.KindEnum:
+		return spec.KindEnum
+	case model.KindMessage:
+		return spec.KindMessage
+	case model.KindStruct:
+		return spec.KindStruct
+	case model.KindService:
+		return spec.KindService
+	}
+	return spec.KindUndefined
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec"
+
+// DefinitionType is a definition type.
+type DefinitionType string
+
+const (
+	DefinitionEnum    DefinitionType = "enum"
+	DefinitionMessage DefinitionType = "message"
+	DefinitionStruct  DefinitionType = "struct"
+	DefinitionService DefinitionType = "service"
+)
+
+// Definition is a package enum, message, struct or service definition.
+type Definition struct {
+	Package *Package
+	File    *File
+
+	Name string
+	Type DefinitionType
+	Doc  string // doc comment
+	Pos  Position
+
+	// Generated is true for generated method request/response messages.
+	Generated bool
+
+	Annotations spec.Annotations
+
+	Enum    *Enum    // enum or nil
+	Message *Message // message or nil
+	Struct  *Struct  // struct or nil
+	Service *Service // service or nil
+}
+
+// Deprecated returns a deprecation reason and true when the definition is deprecated.
+func (d *Definition) Deprecated() (string, bool) {
+	return deprecated(d.Annotations)
+}
+
+// Enum
+
+// Enum is an enum definition.
+type Enum struct {
+	Def      *Definition
+	Values   []*EnumValue
+	Reserved Reserved
+}
+
+// Value returns an enum value by its name or nil.
+func (e *Enum) Value(name string) *EnumValue {
+	for _, v := range e.Values {
+		if v.Name == name {
+			return v
+		}
+	}
+	return nil
+}
+
+// EnumValue is an enum value.
+type EnumValue struct {
+	Enum *Enum
+
+	Name   string
+	Number int
+	Doc    string // doc comment
+	Pos    Position
+
+	Annotations spec.Annotations
+}
+
+// Deprecated returns a deprecation reason and true when the value is deprecated.
+func (v *EnumValue) Deprecated() (string, bool) {
+	return deprecated(v.Annotations)
+}
+
+// Message
+
+// Message is a message definition.
+type Message struct {
+	Def      *Definition
+	Fields   []*Field // fields in declaration order
+	Oneofs   []*Oneof
+	Reserved Reserved
+}
+
+// Field returns a field by its name or nil.
+func (m *Message) Field(name string) *Field {
+	for _, f := range m.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// FieldByTag returns a field by its tag or nil.
+func (m *Message) FieldByTag(tag int) *Field {
+	for _, f := range m.Fields {
+		if f.Tag == tag {
+			return f
+		}
+	}
+	return nil
+}
+
+// Field is a message field.
+type Field struct {
+	Message *Message
+
+	Name    string
+	Tag     int
+	Type    *Type
+	Oneof   *Oneof   // oneof or nil
+	Default *Default // default value or nil
+	Doc     string   // doc comment
+	Pos     Position
+
+	Annotations spec.Annotations
+}
+
+// Deprecated returns a deprecation reason and true when the field is deprecated.
+func (f *Field) Deprecated() (string, bool) {
+	return deprecated(f.Annotations)
+}
+
+// Default is a field default value.
+type Default struct {
+	Text string // literal text
+
+	// Value is a parsed value, bool, int64, uint64, float64, string or *EnumValue.
+	Value any
+}
+
+// Oneof is a set of mutually exclusive message fields.
+type Oneof struct {
+	Name   string
+	Doc    string // doc comment
+	Pos    Position
+	Fields []*Field
+}
+
+// Reserved specifies reserved tags or enum numbers and names.
+type Reserved struct {
+	Ranges []ReservedRange
+	Names  []string
+}
+
+// ReservedRange is an inclusive range of reserved tags or enum numbers.
+type ReservedRange struct {
+	Start int
+	End   int
+}
+
+// Contains returns true if a tag or an enum number is reserved.
+func (r Reserved) Contains(n int) bool {
+	for _, rng := range r.Ranges {
+		if n >= rng.Start && n <= rng.End {
+			return true
+		}
+	}
+	return false
+}
+
+// Struct
+
+// Struct is a struct definition.
+type Struct struct {
+	Def    *Definition
+	Fields []*StructField // fields in layout order
+}
+
+// StructField is a struct field.
+type StructField struct {
+	Struct *Struct
+
+	Name string
+	Type *Type
+	Doc  string // doc comment
+	Pos  Position
+
+	Annotations spec.Annotations
+}
+
+// Deprecated returns a deprecation reason and true when the field is deprecated.
+func (f *StructField) Deprecated() (string, bool) {
+	return deprecated(f.Annotations)
+}
+
+// private
+
+func deprecated(a spec.Annotations) (string, bool) {
+	switch v := a["deprecated"].(type) {
+	case bool:
+		return "", v
+	case string:
+		return v, true
+	}
+	return "", false
+}
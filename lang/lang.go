@@ -0,0 +1,95 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package lang compiles spec packages and exposes their read-only model
+// for linters, doc generators and custom code generators.
+//
+// The model is a snapshot of the compiled packages, it is safe to use concurrently
+// and must not be modified.
+package lang
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/basecomplextech/spec/internal/lang/compiler"
+	"github.com/basecomplextech/spec/internal/lang/model"
+)
+
+// Options specifies compile options.
+type Options struct {
+	// ImportPaths are directories to search for imported packages.
+	ImportPaths []string
+}
+
+// Compile compiles a package from a directory, imported packages are compiled
+// from import paths and are available via file imports.
+func Compile(dir string, opts Options) (*Package, error) {
+	c, err := compiler.New(compiler.Options{
+		ImportPath: opts.ImportPaths,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, err := c.Compile(dir)
+	if err != nil {
+		return nil, convertErrors(err)
+	}
+
+	conv := newConverter()
+	return conv.pkg(pkg), nil
+}
+
+// Error is a compile error with a source position.
+type Error struct {
+	Pos Position // position, can be invalid or have only a path
+	Msg string
+}
+
+// Error returns "path:line:column: msg", omits unknown parts.
+func (e *Error) Error() string {
+	switch {
+	case e.Pos.IsValid():
+		return e.Pos.String() + ": " + e.Msg
+	case e.Pos.Path != "":
+		return e.Pos.Path + ": " + e.Msg
+	}
+	return e.Msg
+}
+
+// Errors is a list of syntax and compile errors, returned by Compile when a package has errors.
+type Errors []*Error
+
+// Error returns errors separated by newlines.
+func (e Errors) Error() string {
+	lines := make([]string, 0, len(e))
+	for _, err := range e {
+		lines = append(lines, err.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// internal
+
+func convertErrors(err error) error {
+	var merrs model.Errors
+	if !errors.As(err, &merrs) {
+		return err
+	}
+
+	errs := make(Errors, 0, len(merrs))
+	for _, merr := range merrs {
+		e := &Error{
+			Pos: Position{
+				Path:   merr.Path,
+				Line:   merr.Pos.Line,
+				Column: merr.Pos.Column,
+			},
+			Msg: merr.Msg,
+		}
+		errs = append(errs, e)
+	}
+	return errs
+}
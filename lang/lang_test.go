@@ -0,0 +1,190 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/basecomplextech/spec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCompile(t *testing.T, id string) *Package {
+	opts := Options{
+		ImportPaths: []string{"../internal/tests"},
+	}
+
+	pkg, err := Compile(filepath.Join("../internal/tests", id), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func TestCompile__should_compile_package(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	assert.Equal(t, "pkg1", pkg.Name)
+	assert.Len(t, pkg.Files, 2)
+
+	goPkg, ok := pkg.Option("go_package")
+	assert.True(t, ok)
+	assert.Equal(t, "github.com/basecomplextech/spec/internal/tests/pkg1", goPkg)
+
+	require.Len(t, pkg.Warnings, 1)
+}
+
+func TestCompile__should_compile_imports(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	file := pkg.Files[1]
+	require.Len(t, file.Imports, 1)
+
+	imp := file.Imports[0]
+	assert.Equal(t, "pkg2", imp.ID)
+	assert.Equal(t, "pkg2", imp.Package.Name)
+	assert.NotNil(t, imp.Package.Definition("Submessage"))
+}
+
+func TestCompile__should_compile_messages(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	def := pkg.Definition("Message")
+	require.NotNil(t, def)
+	assert.Equal(t, DefinitionMessage, def.Type)
+	assert.True(t, def.Message.Reserved.Contains(5))
+
+	field := def.Message.Field("submessages1")
+	require.NotNil(t, field)
+	assert.Equal(t, 75, field.Tag)
+	assert.Equal(t, spec.KindList, field.Type.Kind)
+	assert.Equal(t, "[]pkg2.Submessage", field.Type.String())
+
+	ref := field.Type.Element.Ref
+	require.NotNil(t, ref)
+	assert.Equal(t, "pkg2", ref.Package.Name)
+	assert.Same(t, ref, pkg.Files[1].Imports[0].Package.Definition("Submessage"))
+
+	union := pkg.Definition("Union").Message
+	require.Len(t, union.Oneofs, 1)
+	assert.Same(t, union.Oneofs[0], union.Field("number").Oneof)
+	assert.Equal(t, int64(2), pkg.Definition("Union").Annotations["version"])
+}
+
+func TestCompile__should_compile_defaults(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	msg := pkg.Definition("Defaults").Message
+	assert.Equal(t, int64(-100), msg.Field("int32").Default.Value)
+	assert.Nil(t, msg.Field("plain").Default)
+
+	val := msg.Field("enum1").Default.Value.(*EnumValue)
+	assert.Same(t, pkg.Definition("Enum").Enum.Value("ONE"), val)
+}
+
+func TestCompile__should_compile_deprecated(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	_, ok := pkg.Definition("Legacy").Message.Field("old").Deprecated()
+	assert.True(t, ok)
+
+	reason, ok := pkg.Definition("Enum").Enum.Value("THREE").Deprecated()
+	assert.True(t, ok)
+	assert.Equal(t, "use TEN", reason)
+}
+
+func TestCompile__should_compile_structs(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	str := pkg.Definition("Struct").Struct
+	require.Len(t, str.Fields, 2)
+	assert.Equal(t, "key", str.Fields[0].Name)
+	assert.Equal(t, spec.KindInt32, str.Fields[0].Type.Kind)
+	assert.Equal(t, "Key is a struct key.", str.Fields[0].Doc)
+}
+
+func TestCompile__should_compile_services(t *testing.T) {
+	pkg := testCompile(t, "pkg4")
+
+	def := pkg.Definition("Service")
+	require.NotNil(t, def)
+
+	m := def.Service.Method("method2")
+	require.NotNil(t, m)
+	assert.Equal(t, MethodRequest, m.Type)
+	assert.Equal(t, "ServiceMethod2Request", m.Request.Name)
+	assert.True(t, m.Request.Ref.Generated)
+	assert.Equal(t, "ServiceMethod2Response", m.Response.Name)
+
+	m = def.Service.Method("method23")
+	require.NotNil(t, m)
+	assert.Equal(t, MethodChannel, m.Type)
+	require.NotNil(t, m.Channel)
+	assert.Equal(t, "In", m.Channel.In.Name)
+	assert.Equal(t, "Out", m.Channel.Out.Name)
+
+	m = def.Service.Method("subservice")
+	assert.Equal(t, MethodSubservice, m.Type)
+	assert.Equal(t, "Subservice", m.Subservice.Name)
+	assert.True(t, m.Subservice.Ref.Service.Sub)
+}
+
+func TestCompile__should_compile_positions(t *testing.T) {
+	pkg := testCompile(t, "pkg4")
+
+	def := pkg.Definition("Service")
+	assert.Equal(t, 10, def.Pos.Line)
+	assert.Equal(t, 1, def.Pos.Column)
+	assert.Equal(t, def.File.Path, def.Pos.Path)
+
+	m := def.Service.Method("method")
+	assert.Equal(t, Position{Path: def.File.Path, Line: 15, Column: 5}, m.Pos)
+	assert.Equal(t, def.File.Path+":15:5", m.Pos.String())
+}
+
+func TestCompile__should_return_errors_with_positions(t *testing.T) {
+	dir := t.TempDir()
+	src := "message Message {\n    field1 Unknown1 1;\n    field2 Unknown2 2;\n}\n"
+
+	path := filepath.Join(dir, "test.spec")
+	if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := Compile(dir, Options{})
+
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Equal(t, Position{Path: path, Line: 2, Column: 5}, errs[0].Pos)
+	assert.Equal(t, "field1: type not found: Unknown1", errs[0].Msg)
+	assert.Equal(t, Position{Path: path, Line: 3, Column: 5}, errs[1].Pos)
+}
+
+func TestCompile__should_return_syntax_errors_from_all_files(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.spec": "message A {\n    field int32;\n}\n",
+		"b.spec": "enum B {\n    ONE = ;\n}\n",
+	}
+	for name, src := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := Compile(dir, Options{})
+
+	var errs Errors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+	assert.Equal(t, filepath.Join(dir, "a.spec"), errs[0].Pos.Path)
+	assert.Equal(t, 2, errs[0].Pos.Line)
+	assert.Equal(t, filepath.Join(dir, "b.spec"), errs[1].Pos.Path)
+	assert.Equal(t, 2, errs[1].Pos.Line)
+}
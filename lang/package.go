@@ -0,0 +1,111 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec/internal/lang/langindex"
+)
+
+// Package is a compiled spec package.
+type Package struct {
+	ID   string // import id, i.e. "my/example/test"
+	Name string // package name, i.e. "test"
+	Path string // package directory path
+
+	Files   []*File
+	Options []*Option // package options from all files
+
+	// Definitions are package definitions in file order,
+	// including generated method request/response messages.
+	Definitions []*Definition
+
+	// Warnings are compile warnings, i.e. references to deprecated imported definitions.
+	Warnings []string
+
+	defs map[string]*Definition
+}
+
+// Definition returns a definition by its name or nil.
+func (p *Package) Definition(name string) *Definition {
+	return p.defs[name]
+}
+
+// index builds the definition name index, used by the plugin decoder.
+func (p *Package) index() {
+	p.defs = make(map[string]*Definition, len(p.Definitions))
+	for _, def := range p.Definitions {
+		p.defs[def.Name] = def
+	}
+}
+
+func init() {
+	langindex.Package = func(pkg any) {
+		pkg.(*Package).index()
+	}
+}
+
+// Option returns an option value by its name, i.e. "go_package".
+func (p *Package) Option(name string) (string, bool) {
+	for _, opt := range p.Options {
+		if opt.Name == name {
+			return opt.Value, true
+		}
+	}
+	return "", false
+}
+
+// File is a spec source file.
+type File struct {
+	Package *Package
+
+	Name string // file name, i.e. "service.spec"
+	Path string // file path
+
+	Imports     []*Import
+	Options     []*Option
+	Definitions []*Definition
+}
+
+// Import is a file import.
+type Import struct {
+	File *File
+
+	ID      string   // import id, i.e. "my/example/test"
+	Name    string   // import name or alias, i.e. "test"
+	Package *Package // imported package
+	Pos     Position
+}
+
+// Option is a file option, i.e. go_package="github.com/example/test".
+type Option struct {
+	Name  string
+	Value string
+	Pos   Position
+}
+
+// Position is a source position.
+type Position struct {
+	Path   string // file path
+	Line   int    // line number, starting at 1
+	Column int    // column number, starting at 1
+}
+
+// IsValid returns true if the position is known.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns "path:line:column", "line:column" without a path, or "-" when unknown.
+func (p Position) String() string {
+	switch {
+	case !p.IsValid():
+		return "-"
+	case p.Path == "":
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%v:%d:%d", p.Path, p.Line, p.Column)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import "github.com/basecomplextech/spec"
+
+// MethodType is a service method type.
+type MethodType string
+
+const (
+	MethodRequest    MethodType = "request"
+	MethodOneway     MethodType = "oneway"
+	MethodChannel    MethodType = "channel"
+	MethodSubservice MethodType = "subservice"
+)
+
+// Service is a service or a subservice definition.
+type Service struct {
+	Def     *Definition
+	Sub     bool // subservice
+	Methods []*Method
+}
+
+// Method returns a method by its name or nil.
+func (s *Service) Method(name string) *Method {
+	for _, m := range s.Methods {
+		if m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// Method is a service method.
+type Method struct {
+	Service *Service
+
+	Name string
+	Type MethodType
+	Doc  string // doc comment
+	Pos  Position
+
+	Annotations spec.Annotations
+
+	Request    *Type    // request message or nil
+	Response   *Type    // response message or nil
+	Channel    *Channel // channel or nil
+	Subservice *Type    // subservice or nil
+}
+
+// Deprecated returns a deprecation reason and true when the method is deprecated.
+func (m *Method) Deprecated() (string, bool) {
+	return deprecated(m.Annotations)
+}
+
+// Channel specifies method channel in/out messages.
+type Channel struct {
+	In  *Type // input type or nil
+	Out *Type // output type or nil
+}
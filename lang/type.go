@@ -0,0 +1,41 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package lang
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec"
+)
+
+// Type is a field, method or channel type.
+type Type struct {
+	Kind spec.Kind
+	Name string // builtin type name or definition name
+
+	Key     *Type // map key type
+	Element *Type // list and map element type
+
+	// Import is an import name for imported definitions, i.e. "pkg" in "pkg.Type".
+	Import string
+
+	// Ref is a referenced enum, message, struct or service definition.
+	Ref *Definition
+}
+
+// String returns a spec type string, i.e. "[]pkg.Type" or "map[string]int64".
+func (t *Type) String() string {
+	switch t.Kind {
+	case spec.KindList:
+		return "[]" + t.Element.String()
+	case spec.KindMap:
+		return fmt.Sprintf("map[%v]%v", t.Key, t.Element)
+	}
+
+	if t.Import != "" {
+		return t.Import + "." + t.Name
+	}
+	return t.Name
+}
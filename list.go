@@ -27,3 +27,8 @@ func OpenListErr(b []byte) (List, error) {
 func ParseList(b []byte) (l List, size int, err error) {
 	return types.ParseList(b)
 }
+
+// ParseListOpts recursively parses and returns a list, checks the parse limits.
+func ParseListOpts(b []byte, opts ParseOptions) (l List, size int, err error) {
+	return types.ParseListOpts(b, opts)
+}
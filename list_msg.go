@@ -4,6 +4,8 @@
 
 package spec
 
+import "fmt"
+
 type MessageList[T any] struct {
 	list List
 	open func([]byte) (T, error)
@@ -115,3 +117,15 @@ func (l MessageList[T]) Values() []T {
 
 	return result
 }
+
+// Debug
+
+// String returns a debug text representation of the list.
+func (l MessageList[T]) String() string {
+	return debugList(l.Len(), l.Get)
+}
+
+// Format writes a debug text representation of the list, supports %v, %s and %q verbs.
+func (l MessageList[T]) Format(f fmt.State, verb rune) {
+	debugFormat(f, verb, []byte(l.String()))
+}
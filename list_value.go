@@ -4,6 +4,8 @@
 
 package spec
 
+import "fmt"
+
 type ValueList[T any] struct {
 	list   List
 	decode func([]byte) (T, int, error)
@@ -112,3 +114,15 @@ func (l ValueList[T]) Values() []T {
 
 	return result
 }
+
+// Debug
+
+// String returns a debug text representation of the list.
+func (l ValueList[T]) String() string {
+	return debugList(l.Len(), l.Get)
+}
+
+// Format writes a debug text representation of the list, supports %v, %s and %q verbs.
+func (l ValueList[T]) Format(f fmt.State, verb rune) {
+	debugFormat(f, verb, []byte(l.String()))
+}
@@ -0,0 +1,79 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/basecomplextech/spec/internal/types"
+)
+
+// Map is a raw map of entries sorted by keys.
+type Map = types.Map
+
+// OpenMap opens and returns a map from bytes, or an empty map on error.
+// The method decodes the map table, but not the entries, see [ParseMap].
+func OpenMap(b []byte) Map {
+	return types.OpenMap(b)
+}
+
+// OpenMapErr opens and returns a map from bytes, or an error.
+// The method decodes the map table, but not the entries, see [ParseMap].
+func OpenMapErr(b []byte) (Map, error) {
+	return types.OpenMapErr(b)
+}
+
+// ParseMap recursively parses and returns a map.
+func ParseMap(b []byte) (m Map, size int, err error) {
+	return types.ParseMap(b)
+}
+
+// ParseMapOpts recursively parses and returns a map, checks the parse limits.
+func ParseMapOpts(b []byte, opts ParseOptions) (m Map, size int, err error) {
+	return types.ParseMapOpts(b, opts)
+}
+
+// internal
+
+// searchMap binary searches a map by a typed key, returns an entry index or -1.
+func searchMap[K any](m Map, key K, decode func([]byte) (K, int, error)) int {
+	return m.Search(func(b Value) (int, error) {
+		k, _, err := decode(b)
+		if err != nil {
+			return 0, err
+		}
+		return compareKeys(k, key), nil
+	})
+}
+
+// compareKeys compares two typed map keys, panics on an unsupported key type.
+func compareKeys[K any](a, b K) int {
+	switch a1 := any(a).(type) {
+	case int16:
+		return cmp.Compare(a1, any(b).(int16))
+	case int32:
+		return cmp.Compare(a1, any(b).(int32))
+	case int64:
+		return cmp.Compare(a1, any(b).(int64))
+
+	case uint16:
+		return cmp.Compare(a1, any(b).(uint16))
+	case uint32:
+		return cmp.Compare(a1, any(b).(uint32))
+	case uint64:
+		return cmp.Compare(a1, any(b).(uint64))
+
+	case string:
+		return cmp.Compare(a1, any(b).(string))
+	case String:
+		return cmp.Compare(a1, any(b).(String))
+
+	case interface{ Compare(K) int }:
+		return a1.Compare(b)
+	}
+
+	panic(fmt.Sprintf("spec: unsupported map key type %T", a))
+}
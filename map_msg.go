@@ -0,0 +1,127 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import "fmt"
+
+// MessageMap is a read-only map of messages sorted by keys.
+type MessageMap[K, V any] struct {
+	map_      Map
+	decodeKey func([]byte) (K, int, error)
+	open      func([]byte) (V, error)
+}
+
+// NewMessageMap returns a new message map.
+func NewMessageMap[K, V any](m Map, decodeKey func([]byte) (K, int, error),
+	open func([]byte) (V, error)) MessageMap[K, V] {
+
+	return MessageMap[K, V]{
+		map_:      m,
+		decodeKey: decodeKey,
+		open:      open,
+	}
+}
+
+// OpenMessageMap opens and returns a message map, or an empty map on error.
+func OpenMessageMap[K, V any](b []byte, decodeKey func([]byte) (K, int, error),
+	open func([]byte) (V, error)) MessageMap[K, V] {
+
+	m := OpenMap(b)
+	return NewMessageMap(m, decodeKey, open)
+}
+
+// OpenMessageMapErr opens and returns a message map, or an error.
+func OpenMessageMapErr[K, V any](b []byte, decodeKey func([]byte) (K, int, error),
+	open func([]byte) (V, error)) (_ MessageMap[K, V], err error) {
+
+	m, err := OpenMapErr(b)
+	if err != nil {
+		return
+	}
+	return NewMessageMap(m, decodeKey, open), nil
+}
+
+// ParseMessageMap decodes, recursively validates and returns a map.
+func ParseMessageMap[K, V any](b []byte, decodeKey func([]byte) (K, int, error),
+	open func([]byte) (V, error)) (_ MessageMap[K, V], size int, err error) {
+
+	m, size, err := ParseMap(b)
+	if err != nil {
+		return
+	}
+
+	ln := m.Len()
+	for i := 0; i < ln; i++ {
+		if _, _, err = decodeKey(m.Key(i)); err != nil {
+			return
+		}
+
+		b1 := m.Value(i)
+		if len(b1) == 0 {
+			continue
+		}
+		if _, err = open(b1); err != nil {
+			return
+		}
+	}
+	return NewMessageMap(m, decodeKey, open), size, nil
+}
+
+// Len returns the number of entries in the map.
+func (m MessageMap[K, V]) Len() int {
+	return m.map_.Len()
+}
+
+// Raw returns the exact map bytes.
+func (m MessageMap[K, V]) Raw() []byte {
+	return m.map_.Raw()
+}
+
+// Empty returns true if bytes are empty or map has no entries.
+func (m MessageMap[K, V]) Empty() bool {
+	return m.map_.Empty()
+}
+
+// Get
+
+// Get returns a message by a key, or false.
+func (m MessageMap[K, V]) Get(key K) (v V, ok bool) {
+	i := searchMap(m.map_, key, m.decodeKey)
+	if i < 0 {
+		return v, false
+	}
+	return m.Value(i), true
+}
+
+// Contains returns true if the map contains a key.
+func (m MessageMap[K, V]) Contains(key K) bool {
+	return searchMap(m.map_, key, m.decodeKey) >= 0
+}
+
+// Key returns a key at index i, panics on out of range.
+func (m MessageMap[K, V]) Key(i int) K {
+	b := m.map_.Key(i)
+	key, _, _ := m.decodeKey(b)
+	return key
+}
+
+// Value returns a message at index i, panics on out of range.
+func (m MessageMap[K, V]) Value(i int) V {
+	b := m.map_.Value(i)
+	v, _ := m.open(b)
+	return v
+}
+
+// Debug
+
+// String returns a debug text representation of the map.
+func (m MessageMap[K, V]) String() string {
+	return debugMap(m.Len(), m.Key, m.Value)
+}
+
+// Format writes a debug text representation of the map, supports %v, %s and %q verbs.
+func (m MessageMap[K, V]) Format(f fmt.State, verb rune) {
+	debugFormat(f, verb, []byte(m.String()))
+}
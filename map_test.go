@@ -0,0 +1,86 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMap(t *testing.T, keys ...int64) []byte {
+	w := NewValueMapWriter(NewMapWriter(), EncodeInt64, EncodeString)
+	for _, key := range keys {
+		if err := w.Put(key, "value"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := w.w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestValueMap__should_get_values_by_keys(t *testing.T) {
+	b := testMap(t, 3, -1, 200, 2)
+
+	m, _, err := ParseValueMap(b, DecodeInt64, DecodeString)
+	require.NoError(t, err)
+	assert.Equal(t, 4, m.Len())
+
+	keys := []int64{}
+	for i := 0; i < m.Len(); i++ {
+		keys = append(keys, m.Key(i))
+	}
+	assert.Equal(t, []int64{-1, 2, 3, 200}, keys)
+
+	v, ok := m.Get(200)
+	assert.True(t, ok)
+	assert.Equal(t, "value", v.Unwrap())
+
+	_, ok = m.Get(4)
+	assert.False(t, ok)
+	assert.True(t, m.Contains(-1))
+}
+
+func TestMap_Get__should_not_match_mismatched_key_type(t *testing.T) {
+	b := testMap(t, 1, 2, 3)
+
+	buf := buffer.New()
+	if _, err := EncodeString(buf, "two"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, ok := OpenMap(b).Get(buf.Bytes())
+	assert.False(t, ok)
+
+	m := OpenValueMap(b, DecodeString, DecodeString)
+	_, ok = m.Get("two")
+	assert.False(t, ok)
+	assert.False(t, m.Contains("two"))
+}
+
+func TestParseMap__should_return_error_when_keys_not_sorted(t *testing.T) {
+	b := testMap(t, 1, 2)
+
+	// Swap entries in the small map table, followed by data size, table size and type
+	m := OpenMap(b)
+	raw := m.Raw()
+	table := raw[len(raw)-3-8 : len(raw)-3]
+	e0 := [4]byte(table[:4])
+	copy(table[:4], table[4:])
+	copy(table[4:], e0[:])
+
+	_, _, err := ParseMap(b)
+
+	var e *DecodeError
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, "map[1]", e.PathString())
+}
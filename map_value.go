@@ -0,0 +1,127 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import "fmt"
+
+// ValueMap is a read-only map of primitive values sorted by keys.
+type ValueMap[K, V any] struct {
+	map_      Map
+	decodeKey func([]byte) (K, int, error)
+	decode    func([]byte) (V, int, error)
+}
+
+// NewValueMap returns a new value map.
+func NewValueMap[K, V any](m Map, decodeKey func([]byte) (K, int, error),
+	decode func([]byte) (V, int, error)) ValueMap[K, V] {
+
+	return ValueMap[K, V]{
+		map_:      m,
+		decodeKey: decodeKey,
+		decode:    decode,
+	}
+}
+
+// OpenValueMap opens and returns a value map, or an empty map on error.
+func OpenValueMap[K, V any](b []byte, decodeKey func([]byte) (K, int, error),
+	decode func([]byte) (V, int, error)) ValueMap[K, V] {
+
+	m := OpenMap(b)
+	return NewValueMap(m, decodeKey, decode)
+}
+
+// OpenValueMapErr opens and returns a value map, or an error.
+func OpenValueMapErr[K, V any](b []byte, decodeKey func([]byte) (K, int, error),
+	decode func([]byte) (V, int, error)) (_ ValueMap[K, V], err error) {
+
+	m, err := OpenMapErr(b)
+	if err != nil {
+		return
+	}
+	return NewValueMap(m, decodeKey, decode), nil
+}
+
+// ParseValueMap decodes, recursively validates and returns a map.
+func ParseValueMap[K, V any](b []byte, decodeKey func([]byte) (K, int, error),
+	decode func([]byte) (V, int, error)) (_ ValueMap[K, V], size int, err error) {
+
+	m, size, err := ParseMap(b)
+	if err != nil {
+		return
+	}
+
+	ln := m.Len()
+	for i := 0; i < ln; i++ {
+		if _, _, err = decodeKey(m.Key(i)); err != nil {
+			return
+		}
+
+		b1 := m.Value(i)
+		if len(b1) == 0 {
+			continue
+		}
+		if _, _, err = decode(b1); err != nil {
+			return
+		}
+	}
+	return NewValueMap(m, decodeKey, decode), size, nil
+}
+
+// Len returns the number of entries in the map.
+func (m ValueMap[K, V]) Len() int {
+	return m.map_.Len()
+}
+
+// Raw returns the exact map bytes.
+func (m ValueMap[K, V]) Raw() []byte {
+	return m.map_.Raw()
+}
+
+// Empty returns true if bytes are empty or map has no entries.
+func (m ValueMap[K, V]) Empty() bool {
+	return m.map_.Empty()
+}
+
+// Get
+
+// Get returns a value by a key, or false.
+func (m ValueMap[K, V]) Get(key K) (v V, ok bool) {
+	i := searchMap(m.map_, key, m.decodeKey)
+	if i < 0 {
+		return v, false
+	}
+	return m.Value(i), true
+}
+
+// Contains returns true if the map contains a key.
+func (m ValueMap[K, V]) Contains(key K) bool {
+	return searchMap(m.map_, key, m.decodeKey) >= 0
+}
+
+// Key returns a key at index i, panics on out of range.
+func (m ValueMap[K, V]) Key(i int) K {
+	b := m.map_.Key(i)
+	key, _, _ := m.decodeKey(b)
+	return key
+}
+
+// Value returns a value at index i, panics on out of range.
+func (m ValueMap[K, V]) Value(i int) V {
+	b := m.map_.Value(i)
+	v, _, _ := m.decode(b)
+	return v
+}
+
+// Debug
+
+// String returns a debug text representation of the map.
+func (m ValueMap[K, V]) String() string {
+	return debugMap(m.Len(), m.Key, m.Value)
+}
+
+// Format writes a debug text representation of the map, supports %v, %s and %q verbs.
+func (m ValueMap[K, V]) Format(f fmt.State, verb rune) {
+	debugFormat(f, verb, []byte(m.String()))
+}
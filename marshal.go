@@ -0,0 +1,698 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/basecomplextech/baselibrary/bin"
+)
+
+// Reflection
+//
+// Marshal and Unmarshal map tagged Go structs onto messages without a spec file.
+// Struct fields are tagged with their message field tags, i.e. `spec:"3"`, untagged
+// fields and fields tagged with `spec:"-"` are ignored.
+//
+// Go kinds are mapped onto spec types:
+//
+//	bool                    bool
+//	uint8                   byte
+//	int8, int16             int16
+//	int32                   int32
+//	int, int64              int64
+//	uint16                  uint16
+//	uint32                  uint32
+//	uint, uint64            uint64
+//	float32, float64        float32, float64
+//	bin.Bin64/128/256       bin64, bin128, bin256
+//	string, []byte          string, bytes
+//	[]T                     list
+//	map[K]V                 map
+//	struct, *struct         message
+//	spec.Message            message
+//	spec.Value              any
+//
+// Nil pointers, empty slices and empty maps are skipped when marshalling.
+// Unmarshal zeroes the target struct, copies strings and bytes, and does not
+// reference the source bytes.
+
+// Marshal writes a tagged Go struct or a struct pointer as a message and returns its bytes.
+func Marshal(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, errors.New("marshal: nil pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("marshal: expected struct, got %T", v)
+	}
+
+	c, err := structCodecOf(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	w := NewMessageWriter()
+	if err := c.encode(w, rv); err != nil {
+		return nil, err
+	}
+	return w.Build()
+}
+
+// Unmarshal reads a message into a tagged Go struct, v must be a non-nil struct pointer.
+func Unmarshal(b []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("unmarshal: expected non-nil struct pointer, got %T", v)
+	}
+
+	rv = rv.Elem()
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("unmarshal: expected struct pointer, got %T", v)
+	}
+
+	c, err := structCodecOf(rv.Type())
+	if err != nil {
+		return err
+	}
+
+	msg, err := OpenMessageErr(b)
+	if err != nil {
+		return err
+	}
+	return c.decode(msg, rv)
+}
+
+// internal
+
+var (
+	binTypes = map[reflect.Type]bool{
+		reflect.TypeFor[bin.Bin64]():  true,
+		reflect.TypeFor[bin.Bin128](): true,
+		reflect.TypeFor[bin.Bin256](): true,
+	}
+
+	messageType = reflect.TypeFor[Message]()
+	valueType   = reflect.TypeFor[Value]()
+)
+
+// codecs caches compiled struct codecs by their types.
+var codecs struct {
+	cache sync.Map // map[reflect.Type]*structCodec
+
+	mu       sync.Mutex
+	building map[reflect.Type]*structCodec // recursive types
+}
+
+// structCodecOf returns a cached struct codec or compiles a new one.
+func structCodecOf(t reflect.Type) (*structCodec, error) {
+	if c, ok := codecs.cache.Load(t); ok {
+		return c.(*structCodec), nil
+	}
+
+	codecs.mu.Lock()
+	defer codecs.mu.Unlock()
+
+	if c, ok := codecs.cache.Load(t); ok {
+		return c.(*structCodec), nil
+	}
+
+	codecs.building = make(map[reflect.Type]*structCodec)
+	defer func() { codecs.building = nil }()
+
+	c, err := compileStruct(t)
+	if err != nil {
+		return nil, err
+	}
+
+	for t1, c1 := range codecs.building {
+		codecs.cache.Store(t1, c1)
+	}
+	return c, nil
+}
+
+// struct
+
+type structCodec struct {
+	fields []fieldCodec
+}
+
+type fieldCodec struct {
+	tag   uint16
+	index int
+	name  string
+	codec *valueCodec
+}
+
+// compileStruct compiles a struct codec, must be called with the codecs lock held.
+func compileStruct(t reflect.Type) (*structCodec, error) {
+	if c, ok := codecs.building[t]; ok {
+		return c, nil
+	}
+	if c, ok := codecs.cache.Load(t); ok {
+		return c.(*structCodec), nil
+	}
+
+	c := &structCodec{}
+	codecs.building[t] = c
+
+	tags := make(map[uint16]string)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		s, ok := f.Tag.Lookup("spec")
+		if !ok || s == "-" {
+			continue
+		}
+		if !f.IsExported() {
+			return nil, fmt.Errorf("%v.%v: tagged field is not exported", t, f.Name)
+		}
+
+		tag, err := strconv.ParseUint(s, 10, 16)
+		if err != nil || tag == 0 {
+			return nil, fmt.Errorf("%v.%v: invalid spec tag %q", t, f.Name, s)
+		}
+		if prev, ok := tags[uint16(tag)]; ok {
+			return nil, fmt.Errorf("%v.%v: duplicate spec tag %d, already used by %v", t, f.Name, tag, prev)
+		}
+		tags[uint16(tag)] = f.Name
+
+		vc, err := compileValue(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%v.%v: %w", t, f.Name, err)
+		}
+
+		c.fields = append(c.fields, fieldCodec{
+			tag:   uint16(tag),
+			index: i,
+			name:  f.Name,
+			codec: vc,
+		})
+	}
+	return c, nil
+}
+
+func (c *structCodec) encode(w MessageWriter, v reflect.Value) error {
+	for _, f := range c.fields {
+		fv := v.Field(f.index)
+		if f.codec.empty != nil && f.codec.empty(fv) {
+			continue
+		}
+
+		if err := f.codec.encode(w.Field(f.tag), fv); err != nil {
+			return fmt.Errorf("%v: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+func (c *structCodec) decode(msg Message, v reflect.Value) error {
+	v.SetZero()
+
+	for _, f := range c.fields {
+		b := msg.Field(f.tag)
+		if len(b) == 0 {
+			continue
+		}
+
+		if err := f.codec.decode(b, v.Field(f.index)); err != nil {
+			return fmt.Errorf("%v: %w", f.name, err)
+		}
+	}
+	return nil
+}
+
+// value
+
+type valueCodec struct {
+	encode func(w valueWriter, v reflect.Value) error
+	decode func(b Value, v reflect.Value) error
+	empty  func(v reflect.Value) bool // optional, skips empty fields
+}
+
+func compileValue(t reflect.Type) (*valueCodec, error) {
+	switch {
+	case binTypes[t]:
+		return compileBin(t), nil
+	case t == messageType:
+		return messageCodec, nil
+	case t == valueType:
+		return anyCodec, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return boolCodec, nil
+	case reflect.Uint8:
+		return byteCodec, nil
+
+	case reflect.Int8, reflect.Int16:
+		return int16Codec, nil
+	case reflect.Int32:
+		return int32Codec, nil
+	case reflect.Int, reflect.Int64:
+		return int64Codec, nil
+
+	case reflect.Uint16:
+		return uint16Codec, nil
+	case reflect.Uint32:
+		return uint32Codec, nil
+	case reflect.Uint, reflect.Uint64:
+		return uint64Codec, nil
+
+	case reflect.Float32:
+		return float32Codec, nil
+	case reflect.Float64:
+		return float64Codec, nil
+
+	case reflect.String:
+		return stringCodec, nil
+
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return bytesCodec, nil
+		}
+		return compileList(t)
+
+	case reflect.Map:
+		return compileMap(t)
+
+	case reflect.Struct:
+		return compileMessage(t)
+
+	case reflect.Pointer:
+		if t.Elem().Kind() != reflect.Struct || binTypes[t.Elem()] {
+			break
+		}
+		return compileMessagePointer(t)
+	}
+
+	return nil, fmt.Errorf("unsupported type %v", t)
+}
+
+// scalars
+
+var (
+	boolCodec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Bool(v.Bool())
+		},
+		decode: func(b Value, v reflect.Value) error {
+			x, err := b.BoolErr()
+			if err != nil {
+				return err
+			}
+			v.SetBool(x)
+			return nil
+		},
+	}
+
+	byteCodec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Byte(byte(v.Uint()))
+		},
+		decode: func(b Value, v reflect.Value) error {
+			x, err := b.ByteErr()
+			if err != nil {
+				return err
+			}
+			v.SetUint(uint64(x))
+			return nil
+		},
+	}
+
+	int16Codec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Int16(int16(v.Int()))
+		},
+		decode: decodeInt,
+	}
+
+	int32Codec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Int32(int32(v.Int()))
+		},
+		decode: decodeInt,
+	}
+
+	int64Codec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Int64(v.Int())
+		},
+		decode: decodeInt,
+	}
+
+	uint16Codec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Uint16(uint16(v.Uint()))
+		},
+		decode: decodeUint,
+	}
+
+	uint32Codec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Uint32(uint32(v.Uint()))
+		},
+		decode: decodeUint,
+	}
+
+	uint64Codec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Uint64(v.Uint())
+		},
+		decode: decodeUint,
+	}
+
+	float32Codec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Float32(float32(v.Float()))
+		},
+		decode: decodeFloat,
+	}
+
+	float64Codec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Float64(v.Float())
+		},
+		decode: decodeFloat,
+	}
+
+	bytesCodec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Bytes(v.Bytes())
+		},
+		decode: func(b Value, v reflect.Value) error {
+			x, err := b.BytesErr()
+			if err != nil {
+				return err
+			}
+			v.SetBytes(x.Clone())
+			return nil
+		},
+	}
+
+	stringCodec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.String(v.String())
+		},
+		decode: func(b Value, v reflect.Value) error {
+			x, err := b.StringErr()
+			if err != nil {
+				return err
+			}
+			v.SetString(x.Clone())
+			return nil
+		},
+	}
+
+	anyCodec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Any(v.Bytes())
+		},
+		decode: func(b Value, v reflect.Value) error {
+			v.SetBytes(append([]byte(nil), b...))
+			return nil
+		},
+		empty: func(v reflect.Value) bool {
+			return v.Len() == 0
+		},
+	}
+
+	messageCodec = &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			msg := v.Interface().(Message)
+			return w.Any(msg.Raw())
+		},
+		decode: func(b Value, v reflect.Value) error {
+			msg, err := b.MessageErr()
+			if err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(msg.Clone()))
+			return nil
+		},
+		empty: func(v reflect.Value) bool {
+			return v.Interface().(Message).Empty()
+		},
+	}
+)
+
+func decodeInt(b Value, v reflect.Value) error {
+	x, err := b.Int64Err()
+	if err != nil {
+		return err
+	}
+	if v.OverflowInt(x) {
+		return fmt.Errorf("value %d overflows %v", x, v.Type())
+	}
+	v.SetInt(x)
+	return nil
+}
+
+func decodeUint(b Value, v reflect.Value) error {
+	x, err := b.Uint64Err()
+	if err != nil {
+		return err
+	}
+	if v.OverflowUint(x) {
+		return fmt.Errorf("value %d overflows %v", x, v.Type())
+	}
+	v.SetUint(x)
+	return nil
+}
+
+func decodeFloat(b Value, v reflect.Value) error {
+	x, err := b.Float64Err()
+	if err != nil {
+		return err
+	}
+	v.SetFloat(x)
+	return nil
+}
+
+func compileBin(t reflect.Type) *valueCodec {
+	switch t {
+	case reflect.TypeFor[bin.Bin64]():
+		return &valueCodec{
+			encode: func(w valueWriter, v reflect.Value) error {
+				return w.Bin64(v.Interface().(bin.Bin64))
+			},
+			decode: func(b Value, v reflect.Value) error {
+				x, err := b.Bin64Err()
+				if err != nil {
+					return err
+				}
+				v.Set(reflect.ValueOf(x))
+				return nil
+			},
+		}
+
+	case reflect.TypeFor[bin.Bin128]():
+		return &valueCodec{
+			encode: func(w valueWriter, v reflect.Value) error {
+				return w.Bin128(v.Interface().(bin.Bin128))
+			},
+			decode: func(b Value, v reflect.Value) error {
+				x, err := b.Bin128Err()
+				if err != nil {
+					return err
+				}
+				v.Set(reflect.ValueOf(x))
+				return nil
+			},
+		}
+	}
+
+	return &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			return w.Bin256(v.Interface().(bin.Bin256))
+		},
+		decode: func(b Value, v reflect.Value) error {
+			x, err := b.Bin256Err()
+			if err != nil {
+				return err
+			}
+			v.Set(reflect.ValueOf(x))
+			return nil
+		},
+	}
+}
+
+// list
+
+func compileList(t reflect.Type) (*valueCodec, error) {
+	elem, err := compileValue(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	c := &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			lw := w.List()
+			for i := 0; i < v.Len(); i++ {
+				if err := elem.encode(lw, v.Index(i)); err != nil {
+					return fmt.Errorf("[%d]: %w", i, err)
+				}
+			}
+			return lw.End()
+		},
+		decode: func(b Value, v reflect.Value) error {
+			list, err := b.ListErr()
+			if err != nil {
+				return err
+			}
+
+			n := list.Len()
+			v.Set(reflect.MakeSlice(v.Type(), n, n))
+			for i := 0; i < n; i++ {
+				if err := elem.decode(list.Get(i), v.Index(i)); err != nil {
+					return fmt.Errorf("[%d]: %w", i, err)
+				}
+			}
+			return nil
+		},
+		empty: func(v reflect.Value) bool {
+			return v.Len() == 0
+		},
+	}
+	return c, nil
+}
+
+// map
+
+func compileMap(t reflect.Type) (*valueCodec, error) {
+	key, err := compileValue(t.Key())
+	if err != nil {
+		return nil, err
+	}
+	switch t.Key().Kind() {
+	case reflect.Slice, reflect.Map, reflect.Struct, reflect.Pointer:
+		if !binTypes[t.Key()] {
+			return nil, fmt.Errorf("unsupported map key type %v", t.Key())
+		}
+	}
+
+	elem, err := compileValue(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	c := &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			mw := w.Map()
+			iter := v.MapRange()
+			for iter.Next() {
+				k := iter.Key()
+				if err := key.encode(mw, k); err != nil {
+					return fmt.Errorf("map[%v]: %w", k, err)
+				}
+				if err := elem.encode(mw, iter.Value()); err != nil {
+					return fmt.Errorf("map[%v]: %w", k, err)
+				}
+			}
+			return mw.End()
+		},
+		decode: func(b Value, v reflect.Value) error {
+			m, err := b.MapErr()
+			if err != nil {
+				return err
+			}
+
+			n := m.Len()
+			t := v.Type()
+			v.Set(reflect.MakeMapWithSize(t, n))
+
+			for i := 0; i < n; i++ {
+				k := reflect.New(t.Key()).Elem()
+				if err := key.decode(m.Key(i), k); err != nil {
+					return fmt.Errorf("map[%d]: %w", i, err)
+				}
+
+				e := reflect.New(t.Elem()).Elem()
+				if err := elem.decode(m.Value(i), e); err != nil {
+					return fmt.Errorf("map[%v]: %w", k, err)
+				}
+				v.SetMapIndex(k, e)
+			}
+			return nil
+		},
+		empty: func(v reflect.Value) bool {
+			return v.Len() == 0
+		},
+	}
+	return c, nil
+}
+
+// message
+
+func compileMessage(t reflect.Type) (*valueCodec, error) {
+	sc, err := compileStruct(t)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			mw := w.Message()
+			if err := sc.encode(mw, v); err != nil {
+				return err
+			}
+			return mw.End()
+		},
+		decode: func(b Value, v reflect.Value) error {
+			msg, err := b.MessageErr()
+			if err != nil {
+				return err
+			}
+			return sc.decode(msg, v)
+		},
+	}
+	return c, nil
+}
+
+// compileMessagePointer compiles a struct pointer codec, nil pointers in lists and maps
+// are written as empty messages.
+func compileMessagePointer(t reflect.Type) (*valueCodec, error) {
+	sc, err := compileStruct(t.Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	c := &valueCodec{
+		encode: func(w valueWriter, v reflect.Value) error {
+			mw := w.Message()
+			if !v.IsNil() {
+				if err := sc.encode(mw, v.Elem()); err != nil {
+					return err
+				}
+			}
+			return mw.End()
+		},
+		decode: func(b Value, v reflect.Value) error {
+			msg, err := b.MessageErr()
+			if err != nil {
+				return err
+			}
+
+			p := reflect.New(t.Elem())
+			if err := sc.decode(msg, p.Elem()); err != nil {
+				return err
+			}
+			v.Set(p)
+			return nil
+		},
+		empty: func(v reflect.Value) bool {
+			return v.IsNil()
+		},
+	}
+	return c, nil
+}
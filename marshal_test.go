@@ -0,0 +1,247 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMarshalKind int32
+
+type testMarshalStruct struct {
+	Bool bool `spec:"1"`
+	Byte byte `spec:"2"`
+
+	Int8  int8  `spec:"10"`
+	Int16 int16 `spec:"11"`
+	Int32 int32 `spec:"12"`
+	Int64 int64 `spec:"13"`
+	Int   int   `spec:"14"`
+
+	Uint16 uint16 `spec:"20"`
+	Uint32 uint32 `spec:"21"`
+	Uint64 uint64 `spec:"22"`
+
+	Float32 float32 `spec:"30"`
+	Float64 float64 `spec:"31"`
+
+	Bin64  bin.Bin64  `spec:"40"`
+	Bin128 bin.Bin128 `spec:"41"`
+	Bin256 bin.Bin256 `spec:"42"`
+
+	String string          `spec:"50"`
+	Bytes  []byte          `spec:"51"`
+	Kind   testMarshalKind `spec:"52"`
+
+	Sub     testMarshalSub            `spec:"60"`
+	SubPtr  *testMarshalSub           `spec:"61"`
+	Ints    []int64                   `spec:"62"`
+	Strings []string                  `spec:"63"`
+	Subs    []*testMarshalSub         `spec:"64"`
+	Counts  map[string]int32          `spec:"65"`
+	SubMap  map[int32]*testMarshalSub `spec:"66"`
+
+	Any     Value   `spec:"70"`
+	Message Message `spec:"71"`
+
+	Skipped  string `spec:"-"`
+	Untagged string
+}
+
+type testMarshalSub struct {
+	Value string          `spec:"1"`
+	Next  *testMarshalSub `spec:"2"`
+}
+
+func testMarshalObject(t *testing.T) *testMarshalStruct {
+	vw := NewValueWriter()
+	require.NoError(t, vw.Int32(123))
+	value, err := vw.Build()
+	require.NoError(t, err)
+
+	w := NewMessageWriter()
+	w.Field(1).String("hello")
+	msg, err := w.Build()
+	require.NoError(t, err)
+
+	return &testMarshalStruct{
+		Bool: true,
+		Byte: 255,
+
+		Int8:  math.MinInt8,
+		Int16: math.MinInt16,
+		Int32: math.MinInt32,
+		Int64: math.MinInt64,
+		Int:   math.MaxInt64,
+
+		Uint16: math.MaxUint16,
+		Uint32: math.MaxUint32,
+		Uint64: math.MaxUint64,
+
+		Float32: math.MaxFloat32,
+		Float64: math.MaxFloat64,
+
+		Bin64:  bin.Int64(1),
+		Bin128: bin.Int128(0, 2),
+		Bin256: bin.Int256(0, 0, 0, 3),
+
+		String: "hello, world",
+		Bytes:  []byte("goodbye, world"),
+		Kind:   3,
+
+		Sub: testMarshalSub{
+			Value: "sub",
+			Next:  &testMarshalSub{Value: "next"},
+		},
+		SubPtr:  &testMarshalSub{Value: "ptr"},
+		Ints:    []int64{1, 2, 3},
+		Strings: []string{"a", "b", "c"},
+		Subs: []*testMarshalSub{
+			{Value: "1"},
+			{Value: "2"},
+		},
+		Counts: map[string]int32{"a": 1, "b": 2},
+		SubMap: map[int32]*testMarshalSub{
+			1: {Value: "1"},
+			2: {Value: "2"},
+		},
+
+		Any:     Value(value),
+		Message: OpenMessage(msg),
+	}
+}
+
+func TestMarshal__should_marshal_and_unmarshal_struct(t *testing.T) {
+	obj := testMarshalObject(t)
+
+	b, err := Marshal(obj)
+	require.NoError(t, err)
+
+	var obj1 testMarshalStruct
+	err = Unmarshal(b, &obj1)
+	require.NoError(t, err)
+
+	assert.Equal(t, obj, &obj1)
+}
+
+func TestMarshal__should_write_message_fields_by_tags(t *testing.T) {
+	obj := testMarshalObject(t)
+
+	b, err := Marshal(obj)
+	require.NoError(t, err)
+	msg := OpenMessage(b)
+
+	assert.Equal(t, int32(math.MinInt32), msg.Int32(12))
+	assert.Equal(t, int32(3), msg.Int32(52))
+	assert.Equal(t, "hello, world", msg.String(50).Unwrap())
+	assert.Equal(t, "next", msg.Message(60).Message(2).String(1).Unwrap())
+	assert.Equal(t, 3, msg.List(62).Len())
+	assert.Equal(t, int32(123), msg.Field(70).Int32())
+	assert.False(t, msg.HasField(1000))
+}
+
+func TestMarshal__should_skip_nil_pointers_and_empty_collections(t *testing.T) {
+	obj := &testMarshalStruct{Skipped: "skipped", Untagged: "untagged"}
+
+	b, err := Marshal(obj)
+	require.NoError(t, err)
+	msg := OpenMessage(b)
+
+	assert.True(t, msg.HasField(60))
+	assert.False(t, msg.HasField(61))
+	assert.False(t, msg.HasField(62))
+	assert.False(t, msg.HasField(65))
+	assert.False(t, msg.HasField(70))
+	assert.False(t, msg.HasField(71))
+
+	var obj1 testMarshalStruct
+	err = Unmarshal(b, &obj1)
+	require.NoError(t, err)
+	assert.Equal(t, "", obj1.Skipped)
+	assert.Equal(t, "", obj1.Untagged)
+}
+
+func TestUnmarshal__should_not_reference_source_bytes(t *testing.T) {
+	obj := testMarshalObject(t)
+
+	b, err := Marshal(obj)
+	require.NoError(t, err)
+
+	var obj1 testMarshalStruct
+	err = Unmarshal(b, &obj1)
+	require.NoError(t, err)
+	clear(b)
+
+	assert.Equal(t, "hello, world", obj1.String)
+	assert.Equal(t, []byte("goodbye, world"), obj1.Bytes)
+	assert.Equal(t, "next", obj1.Sub.Next.Value)
+	assert.Equal(t, int32(123), obj1.Any.Int32())
+}
+
+func TestUnmarshal__should_return_error_on_overflow(t *testing.T) {
+	type From struct {
+		Value int32 `spec:"1"`
+	}
+	type To struct {
+		Value int16 `spec:"1"`
+	}
+
+	b, err := Marshal(From{Value: math.MaxInt32})
+	require.NoError(t, err)
+
+	var to To
+	err = Unmarshal(b, &to)
+	assert.ErrorContains(t, err, "overflows int16")
+}
+
+func TestMarshal__should_return_error_on_invalid_struct(t *testing.T) {
+	type Duplicate struct {
+		A int32 `spec:"1"`
+		B int32 `spec:"1"`
+	}
+	type InvalidTag struct {
+		A int32 `spec:"a"`
+	}
+	type Unsupported struct {
+		A chan int `spec:"1"`
+	}
+
+	_, err := Marshal(Duplicate{})
+	assert.ErrorContains(t, err, "duplicate spec tag 1")
+
+	_, err = Marshal(&InvalidTag{})
+	assert.ErrorContains(t, err, "invalid spec tag")
+
+	_, err = Marshal(Unsupported{})
+	assert.ErrorContains(t, err, "unsupported type chan int")
+
+	_, err = Marshal(1)
+	assert.Error(t, err)
+
+	err = Unmarshal(nil, testMarshalSub{})
+	assert.Error(t, err)
+}
+
+func TestMarshal__should_cache_struct_codecs(t *testing.T) {
+	typ := reflect.TypeFor[testMarshalSub]()
+
+	c, err := structCodecOf(typ)
+	require.NoError(t, err)
+
+	c1, err := structCodecOf(typ)
+	require.NoError(t, err)
+	assert.Same(t, c, c1)
+
+	// Compiled codecs are stored in the type cache
+	next, ok := codecs.cache.Load(typ)
+	require.True(t, ok)
+	assert.Same(t, c, next)
+}
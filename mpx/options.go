@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/basecomplextech/baselibrary/units"
+	"github.com/basecomplextech/spec"
 )
 
 type Options struct {
@@ -38,6 +39,16 @@ type Options struct {
 
 	// WriteQueueSize is a max connection write queue size (soft limit).
 	WriteQueueSize units.Bytes `json:"write_queue_size"`
+
+	// Parsing
+
+	// Parse specifies limits for parsing incoming messages, used by RPC servers.
+	Parse spec.ParseOptions `json:"parse"`
+
+	// Deprecation
+
+	// LogDeprecated enables logging calls to deprecated methods, used by RPC servers.
+	LogDeprecated bool `json:"log_deprecated"`
 }
 
 // Default
@@ -55,6 +66,11 @@ func Default() Options {
 		ReadBufferSize:  32 * units.KiB,
 		WriteBufferSize: 32 * units.KiB,
 		WriteQueueSize:  16 * units.MiB,
+
+		Parse: spec.ParseOptions{
+			MaxDepth: 64,
+			MaxSize:  int(16 * units.MiB),
+		},
 	}
 }
 
@@ -70,6 +86,14 @@ func (o Options) Merge(o1 Options) Options {
 	o.ReadBufferSize = nonzero(o.ReadBufferSize, o1.ReadBufferSize)
 	o.WriteBufferSize = nonzero(o.WriteBufferSize, o1.WriteBufferSize)
 	o.WriteQueueSize = nonzero(o.WriteQueueSize, o1.WriteQueueSize)
+
+	o.Parse.MaxDepth = nonzero(o.Parse.MaxDepth, o1.Parse.MaxDepth)
+	o.Parse.MaxSize = nonzero(o.Parse.MaxSize, o1.Parse.MaxSize)
+	o.Parse.MaxListLen = nonzero(o.Parse.MaxListLen, o1.Parse.MaxListLen)
+	o.Parse.MaxMessageFields = nonzero(o.Parse.MaxMessageFields, o1.Parse.MaxMessageFields)
+	o.Parse.MaxBytesLen = nonzero(o.Parse.MaxBytesLen, o1.Parse.MaxBytesLen)
+
+	o.LogDeprecated = o1.LogDeprecated
 	return o
 }
 
@@ -30,3 +30,8 @@ func OpenMessageErr(b []byte) (Message, error) {
 func ParseMessage(b []byte) (_ Message, size int, err error) {
 	return types.ParseMessage(b)
 }
+
+// ParseMessageOpts recursively parses and returns a message, checks the parse limits.
+func ParseMessageOpts(b []byte, opts ParseOptions) (_ Message, size int, err error) {
+	return types.ParseMessageOpts(b, opts)
+}
@@ -0,0 +1,13 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import "github.com/basecomplextech/spec/internal/types"
+
+// ParseOptions specifies limits for recursive parsing, zero values mean no limits.
+type ParseOptions = types.ParseOptions
+
+// ErrLimitExceeded is returned when a parsed value exceeds parse limits.
+var ErrLimitExceeded = types.ErrLimitExceeded
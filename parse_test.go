@@ -0,0 +1,145 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testParseMessage(t *testing.T, depth int) []byte {
+	w := NewMessageWriter()
+	w.Field(1).String("hello")
+
+	list := w.Field(2).List()
+	list.Int32(1)
+	list.Int32(2)
+	list.Int32(3)
+	if err := list.End(); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := []MessageWriter{w.Field(3).Message()}
+	for i := 0; i < depth; i++ {
+		msg := msgs[len(msgs)-1].Field(1).Message()
+		msgs = append(msgs, msg)
+	}
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if err := msgs[i].End(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestParseMessageOpts__should_parse_message_within_limits(t *testing.T) {
+	b := testParseMessage(t, 2)
+
+	opts := ParseOptions{
+		MaxDepth:         4,
+		MaxSize:          len(b),
+		MaxListLen:       3,
+		MaxMessageFields: 3,
+		MaxBytesLen:      5,
+	}
+
+	_, _, err := ParseMessageOpts(b, opts)
+	assert.NoError(t, err)
+}
+
+func TestParseMessageOpts__should_return_error_when_depth_exceeded(t *testing.T) {
+	b := testParseMessage(t, 2)
+
+	_, _, err := ParseMessageOpts(b, ParseOptions{MaxDepth: 3})
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestParseMessageOpts__should_return_error_when_size_exceeded(t *testing.T) {
+	b := testParseMessage(t, 0)
+
+	_, _, err := ParseMessageOpts(b, ParseOptions{MaxSize: len(b) - 1})
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestParseMessageOpts__should_return_error_when_list_len_exceeded(t *testing.T) {
+	b := testParseMessage(t, 0)
+
+	_, _, err := ParseMessageOpts(b, ParseOptions{MaxListLen: 2})
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestParseMessageOpts__should_return_error_when_message_fields_exceeded(t *testing.T) {
+	b := testParseMessage(t, 0)
+
+	_, _, err := ParseMessageOpts(b, ParseOptions{MaxMessageFields: 2})
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+}
+
+func TestParseValueOpts__should_return_error_when_string_len_exceeded(t *testing.T) {
+	w := NewValueWriter()
+	w.String(strings.Repeat("a", 10))
+
+	b, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = ParseValueOpts(b, ParseOptions{MaxBytesLen: 9})
+	assert.ErrorIs(t, err, ErrLimitExceeded)
+
+	_, _, err = ParseValueOpts(b, ParseOptions{MaxBytesLen: 10})
+	assert.NoError(t, err)
+}
+
+// Errors
+
+func TestParseMessage__should_return_decode_error_with_offset_and_path(t *testing.T) {
+	w := NewMessageWriter()
+	w.Field(1).Int32(1)
+
+	list := w.Field(63).List()
+	for i := 0; i < 5; i++ {
+		msg := list.Message()
+		msg.Field(1).Int32(int32(i))
+		if i == 4 {
+			msg.Field(2).String("marker")
+		}
+		if err := msg.End(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := list.End(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := w.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Corrupt the marker string type
+	offset := bytes.Index(b, []byte("marker"))
+	for b[offset] != byte(TypeString) {
+		offset++
+	}
+	b[offset] = 0xff
+
+	_, _, err = ParseMessage(b)
+
+	var e *DecodeError
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, offset, e.Offset)
+	assert.Equal(t, "field 63 -> list[4] -> field 2", e.PathString())
+}
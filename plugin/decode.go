@@ -0,0 +1,544 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/basecomplextech/spec"
+	"github.com/basecomplextech/spec/internal/lang/langindex"
+	"github.com/basecomplextech/spec/lang"
+	"github.com/basecomplextech/spec/proto/pgen"
+)
+
+// decodeRequest decodes a plugin request, links imports and resolves type references.
+func decodeRequest(b []byte) (*Request, error) {
+	preq, _, err := pgen.ParseRequest(b)
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDecoder()
+	pimports := preq.Imports()
+	for i := 0; i < pimports.Len(); i++ {
+		d.pkg(pimports.Get(i))
+	}
+	pkg := d.pkg(preq.Package())
+
+	if err := d.link(); err != nil {
+		return nil, err
+	}
+	return &Request{Package: pkg}, nil
+}
+
+// decodeResponse decodes a plugin response, returns its error if any.
+func decodeResponse(b []byte) ([]File, error) {
+	presp, _, err := pgen.ParseResponse(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if msg := presp.Error().Clone(); msg != "" {
+		return nil, errors.New(msg)
+	}
+
+	pfiles := presp.Files()
+	files := make([]File, 0, pfiles.Len())
+	for i := 0; i < pfiles.Len(); i++ {
+		pfile := pfiles.Get(i)
+
+		file := File{
+			Name:    pfile.Name().Clone(),
+			Content: pfile.Content().Clone(),
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// internal
+
+type decoder struct {
+	pkgs []*lang.Package
+	ids  map[string]*lang.Package
+
+	// pending definitions, filled after all packages are decoded
+	defs  []*lang.Definition
+	pdefs []pgen.Definition
+
+	// pending imports, linked after all packages are decoded
+	imports []*lang.Import
+}
+
+func newDecoder() *decoder {
+	return &decoder{
+		ids: make(map[string]*lang.Package),
+	}
+}
+
+// package
+
+func (d *decoder) pkg(p pgen.Package) *lang.Package {
+	pkg := &lang.Package{
+		ID:   p.Id().Clone(),
+		Name: p.Name().Clone(),
+		Path: p.Path().Clone(),
+	}
+	d.pkgs = append(d.pkgs, pkg)
+	d.ids[pkg.ID] = pkg
+
+	pfiles := p.Files()
+	for i := 0; i < pfiles.Len(); i++ {
+		file := d.file(pkg, pfiles.Get(i))
+		pkg.Files = append(pkg.Files, file)
+		pkg.Options = append(pkg.Options, file.Options...)
+	}
+
+	pwarnings := p.Warnings()
+	for i := 0; i < pwarnings.Len(); i++ {
+		pkg.Warnings = append(pkg.Warnings, pwarnings.Get(i).Clone())
+	}
+
+	langindex.Package(pkg)
+	return pkg
+}
+
+func (d *decoder) file(pkg *lang.Package, p pgen.File) *lang.File {
+	file := &lang.File{
+		Package: pkg,
+		Name:    p.Name().Clone(),
+		Path:    p.Path().Clone(),
+	}
+
+	pimports := p.Imports()
+	for i := 0; i < pimports.Len(); i++ {
+		pimp := pimports.Get(i)
+
+		imp := &lang.Import{
+			File: file,
+			ID:   pimp.Id().Clone(),
+			Name: pimp.Name().Clone(),
+			Pos:  decodePosition(file, pimp.Pos()),
+		}
+		file.Imports = append(file.Imports, imp)
+		d.imports = append(d.imports, imp)
+	}
+
+	poptions := p.Options()
+	for i := 0; i < poptions.Len(); i++ {
+		popt := poptions.Get(i)
+
+		opt := &lang.Option{
+			Name:  popt.Name().Clone(),
+			Value: popt.Value().Clone(),
+			Pos:   decodePosition(file, popt.Pos()),
+		}
+		file.Options = append(file.Options, opt)
+	}
+
+	pdefs := p.Definitions()
+	for i := 0; i < pdefs.Len(); i++ {
+		pdef := pdefs.Get(i)
+
+		def := &lang.Definition{
+			Package: pkg,
+			File:    file,
+
+			Name:      pdef.Name().Clone(),
+			Type:      lang.DefinitionType(pdef.Type().Clone()),
+			Doc:       pdef.Doc().Clone(),
+			Pos:       decodePosition(file, pdef.Pos()),
+			Generated: pdef.Generated(),
+		}
+		file.Definitions = append(file.Definitions, def)
+		pkg.Definitions = append(pkg.Definitions, def)
+
+		d.defs = append(d.defs, def)
+		d.pdefs = append(d.pdefs, pdef)
+	}
+	return file
+}
+
+// link links imports and fills definitions, enums are filled first
+// because field defaults can reference enum values.
+func (d *decoder) link() error {
+	for _, imp := range d.imports {
+		pkg, ok := d.ids[imp.ID]
+		if !ok {
+			return fmt.Errorf("imported package not found: %v", imp.ID)
+		}
+		imp.Package = pkg
+	}
+
+	for i, def := range d.defs {
+		if def.Type != lang.DefinitionEnum {
+			continue
+		}
+		if err := d.definition(def, d.pdefs[i]); err != nil {
+			return err
+		}
+	}
+	for i, def := range d.defs {
+		if def.Type == lang.DefinitionEnum {
+			continue
+		}
+		if err := d.definition(def, d.pdefs[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// definitions
+
+func (d *decoder) definition(def *lang.Definition, p pgen.Definition) (err error) {
+	def.Annotations, err = d.annotations(p.Annotations())
+	if err != nil {
+		return err
+	}
+
+	switch def.Type {
+	case lang.DefinitionEnum:
+		def.Enum, err = d.enum(def, p.EnumDef())
+	case lang.DefinitionMessage:
+		def.Message, err = d.message(def, p.MessageDef())
+	case lang.DefinitionStruct:
+		def.Struct, err = d.struct_(def, p.StructDef())
+	case lang.DefinitionService:
+		def.Service, err = d.service(def, p.ServiceDef())
+	default:
+		err = fmt.Errorf("unsupported definition type %q", def.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("%v: %w", def.Name, err)
+	}
+	return nil
+}
+
+func (d *decoder) enum(def *lang.Definition, p pgen.Enum) (*lang.Enum, error) {
+	enum := &lang.Enum{
+		Def:      def,
+		Reserved: decodeReserved(p.Reserved()),
+	}
+
+	pvalues := p.Values()
+	for i := 0; i < pvalues.Len(); i++ {
+		pval := pvalues.Get(i)
+
+		annots, err := d.annotations(pval.Annotations())
+		if err != nil {
+			return nil, err
+		}
+
+		val := &lang.EnumValue{
+			Enum: enum,
+
+			Name:   pval.Name().Clone(),
+			Number: int(pval.Number()),
+			Doc:    pval.Doc().Clone(),
+			Pos:    decodePosition(def.File, pval.Pos()),
+
+			Annotations: annots,
+		}
+		enum.Values = append(enum.Values, val)
+	}
+	return enum, nil
+}
+
+func (d *decoder) message(def *lang.Definition, p pgen.Message) (*lang.Message, error) {
+	msg := &lang.Message{
+		Def:      def,
+		Reserved: decodeReserved(p.Reserved()),
+	}
+
+	poneofs := p.Oneofs()
+	for i := 0; i < poneofs.Len(); i++ {
+		poneof := poneofs.Get(i)
+
+		oneof := &lang.Oneof{
+			Name: poneof.Name().Clone(),
+			Doc:  poneof.Doc().Clone(),
+			Pos:  decodePosition(def.File, poneof.Pos()),
+		}
+		msg.Oneofs = append(msg.Oneofs, oneof)
+	}
+
+	pfields := p.Fields()
+	for i := 0; i < pfields.Len(); i++ {
+		field, err := d.field(msg, pfields.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		msg.Fields = append(msg.Fields, field)
+	}
+	return msg, nil
+}
+
+func (d *decoder) field(msg *lang.Message, p pgen.Field) (*lang.Field, error) {
+	typ, err := d.type_(p.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	annots, err := d.annotations(p.Annotations())
+	if err != nil {
+		return nil, err
+	}
+
+	field := &lang.Field{
+		Message: msg,
+
+		Name: p.Name().Clone(),
+		Tag:  int(p.Tag()),
+		Type: typ,
+		Doc:  p.Doc().Clone(),
+		Pos:  decodePosition(msg.Def.File, p.Pos()),
+
+		Annotations: annots,
+	}
+
+	// Oneof
+	if name := p.OneofName().Unwrap(); name != "" {
+		for _, oneof := range msg.Oneofs {
+			if oneof.Name == name {
+				field.Oneof = oneof
+				oneof.Fields = append(oneof.Fields, field)
+				break
+			}
+		}
+		if field.Oneof == nil {
+			return nil, fmt.Errorf("%v: oneof not found: %v", field.Name, name)
+		}
+	}
+
+	// Default
+	if p.HasDefault() {
+		pdef := p.Default()
+
+		value, err := d.value(pdef.Value(), typ)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", field.Name, err)
+		}
+
+		field.Default = &lang.Default{
+			Text:  pdef.Text().Clone(),
+			Value: value,
+		}
+	}
+	return field, nil
+}
+
+func (d *decoder) struct_(def *lang.Definition, p pgen.Struct) (*lang.Struct, error) {
+	str := &lang.Struct{Def: def}
+
+	pfields := p.Fields()
+	for i := 0; i < pfields.Len(); i++ {
+		pfield := pfields.Get(i)
+
+		typ, err := d.type_(pfield.Type())
+		if err != nil {
+			return nil, err
+		}
+		annots, err := d.annotations(pfield.Annotations())
+		if err != nil {
+			return nil, err
+		}
+
+		field := &lang.StructField{
+			Struct: str,
+
+			Name: pfield.Name().Clone(),
+			Type: typ,
+			Doc:  pfield.Doc().Clone(),
+			Pos:  decodePosition(def.File, pfield.Pos()),
+
+			Annotations: annots,
+		}
+		str.Fields = append(str.Fields, field)
+	}
+	return str, nil
+}
+
+func (d *decoder) service(def *lang.Definition, p pgen.Service) (*lang.Service, error) {
+	srv := &lang.Service{
+		Def: def,
+		Sub: p.Sub(),
+	}
+
+	pmethods := p.Methods()
+	for i := 0; i < pmethods.Len(); i++ {
+		m, err := d.method(srv, pmethods.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		srv.Methods = append(srv.Methods, m)
+	}
+	return srv, nil
+}
+
+func (d *decoder) method(srv *lang.Service, p pgen.Method) (_ *lang.Method, err error) {
+	m := &lang.Method{
+		Service: srv,
+
+		Name: p.Name().Clone(),
+		Type: lang.MethodType(p.Type().Clone()),
+		Doc:  p.Doc().Clone(),
+		Pos:  decodePosition(srv.Def.File, p.Pos()),
+	}
+
+	m.Annotations, err = d.annotations(p.Annotations())
+	if err != nil {
+		return nil, err
+	}
+
+	if p.HasRequest() {
+		if m.Request, err = d.type_(p.Request()); err != nil {
+			return nil, err
+		}
+	}
+	if p.HasResponse() {
+		if m.Response, err = d.type_(p.Response()); err != nil {
+			return nil, err
+		}
+	}
+	if p.HasChannel() {
+		pch := p.Channel()
+		m.Channel = &lang.Channel{}
+
+		if pch.HasIn() {
+			if m.Channel.In, err = d.type_(pch.In()); err != nil {
+				return nil, err
+			}
+		}
+		if pch.HasOut() {
+			if m.Channel.Out, err = d.type_(pch.Out()); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if p.HasSubservice() {
+		if m.Subservice, err = d.type_(p.Subservice()); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// types
+
+func (d *decoder) type_(p pgen.Type) (_ *lang.Type, err error) {
+	t := &lang.Type{
+		Kind:   spec.Kind(p.Kind()),
+		Name:   p.Name().Clone(),
+		Import: p.Import().Clone(),
+	}
+
+	if p.HasKey() {
+		if t.Key, err = d.type_(p.Key()); err != nil {
+			return nil, err
+		}
+	}
+	if p.HasElement() {
+		if t.Element, err = d.type_(p.Element()); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.HasPackage() {
+		id := p.Package().Unwrap()
+		pkg, ok := d.ids[id]
+		if !ok {
+			return nil, fmt.Errorf("package not found: %v", id)
+		}
+
+		t.Ref = pkg.Definition(t.Name)
+		if t.Ref == nil {
+			return nil, fmt.Errorf("type not found: %v.%v", id, t.Name)
+		}
+	}
+	return t, nil
+}
+
+// values
+
+func (d *decoder) annotations(p spec.MessageList[pgen.Annotation]) (spec.Annotations, error) {
+	n := p.Len()
+	if n == 0 {
+		return nil, nil
+	}
+
+	result := make(spec.Annotations, n)
+	for i := 0; i < n; i++ {
+		pannot := p.Get(i)
+
+		value, err := d.value(pannot.Value(), nil)
+		if err != nil {
+			return nil, err
+		}
+		result[pannot.Name().Clone()] = value
+	}
+	return result, nil
+}
+
+// value decodes a value, enum values are resolved using a type.
+func (d *decoder) value(p pgen.Value, typ *lang.Type) (any, error) {
+	switch p.WhichKind() {
+	case pgen.ValueKind_BoolValue:
+		return p.BoolValue(), nil
+	case pgen.ValueKind_IntValue:
+		return p.IntValue(), nil
+	case pgen.ValueKind_UintValue:
+		return p.UintValue(), nil
+	case pgen.ValueKind_FloatValue:
+		return p.FloatValue(), nil
+	case pgen.ValueKind_StringValue:
+		return p.StringValue().Clone(), nil
+
+	case pgen.ValueKind_EnumValue:
+		name := p.EnumValue().Unwrap()
+		if typ == nil || typ.Ref == nil || typ.Ref.Enum == nil {
+			return nil, fmt.Errorf("enum value %v without enum type", name)
+		}
+
+		val := typ.Ref.Enum.Value(name)
+		if val == nil {
+			return nil, fmt.Errorf("enum value not found: %v", name)
+		}
+		return val, nil
+	}
+	return nil, fmt.Errorf("unsupported value")
+}
+
+func decodeReserved(p pgen.Reserved) lang.Reserved {
+	var r lang.Reserved
+
+	pranges := p.Ranges()
+	for i := 0; i < pranges.Len(); i++ {
+		prng := pranges.Get(i)
+		r.Ranges = append(r.Ranges, lang.ReservedRange{
+			Start: int(prng.Start),
+			End:   int(prng.End),
+		})
+	}
+
+	pnames := p.Names()
+	for i := 0; i < pnames.Len(); i++ {
+		r.Names = append(r.Names, pnames.Get(i).Clone())
+	}
+	return r
+}
+
+func decodePosition(file *lang.File, p pgen.Position) lang.Position {
+	if p.Line <= 0 {
+		return lang.Position{}
+	}
+
+	return lang.Position{
+		Path:   file.Path,
+		Line:   int(p.Line),
+		Column: int(p.Column),
+	}
+}
@@ -0,0 +1,459 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/basecomplextech/spec"
+	"github.com/basecomplextech/spec/lang"
+	"github.com/basecomplextech/spec/proto/pgen"
+)
+
+// encodeRequest encodes a package and its transitive imports into a plugin request.
+func encodeRequest(pkg *lang.Package) ([]byte, error) {
+	w := pgen.NewRequestWriter()
+	if err := encodePackage(w.Package(), pkg); err != nil {
+		return nil, err
+	}
+
+	imports := w.Imports()
+	for _, imp := range packageImports(pkg) {
+		if err := encodePackage(imports.Add(), imp); err != nil {
+			return nil, err
+		}
+	}
+	if err := imports.End(); err != nil {
+		return nil, err
+	}
+
+	req, err := w.Build()
+	if err != nil {
+		return nil, err
+	}
+	return req.Unwrap().Raw(), nil
+}
+
+// encodeResponse encodes generated files or an error into a plugin response.
+func encodeResponse(files []File, err error) ([]byte, error) {
+	w := pgen.NewResponseWriter()
+	if err != nil {
+		w.Error(err.Error())
+	}
+
+	list := w.Files()
+	for _, file := range files {
+		w1 := list.Add()
+		w1.Name(file.Name)
+		w1.Content(file.Content)
+		if err := w1.End(); err != nil {
+			return nil, err
+		}
+	}
+	if err := list.End(); err != nil {
+		return nil, err
+	}
+
+	resp, err := w.Build()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Unwrap().Raw(), nil
+}
+
+// package
+
+func encodePackage(w pgen.PackageWriter, pkg *lang.Package) error {
+	w.Id(pkg.ID)
+	w.Name(pkg.Name)
+	w.Path(pkg.Path)
+
+	files := w.Files()
+	for _, file := range pkg.Files {
+		if err := encodeFile(files.Add(), file); err != nil {
+			return err
+		}
+	}
+	if err := files.End(); err != nil {
+		return err
+	}
+
+	warnings := w.Warnings()
+	for _, warning := range pkg.Warnings {
+		warnings.Add(warning)
+	}
+	if err := warnings.End(); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeFile(w pgen.FileWriter, file *lang.File) error {
+	w.Name(file.Name)
+	w.Path(file.Path)
+
+	imports := w.Imports()
+	for _, imp := range file.Imports {
+		w1 := imports.Add()
+		w1.Id(imp.ID)
+		w1.Name(imp.Name)
+		w1.Pos(encodePosition(imp.Pos))
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	if err := imports.End(); err != nil {
+		return err
+	}
+
+	options := w.Options()
+	for _, opt := range file.Options {
+		w1 := options.Add()
+		w1.Name(opt.Name)
+		w1.Value(opt.Value)
+		w1.Pos(encodePosition(opt.Pos))
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	if err := options.End(); err != nil {
+		return err
+	}
+
+	defs := w.Definitions()
+	for _, def := range file.Definitions {
+		if err := encodeDefinition(defs.Add(), def); err != nil {
+			return err
+		}
+	}
+	if err := defs.End(); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+// definitions
+
+func encodeDefinition(w pgen.DefinitionWriter, def *lang.Definition) error {
+	w.Name(def.Name)
+	w.Type(string(def.Type))
+	w.Doc(def.Doc)
+	w.Pos(encodePosition(def.Pos))
+	w.Generated(def.Generated)
+
+	if err := encodeAnnotations(w.Annotations(), def.Annotations); err != nil {
+		return err
+	}
+
+	var err error
+	switch def.Type {
+	case lang.DefinitionEnum:
+		err = encodeEnum(w.EnumDef(), def.Enum)
+	case lang.DefinitionMessage:
+		err = encodeMessage(w.MessageDef(), def.Message)
+	case lang.DefinitionStruct:
+		err = encodeStruct(w.StructDef(), def.Struct)
+	case lang.DefinitionService:
+		err = encodeService(w.ServiceDef(), def.Service)
+	default:
+		err = fmt.Errorf("unsupported definition type %q", def.Type)
+	}
+	if err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeEnum(w pgen.EnumWriter, enum *lang.Enum) error {
+	values := w.Values()
+	for _, val := range enum.Values {
+		w1 := values.Add()
+		w1.Name(val.Name)
+		w1.Number(int32(val.Number))
+		w1.Doc(val.Doc)
+		w1.Pos(encodePosition(val.Pos))
+
+		if err := encodeAnnotations(w1.Annotations(), val.Annotations); err != nil {
+			return err
+		}
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	if err := values.End(); err != nil {
+		return err
+	}
+
+	if err := encodeReserved(w.Reserved(), enum.Reserved); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeMessage(w pgen.MessageWriter, msg *lang.Message) error {
+	fields := w.Fields()
+	for _, field := range msg.Fields {
+		if err := encodeField(fields.Add(), field); err != nil {
+			return err
+		}
+	}
+	if err := fields.End(); err != nil {
+		return err
+	}
+
+	oneofs := w.Oneofs()
+	for _, oneof := range msg.Oneofs {
+		w1 := oneofs.Add()
+		w1.Name(oneof.Name)
+		w1.Doc(oneof.Doc)
+		w1.Pos(encodePosition(oneof.Pos))
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	if err := oneofs.End(); err != nil {
+		return err
+	}
+
+	if err := encodeReserved(w.Reserved(), msg.Reserved); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeField(w pgen.FieldWriter, field *lang.Field) error {
+	w.Name(field.Name)
+	w.Tag(int32(field.Tag))
+	if err := encodeType(w.Type(), field.Type); err != nil {
+		return err
+	}
+	if field.Oneof != nil {
+		w.OneofName(field.Oneof.Name)
+	}
+
+	if d := field.Default; d != nil {
+		w1 := w.Default()
+		w1.Text(d.Text)
+		if err := encodeValue(w1.Value(), d.Value); err != nil {
+			return err
+		}
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+
+	w.Doc(field.Doc)
+	w.Pos(encodePosition(field.Pos))
+
+	if err := encodeAnnotations(w.Annotations(), field.Annotations); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeStruct(w pgen.StructWriter, str *lang.Struct) error {
+	fields := w.Fields()
+	for _, field := range str.Fields {
+		w1 := fields.Add()
+		w1.Name(field.Name)
+		if err := encodeType(w1.Type(), field.Type); err != nil {
+			return err
+		}
+		w1.Doc(field.Doc)
+		w1.Pos(encodePosition(field.Pos))
+
+		if err := encodeAnnotations(w1.Annotations(), field.Annotations); err != nil {
+			return err
+		}
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	if err := fields.End(); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeService(w pgen.ServiceWriter, srv *lang.Service) error {
+	w.Sub(srv.Sub)
+
+	methods := w.Methods()
+	for _, m := range srv.Methods {
+		if err := encodeMethod(methods.Add(), m); err != nil {
+			return err
+		}
+	}
+	if err := methods.End(); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodeMethod(w pgen.MethodWriter, m *lang.Method) error {
+	w.Name(m.Name)
+	w.Type(string(m.Type))
+	w.Doc(m.Doc)
+	w.Pos(encodePosition(m.Pos))
+
+	if err := encodeAnnotations(w.Annotations(), m.Annotations); err != nil {
+		return err
+	}
+
+	if t := m.Request; t != nil {
+		if err := encodeType(w.Request(), t); err != nil {
+			return err
+		}
+	}
+	if t := m.Response; t != nil {
+		if err := encodeType(w.Response(), t); err != nil {
+			return err
+		}
+	}
+	if ch := m.Channel; ch != nil {
+		w1 := w.Channel()
+		if ch.In != nil {
+			if err := encodeType(w1.In(), ch.In); err != nil {
+				return err
+			}
+		}
+		if ch.Out != nil {
+			if err := encodeType(w1.Out(), ch.Out); err != nil {
+				return err
+			}
+		}
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	if t := m.Subservice; t != nil {
+		if err := encodeType(w.Subservice(), t); err != nil {
+			return err
+		}
+	}
+	return w.End()
+}
+
+// types
+
+func encodeType(w pgen.TypeWriter, t *lang.Type) error {
+	w.Kind(int32(t.Kind))
+	w.Name(t.Name)
+
+	if t.Key != nil {
+		if err := encodeType(w.Key(), t.Key); err != nil {
+			return err
+		}
+	}
+	if t.Element != nil {
+		if err := encodeType(w.Element(), t.Element); err != nil {
+			return err
+		}
+	}
+
+	w.Import(t.Import)
+	if t.Ref != nil {
+		w.Package(t.Ref.Package.ID)
+	}
+	return w.End()
+}
+
+// values
+
+func encodeAnnotations(w spec.MessageListWriter[pgen.AnnotationWriter], a spec.Annotations) error {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		w1 := w.Add()
+		w1.Name(name)
+		if err := encodeValue(w1.Value(), a[name]); err != nil {
+			return err
+		}
+		if err := w1.End(); err != nil {
+			return err
+		}
+	}
+	return w.End()
+}
+
+func encodeValue(w pgen.ValueWriter, v any) error {
+	switch v := v.(type) {
+	case bool:
+		w.BoolValue(v)
+	case int64:
+		w.IntValue(v)
+	case uint64:
+		w.UintValue(v)
+	case float64:
+		w.FloatValue(v)
+	case string:
+		w.StringValue(v)
+	case *lang.EnumValue:
+		w.EnumValue(v.Name)
+	default:
+		return fmt.Errorf("unsupported value %T", v)
+	}
+	return w.End()
+}
+
+func encodeReserved(w pgen.ReservedWriter, r lang.Reserved) error {
+	ranges := w.Ranges()
+	for _, rng := range r.Ranges {
+		ranges.Add(pgen.ReservedRange{
+			Start: int32(rng.Start),
+			End:   int32(rng.End),
+		})
+	}
+	if err := ranges.End(); err != nil {
+		return err
+	}
+
+	names := w.Names()
+	for _, name := range r.Names {
+		names.Add(name)
+	}
+	if err := names.End(); err != nil {
+		return err
+	}
+	return w.End()
+}
+
+func encodePosition(pos lang.Position) pgen.Position {
+	return pgen.Position{
+		Line:   int32(pos.Line),
+		Column: int32(pos.Column),
+	}
+}
+
+// util
+
+// packageImports returns transitive package imports in dependency order.
+func packageImports(pkg *lang.Package) []*lang.Package {
+	var result []*lang.Package
+	seen := map[string]struct{}{pkg.ID: {}}
+
+	var visit func(pkg *lang.Package)
+	visit = func(pkg *lang.Package) {
+		for _, file := range pkg.Files {
+			for _, imp := range file.Imports {
+				if _, ok := seen[imp.ID]; ok {
+					continue
+				}
+				seen[imp.ID] = struct{}{}
+
+				visit(imp.Package)
+				result = append(result, imp.Package)
+			}
+		}
+	}
+
+	visit(pkg)
+	return result
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package plugin implements the generator plugin protocol.
+//
+// A plugin is a spec-gen-<name> executable which is run by "spec generate --plugin=name".
+// It reads a compiled package from stdin as a pgen.Request message, generates files
+// and writes them to stdout as a pgen.Response message, similar to protoc plugins.
+//
+// Example plugin:
+//
+//	func main() {
+//		plugin.Run(func(req *plugin.Request) ([]plugin.File, error) {
+//			var b strings.Builder
+//			for _, def := range req.Package.Definitions {
+//				fmt.Fprintln(&b, def.Name)
+//			}
+//			return []plugin.File{{Name: "definitions.txt", Content: []byte(b.String())}}, nil
+//		})
+//	}
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/basecomplextech/spec/lang"
+)
+
+// Prefix is a plugin executable name prefix.
+const Prefix = "spec-gen-"
+
+// Request is a plugin request.
+type Request struct {
+	// Package is a compiled package, imported packages are available via file imports.
+	Package *lang.Package
+}
+
+// File is a generated file.
+type File struct {
+	Name    string // file path relative to the output directory
+	Content []byte
+}
+
+// Handler generates files from a request.
+type Handler func(req *Request) ([]File, error)
+
+// Run reads a request from stdin, calls a handler and writes a response to stdout,
+// exits with a non-zero code on errors.
+func Run(handler Handler) {
+	if err := Handle(os.Stdin, os.Stdout, handler); err != nil {
+		fmt.Fprintf(os.Stderr, "%v: %v\n", filepath.Base(os.Args[0]), err)
+		os.Exit(1)
+	}
+}
+
+// Handle reads a request from a reader, calls a handler and writes a response to a writer.
+//
+// Handler errors are returned to the spec generator in the response,
+// other errors are returned from the function.
+func Handle(r io.Reader, w io.Writer, handler Handler) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	req, err := decodeRequest(b)
+	if err != nil {
+		return fmt.Errorf("failed to decode request: %w", err)
+	}
+
+	files, err := handler(req)
+	resp, err := encodeResponse(files, err)
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+
+	_, err = w.Write(resp)
+	return err
+}
+
+// Exec runs a spec-gen-<name> plugin with a package and writes generated files to a directory.
+func Exec(name string, pkg *lang.Package, dir string) error {
+	path, err := exec.LookPath(Prefix + name)
+	if err != nil {
+		return fmt.Errorf("plugin %v: %w", name, err)
+	}
+
+	req, err := encodeRequest(pkg)
+	if err != nil {
+		return fmt.Errorf("plugin %v: failed to encode request: %w", name, err)
+	}
+
+	// Run plugin
+	var stdout bytes.Buffer
+	cmd := exec.Command(path)
+	cmd.Stdin = bytes.NewReader(req)
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("plugin %v: %w", name, err)
+	}
+
+	// Decode response
+	files, err := decodeResponse(stdout.Bytes())
+	if err != nil {
+		return fmt.Errorf("plugin %v: %w", name, err)
+	}
+
+	// Write files
+	for _, file := range files {
+		if !filepath.IsLocal(file.Name) {
+			return fmt.Errorf("plugin %v: invalid file name %q, must be a relative path", name, file.Name)
+		}
+
+		path := filepath.Join(dir, file.Name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, file.Content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
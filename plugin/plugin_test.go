@@ -0,0 +1,192 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/basecomplextech/spec/lang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPluginEnv makes the test binary act as a spec-gen-test plugin.
+const testPluginEnv = "SPEC_TEST_PLUGIN"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(testPluginEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "error":
+		Run(func(req *Request) ([]File, error) {
+			return nil, errors.New("test error")
+		})
+	default:
+		Run(testHandler)
+	}
+	os.Exit(0)
+}
+
+func testHandler(req *Request) ([]File, error) {
+	var b strings.Builder
+	for _, def := range req.Package.Definitions {
+		fmt.Fprintln(&b, def.Name)
+	}
+
+	file := File{
+		Name:    filepath.Join("out", req.Package.Name+".txt"),
+		Content: []byte(b.String()),
+	}
+	return []File{file}, nil
+}
+
+func testCompile(t *testing.T, id string) *lang.Package {
+	opts := lang.Options{
+		ImportPaths: []string{"../internal/tests"},
+	}
+
+	pkg, err := lang.Compile(filepath.Join("../internal/tests", id), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pkg
+}
+
+func testRoundtrip(t *testing.T, id string) *lang.Package {
+	pkg := testCompile(t, id)
+
+	b, err := encodeRequest(pkg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := decodeRequest(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req.Package
+}
+
+// testPlugin installs the test binary as a spec-gen-test plugin.
+func testPlugin(t *testing.T, mode string) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if err := os.Symlink(exe, filepath.Join(dir, Prefix+"test")); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv(testPluginEnv, mode)
+}
+
+// Request
+
+func TestRequest__should_encode_decode_package(t *testing.T) {
+	pkg0 := testCompile(t, "pkg1")
+	pkg := testRoundtrip(t, "pkg1")
+
+	assert.Equal(t, pkg0.ID, pkg.ID)
+	assert.Equal(t, pkg0.Name, pkg.Name)
+	assert.Equal(t, pkg0.Warnings, pkg.Warnings)
+	assert.Len(t, pkg.Files, len(pkg0.Files))
+	assert.Len(t, pkg.Definitions, len(pkg0.Definitions))
+
+	goPkg, ok := pkg.Option("go_package")
+	assert.True(t, ok)
+	assert.Equal(t, "github.com/basecomplextech/spec/internal/tests/pkg1", goPkg)
+}
+
+func TestRequest__should_encode_decode_imports(t *testing.T) {
+	pkg := testRoundtrip(t, "pkg1")
+
+	imp := pkg.Files[1].Imports[0]
+	assert.Equal(t, "pkg2", imp.ID)
+	require.NotNil(t, imp.Package)
+
+	field := pkg.Definition("Message").Message.Field("submessages1")
+	require.NotNil(t, field)
+	assert.Equal(t, "[]pkg2.Submessage", field.Type.String())
+	assert.Same(t, imp.Package.Definition("Submessage"), field.Type.Element.Ref)
+}
+
+func TestRequest__should_encode_decode_messages(t *testing.T) {
+	pkg := testRoundtrip(t, "pkg1")
+
+	def := pkg.Definition("Message")
+	assert.True(t, def.Message.Reserved.Contains(5))
+
+	union := pkg.Definition("Union")
+	require.Len(t, union.Message.Oneofs, 1)
+	assert.Same(t, union.Message.Oneofs[0], union.Message.Field("number").Oneof)
+	assert.Equal(t, int64(2), union.Annotations["version"])
+
+	msg := pkg.Definition("Defaults").Message
+	assert.Equal(t, int64(-100), msg.Field("int32").Default.Value)
+	assert.Same(t, pkg.Definition("Enum").Enum.Value("ONE"), msg.Field("enum1").Default.Value)
+
+	reason, ok := pkg.Definition("Enum").Enum.Value("THREE").Deprecated()
+	assert.True(t, ok)
+	assert.Equal(t, "use TEN", reason)
+}
+
+func TestRequest__should_encode_decode_services(t *testing.T) {
+	pkg := testRoundtrip(t, "pkg4")
+
+	def := pkg.Definition("Service")
+	assert.Equal(t, lang.Position{Path: def.File.Path, Line: 10, Column: 1}, def.Pos)
+
+	m := def.Service.Method("method2")
+	require.NotNil(t, m)
+	assert.Equal(t, lang.MethodRequest, m.Type)
+	assert.True(t, m.Request.Ref.Generated)
+
+	m = def.Service.Method("method23")
+	require.NotNil(t, m.Channel)
+	assert.Equal(t, "In", m.Channel.In.Name)
+	assert.Equal(t, "Out", m.Channel.Out.Name)
+
+	m = def.Service.Method("subservice")
+	assert.True(t, m.Subservice.Ref.Service.Sub)
+}
+
+// Exec
+
+func TestExec__should_write_plugin_files(t *testing.T) {
+	testPlugin(t, "ok")
+	pkg := testCompile(t, "pkg1")
+
+	dir := t.TempDir()
+	err := Exec("test", pkg, dir)
+	require.NoError(t, err)
+
+	b, err := os.ReadFile(filepath.Join(dir, "out", "pkg1.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "Message\n")
+}
+
+func TestExec__should_return_plugin_error(t *testing.T) {
+	testPlugin(t, "error")
+	pkg := testCompile(t, "pkg1")
+
+	err := Exec("test", pkg, t.TempDir())
+	require.Error(t, err)
+	assert.Equal(t, "plugin test: test error", err.Error())
+}
+
+func TestExec__should_return_error_when_plugin_not_found(t *testing.T) {
+	pkg := testCompile(t, "pkg1")
+
+	err := Exec("not-found", pkg, t.TempDir())
+	assert.Error(t, err)
+}
@@ -0,0 +1,194 @@
+// Generator plugin protocol, a request is written to a plugin stdin,
+// a response is read from its stdout.
+
+// Request
+
+// Request is a plugin request.
+message Request {
+    package     Package     1;  // compiled package
+    imports     []Package   2;  // imported packages, including transitive imports
+}
+
+message Package {
+    id          string      1;
+    name        string      2;
+    path        string      3;
+    files       []File      4;
+    warnings    []string    5;
+}
+
+message File {
+    name        string          1;
+    path        string          2;
+    imports     []Import        3;
+    options     []Option        4;
+    definitions []Definition    5;
+}
+
+message Import {
+    id      string      1;
+    name    string      2;
+    pos     Position    3;
+}
+
+message Option {
+    name    string      1;
+    value   string      2;
+    pos     Position    3;
+}
+
+struct Position {
+    line    int32;
+    column  int32;
+}
+
+// Definitions
+
+message Definition {
+    name        string          1;
+    type        string          2;  // enum, message, struct or service
+    doc         string          3;
+    pos         Position        4;
+    generated   bool            5;
+    annotations []Annotation    6;
+
+    enum_def    Enum            10;
+    message_def Message         11;
+    struct_def  Struct          12;
+    service_def Service         13;
+}
+
+message Annotation {
+    name    string  1;
+    value   Value   2;
+}
+
+message Value {
+    oneof kind {
+        bool_value      bool        1;
+        int_value       int64       2;
+        uint_value      uint64      3;
+        float_value     float64     4;
+        string_value    string      5;
+        enum_value      string      6;  // enum value name
+    }
+}
+
+message Reserved {
+    ranges  []ReservedRange 1;
+    names   []string        2;
+}
+
+struct ReservedRange {
+    start   int32;
+    end     int32;
+}
+
+// Enum
+
+message Enum {
+    values      []EnumValue 1;
+    reserved    Reserved    2;
+}
+
+message EnumValue {
+    name        string          1;
+    number      int32           2;
+    doc         string          3;
+    pos         Position        4;
+    annotations []Annotation    5;
+}
+
+// Message
+
+message Message {
+    fields      []Field     1;
+    oneofs      []Oneof     2;
+    reserved    Reserved    3;
+}
+
+message Field {
+    name        string          1;
+    tag         int32           2;
+    type        Type            3;
+    oneof_name  string          4;  // oneof name or empty
+    default     Default         5;
+    doc         string          6;
+    pos         Position        7;
+    annotations []Annotation    8;
+}
+
+message Default {
+    text    string  1;
+    value   Value   2;
+}
+
+message Oneof {
+    name    string      1;
+    doc     string      2;
+    pos     Position    3;
+}
+
+// Struct
+
+message Struct {
+    fields  []StructField   1;
+}
+
+message StructField {
+    name        string          1;
+    type        Type            2;
+    doc         string          3;
+    pos         Position        4;
+    annotations []Annotation    5;
+}
+
+// Service
+
+message Service {
+    sub     bool        1;
+    methods []Method    2;
+}
+
+message Method {
+    name        string          1;
+    type        string          2;  // request, oneway, channel or subservice
+    doc         string          3;
+    pos         Position        4;
+    annotations []Annotation    5;
+
+    request     Type            10;
+    response    Type            11;
+    channel     Channel         12;
+    subservice  Type            13;
+}
+
+message Channel {
+    in      Type    1;
+    out     Type    2;
+}
+
+// Type
+
+message Type {
+    kind        int32   1;  // spec.Kind
+    name        string  2;
+    key         Type    3;
+    element     Type    4;
+    import      string  5;  // import name
+    package     string  6;  // referenced definition package id
+}
+
+// Response
+
+// Response is a plugin response.
+message Response {
+    files   []OutputFile    1;
+    error   string          2;  // error message or empty
+}
+
+// OutputFile is a generated file.
+message OutputFile {
+    name    string  1;  // file path relative to the output directory
+    content bytes   2;
+}
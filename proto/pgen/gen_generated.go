@@ -0,0 +1,3645 @@
+package pgen
+
+import (
+	"fmt"
+	"github.com/basecomplextech/baselibrary/alloc"
+	"github.com/basecomplextech/baselibrary/async"
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/baselibrary/pools"
+	"github.com/basecomplextech/baselibrary/ref"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/basecomplextech/spec"
+)
+
+var (
+	_ fmt.State
+	_ alloc.Buffer
+	_ async.Context
+	_ bin.Bin128
+	_ buffer.Buffer
+	_ spec.MessageTable
+	_ pools.Pool[any]
+	_ ref.Ref
+	_ spec.Type
+	_ status.Status
+)
+
+// Request
+
+// Request is a plugin request.
+type Request struct {
+	msg spec.Message
+}
+
+func NewRequest(msg spec.Message) Request {
+	return Request{msg}
+}
+
+func OpenRequest(b []byte) Request {
+	msg := spec.OpenMessage(b)
+	return Request{msg}
+}
+
+func OpenRequestErr(b []byte) (_ Request, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Request{msg}, err
+}
+
+func ParseRequest(b []byte) (_ Request, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Request{}, size, requestDescriptor.DescribeError(err)
+	}
+	return Request{msg}, size, nil
+}
+
+func (m Request) Package() Package { return NewPackage(m.msg.Message(1)) }
+func (m Request) Imports() spec.MessageList[Package] {
+	return spec.NewMessageList(m.msg.List(2), OpenPackageErr)
+}
+
+func (m Request) HasPackage() bool { return m.msg.HasField(1) }
+func (m Request) HasImports() bool { return m.msg.HasField(2) }
+
+func (m Request) Clone() Request                        { return Request{m.msg.Clone()} }
+func (m Request) CloneToArena(a alloc.Arena) Request    { return Request{m.msg.CloneToArena(a)} }
+func (m Request) CloneToBuffer(b buffer.Buffer) Request { return Request{m.msg.CloneToBuffer(b)} }
+
+func (m Request) IsEmpty() bool        { return m.msg.Empty() }
+func (m Request) Unwrap() spec.Message { return m.msg }
+func (m Request) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(requestDescriptor, m.msg)
+}
+
+func (m *Request) UnmarshalJSON(b []byte) error {
+	w := NewRequestWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Request) String() string {
+	return spec.MessageString(requestDescriptor, m.msg)
+}
+
+func (m Request) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, requestDescriptor, m.msg)
+}
+
+var requestDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Request",
+}
+
+func init() {
+	requestDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "package", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Package", Message: packageDescriptor}},
+		{Name: "imports", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Package", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Package", Message: packageDescriptor}}},
+	}
+}
+
+func (m Request) Descriptor() *spec.MessageDescriptor {
+	return requestDescriptor
+}
+
+// Package
+
+type Package struct {
+	msg spec.Message
+}
+
+func NewPackage(msg spec.Message) Package {
+	return Package{msg}
+}
+
+func OpenPackage(b []byte) Package {
+	msg := spec.OpenMessage(b)
+	return Package{msg}
+}
+
+func OpenPackageErr(b []byte) (_ Package, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Package{msg}, err
+}
+
+func ParsePackage(b []byte) (_ Package, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Package{}, size, packageDescriptor.DescribeError(err)
+	}
+	return Package{msg}, size, nil
+}
+
+func (m Package) Id() spec.String   { return m.msg.String(1) }
+func (m Package) Name() spec.String { return m.msg.String(2) }
+func (m Package) Path() spec.String { return m.msg.String(3) }
+func (m Package) Files() spec.MessageList[File] {
+	return spec.NewMessageList(m.msg.List(4), OpenFileErr)
+}
+func (m Package) Warnings() spec.ValueList[spec.String] {
+	return spec.NewValueList(m.msg.List(5), spec.DecodeString)
+}
+
+func (m Package) HasId() bool       { return m.msg.HasField(1) }
+func (m Package) HasName() bool     { return m.msg.HasField(2) }
+func (m Package) HasPath() bool     { return m.msg.HasField(3) }
+func (m Package) HasFiles() bool    { return m.msg.HasField(4) }
+func (m Package) HasWarnings() bool { return m.msg.HasField(5) }
+
+func (m Package) Clone() Package                        { return Package{m.msg.Clone()} }
+func (m Package) CloneToArena(a alloc.Arena) Package    { return Package{m.msg.CloneToArena(a)} }
+func (m Package) CloneToBuffer(b buffer.Buffer) Package { return Package{m.msg.CloneToBuffer(b)} }
+
+func (m Package) IsEmpty() bool        { return m.msg.Empty() }
+func (m Package) Unwrap() spec.Message { return m.msg }
+func (m Package) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(packageDescriptor, m.msg)
+}
+
+func (m *Package) UnmarshalJSON(b []byte) error {
+	w := NewPackageWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Package) String() string {
+	return spec.MessageString(packageDescriptor, m.msg)
+}
+
+func (m Package) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, packageDescriptor, m.msg)
+}
+
+var packageDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Package",
+}
+
+func init() {
+	packageDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "id", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "name", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "path", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "files", Tag: 4, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]File", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "File", Message: fileDescriptor}}},
+		{Name: "warnings", Tag: 5, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]string", Element: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}}},
+	}
+}
+
+func (m Package) Descriptor() *spec.MessageDescriptor {
+	return packageDescriptor
+}
+
+// File
+
+type File struct {
+	msg spec.Message
+}
+
+func NewFile(msg spec.Message) File {
+	return File{msg}
+}
+
+func OpenFile(b []byte) File {
+	msg := spec.OpenMessage(b)
+	return File{msg}
+}
+
+func OpenFileErr(b []byte) (_ File, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return File{msg}, err
+}
+
+func ParseFile(b []byte) (_ File, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return File{}, size, fileDescriptor.DescribeError(err)
+	}
+	return File{msg}, size, nil
+}
+
+func (m File) Name() spec.String { return m.msg.String(1) }
+func (m File) Path() spec.String { return m.msg.String(2) }
+func (m File) Imports() spec.MessageList[Import] {
+	return spec.NewMessageList(m.msg.List(3), OpenImportErr)
+}
+func (m File) Options() spec.MessageList[Option] {
+	return spec.NewMessageList(m.msg.List(4), OpenOptionErr)
+}
+func (m File) Definitions() spec.MessageList[Definition] {
+	return spec.NewMessageList(m.msg.List(5), OpenDefinitionErr)
+}
+
+func (m File) HasName() bool        { return m.msg.HasField(1) }
+func (m File) HasPath() bool        { return m.msg.HasField(2) }
+func (m File) HasImports() bool     { return m.msg.HasField(3) }
+func (m File) HasOptions() bool     { return m.msg.HasField(4) }
+func (m File) HasDefinitions() bool { return m.msg.HasField(5) }
+
+func (m File) Clone() File                        { return File{m.msg.Clone()} }
+func (m File) CloneToArena(a alloc.Arena) File    { return File{m.msg.CloneToArena(a)} }
+func (m File) CloneToBuffer(b buffer.Buffer) File { return File{m.msg.CloneToBuffer(b)} }
+
+func (m File) IsEmpty() bool        { return m.msg.Empty() }
+func (m File) Unwrap() spec.Message { return m.msg }
+func (m File) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(fileDescriptor, m.msg)
+}
+
+func (m *File) UnmarshalJSON(b []byte) error {
+	w := NewFileWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m File) String() string {
+	return spec.MessageString(fileDescriptor, m.msg)
+}
+
+func (m File) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, fileDescriptor, m.msg)
+}
+
+var fileDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "File",
+}
+
+func init() {
+	fileDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "name", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "path", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "imports", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Import", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Import", Message: importDescriptor}}},
+		{Name: "options", Tag: 4, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Option", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Option", Message: optionDescriptor}}},
+		{Name: "definitions", Tag: 5, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Definition", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Definition", Message: definitionDescriptor}}},
+	}
+}
+
+func (m File) Descriptor() *spec.MessageDescriptor {
+	return fileDescriptor
+}
+
+// Import
+
+type Import struct {
+	msg spec.Message
+}
+
+func NewImport(msg spec.Message) Import {
+	return Import{msg}
+}
+
+func OpenImport(b []byte) Import {
+	msg := spec.OpenMessage(b)
+	return Import{msg}
+}
+
+func OpenImportErr(b []byte) (_ Import, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Import{msg}, err
+}
+
+func ParseImport(b []byte) (_ Import, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Import{}, size, importDescriptor.DescribeError(err)
+	}
+	return Import{msg}, size, nil
+}
+
+func (m Import) Id() spec.String   { return m.msg.String(1) }
+func (m Import) Name() spec.String { return m.msg.String(2) }
+func (m Import) Pos() Position     { return OpenPosition(m.msg.FieldRaw(3)) }
+
+func (m Import) HasId() bool   { return m.msg.HasField(1) }
+func (m Import) HasName() bool { return m.msg.HasField(2) }
+func (m Import) HasPos() bool  { return m.msg.HasField(3) }
+
+func (m Import) Clone() Import                        { return Import{m.msg.Clone()} }
+func (m Import) CloneToArena(a alloc.Arena) Import    { return Import{m.msg.CloneToArena(a)} }
+func (m Import) CloneToBuffer(b buffer.Buffer) Import { return Import{m.msg.CloneToBuffer(b)} }
+
+func (m Import) IsEmpty() bool        { return m.msg.Empty() }
+func (m Import) Unwrap() spec.Message { return m.msg }
+func (m Import) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(importDescriptor, m.msg)
+}
+
+func (m *Import) UnmarshalJSON(b []byte) error {
+	w := NewImportWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Import) String() string {
+	return spec.MessageString(importDescriptor, m.msg)
+}
+
+func (m Import) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, importDescriptor, m.msg)
+}
+
+var importDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Import",
+}
+
+func init() {
+	importDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "id", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "name", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "pos", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindStruct, Name: "Position", Struct: positionDescriptor}},
+	}
+}
+
+func (m Import) Descriptor() *spec.MessageDescriptor {
+	return importDescriptor
+}
+
+// Option
+
+type Option struct {
+	msg spec.Message
+}
+
+func NewOption(msg spec.Message) Option {
+	return Option{msg}
+}
+
+func OpenOption(b []byte) Option {
+	msg := spec.OpenMessage(b)
+	return Option{msg}
+}
+
+func OpenOptionErr(b []byte) (_ Option, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Option{msg}, err
+}
+
+func ParseOption(b []byte) (_ Option, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Option{}, size, optionDescriptor.DescribeError(err)
+	}
+	return Option{msg}, size, nil
+}
+
+func (m Option) Name() spec.String  { return m.msg.String(1) }
+func (m Option) Value() spec.String { return m.msg.String(2) }
+func (m Option) Pos() Position      { return OpenPosition(m.msg.FieldRaw(3)) }
+
+func (m Option) HasName() bool  { return m.msg.HasField(1) }
+func (m Option) HasValue() bool { return m.msg.HasField(2) }
+func (m Option) HasPos() bool   { return m.msg.HasField(3) }
+
+func (m Option) Clone() Option                        { return Option{m.msg.Clone()} }
+func (m Option) CloneToArena(a alloc.Arena) Option    { return Option{m.msg.CloneToArena(a)} }
+func (m Option) CloneToBuffer(b buffer.Buffer) Option { return Option{m.msg.CloneToBuffer(b)} }
+
+func (m Option) IsEmpty() bool        { return m.msg.Empty() }
+func (m Option) Unwrap() spec.Message { return m.msg }
+func (m Option) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(optionDescriptor, m.msg)
+}
+
+func (m *Option) UnmarshalJSON(b []byte) error {
+	w := NewOptionWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Option) String() string {
+	return spec.MessageString(optionDescriptor, m.msg)
+}
+
+func (m Option) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, optionDescriptor, m.msg)
+}
+
+var optionDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Option",
+}
+
+func init() {
+	optionDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "name", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "value", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "pos", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindStruct, Name: "Position", Struct: positionDescriptor}},
+	}
+}
+
+func (m Option) Descriptor() *spec.MessageDescriptor {
+	return optionDescriptor
+}
+
+// Position
+
+type Position struct {
+	Line   int32 `json:"line"`
+	Column int32 `json:"column"`
+}
+
+func OpenPosition(b []byte) Position {
+	s, _, _ := DecodePosition(b)
+	return s
+}
+
+func DecodePosition(b []byte) (s Position, size int, err error) {
+	size, err = s.Decode(b)
+	return s, size, err
+}
+
+func EncodePositionTo(b buffer.Buffer, s Position) (int, error) {
+	return s.EncodeTo(b)
+}
+
+func (s *Position) Decode(b []byte) (size int, err error) {
+	dataSize, size, err := spec.DecodeStruct(b)
+	if err != nil || size == 0 {
+		return
+	}
+
+	b = b[len(b)-size:]
+	n := size - dataSize
+	off := len(b) - n
+
+	// Decode in reverse order
+
+	s.Column, n, err = spec.DecodeInt32(b[:off])
+	if err != nil {
+		return
+	}
+	off -= n
+
+	s.Line, n, err = spec.DecodeInt32(b[:off])
+	if err != nil {
+		return
+	}
+	off -= n
+
+	return size, err
+}
+
+func (s Position) EncodeTo(b buffer.Buffer) (int, error) {
+	var dataSize, n int
+	var err error
+
+	n, err = spec.EncodeInt32(b, s.Line)
+	if err != nil {
+		return 0, err
+	}
+	dataSize += n
+
+	n, err = spec.EncodeInt32(b, s.Column)
+	if err != nil {
+		return 0, err
+	}
+	dataSize += n
+
+	n, err = spec.EncodeStruct(b, dataSize)
+	if err != nil {
+		return 0, err
+	}
+	return dataSize + n, nil
+}
+
+func (s Position) String() string {
+	return spec.StructString(positionDescriptor, s, EncodePositionTo)
+}
+
+func (s Position) Format(f fmt.State, verb rune) {
+	spec.FormatStruct(f, verb, positionDescriptor, s, EncodePositionTo)
+}
+
+var positionDescriptor = &spec.StructDescriptor{
+	Package: "pgen",
+	Name:    "Position",
+}
+
+func init() {
+	positionDescriptor.Fields = []*spec.StructFieldDescriptor{
+		{Name: "line", Type: &spec.TypeDescriptor{Kind: spec.KindInt32, Name: "int32"}},
+		{Name: "column", Type: &spec.TypeDescriptor{Kind: spec.KindInt32, Name: "int32"}},
+	}
+}
+
+func (s Position) Descriptor() *spec.StructDescriptor {
+	return positionDescriptor
+}
+
+// Definition
+
+type Definition struct {
+	msg spec.Message
+}
+
+func NewDefinition(msg spec.Message) Definition {
+	return Definition{msg}
+}
+
+func OpenDefinition(b []byte) Definition {
+	msg := spec.OpenMessage(b)
+	return Definition{msg}
+}
+
+func OpenDefinitionErr(b []byte) (_ Definition, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Definition{msg}, err
+}
+
+func ParseDefinition(b []byte) (_ Definition, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Definition{}, size, definitionDescriptor.DescribeError(err)
+	}
+	return Definition{msg}, size, nil
+}
+
+func (m Definition) Name() spec.String { return m.msg.String(1) }
+func (m Definition) Type() spec.String { return m.msg.String(2) }
+func (m Definition) Doc() spec.String  { return m.msg.String(3) }
+func (m Definition) Pos() Position     { return OpenPosition(m.msg.FieldRaw(4)) }
+func (m Definition) Generated() bool   { return m.msg.Bool(5) }
+func (m Definition) Annotations() spec.MessageList[Annotation] {
+	return spec.NewMessageList(m.msg.List(6), OpenAnnotationErr)
+}
+func (m Definition) EnumDef() Enum       { return NewEnum(m.msg.Message(10)) }
+func (m Definition) MessageDef() Message { return NewMessage(m.msg.Message(11)) }
+func (m Definition) StructDef() Struct   { return NewStruct(m.msg.Message(12)) }
+func (m Definition) ServiceDef() Service { return NewService(m.msg.Message(13)) }
+
+func (m Definition) HasName() bool        { return m.msg.HasField(1) }
+func (m Definition) HasType() bool        { return m.msg.HasField(2) }
+func (m Definition) HasDoc() bool         { return m.msg.HasField(3) }
+func (m Definition) HasPos() bool         { return m.msg.HasField(4) }
+func (m Definition) HasGenerated() bool   { return m.msg.HasField(5) }
+func (m Definition) HasAnnotations() bool { return m.msg.HasField(6) }
+func (m Definition) HasEnumDef() bool     { return m.msg.HasField(10) }
+func (m Definition) HasMessageDef() bool  { return m.msg.HasField(11) }
+func (m Definition) HasStructDef() bool   { return m.msg.HasField(12) }
+func (m Definition) HasServiceDef() bool  { return m.msg.HasField(13) }
+
+func (m Definition) Clone() Definition                     { return Definition{m.msg.Clone()} }
+func (m Definition) CloneToArena(a alloc.Arena) Definition { return Definition{m.msg.CloneToArena(a)} }
+func (m Definition) CloneToBuffer(b buffer.Buffer) Definition {
+	return Definition{m.msg.CloneToBuffer(b)}
+}
+
+func (m Definition) IsEmpty() bool        { return m.msg.Empty() }
+func (m Definition) Unwrap() spec.Message { return m.msg }
+func (m Definition) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(definitionDescriptor, m.msg)
+}
+
+func (m *Definition) UnmarshalJSON(b []byte) error {
+	w := NewDefinitionWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Definition) String() string {
+	return spec.MessageString(definitionDescriptor, m.msg)
+}
+
+func (m Definition) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, definitionDescriptor, m.msg)
+}
+
+var definitionDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Definition",
+}
+
+func init() {
+	definitionDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "name", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "type", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "doc", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "pos", Tag: 4, Type: &spec.TypeDescriptor{Kind: spec.KindStruct, Name: "Position", Struct: positionDescriptor}},
+		{Name: "generated", Tag: 5, Type: &spec.TypeDescriptor{Kind: spec.KindBool, Name: "bool"}},
+		{Name: "annotations", Tag: 6, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Annotation", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Annotation", Message: annotationDescriptor}}},
+		{Name: "enum_def", Tag: 10, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Enum", Message: enumDescriptor}},
+		{Name: "message_def", Tag: 11, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Message", Message: messageDescriptor}},
+		{Name: "struct_def", Tag: 12, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Struct", Message: structDescriptor}},
+		{Name: "service_def", Tag: 13, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Service", Message: serviceDescriptor}},
+	}
+}
+
+func (m Definition) Descriptor() *spec.MessageDescriptor {
+	return definitionDescriptor
+}
+
+// Annotation
+
+type Annotation struct {
+	msg spec.Message
+}
+
+func NewAnnotation(msg spec.Message) Annotation {
+	return Annotation{msg}
+}
+
+func OpenAnnotation(b []byte) Annotation {
+	msg := spec.OpenMessage(b)
+	return Annotation{msg}
+}
+
+func OpenAnnotationErr(b []byte) (_ Annotation, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Annotation{msg}, err
+}
+
+func ParseAnnotation(b []byte) (_ Annotation, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Annotation{}, size, annotationDescriptor.DescribeError(err)
+	}
+	return Annotation{msg}, size, nil
+}
+
+func (m Annotation) Name() spec.String { return m.msg.String(1) }
+func (m Annotation) Value() Value      { return NewValue(m.msg.Message(2)) }
+
+func (m Annotation) HasName() bool  { return m.msg.HasField(1) }
+func (m Annotation) HasValue() bool { return m.msg.HasField(2) }
+
+func (m Annotation) Clone() Annotation                     { return Annotation{m.msg.Clone()} }
+func (m Annotation) CloneToArena(a alloc.Arena) Annotation { return Annotation{m.msg.CloneToArena(a)} }
+func (m Annotation) CloneToBuffer(b buffer.Buffer) Annotation {
+	return Annotation{m.msg.CloneToBuffer(b)}
+}
+
+func (m Annotation) IsEmpty() bool        { return m.msg.Empty() }
+func (m Annotation) Unwrap() spec.Message { return m.msg }
+func (m Annotation) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(annotationDescriptor, m.msg)
+}
+
+func (m *Annotation) UnmarshalJSON(b []byte) error {
+	w := NewAnnotationWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Annotation) String() string {
+	return spec.MessageString(annotationDescriptor, m.msg)
+}
+
+func (m Annotation) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, annotationDescriptor, m.msg)
+}
+
+var annotationDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Annotation",
+}
+
+func init() {
+	annotationDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "name", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "value", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Value", Message: valueDescriptor}},
+	}
+}
+
+func (m Annotation) Descriptor() *spec.MessageDescriptor {
+	return annotationDescriptor
+}
+
+// Value
+
+type Value struct {
+	msg spec.Message
+}
+
+// ValueKind is a Value.kind oneof field, or none.
+type ValueKind int32
+
+const (
+	ValueKind_None        ValueKind = 0
+	ValueKind_BoolValue   ValueKind = 1
+	ValueKind_IntValue    ValueKind = 2
+	ValueKind_UintValue   ValueKind = 3
+	ValueKind_FloatValue  ValueKind = 4
+	ValueKind_StringValue ValueKind = 5
+	ValueKind_EnumValue   ValueKind = 6
+)
+
+func (v ValueKind) String() string {
+	switch v {
+	case ValueKind_None:
+		return "none"
+	case ValueKind_BoolValue:
+		return "bool_value"
+	case ValueKind_IntValue:
+		return "int_value"
+	case ValueKind_UintValue:
+		return "uint_value"
+	case ValueKind_FloatValue:
+		return "float_value"
+	case ValueKind_StringValue:
+		return "string_value"
+	case ValueKind_EnumValue:
+		return "enum_value"
+	}
+	return ""
+}
+
+func NewValue(msg spec.Message) Value {
+	return Value{msg}
+}
+
+func OpenValue(b []byte) Value {
+	msg := spec.OpenMessage(b)
+	return Value{msg}
+}
+
+func OpenValueErr(b []byte) (_ Value, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Value{msg}, err
+}
+
+func ParseValue(b []byte) (_ Value, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Value{}, size, valueDescriptor.DescribeError(err)
+	}
+	if err := valueDescriptor.ValidateOneofs(msg); err != nil {
+		return Value{}, size, err
+	}
+	return Value{msg}, size, nil
+}
+
+func (m Value) BoolValue() bool          { return m.msg.Bool(1) }
+func (m Value) IntValue() int64          { return m.msg.Int64(2) }
+func (m Value) UintValue() uint64        { return m.msg.Uint64(3) }
+func (m Value) FloatValue() float64      { return m.msg.Float64(4) }
+func (m Value) StringValue() spec.String { return m.msg.String(5) }
+func (m Value) EnumValue() spec.String   { return m.msg.String(6) }
+
+func (m Value) HasBoolValue() bool   { return m.msg.HasField(1) }
+func (m Value) HasIntValue() bool    { return m.msg.HasField(2) }
+func (m Value) HasUintValue() bool   { return m.msg.HasField(3) }
+func (m Value) HasFloatValue() bool  { return m.msg.HasField(4) }
+func (m Value) HasStringValue() bool { return m.msg.HasField(5) }
+func (m Value) HasEnumValue() bool   { return m.msg.HasField(6) }
+
+func (m Value) WhichKind() ValueKind {
+	switch {
+	case m.msg.HasField(1):
+		return ValueKind_BoolValue
+	case m.msg.HasField(2):
+		return ValueKind_IntValue
+	case m.msg.HasField(3):
+		return ValueKind_UintValue
+	case m.msg.HasField(4):
+		return ValueKind_FloatValue
+	case m.msg.HasField(5):
+		return ValueKind_StringValue
+	case m.msg.HasField(6):
+		return ValueKind_EnumValue
+	}
+	return ValueKind_None
+}
+
+func (m Value) Clone() Value                        { return Value{m.msg.Clone()} }
+func (m Value) CloneToArena(a alloc.Arena) Value    { return Value{m.msg.CloneToArena(a)} }
+func (m Value) CloneToBuffer(b buffer.Buffer) Value { return Value{m.msg.CloneToBuffer(b)} }
+
+func (m Value) IsEmpty() bool        { return m.msg.Empty() }
+func (m Value) Unwrap() spec.Message { return m.msg }
+func (m Value) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(valueDescriptor, m.msg)
+}
+
+func (m *Value) UnmarshalJSON(b []byte) error {
+	w := NewValueWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Value) String() string {
+	return spec.MessageString(valueDescriptor, m.msg)
+}
+
+func (m Value) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, valueDescriptor, m.msg)
+}
+
+var valueDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Value",
+}
+
+func init() {
+	valueDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "bool_value", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindBool, Name: "bool"}},
+		{Name: "int_value", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindInt64, Name: "int64"}},
+		{Name: "uint_value", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindUint64, Name: "uint64"}},
+		{Name: "float_value", Tag: 4, Type: &spec.TypeDescriptor{Kind: spec.KindFloat64, Name: "float64"}},
+		{Name: "string_value", Tag: 5, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "enum_value", Tag: 6, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+	}
+	valueDescriptor.Oneofs = []*spec.OneofDescriptor{
+		{Name: "kind", Fields: []*spec.FieldDescriptor{valueDescriptor.Fields[0], valueDescriptor.Fields[1], valueDescriptor.Fields[2], valueDescriptor.Fields[3], valueDescriptor.Fields[4], valueDescriptor.Fields[5]}},
+	}
+}
+
+func (m Value) Descriptor() *spec.MessageDescriptor {
+	return valueDescriptor
+}
+
+// Reserved
+
+type Reserved struct {
+	msg spec.Message
+}
+
+func NewReserved(msg spec.Message) Reserved {
+	return Reserved{msg}
+}
+
+func OpenReserved(b []byte) Reserved {
+	msg := spec.OpenMessage(b)
+	return Reserved{msg}
+}
+
+func OpenReservedErr(b []byte) (_ Reserved, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Reserved{msg}, err
+}
+
+func ParseReserved(b []byte) (_ Reserved, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Reserved{}, size, reservedDescriptor.DescribeError(err)
+	}
+	return Reserved{msg}, size, nil
+}
+
+func (m Reserved) Ranges() spec.ValueList[ReservedRange] {
+	return spec.NewValueList(m.msg.List(1), DecodeReservedRange)
+}
+func (m Reserved) Names() spec.ValueList[spec.String] {
+	return spec.NewValueList(m.msg.List(2), spec.DecodeString)
+}
+
+func (m Reserved) HasRanges() bool { return m.msg.HasField(1) }
+func (m Reserved) HasNames() bool  { return m.msg.HasField(2) }
+
+func (m Reserved) Clone() Reserved                        { return Reserved{m.msg.Clone()} }
+func (m Reserved) CloneToArena(a alloc.Arena) Reserved    { return Reserved{m.msg.CloneToArena(a)} }
+func (m Reserved) CloneToBuffer(b buffer.Buffer) Reserved { return Reserved{m.msg.CloneToBuffer(b)} }
+
+func (m Reserved) IsEmpty() bool        { return m.msg.Empty() }
+func (m Reserved) Unwrap() spec.Message { return m.msg }
+func (m Reserved) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(reservedDescriptor, m.msg)
+}
+
+func (m *Reserved) UnmarshalJSON(b []byte) error {
+	w := NewReservedWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Reserved) String() string {
+	return spec.MessageString(reservedDescriptor, m.msg)
+}
+
+func (m Reserved) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, reservedDescriptor, m.msg)
+}
+
+var reservedDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Reserved",
+}
+
+func init() {
+	reservedDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "ranges", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]ReservedRange", Element: &spec.TypeDescriptor{Kind: spec.KindStruct, Name: "ReservedRange", Struct: reservedRangeDescriptor}}},
+		{Name: "names", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]string", Element: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}}},
+	}
+}
+
+func (m Reserved) Descriptor() *spec.MessageDescriptor {
+	return reservedDescriptor
+}
+
+// ReservedRange
+
+type ReservedRange struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+func OpenReservedRange(b []byte) ReservedRange {
+	s, _, _ := DecodeReservedRange(b)
+	return s
+}
+
+func DecodeReservedRange(b []byte) (s ReservedRange, size int, err error) {
+	size, err = s.Decode(b)
+	return s, size, err
+}
+
+func EncodeReservedRangeTo(b buffer.Buffer, s ReservedRange) (int, error) {
+	return s.EncodeTo(b)
+}
+
+func (s *ReservedRange) Decode(b []byte) (size int, err error) {
+	dataSize, size, err := spec.DecodeStruct(b)
+	if err != nil || size == 0 {
+		return
+	}
+
+	b = b[len(b)-size:]
+	n := size - dataSize
+	off := len(b) - n
+
+	// Decode in reverse order
+
+	s.End, n, err = spec.DecodeInt32(b[:off])
+	if err != nil {
+		return
+	}
+	off -= n
+
+	s.Start, n, err = spec.DecodeInt32(b[:off])
+	if err != nil {
+		return
+	}
+	off -= n
+
+	return size, err
+}
+
+func (s ReservedRange) EncodeTo(b buffer.Buffer) (int, error) {
+	var dataSize, n int
+	var err error
+
+	n, err = spec.EncodeInt32(b, s.Start)
+	if err != nil {
+		return 0, err
+	}
+	dataSize += n
+
+	n, err = spec.EncodeInt32(b, s.End)
+	if err != nil {
+		return 0, err
+	}
+	dataSize += n
+
+	n, err = spec.EncodeStruct(b, dataSize)
+	if err != nil {
+		return 0, err
+	}
+	return dataSize + n, nil
+}
+
+func (s ReservedRange) String() string {
+	return spec.StructString(reservedRangeDescriptor, s, EncodeReservedRangeTo)
+}
+
+func (s ReservedRange) Format(f fmt.State, verb rune) {
+	spec.FormatStruct(f, verb, reservedRangeDescriptor, s, EncodeReservedRangeTo)
+}
+
+var reservedRangeDescriptor = &spec.StructDescriptor{
+	Package: "pgen",
+	Name:    "ReservedRange",
+}
+
+func init() {
+	reservedRangeDescriptor.Fields = []*spec.StructFieldDescriptor{
+		{Name: "start", Type: &spec.TypeDescriptor{Kind: spec.KindInt32, Name: "int32"}},
+		{Name: "end", Type: &spec.TypeDescriptor{Kind: spec.KindInt32, Name: "int32"}},
+	}
+}
+
+func (s ReservedRange) Descriptor() *spec.StructDescriptor {
+	return reservedRangeDescriptor
+}
+
+// Enum
+
+type Enum struct {
+	msg spec.Message
+}
+
+func NewEnum(msg spec.Message) Enum {
+	return Enum{msg}
+}
+
+func OpenEnum(b []byte) Enum {
+	msg := spec.OpenMessage(b)
+	return Enum{msg}
+}
+
+func OpenEnumErr(b []byte) (_ Enum, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Enum{msg}, err
+}
+
+func ParseEnum(b []byte) (_ Enum, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Enum{}, size, enumDescriptor.DescribeError(err)
+	}
+	return Enum{msg}, size, nil
+}
+
+func (m Enum) Values() spec.MessageList[EnumValue] {
+	return spec.NewMessageList(m.msg.List(1), OpenEnumValueErr)
+}
+func (m Enum) Reserved() Reserved { return NewReserved(m.msg.Message(2)) }
+
+func (m Enum) HasValues() bool   { return m.msg.HasField(1) }
+func (m Enum) HasReserved() bool { return m.msg.HasField(2) }
+
+func (m Enum) Clone() Enum                        { return Enum{m.msg.Clone()} }
+func (m Enum) CloneToArena(a alloc.Arena) Enum    { return Enum{m.msg.CloneToArena(a)} }
+func (m Enum) CloneToBuffer(b buffer.Buffer) Enum { return Enum{m.msg.CloneToBuffer(b)} }
+
+func (m Enum) IsEmpty() bool        { return m.msg.Empty() }
+func (m Enum) Unwrap() spec.Message { return m.msg }
+func (m Enum) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(enumDescriptor, m.msg)
+}
+
+func (m *Enum) UnmarshalJSON(b []byte) error {
+	w := NewEnumWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Enum) String() string {
+	return spec.MessageString(enumDescriptor, m.msg)
+}
+
+func (m Enum) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, enumDescriptor, m.msg)
+}
+
+var enumDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Enum",
+}
+
+func init() {
+	enumDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "values", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]EnumValue", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "EnumValue", Message: enumValueDescriptor}}},
+		{Name: "reserved", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Reserved", Message: reservedDescriptor}},
+	}
+}
+
+func (m Enum) Descriptor() *spec.MessageDescriptor {
+	return enumDescriptor
+}
+
+// EnumValue
+
+type EnumValue struct {
+	msg spec.Message
+}
+
+func NewEnumValue(msg spec.Message) EnumValue {
+	return EnumValue{msg}
+}
+
+func OpenEnumValue(b []byte) EnumValue {
+	msg := spec.OpenMessage(b)
+	return EnumValue{msg}
+}
+
+func OpenEnumValueErr(b []byte) (_ EnumValue, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return EnumValue{msg}, err
+}
+
+func ParseEnumValue(b []byte) (_ EnumValue, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return EnumValue{}, size, enumValueDescriptor.DescribeError(err)
+	}
+	return EnumValue{msg}, size, nil
+}
+
+func (m EnumValue) Name() spec.String { return m.msg.String(1) }
+func (m EnumValue) Number() int32     { return m.msg.Int32(2) }
+func (m EnumValue) Doc() spec.String  { return m.msg.String(3) }
+func (m EnumValue) Pos() Position     { return OpenPosition(m.msg.FieldRaw(4)) }
+func (m EnumValue) Annotations() spec.MessageList[Annotation] {
+	return spec.NewMessageList(m.msg.List(5), OpenAnnotationErr)
+}
+
+func (m EnumValue) HasName() bool        { return m.msg.HasField(1) }
+func (m EnumValue) HasNumber() bool      { return m.msg.HasField(2) }
+func (m EnumValue) HasDoc() bool         { return m.msg.HasField(3) }
+func (m EnumValue) HasPos() bool         { return m.msg.HasField(4) }
+func (m EnumValue) HasAnnotations() bool { return m.msg.HasField(5) }
+
+func (m EnumValue) Clone() EnumValue                        { return EnumValue{m.msg.Clone()} }
+func (m EnumValue) CloneToArena(a alloc.Arena) EnumValue    { return EnumValue{m.msg.CloneToArena(a)} }
+func (m EnumValue) CloneToBuffer(b buffer.Buffer) EnumValue { return EnumValue{m.msg.CloneToBuffer(b)} }
+
+func (m EnumValue) IsEmpty() bool        { return m.msg.Empty() }
+func (m EnumValue) Unwrap() spec.Message { return m.msg }
+func (m EnumValue) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(enumValueDescriptor, m.msg)
+}
+
+func (m *EnumValue) UnmarshalJSON(b []byte) error {
+	w := NewEnumValueWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m EnumValue) String() string {
+	return spec.MessageString(enumValueDescriptor, m.msg)
+}
+
+func (m EnumValue) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, enumValueDescriptor, m.msg)
+}
+
+var enumValueDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "EnumValue",
+}
+
+func init() {
+	enumValueDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "name", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "number", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindInt32, Name: "int32"}},
+		{Name: "doc", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "pos", Tag: 4, Type: &spec.TypeDescriptor{Kind: spec.KindStruct, Name: "Position", Struct: positionDescriptor}},
+		{Name: "annotations", Tag: 5, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Annotation", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Annotation", Message: annotationDescriptor}}},
+	}
+}
+
+func (m EnumValue) Descriptor() *spec.MessageDescriptor {
+	return enumValueDescriptor
+}
+
+// Message
+
+type Message struct {
+	msg spec.Message
+}
+
+func NewMessage(msg spec.Message) Message {
+	return Message{msg}
+}
+
+func OpenMessage(b []byte) Message {
+	msg := spec.OpenMessage(b)
+	return Message{msg}
+}
+
+func OpenMessageErr(b []byte) (_ Message, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Message{msg}, err
+}
+
+func ParseMessage(b []byte) (_ Message, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Message{}, size, messageDescriptor.DescribeError(err)
+	}
+	return Message{msg}, size, nil
+}
+
+func (m Message) Fields() spec.MessageList[Field] {
+	return spec.NewMessageList(m.msg.List(1), OpenFieldErr)
+}
+func (m Message) Oneofs() spec.MessageList[Oneof] {
+	return spec.NewMessageList(m.msg.List(2), OpenOneofErr)
+}
+func (m Message) Reserved() Reserved { return NewReserved(m.msg.Message(3)) }
+
+func (m Message) HasFields() bool   { return m.msg.HasField(1) }
+func (m Message) HasOneofs() bool   { return m.msg.HasField(2) }
+func (m Message) HasReserved() bool { return m.msg.HasField(3) }
+
+func (m Message) Clone() Message                        { return Message{m.msg.Clone()} }
+func (m Message) CloneToArena(a alloc.Arena) Message    { return Message{m.msg.CloneToArena(a)} }
+func (m Message) CloneToBuffer(b buffer.Buffer) Message { return Message{m.msg.CloneToBuffer(b)} }
+
+func (m Message) IsEmpty() bool        { return m.msg.Empty() }
+func (m Message) Unwrap() spec.Message { return m.msg }
+func (m Message) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(messageDescriptor, m.msg)
+}
+
+func (m *Message) UnmarshalJSON(b []byte) error {
+	w := NewMessageWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Message) String() string {
+	return spec.MessageString(messageDescriptor, m.msg)
+}
+
+func (m Message) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, messageDescriptor, m.msg)
+}
+
+var messageDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Message",
+}
+
+func init() {
+	messageDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "fields", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Field", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Field", Message: fieldDescriptor}}},
+		{Name: "oneofs", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Oneof", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Oneof", Message: oneofDescriptor}}},
+		{Name: "reserved", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Reserved", Message: reservedDescriptor}},
+	}
+}
+
+func (m Message) Descriptor() *spec.MessageDescriptor {
+	return messageDescriptor
+}
+
+// Field
+
+type Field struct {
+	msg spec.Message
+}
+
+func NewField(msg spec.Message) Field {
+	return Field{msg}
+}
+
+func OpenField(b []byte) Field {
+	msg := spec.OpenMessage(b)
+	return Field{msg}
+}
+
+func OpenFieldErr(b []byte) (_ Field, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Field{msg}, err
+}
+
+func ParseField(b []byte) (_ Field, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Field{}, size, fieldDescriptor.DescribeError(err)
+	}
+	return Field{msg}, size, nil
+}
+
+func (m Field) Name() spec.String      { return m.msg.String(1) }
+func (m Field) Tag() int32             { return m.msg.Int32(2) }
+func (m Field) Type() Type             { return NewType(m.msg.Message(3)) }
+func (m Field) OneofName() spec.String { return m.msg.String(4) }
+func (m Field) Default() Default       { return NewDefault(m.msg.Message(5)) }
+func (m Field) Doc() spec.String       { return m.msg.String(6) }
+func (m Field) Pos() Position          { return OpenPosition(m.msg.FieldRaw(7)) }
+func (m Field) Annotations() spec.MessageList[Annotation] {
+	return spec.NewMessageList(m.msg.List(8), OpenAnnotationErr)
+}
+
+func (m Field) HasName() bool        { return m.msg.HasField(1) }
+func (m Field) HasTag() bool         { return m.msg.HasField(2) }
+func (m Field) HasType() bool        { return m.msg.HasField(3) }
+func (m Field) HasOneofName() bool   { return m.msg.HasField(4) }
+func (m Field) HasDefault() bool     { return m.msg.HasField(5) }
+func (m Field) HasDoc() bool         { return m.msg.HasField(6) }
+func (m Field) HasPos() bool         { return m.msg.HasField(7) }
+func (m Field) HasAnnotations() bool { return m.msg.HasField(8) }
+
+func (m Field) Clone() Field                        { return Field{m.msg.Clone()} }
+func (m Field) CloneToArena(a alloc.Arena) Field    { return Field{m.msg.CloneToArena(a)} }
+func (m Field) CloneToBuffer(b buffer.Buffer) Field { return Field{m.msg.CloneToBuffer(b)} }
+
+func (m Field) IsEmpty() bool        { return m.msg.Empty() }
+func (m Field) Unwrap() spec.Message { return m.msg }
+func (m Field) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(fieldDescriptor, m.msg)
+}
+
+func (m *Field) UnmarshalJSON(b []byte) error {
+	w := NewFieldWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Field) String() string {
+	return spec.MessageString(fieldDescriptor, m.msg)
+}
+
+func (m Field) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, fieldDescriptor, m.msg)
+}
+
+var fieldDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Field",
+}
+
+func init() {
+	fieldDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "name", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "tag", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindInt32, Name: "int32"}},
+		{Name: "type", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Type", Message: typeDescriptor}},
+		{Name: "oneof_name", Tag: 4, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "default", Tag: 5, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Default", Message: defaultDescriptor}},
+		{Name: "doc", Tag: 6, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "pos", Tag: 7, Type: &spec.TypeDescriptor{Kind: spec.KindStruct, Name: "Position", Struct: positionDescriptor}},
+		{Name: "annotations", Tag: 8, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Annotation", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Annotation", Message: annotationDescriptor}}},
+	}
+}
+
+func (m Field) Descriptor() *spec.MessageDescriptor {
+	return fieldDescriptor
+}
+
+// Default
+
+type Default struct {
+	msg spec.Message
+}
+
+func NewDefault(msg spec.Message) Default {
+	return Default{msg}
+}
+
+func OpenDefault(b []byte) Default {
+	msg := spec.OpenMessage(b)
+	return Default{msg}
+}
+
+func OpenDefaultErr(b []byte) (_ Default, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Default{msg}, err
+}
+
+func ParseDefault(b []byte) (_ Default, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Default{}, size, defaultDescriptor.DescribeError(err)
+	}
+	return Default{msg}, size, nil
+}
+
+func (m Default) Text() spec.String { return m.msg.String(1) }
+func (m Default) Value() Value      { return NewValue(m.msg.Message(2)) }
+
+func (m Default) HasText() bool  { return m.msg.HasField(1) }
+func (m Default) HasValue() bool { return m.msg.HasField(2) }
+
+func (m Default) Clone() Default                        { return Default{m.msg.Clone()} }
+func (m Default) CloneToArena(a alloc.Arena) Default    { return Default{m.msg.CloneToArena(a)} }
+func (m Default) CloneToBuffer(b buffer.Buffer) Default { return Default{m.msg.CloneToBuffer(b)} }
+
+func (m Default) IsEmpty() bool        { return m.msg.Empty() }
+func (m Default) Unwrap() spec.Message { return m.msg }
+func (m Default) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(defaultDescriptor, m.msg)
+}
+
+func (m *Default) UnmarshalJSON(b []byte) error {
+	w := NewDefaultWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Default) String() string {
+	return spec.MessageString(defaultDescriptor, m.msg)
+}
+
+func (m Default) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, defaultDescriptor, m.msg)
+}
+
+var defaultDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Default",
+}
+
+func init() {
+	defaultDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "text", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "value", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Value", Message: valueDescriptor}},
+	}
+}
+
+func (m Default) Descriptor() *spec.MessageDescriptor {
+	return defaultDescriptor
+}
+
+// Oneof
+
+type Oneof struct {
+	msg spec.Message
+}
+
+func NewOneof(msg spec.Message) Oneof {
+	return Oneof{msg}
+}
+
+func OpenOneof(b []byte) Oneof {
+	msg := spec.OpenMessage(b)
+	return Oneof{msg}
+}
+
+func OpenOneofErr(b []byte) (_ Oneof, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Oneof{msg}, err
+}
+
+func ParseOneof(b []byte) (_ Oneof, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Oneof{}, size, oneofDescriptor.DescribeError(err)
+	}
+	return Oneof{msg}, size, nil
+}
+
+func (m Oneof) Name() spec.String { return m.msg.String(1) }
+func (m Oneof) Doc() spec.String  { return m.msg.String(2) }
+func (m Oneof) Pos() Position     { return OpenPosition(m.msg.FieldRaw(3)) }
+
+func (m Oneof) HasName() bool { return m.msg.HasField(1) }
+func (m Oneof) HasDoc() bool  { return m.msg.HasField(2) }
+func (m Oneof) HasPos() bool  { return m.msg.HasField(3) }
+
+func (m Oneof) Clone() Oneof                        { return Oneof{m.msg.Clone()} }
+func (m Oneof) CloneToArena(a alloc.Arena) Oneof    { return Oneof{m.msg.CloneToArena(a)} }
+func (m Oneof) CloneToBuffer(b buffer.Buffer) Oneof { return Oneof{m.msg.CloneToBuffer(b)} }
+
+func (m Oneof) IsEmpty() bool        { return m.msg.Empty() }
+func (m Oneof) Unwrap() spec.Message { return m.msg }
+func (m Oneof) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(oneofDescriptor, m.msg)
+}
+
+func (m *Oneof) UnmarshalJSON(b []byte) error {
+	w := NewOneofWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Oneof) String() string {
+	return spec.MessageString(oneofDescriptor, m.msg)
+}
+
+func (m Oneof) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, oneofDescriptor, m.msg)
+}
+
+var oneofDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Oneof",
+}
+
+func init() {
+	oneofDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "name", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "doc", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "pos", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindStruct, Name: "Position", Struct: positionDescriptor}},
+	}
+}
+
+func (m Oneof) Descriptor() *spec.MessageDescriptor {
+	return oneofDescriptor
+}
+
+// Struct
+
+type Struct struct {
+	msg spec.Message
+}
+
+func NewStruct(msg spec.Message) Struct {
+	return Struct{msg}
+}
+
+func OpenStruct(b []byte) Struct {
+	msg := spec.OpenMessage(b)
+	return Struct{msg}
+}
+
+func OpenStructErr(b []byte) (_ Struct, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Struct{msg}, err
+}
+
+func ParseStruct(b []byte) (_ Struct, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Struct{}, size, structDescriptor.DescribeError(err)
+	}
+	return Struct{msg}, size, nil
+}
+
+func (m Struct) Fields() spec.MessageList[StructField] {
+	return spec.NewMessageList(m.msg.List(1), OpenStructFieldErr)
+}
+func (m Struct) HasFields() bool                      { return m.msg.HasField(1) }
+func (m Struct) Clone() Struct                        { return Struct{m.msg.Clone()} }
+func (m Struct) CloneToArena(a alloc.Arena) Struct    { return Struct{m.msg.CloneToArena(a)} }
+func (m Struct) CloneToBuffer(b buffer.Buffer) Struct { return Struct{m.msg.CloneToBuffer(b)} }
+
+func (m Struct) IsEmpty() bool        { return m.msg.Empty() }
+func (m Struct) Unwrap() spec.Message { return m.msg }
+func (m Struct) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(structDescriptor, m.msg)
+}
+
+func (m *Struct) UnmarshalJSON(b []byte) error {
+	w := NewStructWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Struct) String() string {
+	return spec.MessageString(structDescriptor, m.msg)
+}
+
+func (m Struct) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, structDescriptor, m.msg)
+}
+
+var structDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Struct",
+}
+
+func init() {
+	structDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "fields", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]StructField", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "StructField", Message: structFieldDescriptor}}},
+	}
+}
+
+func (m Struct) Descriptor() *spec.MessageDescriptor {
+	return structDescriptor
+}
+
+// StructField
+
+type StructField struct {
+	msg spec.Message
+}
+
+func NewStructField(msg spec.Message) StructField {
+	return StructField{msg}
+}
+
+func OpenStructField(b []byte) StructField {
+	msg := spec.OpenMessage(b)
+	return StructField{msg}
+}
+
+func OpenStructFieldErr(b []byte) (_ StructField, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return StructField{msg}, err
+}
+
+func ParseStructField(b []byte) (_ StructField, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return StructField{}, size, structFieldDescriptor.DescribeError(err)
+	}
+	return StructField{msg}, size, nil
+}
+
+func (m StructField) Name() spec.String { return m.msg.String(1) }
+func (m StructField) Type() Type        { return NewType(m.msg.Message(2)) }
+func (m StructField) Doc() spec.String  { return m.msg.String(3) }
+func (m StructField) Pos() Position     { return OpenPosition(m.msg.FieldRaw(4)) }
+func (m StructField) Annotations() spec.MessageList[Annotation] {
+	return spec.NewMessageList(m.msg.List(5), OpenAnnotationErr)
+}
+
+func (m StructField) HasName() bool        { return m.msg.HasField(1) }
+func (m StructField) HasType() bool        { return m.msg.HasField(2) }
+func (m StructField) HasDoc() bool         { return m.msg.HasField(3) }
+func (m StructField) HasPos() bool         { return m.msg.HasField(4) }
+func (m StructField) HasAnnotations() bool { return m.msg.HasField(5) }
+
+func (m StructField) Clone() StructField { return StructField{m.msg.Clone()} }
+func (m StructField) CloneToArena(a alloc.Arena) StructField {
+	return StructField{m.msg.CloneToArena(a)}
+}
+func (m StructField) CloneToBuffer(b buffer.Buffer) StructField {
+	return StructField{m.msg.CloneToBuffer(b)}
+}
+
+func (m StructField) IsEmpty() bool        { return m.msg.Empty() }
+func (m StructField) Unwrap() spec.Message { return m.msg }
+func (m StructField) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(structFieldDescriptor, m.msg)
+}
+
+func (m *StructField) UnmarshalJSON(b []byte) error {
+	w := NewStructFieldWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m StructField) String() string {
+	return spec.MessageString(structFieldDescriptor, m.msg)
+}
+
+func (m StructField) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, structFieldDescriptor, m.msg)
+}
+
+var structFieldDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "StructField",
+}
+
+func init() {
+	structFieldDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "name", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "type", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Type", Message: typeDescriptor}},
+		{Name: "doc", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "pos", Tag: 4, Type: &spec.TypeDescriptor{Kind: spec.KindStruct, Name: "Position", Struct: positionDescriptor}},
+		{Name: "annotations", Tag: 5, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Annotation", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Annotation", Message: annotationDescriptor}}},
+	}
+}
+
+func (m StructField) Descriptor() *spec.MessageDescriptor {
+	return structFieldDescriptor
+}
+
+// Service
+
+type Service struct {
+	msg spec.Message
+}
+
+func NewService(msg spec.Message) Service {
+	return Service{msg}
+}
+
+func OpenService(b []byte) Service {
+	msg := spec.OpenMessage(b)
+	return Service{msg}
+}
+
+func OpenServiceErr(b []byte) (_ Service, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Service{msg}, err
+}
+
+func ParseService(b []byte) (_ Service, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Service{}, size, serviceDescriptor.DescribeError(err)
+	}
+	return Service{msg}, size, nil
+}
+
+func (m Service) Sub() bool { return m.msg.Bool(1) }
+func (m Service) Methods() spec.MessageList[Method] {
+	return spec.NewMessageList(m.msg.List(2), OpenMethodErr)
+}
+
+func (m Service) HasSub() bool     { return m.msg.HasField(1) }
+func (m Service) HasMethods() bool { return m.msg.HasField(2) }
+
+func (m Service) Clone() Service                        { return Service{m.msg.Clone()} }
+func (m Service) CloneToArena(a alloc.Arena) Service    { return Service{m.msg.CloneToArena(a)} }
+func (m Service) CloneToBuffer(b buffer.Buffer) Service { return Service{m.msg.CloneToBuffer(b)} }
+
+func (m Service) IsEmpty() bool        { return m.msg.Empty() }
+func (m Service) Unwrap() spec.Message { return m.msg }
+func (m Service) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(serviceDescriptor, m.msg)
+}
+
+func (m *Service) UnmarshalJSON(b []byte) error {
+	w := NewServiceWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Service) String() string {
+	return spec.MessageString(serviceDescriptor, m.msg)
+}
+
+func (m Service) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, serviceDescriptor, m.msg)
+}
+
+var serviceDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Service",
+}
+
+func init() {
+	serviceDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "sub", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindBool, Name: "bool"}},
+		{Name: "methods", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Method", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Method", Message: methodDescriptor}}},
+	}
+}
+
+func (m Service) Descriptor() *spec.MessageDescriptor {
+	return serviceDescriptor
+}
+
+// Method
+
+type Method struct {
+	msg spec.Message
+}
+
+func NewMethod(msg spec.Message) Method {
+	return Method{msg}
+}
+
+func OpenMethod(b []byte) Method {
+	msg := spec.OpenMessage(b)
+	return Method{msg}
+}
+
+func OpenMethodErr(b []byte) (_ Method, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Method{msg}, err
+}
+
+func ParseMethod(b []byte) (_ Method, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Method{}, size, methodDescriptor.DescribeError(err)
+	}
+	return Method{msg}, size, nil
+}
+
+func (m Method) Name() spec.String { return m.msg.String(1) }
+func (m Method) Type() spec.String { return m.msg.String(2) }
+func (m Method) Doc() spec.String  { return m.msg.String(3) }
+func (m Method) Pos() Position     { return OpenPosition(m.msg.FieldRaw(4)) }
+func (m Method) Annotations() spec.MessageList[Annotation] {
+	return spec.NewMessageList(m.msg.List(5), OpenAnnotationErr)
+}
+func (m Method) Request() Type    { return NewType(m.msg.Message(10)) }
+func (m Method) Response() Type   { return NewType(m.msg.Message(11)) }
+func (m Method) Channel() Channel { return NewChannel(m.msg.Message(12)) }
+func (m Method) Subservice() Type { return NewType(m.msg.Message(13)) }
+
+func (m Method) HasName() bool        { return m.msg.HasField(1) }
+func (m Method) HasType() bool        { return m.msg.HasField(2) }
+func (m Method) HasDoc() bool         { return m.msg.HasField(3) }
+func (m Method) HasPos() bool         { return m.msg.HasField(4) }
+func (m Method) HasAnnotations() bool { return m.msg.HasField(5) }
+func (m Method) HasRequest() bool     { return m.msg.HasField(10) }
+func (m Method) HasResponse() bool    { return m.msg.HasField(11) }
+func (m Method) HasChannel() bool     { return m.msg.HasField(12) }
+func (m Method) HasSubservice() bool  { return m.msg.HasField(13) }
+
+func (m Method) Clone() Method                        { return Method{m.msg.Clone()} }
+func (m Method) CloneToArena(a alloc.Arena) Method    { return Method{m.msg.CloneToArena(a)} }
+func (m Method) CloneToBuffer(b buffer.Buffer) Method { return Method{m.msg.CloneToBuffer(b)} }
+
+func (m Method) IsEmpty() bool        { return m.msg.Empty() }
+func (m Method) Unwrap() spec.Message { return m.msg }
+func (m Method) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(methodDescriptor, m.msg)
+}
+
+func (m *Method) UnmarshalJSON(b []byte) error {
+	w := NewMethodWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Method) String() string {
+	return spec.MessageString(methodDescriptor, m.msg)
+}
+
+func (m Method) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, methodDescriptor, m.msg)
+}
+
+var methodDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Method",
+}
+
+func init() {
+	methodDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "name", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "type", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "doc", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "pos", Tag: 4, Type: &spec.TypeDescriptor{Kind: spec.KindStruct, Name: "Position", Struct: positionDescriptor}},
+		{Name: "annotations", Tag: 5, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Annotation", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Annotation", Message: annotationDescriptor}}},
+		{Name: "request", Tag: 10, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Type", Message: typeDescriptor}},
+		{Name: "response", Tag: 11, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Type", Message: typeDescriptor}},
+		{Name: "channel", Tag: 12, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Channel", Message: channelDescriptor}},
+		{Name: "subservice", Tag: 13, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Type", Message: typeDescriptor}},
+	}
+}
+
+func (m Method) Descriptor() *spec.MessageDescriptor {
+	return methodDescriptor
+}
+
+// Channel
+
+type Channel struct {
+	msg spec.Message
+}
+
+func NewChannel(msg spec.Message) Channel {
+	return Channel{msg}
+}
+
+func OpenChannel(b []byte) Channel {
+	msg := spec.OpenMessage(b)
+	return Channel{msg}
+}
+
+func OpenChannelErr(b []byte) (_ Channel, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Channel{msg}, err
+}
+
+func ParseChannel(b []byte) (_ Channel, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Channel{}, size, channelDescriptor.DescribeError(err)
+	}
+	return Channel{msg}, size, nil
+}
+
+func (m Channel) In() Type  { return NewType(m.msg.Message(1)) }
+func (m Channel) Out() Type { return NewType(m.msg.Message(2)) }
+
+func (m Channel) HasIn() bool  { return m.msg.HasField(1) }
+func (m Channel) HasOut() bool { return m.msg.HasField(2) }
+
+func (m Channel) Clone() Channel                        { return Channel{m.msg.Clone()} }
+func (m Channel) CloneToArena(a alloc.Arena) Channel    { return Channel{m.msg.CloneToArena(a)} }
+func (m Channel) CloneToBuffer(b buffer.Buffer) Channel { return Channel{m.msg.CloneToBuffer(b)} }
+
+func (m Channel) IsEmpty() bool        { return m.msg.Empty() }
+func (m Channel) Unwrap() spec.Message { return m.msg }
+func (m Channel) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(channelDescriptor, m.msg)
+}
+
+func (m *Channel) UnmarshalJSON(b []byte) error {
+	w := NewChannelWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Channel) String() string {
+	return spec.MessageString(channelDescriptor, m.msg)
+}
+
+func (m Channel) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, channelDescriptor, m.msg)
+}
+
+var channelDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Channel",
+}
+
+func init() {
+	channelDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "in", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Type", Message: typeDescriptor}},
+		{Name: "out", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Type", Message: typeDescriptor}},
+	}
+}
+
+func (m Channel) Descriptor() *spec.MessageDescriptor {
+	return channelDescriptor
+}
+
+// Type
+
+type Type struct {
+	msg spec.Message
+}
+
+func NewType(msg spec.Message) Type {
+	return Type{msg}
+}
+
+func OpenType(b []byte) Type {
+	msg := spec.OpenMessage(b)
+	return Type{msg}
+}
+
+func OpenTypeErr(b []byte) (_ Type, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Type{msg}, err
+}
+
+func ParseType(b []byte) (_ Type, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Type{}, size, typeDescriptor.DescribeError(err)
+	}
+	return Type{msg}, size, nil
+}
+
+func (m Type) Kind() int32          { return m.msg.Int32(1) }
+func (m Type) Name() spec.String    { return m.msg.String(2) }
+func (m Type) Key() Type            { return NewType(m.msg.Message(3)) }
+func (m Type) Element() Type        { return NewType(m.msg.Message(4)) }
+func (m Type) Import() spec.String  { return m.msg.String(5) }
+func (m Type) Package() spec.String { return m.msg.String(6) }
+
+func (m Type) HasKind() bool    { return m.msg.HasField(1) }
+func (m Type) HasName() bool    { return m.msg.HasField(2) }
+func (m Type) HasKey() bool     { return m.msg.HasField(3) }
+func (m Type) HasElement() bool { return m.msg.HasField(4) }
+func (m Type) HasImport() bool  { return m.msg.HasField(5) }
+func (m Type) HasPackage() bool { return m.msg.HasField(6) }
+
+func (m Type) Clone() Type                        { return Type{m.msg.Clone()} }
+func (m Type) CloneToArena(a alloc.Arena) Type    { return Type{m.msg.CloneToArena(a)} }
+func (m Type) CloneToBuffer(b buffer.Buffer) Type { return Type{m.msg.CloneToBuffer(b)} }
+
+func (m Type) IsEmpty() bool        { return m.msg.Empty() }
+func (m Type) Unwrap() spec.Message { return m.msg }
+func (m Type) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(typeDescriptor, m.msg)
+}
+
+func (m *Type) UnmarshalJSON(b []byte) error {
+	w := NewTypeWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Type) String() string {
+	return spec.MessageString(typeDescriptor, m.msg)
+}
+
+func (m Type) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, typeDescriptor, m.msg)
+}
+
+var typeDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Type",
+}
+
+func init() {
+	typeDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "kind", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindInt32, Name: "int32"}},
+		{Name: "name", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "key", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Type", Message: typeDescriptor}},
+		{Name: "element", Tag: 4, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Type", Message: typeDescriptor}},
+		{Name: "import", Tag: 5, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "package", Tag: 6, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+	}
+}
+
+func (m Type) Descriptor() *spec.MessageDescriptor {
+	return typeDescriptor
+}
+
+// Response
+
+// Response is a plugin response.
+type Response struct {
+	msg spec.Message
+}
+
+func NewResponse(msg spec.Message) Response {
+	return Response{msg}
+}
+
+func OpenResponse(b []byte) Response {
+	msg := spec.OpenMessage(b)
+	return Response{msg}
+}
+
+func OpenResponseErr(b []byte) (_ Response, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return Response{msg}, err
+}
+
+func ParseResponse(b []byte) (_ Response, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return Response{}, size, responseDescriptor.DescribeError(err)
+	}
+	return Response{msg}, size, nil
+}
+
+func (m Response) Files() spec.MessageList[OutputFile] {
+	return spec.NewMessageList(m.msg.List(1), OpenOutputFileErr)
+}
+func (m Response) Error() spec.String { return m.msg.String(2) }
+
+func (m Response) HasFiles() bool { return m.msg.HasField(1) }
+func (m Response) HasError() bool { return m.msg.HasField(2) }
+
+func (m Response) Clone() Response                        { return Response{m.msg.Clone()} }
+func (m Response) CloneToArena(a alloc.Arena) Response    { return Response{m.msg.CloneToArena(a)} }
+func (m Response) CloneToBuffer(b buffer.Buffer) Response { return Response{m.msg.CloneToBuffer(b)} }
+
+func (m Response) IsEmpty() bool        { return m.msg.Empty() }
+func (m Response) Unwrap() spec.Message { return m.msg }
+func (m Response) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(responseDescriptor, m.msg)
+}
+
+func (m *Response) UnmarshalJSON(b []byte) error {
+	w := NewResponseWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Response) String() string {
+	return spec.MessageString(responseDescriptor, m.msg)
+}
+
+func (m Response) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, responseDescriptor, m.msg)
+}
+
+var responseDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "Response",
+}
+
+func init() {
+	responseDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "files", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]OutputFile", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "OutputFile", Message: outputFileDescriptor}}},
+		{Name: "error", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+	}
+}
+
+func (m Response) Descriptor() *spec.MessageDescriptor {
+	return responseDescriptor
+}
+
+// OutputFile
+
+// OutputFile is a generated file.
+type OutputFile struct {
+	msg spec.Message
+}
+
+func NewOutputFile(msg spec.Message) OutputFile {
+	return OutputFile{msg}
+}
+
+func OpenOutputFile(b []byte) OutputFile {
+	msg := spec.OpenMessage(b)
+	return OutputFile{msg}
+}
+
+func OpenOutputFileErr(b []byte) (_ OutputFile, err error) {
+	msg, err := spec.OpenMessageErr(b)
+	return OutputFile{msg}, err
+}
+
+func ParseOutputFile(b []byte) (_ OutputFile, size int, err error) {
+	msg, size, err := spec.ParseMessage(b)
+	if err != nil {
+		return OutputFile{}, size, outputFileDescriptor.DescribeError(err)
+	}
+	return OutputFile{msg}, size, nil
+}
+
+func (m OutputFile) Name() spec.String   { return m.msg.String(1) }
+func (m OutputFile) Content() spec.Bytes { return m.msg.Bytes(2) }
+
+func (m OutputFile) HasName() bool    { return m.msg.HasField(1) }
+func (m OutputFile) HasContent() bool { return m.msg.HasField(2) }
+
+func (m OutputFile) Clone() OutputFile                     { return OutputFile{m.msg.Clone()} }
+func (m OutputFile) CloneToArena(a alloc.Arena) OutputFile { return OutputFile{m.msg.CloneToArena(a)} }
+func (m OutputFile) CloneToBuffer(b buffer.Buffer) OutputFile {
+	return OutputFile{m.msg.CloneToBuffer(b)}
+}
+
+func (m OutputFile) IsEmpty() bool        { return m.msg.Empty() }
+func (m OutputFile) Unwrap() spec.Message { return m.msg }
+func (m OutputFile) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(outputFileDescriptor, m.msg)
+}
+
+func (m *OutputFile) UnmarshalJSON(b []byte) error {
+	w := NewOutputFileWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m OutputFile) String() string {
+	return spec.MessageString(outputFileDescriptor, m.msg)
+}
+
+func (m OutputFile) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, outputFileDescriptor, m.msg)
+}
+
+var outputFileDescriptor = &spec.MessageDescriptor{
+	Package: "pgen",
+	Name:    "OutputFile",
+}
+
+func init() {
+	outputFileDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "name", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "content", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindBytes, Name: "bytes"}},
+	}
+}
+
+func (m OutputFile) Descriptor() *spec.MessageDescriptor {
+	return outputFileDescriptor
+}
+
+// RequestWriter
+
+type RequestWriter struct {
+	w spec.MessageWriter
+}
+
+func NewRequestWriter() RequestWriter {
+	w := spec.NewMessageWriter()
+	return RequestWriter{w: w}
+}
+
+func NewRequestWriterBuffer(b buffer.Buffer) RequestWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return RequestWriter{w: w}
+}
+
+func NewRequestWriterTo(w spec.MessageWriter) RequestWriter {
+	return RequestWriter{w: w}
+}
+
+func (w RequestWriter) Package() PackageWriter {
+	w1 := w.w.Field(1).Message()
+	return NewPackageWriterTo(w1)
+}
+func (w RequestWriter) CopyPackage(v Package) error {
+	return w.w.Field(1).Any(v.Unwrap().Raw())
+}
+func (w RequestWriter) Imports() spec.MessageListWriter[PackageWriter] {
+	w1 := w.w.Field(2).List()
+	return spec.NewMessageListWriter(w1, NewPackageWriterTo)
+}
+
+func (w RequestWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, requestDescriptor, b)
+}
+
+func (w RequestWriter) Merge(msg Request) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w RequestWriter) End() error {
+	return w.w.End()
+}
+
+func (w RequestWriter) Build() (_ Request, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenRequestErr(bytes)
+}
+
+func (w RequestWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// PackageWriter
+
+type PackageWriter struct {
+	w spec.MessageWriter
+}
+
+func NewPackageWriter() PackageWriter {
+	w := spec.NewMessageWriter()
+	return PackageWriter{w: w}
+}
+
+func NewPackageWriterBuffer(b buffer.Buffer) PackageWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return PackageWriter{w: w}
+}
+
+func NewPackageWriterTo(w spec.MessageWriter) PackageWriter {
+	return PackageWriter{w: w}
+}
+
+func (w PackageWriter) Id(v string)   { w.w.Field(1).String(v) }
+func (w PackageWriter) Name(v string) { w.w.Field(2).String(v) }
+func (w PackageWriter) Path(v string) { w.w.Field(3).String(v) }
+func (w PackageWriter) Files() spec.MessageListWriter[FileWriter] {
+	w1 := w.w.Field(4).List()
+	return spec.NewMessageListWriter(w1, NewFileWriterTo)
+}
+func (w PackageWriter) Warnings() spec.ValueListWriter[string] {
+	w1 := w.w.Field(5).List()
+	return spec.NewValueListWriter(w1, spec.EncodeString)
+}
+
+func (w PackageWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, packageDescriptor, b)
+}
+
+func (w PackageWriter) Merge(msg Package) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w PackageWriter) End() error {
+	return w.w.End()
+}
+
+func (w PackageWriter) Build() (_ Package, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenPackageErr(bytes)
+}
+
+func (w PackageWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// FileWriter
+
+type FileWriter struct {
+	w spec.MessageWriter
+}
+
+func NewFileWriter() FileWriter {
+	w := spec.NewMessageWriter()
+	return FileWriter{w: w}
+}
+
+func NewFileWriterBuffer(b buffer.Buffer) FileWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return FileWriter{w: w}
+}
+
+func NewFileWriterTo(w spec.MessageWriter) FileWriter {
+	return FileWriter{w: w}
+}
+
+func (w FileWriter) Name(v string) { w.w.Field(1).String(v) }
+func (w FileWriter) Path(v string) { w.w.Field(2).String(v) }
+func (w FileWriter) Imports() spec.MessageListWriter[ImportWriter] {
+	w1 := w.w.Field(3).List()
+	return spec.NewMessageListWriter(w1, NewImportWriterTo)
+}
+func (w FileWriter) Options() spec.MessageListWriter[OptionWriter] {
+	w1 := w.w.Field(4).List()
+	return spec.NewMessageListWriter(w1, NewOptionWriterTo)
+}
+func (w FileWriter) Definitions() spec.MessageListWriter[DefinitionWriter] {
+	w1 := w.w.Field(5).List()
+	return spec.NewMessageListWriter(w1, NewDefinitionWriterTo)
+}
+
+func (w FileWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, fileDescriptor, b)
+}
+
+func (w FileWriter) Merge(msg File) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w FileWriter) End() error {
+	return w.w.End()
+}
+
+func (w FileWriter) Build() (_ File, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenFileErr(bytes)
+}
+
+func (w FileWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// ImportWriter
+
+type ImportWriter struct {
+	w spec.MessageWriter
+}
+
+func NewImportWriter() ImportWriter {
+	w := spec.NewMessageWriter()
+	return ImportWriter{w: w}
+}
+
+func NewImportWriterBuffer(b buffer.Buffer) ImportWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return ImportWriter{w: w}
+}
+
+func NewImportWriterTo(w spec.MessageWriter) ImportWriter {
+	return ImportWriter{w: w}
+}
+
+func (w ImportWriter) Id(v string)    { w.w.Field(1).String(v) }
+func (w ImportWriter) Name(v string)  { w.w.Field(2).String(v) }
+func (w ImportWriter) Pos(v Position) { spec.WriteField(w.w.Field(3), v, EncodePositionTo) }
+
+func (w ImportWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, importDescriptor, b)
+}
+
+func (w ImportWriter) Merge(msg Import) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w ImportWriter) End() error {
+	return w.w.End()
+}
+
+func (w ImportWriter) Build() (_ Import, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenImportErr(bytes)
+}
+
+func (w ImportWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// OptionWriter
+
+type OptionWriter struct {
+	w spec.MessageWriter
+}
+
+func NewOptionWriter() OptionWriter {
+	w := spec.NewMessageWriter()
+	return OptionWriter{w: w}
+}
+
+func NewOptionWriterBuffer(b buffer.Buffer) OptionWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return OptionWriter{w: w}
+}
+
+func NewOptionWriterTo(w spec.MessageWriter) OptionWriter {
+	return OptionWriter{w: w}
+}
+
+func (w OptionWriter) Name(v string)  { w.w.Field(1).String(v) }
+func (w OptionWriter) Value(v string) { w.w.Field(2).String(v) }
+func (w OptionWriter) Pos(v Position) { spec.WriteField(w.w.Field(3), v, EncodePositionTo) }
+
+func (w OptionWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, optionDescriptor, b)
+}
+
+func (w OptionWriter) Merge(msg Option) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w OptionWriter) End() error {
+	return w.w.End()
+}
+
+func (w OptionWriter) Build() (_ Option, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenOptionErr(bytes)
+}
+
+func (w OptionWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// DefinitionWriter
+
+type DefinitionWriter struct {
+	w spec.MessageWriter
+}
+
+func NewDefinitionWriter() DefinitionWriter {
+	w := spec.NewMessageWriter()
+	return DefinitionWriter{w: w}
+}
+
+func NewDefinitionWriterBuffer(b buffer.Buffer) DefinitionWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return DefinitionWriter{w: w}
+}
+
+func NewDefinitionWriterTo(w spec.MessageWriter) DefinitionWriter {
+	return DefinitionWriter{w: w}
+}
+
+func (w DefinitionWriter) Name(v string)    { w.w.Field(1).String(v) }
+func (w DefinitionWriter) Type(v string)    { w.w.Field(2).String(v) }
+func (w DefinitionWriter) Doc(v string)     { w.w.Field(3).String(v) }
+func (w DefinitionWriter) Pos(v Position)   { spec.WriteField(w.w.Field(4), v, EncodePositionTo) }
+func (w DefinitionWriter) Generated(v bool) { w.w.Field(5).Bool(v) }
+func (w DefinitionWriter) Annotations() spec.MessageListWriter[AnnotationWriter] {
+	w1 := w.w.Field(6).List()
+	return spec.NewMessageListWriter(w1, NewAnnotationWriterTo)
+}
+func (w DefinitionWriter) EnumDef() EnumWriter {
+	w1 := w.w.Field(10).Message()
+	return NewEnumWriterTo(w1)
+}
+func (w DefinitionWriter) CopyEnumDef(v Enum) error {
+	return w.w.Field(10).Any(v.Unwrap().Raw())
+}
+func (w DefinitionWriter) MessageDef() MessageWriter {
+	w1 := w.w.Field(11).Message()
+	return NewMessageWriterTo(w1)
+}
+func (w DefinitionWriter) CopyMessageDef(v Message) error {
+	return w.w.Field(11).Any(v.Unwrap().Raw())
+}
+func (w DefinitionWriter) StructDef() StructWriter {
+	w1 := w.w.Field(12).Message()
+	return NewStructWriterTo(w1)
+}
+func (w DefinitionWriter) CopyStructDef(v Struct) error {
+	return w.w.Field(12).Any(v.Unwrap().Raw())
+}
+func (w DefinitionWriter) ServiceDef() ServiceWriter {
+	w1 := w.w.Field(13).Message()
+	return NewServiceWriterTo(w1)
+}
+func (w DefinitionWriter) CopyServiceDef(v Service) error {
+	return w.w.Field(13).Any(v.Unwrap().Raw())
+}
+
+func (w DefinitionWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, definitionDescriptor, b)
+}
+
+func (w DefinitionWriter) Merge(msg Definition) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w DefinitionWriter) End() error {
+	return w.w.End()
+}
+
+func (w DefinitionWriter) Build() (_ Definition, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenDefinitionErr(bytes)
+}
+
+func (w DefinitionWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// AnnotationWriter
+
+type AnnotationWriter struct {
+	w spec.MessageWriter
+}
+
+func NewAnnotationWriter() AnnotationWriter {
+	w := spec.NewMessageWriter()
+	return AnnotationWriter{w: w}
+}
+
+func NewAnnotationWriterBuffer(b buffer.Buffer) AnnotationWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return AnnotationWriter{w: w}
+}
+
+func NewAnnotationWriterTo(w spec.MessageWriter) AnnotationWriter {
+	return AnnotationWriter{w: w}
+}
+
+func (w AnnotationWriter) Name(v string) { w.w.Field(1).String(v) }
+func (w AnnotationWriter) Value() ValueWriter {
+	w1 := w.w.Field(2).Message()
+	return NewValueWriterTo(w1)
+}
+func (w AnnotationWriter) CopyValue(v Value) error {
+	return w.w.Field(2).Any(v.Unwrap().Raw())
+}
+
+func (w AnnotationWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, annotationDescriptor, b)
+}
+
+func (w AnnotationWriter) Merge(msg Annotation) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w AnnotationWriter) End() error {
+	return w.w.End()
+}
+
+func (w AnnotationWriter) Build() (_ Annotation, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenAnnotationErr(bytes)
+}
+
+func (w AnnotationWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// ValueWriter
+
+// ValueWriter writes Value messages. Setters fail the writer when another field
+// of the same oneof is already set, End and Build fail unless exactly one field is set.
+type ValueWriter struct {
+	w spec.MessageWriter
+}
+
+func NewValueWriter() ValueWriter {
+	w := spec.NewMessageWriter()
+	return ValueWriter{w: w}
+}
+
+func NewValueWriterBuffer(b buffer.Buffer) ValueWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return ValueWriter{w: w}
+}
+
+func NewValueWriterTo(w spec.MessageWriter) ValueWriter {
+	return ValueWriter{w: w}
+}
+
+func (w ValueWriter) BoolValue(v bool) {
+	valueDescriptor.Oneofs[0].CheckWriter(w.w, 1)
+	w.w.Field(1).Bool(v)
+}
+func (w ValueWriter) IntValue(v int64) {
+	valueDescriptor.Oneofs[0].CheckWriter(w.w, 2)
+	w.w.Field(2).Int64(v)
+}
+func (w ValueWriter) UintValue(v uint64) {
+	valueDescriptor.Oneofs[0].CheckWriter(w.w, 3)
+	w.w.Field(3).Uint64(v)
+}
+func (w ValueWriter) FloatValue(v float64) {
+	valueDescriptor.Oneofs[0].CheckWriter(w.w, 4)
+	w.w.Field(4).Float64(v)
+}
+func (w ValueWriter) StringValue(v string) {
+	valueDescriptor.Oneofs[0].CheckWriter(w.w, 5)
+	w.w.Field(5).String(v)
+}
+func (w ValueWriter) EnumValue(v string) {
+	valueDescriptor.Oneofs[0].CheckWriter(w.w, 6)
+	w.w.Field(6).String(v)
+}
+
+func (w ValueWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, valueDescriptor, b)
+}
+
+func (w ValueWriter) Merge(msg Value) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w ValueWriter) End() error {
+	if err := valueDescriptor.ValidateOneofsWriter(w.w); err != nil {
+		return err
+	}
+	return w.w.End()
+}
+
+func (w ValueWriter) Build() (_ Value, err error) {
+	if err = valueDescriptor.ValidateOneofsWriter(w.w); err != nil {
+		return
+	}
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenValueErr(bytes)
+}
+
+func (w ValueWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// ReservedWriter
+
+type ReservedWriter struct {
+	w spec.MessageWriter
+}
+
+func NewReservedWriter() ReservedWriter {
+	w := spec.NewMessageWriter()
+	return ReservedWriter{w: w}
+}
+
+func NewReservedWriterBuffer(b buffer.Buffer) ReservedWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return ReservedWriter{w: w}
+}
+
+func NewReservedWriterTo(w spec.MessageWriter) ReservedWriter {
+	return ReservedWriter{w: w}
+}
+
+func (w ReservedWriter) Ranges() spec.ValueListWriter[ReservedRange] {
+	w1 := w.w.Field(1).List()
+	return spec.NewValueListWriter(w1, EncodeReservedRangeTo)
+}
+func (w ReservedWriter) Names() spec.ValueListWriter[string] {
+	w1 := w.w.Field(2).List()
+	return spec.NewValueListWriter(w1, spec.EncodeString)
+}
+
+func (w ReservedWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, reservedDescriptor, b)
+}
+
+func (w ReservedWriter) Merge(msg Reserved) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w ReservedWriter) End() error {
+	return w.w.End()
+}
+
+func (w ReservedWriter) Build() (_ Reserved, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenReservedErr(bytes)
+}
+
+func (w ReservedWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// EnumWriter
+
+type EnumWriter struct {
+	w spec.MessageWriter
+}
+
+func NewEnumWriter() EnumWriter {
+	w := spec.NewMessageWriter()
+	return EnumWriter{w: w}
+}
+
+func NewEnumWriterBuffer(b buffer.Buffer) EnumWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return EnumWriter{w: w}
+}
+
+func NewEnumWriterTo(w spec.MessageWriter) EnumWriter {
+	return EnumWriter{w: w}
+}
+
+func (w EnumWriter) Values() spec.MessageListWriter[EnumValueWriter] {
+	w1 := w.w.Field(1).List()
+	return spec.NewMessageListWriter(w1, NewEnumValueWriterTo)
+}
+func (w EnumWriter) Reserved() ReservedWriter {
+	w1 := w.w.Field(2).Message()
+	return NewReservedWriterTo(w1)
+}
+func (w EnumWriter) CopyReserved(v Reserved) error {
+	return w.w.Field(2).Any(v.Unwrap().Raw())
+}
+
+func (w EnumWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, enumDescriptor, b)
+}
+
+func (w EnumWriter) Merge(msg Enum) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w EnumWriter) End() error {
+	return w.w.End()
+}
+
+func (w EnumWriter) Build() (_ Enum, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenEnumErr(bytes)
+}
+
+func (w EnumWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// EnumValueWriter
+
+type EnumValueWriter struct {
+	w spec.MessageWriter
+}
+
+func NewEnumValueWriter() EnumValueWriter {
+	w := spec.NewMessageWriter()
+	return EnumValueWriter{w: w}
+}
+
+func NewEnumValueWriterBuffer(b buffer.Buffer) EnumValueWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return EnumValueWriter{w: w}
+}
+
+func NewEnumValueWriterTo(w spec.MessageWriter) EnumValueWriter {
+	return EnumValueWriter{w: w}
+}
+
+func (w EnumValueWriter) Name(v string)  { w.w.Field(1).String(v) }
+func (w EnumValueWriter) Number(v int32) { w.w.Field(2).Int32(v) }
+func (w EnumValueWriter) Doc(v string)   { w.w.Field(3).String(v) }
+func (w EnumValueWriter) Pos(v Position) { spec.WriteField(w.w.Field(4), v, EncodePositionTo) }
+func (w EnumValueWriter) Annotations() spec.MessageListWriter[AnnotationWriter] {
+	w1 := w.w.Field(5).List()
+	return spec.NewMessageListWriter(w1, NewAnnotationWriterTo)
+}
+
+func (w EnumValueWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, enumValueDescriptor, b)
+}
+
+func (w EnumValueWriter) Merge(msg EnumValue) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w EnumValueWriter) End() error {
+	return w.w.End()
+}
+
+func (w EnumValueWriter) Build() (_ EnumValue, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenEnumValueErr(bytes)
+}
+
+func (w EnumValueWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// MessageWriter
+
+type MessageWriter struct {
+	w spec.MessageWriter
+}
+
+func NewMessageWriter() MessageWriter {
+	w := spec.NewMessageWriter()
+	return MessageWriter{w: w}
+}
+
+func NewMessageWriterBuffer(b buffer.Buffer) MessageWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return MessageWriter{w: w}
+}
+
+func NewMessageWriterTo(w spec.MessageWriter) MessageWriter {
+	return MessageWriter{w: w}
+}
+
+func (w MessageWriter) Fields() spec.MessageListWriter[FieldWriter] {
+	w1 := w.w.Field(1).List()
+	return spec.NewMessageListWriter(w1, NewFieldWriterTo)
+}
+func (w MessageWriter) Oneofs() spec.MessageListWriter[OneofWriter] {
+	w1 := w.w.Field(2).List()
+	return spec.NewMessageListWriter(w1, NewOneofWriterTo)
+}
+func (w MessageWriter) Reserved() ReservedWriter {
+	w1 := w.w.Field(3).Message()
+	return NewReservedWriterTo(w1)
+}
+func (w MessageWriter) CopyReserved(v Reserved) error {
+	return w.w.Field(3).Any(v.Unwrap().Raw())
+}
+
+func (w MessageWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, messageDescriptor, b)
+}
+
+func (w MessageWriter) Merge(msg Message) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w MessageWriter) End() error {
+	return w.w.End()
+}
+
+func (w MessageWriter) Build() (_ Message, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenMessageErr(bytes)
+}
+
+func (w MessageWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// FieldWriter
+
+type FieldWriter struct {
+	w spec.MessageWriter
+}
+
+func NewFieldWriter() FieldWriter {
+	w := spec.NewMessageWriter()
+	return FieldWriter{w: w}
+}
+
+func NewFieldWriterBuffer(b buffer.Buffer) FieldWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return FieldWriter{w: w}
+}
+
+func NewFieldWriterTo(w spec.MessageWriter) FieldWriter {
+	return FieldWriter{w: w}
+}
+
+func (w FieldWriter) Name(v string) { w.w.Field(1).String(v) }
+func (w FieldWriter) Tag(v int32)   { w.w.Field(2).Int32(v) }
+func (w FieldWriter) Type() TypeWriter {
+	w1 := w.w.Field(3).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w FieldWriter) CopyType(v Type) error {
+	return w.w.Field(3).Any(v.Unwrap().Raw())
+}
+func (w FieldWriter) OneofName(v string) { w.w.Field(4).String(v) }
+func (w FieldWriter) Default() DefaultWriter {
+	w1 := w.w.Field(5).Message()
+	return NewDefaultWriterTo(w1)
+}
+func (w FieldWriter) CopyDefault(v Default) error {
+	return w.w.Field(5).Any(v.Unwrap().Raw())
+}
+func (w FieldWriter) Doc(v string)   { w.w.Field(6).String(v) }
+func (w FieldWriter) Pos(v Position) { spec.WriteField(w.w.Field(7), v, EncodePositionTo) }
+func (w FieldWriter) Annotations() spec.MessageListWriter[AnnotationWriter] {
+	w1 := w.w.Field(8).List()
+	return spec.NewMessageListWriter(w1, NewAnnotationWriterTo)
+}
+
+func (w FieldWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, fieldDescriptor, b)
+}
+
+func (w FieldWriter) Merge(msg Field) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w FieldWriter) End() error {
+	return w.w.End()
+}
+
+func (w FieldWriter) Build() (_ Field, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenFieldErr(bytes)
+}
+
+func (w FieldWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// DefaultWriter
+
+type DefaultWriter struct {
+	w spec.MessageWriter
+}
+
+func NewDefaultWriter() DefaultWriter {
+	w := spec.NewMessageWriter()
+	return DefaultWriter{w: w}
+}
+
+func NewDefaultWriterBuffer(b buffer.Buffer) DefaultWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return DefaultWriter{w: w}
+}
+
+func NewDefaultWriterTo(w spec.MessageWriter) DefaultWriter {
+	return DefaultWriter{w: w}
+}
+
+func (w DefaultWriter) Text(v string) { w.w.Field(1).String(v) }
+func (w DefaultWriter) Value() ValueWriter {
+	w1 := w.w.Field(2).Message()
+	return NewValueWriterTo(w1)
+}
+func (w DefaultWriter) CopyValue(v Value) error {
+	return w.w.Field(2).Any(v.Unwrap().Raw())
+}
+
+func (w DefaultWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, defaultDescriptor, b)
+}
+
+func (w DefaultWriter) Merge(msg Default) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w DefaultWriter) End() error {
+	return w.w.End()
+}
+
+func (w DefaultWriter) Build() (_ Default, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenDefaultErr(bytes)
+}
+
+func (w DefaultWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// OneofWriter
+
+type OneofWriter struct {
+	w spec.MessageWriter
+}
+
+func NewOneofWriter() OneofWriter {
+	w := spec.NewMessageWriter()
+	return OneofWriter{w: w}
+}
+
+func NewOneofWriterBuffer(b buffer.Buffer) OneofWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return OneofWriter{w: w}
+}
+
+func NewOneofWriterTo(w spec.MessageWriter) OneofWriter {
+	return OneofWriter{w: w}
+}
+
+func (w OneofWriter) Name(v string)  { w.w.Field(1).String(v) }
+func (w OneofWriter) Doc(v string)   { w.w.Field(2).String(v) }
+func (w OneofWriter) Pos(v Position) { spec.WriteField(w.w.Field(3), v, EncodePositionTo) }
+
+func (w OneofWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, oneofDescriptor, b)
+}
+
+func (w OneofWriter) Merge(msg Oneof) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w OneofWriter) End() error {
+	return w.w.End()
+}
+
+func (w OneofWriter) Build() (_ Oneof, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenOneofErr(bytes)
+}
+
+func (w OneofWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// StructWriter
+
+type StructWriter struct {
+	w spec.MessageWriter
+}
+
+func NewStructWriter() StructWriter {
+	w := spec.NewMessageWriter()
+	return StructWriter{w: w}
+}
+
+func NewStructWriterBuffer(b buffer.Buffer) StructWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return StructWriter{w: w}
+}
+
+func NewStructWriterTo(w spec.MessageWriter) StructWriter {
+	return StructWriter{w: w}
+}
+
+func (w StructWriter) Fields() spec.MessageListWriter[StructFieldWriter] {
+	w1 := w.w.Field(1).List()
+	return spec.NewMessageListWriter(w1, NewStructFieldWriterTo)
+}
+
+func (w StructWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, structDescriptor, b)
+}
+
+func (w StructWriter) Merge(msg Struct) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w StructWriter) End() error {
+	return w.w.End()
+}
+
+func (w StructWriter) Build() (_ Struct, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenStructErr(bytes)
+}
+
+func (w StructWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// StructFieldWriter
+
+type StructFieldWriter struct {
+	w spec.MessageWriter
+}
+
+func NewStructFieldWriter() StructFieldWriter {
+	w := spec.NewMessageWriter()
+	return StructFieldWriter{w: w}
+}
+
+func NewStructFieldWriterBuffer(b buffer.Buffer) StructFieldWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return StructFieldWriter{w: w}
+}
+
+func NewStructFieldWriterTo(w spec.MessageWriter) StructFieldWriter {
+	return StructFieldWriter{w: w}
+}
+
+func (w StructFieldWriter) Name(v string) { w.w.Field(1).String(v) }
+func (w StructFieldWriter) Type() TypeWriter {
+	w1 := w.w.Field(2).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w StructFieldWriter) CopyType(v Type) error {
+	return w.w.Field(2).Any(v.Unwrap().Raw())
+}
+func (w StructFieldWriter) Doc(v string)   { w.w.Field(3).String(v) }
+func (w StructFieldWriter) Pos(v Position) { spec.WriteField(w.w.Field(4), v, EncodePositionTo) }
+func (w StructFieldWriter) Annotations() spec.MessageListWriter[AnnotationWriter] {
+	w1 := w.w.Field(5).List()
+	return spec.NewMessageListWriter(w1, NewAnnotationWriterTo)
+}
+
+func (w StructFieldWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, structFieldDescriptor, b)
+}
+
+func (w StructFieldWriter) Merge(msg StructField) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w StructFieldWriter) End() error {
+	return w.w.End()
+}
+
+func (w StructFieldWriter) Build() (_ StructField, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenStructFieldErr(bytes)
+}
+
+func (w StructFieldWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// ServiceWriter
+
+type ServiceWriter struct {
+	w spec.MessageWriter
+}
+
+func NewServiceWriter() ServiceWriter {
+	w := spec.NewMessageWriter()
+	return ServiceWriter{w: w}
+}
+
+func NewServiceWriterBuffer(b buffer.Buffer) ServiceWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return ServiceWriter{w: w}
+}
+
+func NewServiceWriterTo(w spec.MessageWriter) ServiceWriter {
+	return ServiceWriter{w: w}
+}
+
+func (w ServiceWriter) Sub(v bool) { w.w.Field(1).Bool(v) }
+func (w ServiceWriter) Methods() spec.MessageListWriter[MethodWriter] {
+	w1 := w.w.Field(2).List()
+	return spec.NewMessageListWriter(w1, NewMethodWriterTo)
+}
+
+func (w ServiceWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, serviceDescriptor, b)
+}
+
+func (w ServiceWriter) Merge(msg Service) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w ServiceWriter) End() error {
+	return w.w.End()
+}
+
+func (w ServiceWriter) Build() (_ Service, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenServiceErr(bytes)
+}
+
+func (w ServiceWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// MethodWriter
+
+type MethodWriter struct {
+	w spec.MessageWriter
+}
+
+func NewMethodWriter() MethodWriter {
+	w := spec.NewMessageWriter()
+	return MethodWriter{w: w}
+}
+
+func NewMethodWriterBuffer(b buffer.Buffer) MethodWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return MethodWriter{w: w}
+}
+
+func NewMethodWriterTo(w spec.MessageWriter) MethodWriter {
+	return MethodWriter{w: w}
+}
+
+func (w MethodWriter) Name(v string)  { w.w.Field(1).String(v) }
+func (w MethodWriter) Type(v string)  { w.w.Field(2).String(v) }
+func (w MethodWriter) Doc(v string)   { w.w.Field(3).String(v) }
+func (w MethodWriter) Pos(v Position) { spec.WriteField(w.w.Field(4), v, EncodePositionTo) }
+func (w MethodWriter) Annotations() spec.MessageListWriter[AnnotationWriter] {
+	w1 := w.w.Field(5).List()
+	return spec.NewMessageListWriter(w1, NewAnnotationWriterTo)
+}
+func (w MethodWriter) Request() TypeWriter {
+	w1 := w.w.Field(10).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w MethodWriter) CopyRequest(v Type) error {
+	return w.w.Field(10).Any(v.Unwrap().Raw())
+}
+func (w MethodWriter) Response() TypeWriter {
+	w1 := w.w.Field(11).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w MethodWriter) CopyResponse(v Type) error {
+	return w.w.Field(11).Any(v.Unwrap().Raw())
+}
+func (w MethodWriter) Channel() ChannelWriter {
+	w1 := w.w.Field(12).Message()
+	return NewChannelWriterTo(w1)
+}
+func (w MethodWriter) CopyChannel(v Channel) error {
+	return w.w.Field(12).Any(v.Unwrap().Raw())
+}
+func (w MethodWriter) Subservice() TypeWriter {
+	w1 := w.w.Field(13).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w MethodWriter) CopySubservice(v Type) error {
+	return w.w.Field(13).Any(v.Unwrap().Raw())
+}
+
+func (w MethodWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, methodDescriptor, b)
+}
+
+func (w MethodWriter) Merge(msg Method) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w MethodWriter) End() error {
+	return w.w.End()
+}
+
+func (w MethodWriter) Build() (_ Method, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenMethodErr(bytes)
+}
+
+func (w MethodWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// ChannelWriter
+
+type ChannelWriter struct {
+	w spec.MessageWriter
+}
+
+func NewChannelWriter() ChannelWriter {
+	w := spec.NewMessageWriter()
+	return ChannelWriter{w: w}
+}
+
+func NewChannelWriterBuffer(b buffer.Buffer) ChannelWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return ChannelWriter{w: w}
+}
+
+func NewChannelWriterTo(w spec.MessageWriter) ChannelWriter {
+	return ChannelWriter{w: w}
+}
+
+func (w ChannelWriter) In() TypeWriter {
+	w1 := w.w.Field(1).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w ChannelWriter) CopyIn(v Type) error {
+	return w.w.Field(1).Any(v.Unwrap().Raw())
+}
+func (w ChannelWriter) Out() TypeWriter {
+	w1 := w.w.Field(2).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w ChannelWriter) CopyOut(v Type) error {
+	return w.w.Field(2).Any(v.Unwrap().Raw())
+}
+
+func (w ChannelWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, channelDescriptor, b)
+}
+
+func (w ChannelWriter) Merge(msg Channel) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w ChannelWriter) End() error {
+	return w.w.End()
+}
+
+func (w ChannelWriter) Build() (_ Channel, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenChannelErr(bytes)
+}
+
+func (w ChannelWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// TypeWriter
+
+type TypeWriter struct {
+	w spec.MessageWriter
+}
+
+func NewTypeWriter() TypeWriter {
+	w := spec.NewMessageWriter()
+	return TypeWriter{w: w}
+}
+
+func NewTypeWriterBuffer(b buffer.Buffer) TypeWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return TypeWriter{w: w}
+}
+
+func NewTypeWriterTo(w spec.MessageWriter) TypeWriter {
+	return TypeWriter{w: w}
+}
+
+func (w TypeWriter) Kind(v int32)  { w.w.Field(1).Int32(v) }
+func (w TypeWriter) Name(v string) { w.w.Field(2).String(v) }
+func (w TypeWriter) Key() TypeWriter {
+	w1 := w.w.Field(3).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w TypeWriter) CopyKey(v Type) error {
+	return w.w.Field(3).Any(v.Unwrap().Raw())
+}
+func (w TypeWriter) Element() TypeWriter {
+	w1 := w.w.Field(4).Message()
+	return NewTypeWriterTo(w1)
+}
+func (w TypeWriter) CopyElement(v Type) error {
+	return w.w.Field(4).Any(v.Unwrap().Raw())
+}
+func (w TypeWriter) Import(v string)  { w.w.Field(5).String(v) }
+func (w TypeWriter) Package(v string) { w.w.Field(6).String(v) }
+
+func (w TypeWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, typeDescriptor, b)
+}
+
+func (w TypeWriter) Merge(msg Type) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w TypeWriter) End() error {
+	return w.w.End()
+}
+
+func (w TypeWriter) Build() (_ Type, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenTypeErr(bytes)
+}
+
+func (w TypeWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// ResponseWriter
+
+type ResponseWriter struct {
+	w spec.MessageWriter
+}
+
+func NewResponseWriter() ResponseWriter {
+	w := spec.NewMessageWriter()
+	return ResponseWriter{w: w}
+}
+
+func NewResponseWriterBuffer(b buffer.Buffer) ResponseWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return ResponseWriter{w: w}
+}
+
+func NewResponseWriterTo(w spec.MessageWriter) ResponseWriter {
+	return ResponseWriter{w: w}
+}
+
+func (w ResponseWriter) Files() spec.MessageListWriter[OutputFileWriter] {
+	w1 := w.w.Field(1).List()
+	return spec.NewMessageListWriter(w1, NewOutputFileWriterTo)
+}
+func (w ResponseWriter) Error(v string) { w.w.Field(2).String(v) }
+
+func (w ResponseWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, responseDescriptor, b)
+}
+
+func (w ResponseWriter) Merge(msg Response) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w ResponseWriter) End() error {
+	return w.w.End()
+}
+
+func (w ResponseWriter) Build() (_ Response, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenResponseErr(bytes)
+}
+
+func (w ResponseWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
+
+// OutputFileWriter
+
+type OutputFileWriter struct {
+	w spec.MessageWriter
+}
+
+func NewOutputFileWriter() OutputFileWriter {
+	w := spec.NewMessageWriter()
+	return OutputFileWriter{w: w}
+}
+
+func NewOutputFileWriterBuffer(b buffer.Buffer) OutputFileWriter {
+	w := spec.NewMessageWriterBuffer(b)
+	return OutputFileWriter{w: w}
+}
+
+func NewOutputFileWriterTo(w spec.MessageWriter) OutputFileWriter {
+	return OutputFileWriter{w: w}
+}
+
+func (w OutputFileWriter) Name(v string)    { w.w.Field(1).String(v) }
+func (w OutputFileWriter) Content(v []byte) { w.w.Field(2).Bytes(v) }
+
+func (w OutputFileWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, outputFileDescriptor, b)
+}
+
+func (w OutputFileWriter) Merge(msg OutputFile) error {
+	return w.w.Merge(msg.Unwrap())
+}
+
+func (w OutputFileWriter) End() error {
+	return w.w.End()
+}
+
+func (w OutputFileWriter) Build() (_ OutputFile, err error) {
+	bytes, err := w.w.Build()
+	if err != nil {
+		return
+	}
+	return OpenOutputFileErr(bytes)
+}
+
+func (w OutputFileWriter) Unwrap() spec.MessageWriter {
+	return w.w
+}
@@ -1,6 +1,7 @@
 package pmpx
 
 import (
+	"fmt"
 	"github.com/basecomplextech/baselibrary/alloc"
 	"github.com/basecomplextech/baselibrary/async"
 	"github.com/basecomplextech/baselibrary/bin"
@@ -12,6 +13,7 @@ import (
 )
 
 var (
+	_ fmt.State
 	_ alloc.Buffer
 	_ async.Context
 	_ bin.Bin128
@@ -60,6 +62,32 @@ func (e Version) String() string {
 	return ""
 }
 
+func (e Version) MarshalJSON() ([]byte, error) {
+	return spec.MarshalEnumJSON(versionDescriptor, int32(e))
+}
+
+func (e *Version) UnmarshalJSON(b []byte) error {
+	v, err := spec.UnmarshalEnumJSON(versionDescriptor, b)
+	if err != nil {
+		return err
+	}
+	*e = Version(v)
+	return nil
+}
+
+var versionDescriptor = &spec.EnumDescriptor{
+	Package: "pmpx",
+	Name:    "Version",
+	Values: []*spec.EnumValueDescriptor{
+		{Name: "UNDEFINED", Number: 0},
+		{Name: "VERSION_1_0", Number: 10},
+	},
+}
+
+func (e Version) Descriptor() *spec.EnumDescriptor {
+	return versionDescriptor
+}
+
 // Code
 
 type Code int32
@@ -115,6 +143,38 @@ func (e Code) String() string {
 	return ""
 }
 
+func (e Code) MarshalJSON() ([]byte, error) {
+	return spec.MarshalEnumJSON(codeDescriptor, int32(e))
+}
+
+func (e *Code) UnmarshalJSON(b []byte) error {
+	v, err := spec.UnmarshalEnumJSON(codeDescriptor, b)
+	if err != nil {
+		return err
+	}
+	*e = Code(v)
+	return nil
+}
+
+var codeDescriptor = &spec.EnumDescriptor{
+	Package: "pmpx",
+	Name:    "Code",
+	Values: []*spec.EnumValueDescriptor{
+		{Name: "UNDEFINED", Number: 0},
+		{Name: "CONNECT_REQUEST", Number: 1},
+		{Name: "CONNECT_RESPONSE", Number: 2},
+		{Name: "BATCH", Number: 3},
+		{Name: "CHANNEL_OPEN", Number: 10},
+		{Name: "CHANNEL_CLOSE", Number: 11},
+		{Name: "CHANNEL_DATA", Number: 12},
+		{Name: "CHANNEL_WINDOW", Number: 13},
+	},
+}
+
+func (e Code) Descriptor() *spec.EnumDescriptor {
+	return codeDescriptor
+}
+
 // Message
 
 type Message struct {
@@ -132,16 +192,13 @@ func OpenMessage(b []byte) Message {
 
 func OpenMessageErr(b []byte) (_ Message, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Message{msg}, nil
+	return Message{msg}, err
 }
 
 func ParseMessage(b []byte) (_ Message, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return Message{}, size, messageDescriptor.DescribeError(err)
 	}
 	return Message{msg}, size, nil
 }
@@ -164,11 +221,58 @@ func (m Message) HasChannelClose() bool    { return m.msg.HasField(11) }
 func (m Message) HasChannelData() bool     { return m.msg.HasField(12) }
 func (m Message) HasChannelWindow() bool   { return m.msg.HasField(13) }
 
-func (m Message) IsEmpty() bool                         { return m.msg.Empty() }
 func (m Message) Clone() Message                        { return Message{m.msg.Clone()} }
 func (m Message) CloneToArena(a alloc.Arena) Message    { return Message{m.msg.CloneToArena(a)} }
 func (m Message) CloneToBuffer(b buffer.Buffer) Message { return Message{m.msg.CloneToBuffer(b)} }
-func (m Message) Unwrap() spec.Message                  { return m.msg }
+
+func (m Message) IsEmpty() bool        { return m.msg.Empty() }
+func (m Message) Unwrap() spec.Message { return m.msg }
+func (m Message) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(messageDescriptor, m.msg)
+}
+
+func (m *Message) UnmarshalJSON(b []byte) error {
+	w := NewMessageWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Message) String() string {
+	return spec.MessageString(messageDescriptor, m.msg)
+}
+
+func (m Message) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, messageDescriptor, m.msg)
+}
+
+var messageDescriptor = &spec.MessageDescriptor{
+	Package: "pmpx",
+	Name:    "Message",
+}
+
+func init() {
+	messageDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "code", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindEnum, Name: "Code", Enum: codeDescriptor}},
+		{Name: "connect_request", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "ConnectRequest", Message: connectRequestDescriptor}},
+		{Name: "connect_response", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "ConnectResponse", Message: connectResponseDescriptor}},
+		{Name: "batch", Tag: 4, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Batch", Message: batchDescriptor}},
+		{Name: "channel_open", Tag: 10, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "ChannelOpen", Message: channelOpenDescriptor}},
+		{Name: "channel_close", Tag: 11, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "ChannelClose", Message: channelCloseDescriptor}},
+		{Name: "channel_data", Tag: 12, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "ChannelData", Message: channelDataDescriptor}},
+		{Name: "channel_window", Tag: 13, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "ChannelWindow", Message: channelWindowDescriptor}},
+	}
+}
+
+func (m Message) Descriptor() *spec.MessageDescriptor {
+	return messageDescriptor
+}
 
 // ConnectRequest
 
@@ -187,16 +291,13 @@ func OpenConnectRequest(b []byte) ConnectRequest {
 
 func OpenConnectRequestErr(b []byte) (_ ConnectRequest, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ConnectRequest{msg}, nil
+	return ConnectRequest{msg}, err
 }
 
 func ParseConnectRequest(b []byte) (_ ConnectRequest, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return ConnectRequest{}, size, connectRequestDescriptor.DescribeError(err)
 	}
 	return ConnectRequest{msg}, size, nil
 }
@@ -211,7 +312,6 @@ func (m ConnectRequest) Compression() spec.ValueList[ConnectCompression] {
 func (m ConnectRequest) HasVersions() bool    { return m.msg.HasField(1) }
 func (m ConnectRequest) HasCompression() bool { return m.msg.HasField(2) }
 
-func (m ConnectRequest) IsEmpty() bool         { return m.msg.Empty() }
 func (m ConnectRequest) Clone() ConnectRequest { return ConnectRequest{m.msg.Clone()} }
 func (m ConnectRequest) CloneToArena(a alloc.Arena) ConnectRequest {
 	return ConnectRequest{m.msg.CloneToArena(a)}
@@ -219,7 +319,49 @@ func (m ConnectRequest) CloneToArena(a alloc.Arena) ConnectRequest {
 func (m ConnectRequest) CloneToBuffer(b buffer.Buffer) ConnectRequest {
 	return ConnectRequest{m.msg.CloneToBuffer(b)}
 }
+
+func (m ConnectRequest) IsEmpty() bool        { return m.msg.Empty() }
 func (m ConnectRequest) Unwrap() spec.Message { return m.msg }
+func (m ConnectRequest) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(connectRequestDescriptor, m.msg)
+}
+
+func (m *ConnectRequest) UnmarshalJSON(b []byte) error {
+	w := NewConnectRequestWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m ConnectRequest) String() string {
+	return spec.MessageString(connectRequestDescriptor, m.msg)
+}
+
+func (m ConnectRequest) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, connectRequestDescriptor, m.msg)
+}
+
+var connectRequestDescriptor = &spec.MessageDescriptor{
+	Package: "pmpx",
+	Name:    "ConnectRequest",
+}
+
+func init() {
+	connectRequestDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "versions", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Version", Element: &spec.TypeDescriptor{Kind: spec.KindEnum, Name: "Version", Enum: versionDescriptor}}},
+		{Name: "compression", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]ConnectCompression", Element: &spec.TypeDescriptor{Kind: spec.KindEnum, Name: "ConnectCompression", Enum: connectCompressionDescriptor}}},
+	}
+}
+
+func (m ConnectRequest) Descriptor() *spec.MessageDescriptor {
+	return connectRequestDescriptor
+}
 
 // ConnectResponse
 
@@ -238,16 +380,13 @@ func OpenConnectResponse(b []byte) ConnectResponse {
 
 func OpenConnectResponseErr(b []byte) (_ ConnectResponse, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ConnectResponse{msg}, nil
+	return ConnectResponse{msg}, err
 }
 
 func ParseConnectResponse(b []byte) (_ ConnectResponse, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return ConnectResponse{}, size, connectResponseDescriptor.DescribeError(err)
 	}
 	return ConnectResponse{msg}, size, nil
 }
@@ -264,7 +403,6 @@ func (m ConnectResponse) HasError() bool       { return m.msg.HasField(2) }
 func (m ConnectResponse) HasVersion() bool     { return m.msg.HasField(10) }
 func (m ConnectResponse) HasCompression() bool { return m.msg.HasField(11) }
 
-func (m ConnectResponse) IsEmpty() bool          { return m.msg.Empty() }
 func (m ConnectResponse) Clone() ConnectResponse { return ConnectResponse{m.msg.Clone()} }
 func (m ConnectResponse) CloneToArena(a alloc.Arena) ConnectResponse {
 	return ConnectResponse{m.msg.CloneToArena(a)}
@@ -272,7 +410,51 @@ func (m ConnectResponse) CloneToArena(a alloc.Arena) ConnectResponse {
 func (m ConnectResponse) CloneToBuffer(b buffer.Buffer) ConnectResponse {
 	return ConnectResponse{m.msg.CloneToBuffer(b)}
 }
+
+func (m ConnectResponse) IsEmpty() bool        { return m.msg.Empty() }
 func (m ConnectResponse) Unwrap() spec.Message { return m.msg }
+func (m ConnectResponse) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(connectResponseDescriptor, m.msg)
+}
+
+func (m *ConnectResponse) UnmarshalJSON(b []byte) error {
+	w := NewConnectResponseWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m ConnectResponse) String() string {
+	return spec.MessageString(connectResponseDescriptor, m.msg)
+}
+
+func (m ConnectResponse) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, connectResponseDescriptor, m.msg)
+}
+
+var connectResponseDescriptor = &spec.MessageDescriptor{
+	Package: "pmpx",
+	Name:    "ConnectResponse",
+}
+
+func init() {
+	connectResponseDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "ok", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindBool, Name: "bool"}},
+		{Name: "error", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "version", Tag: 10, Type: &spec.TypeDescriptor{Kind: spec.KindEnum, Name: "Version", Enum: versionDescriptor}},
+		{Name: "compression", Tag: 11, Type: &spec.TypeDescriptor{Kind: spec.KindEnum, Name: "ConnectCompression", Enum: connectCompressionDescriptor}},
+	}
+}
+
+func (m ConnectResponse) Descriptor() *spec.MessageDescriptor {
+	return connectResponseDescriptor
+}
 
 // ConnectCompression
 
@@ -311,8 +493,36 @@ func (e ConnectCompression) String() string {
 	return ""
 }
 
+func (e ConnectCompression) MarshalJSON() ([]byte, error) {
+	return spec.MarshalEnumJSON(connectCompressionDescriptor, int32(e))
+}
+
+func (e *ConnectCompression) UnmarshalJSON(b []byte) error {
+	v, err := spec.UnmarshalEnumJSON(connectCompressionDescriptor, b)
+	if err != nil {
+		return err
+	}
+	*e = ConnectCompression(v)
+	return nil
+}
+
+var connectCompressionDescriptor = &spec.EnumDescriptor{
+	Package: "pmpx",
+	Name:    "ConnectCompression",
+	Values: []*spec.EnumValueDescriptor{
+		{Name: "NONE", Number: 0},
+		{Name: "LZ4", Number: 1},
+	},
+}
+
+func (e ConnectCompression) Descriptor() *spec.EnumDescriptor {
+	return connectCompressionDescriptor
+}
+
 // Batch
 
+// Batch combines multiple channel messages into a single message.
+// For example, open, data and immediate close, or data and close.
 type Batch struct {
 	msg spec.Message
 }
@@ -328,16 +538,13 @@ func OpenBatch(b []byte) Batch {
 
 func OpenBatchErr(b []byte) (_ Batch, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Batch{msg}, nil
+	return Batch{msg}, err
 }
 
 func ParseBatch(b []byte) (_ Batch, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return Batch{}, size, batchDescriptor.DescribeError(err)
 	}
 	return Batch{msg}, size, nil
 }
@@ -346,11 +553,51 @@ func (m Batch) List() spec.MessageList[Message] {
 	return spec.NewMessageList(m.msg.List(1), OpenMessageErr)
 }
 func (m Batch) HasList() bool                       { return m.msg.HasField(1) }
-func (m Batch) IsEmpty() bool                       { return m.msg.Empty() }
 func (m Batch) Clone() Batch                        { return Batch{m.msg.Clone()} }
 func (m Batch) CloneToArena(a alloc.Arena) Batch    { return Batch{m.msg.CloneToArena(a)} }
 func (m Batch) CloneToBuffer(b buffer.Buffer) Batch { return Batch{m.msg.CloneToBuffer(b)} }
-func (m Batch) Unwrap() spec.Message                { return m.msg }
+
+func (m Batch) IsEmpty() bool        { return m.msg.Empty() }
+func (m Batch) Unwrap() spec.Message { return m.msg }
+func (m Batch) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(batchDescriptor, m.msg)
+}
+
+func (m *Batch) UnmarshalJSON(b []byte) error {
+	w := NewBatchWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Batch) String() string {
+	return spec.MessageString(batchDescriptor, m.msg)
+}
+
+func (m Batch) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, batchDescriptor, m.msg)
+}
+
+var batchDescriptor = &spec.MessageDescriptor{
+	Package: "pmpx",
+	Name:    "Batch",
+}
+
+func init() {
+	batchDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "list", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Message", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Message", Message: messageDescriptor}}},
+	}
+}
+
+func (m Batch) Descriptor() *spec.MessageDescriptor {
+	return batchDescriptor
+}
 
 // ChannelOpen
 
@@ -369,16 +616,13 @@ func OpenChannelOpen(b []byte) ChannelOpen {
 
 func OpenChannelOpenErr(b []byte) (_ ChannelOpen, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ChannelOpen{msg}, nil
+	return ChannelOpen{msg}, err
 }
 
 func ParseChannelOpen(b []byte) (_ ChannelOpen, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return ChannelOpen{}, size, channelOpenDescriptor.DescribeError(err)
 	}
 	return ChannelOpen{msg}, size, nil
 }
@@ -391,7 +635,6 @@ func (m ChannelOpen) HasId() bool     { return m.msg.HasField(1) }
 func (m ChannelOpen) HasWindow() bool { return m.msg.HasField(2) }
 func (m ChannelOpen) HasData() bool   { return m.msg.HasField(3) }
 
-func (m ChannelOpen) IsEmpty() bool      { return m.msg.Empty() }
 func (m ChannelOpen) Clone() ChannelOpen { return ChannelOpen{m.msg.Clone()} }
 func (m ChannelOpen) CloneToArena(a alloc.Arena) ChannelOpen {
 	return ChannelOpen{m.msg.CloneToArena(a)}
@@ -399,7 +642,50 @@ func (m ChannelOpen) CloneToArena(a alloc.Arena) ChannelOpen {
 func (m ChannelOpen) CloneToBuffer(b buffer.Buffer) ChannelOpen {
 	return ChannelOpen{m.msg.CloneToBuffer(b)}
 }
+
+func (m ChannelOpen) IsEmpty() bool        { return m.msg.Empty() }
 func (m ChannelOpen) Unwrap() spec.Message { return m.msg }
+func (m ChannelOpen) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(channelOpenDescriptor, m.msg)
+}
+
+func (m *ChannelOpen) UnmarshalJSON(b []byte) error {
+	w := NewChannelOpenWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m ChannelOpen) String() string {
+	return spec.MessageString(channelOpenDescriptor, m.msg)
+}
+
+func (m ChannelOpen) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, channelOpenDescriptor, m.msg)
+}
+
+var channelOpenDescriptor = &spec.MessageDescriptor{
+	Package: "pmpx",
+	Name:    "ChannelOpen",
+}
+
+func init() {
+	channelOpenDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "id", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindBin128, Name: "bin128"}},
+		{Name: "window", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindInt32, Name: "int32"}},
+		{Name: "data", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindBytes, Name: "bytes"}},
+	}
+}
+
+func (m ChannelOpen) Descriptor() *spec.MessageDescriptor {
+	return channelOpenDescriptor
+}
 
 // ChannelClose
 
@@ -418,16 +704,13 @@ func OpenChannelClose(b []byte) ChannelClose {
 
 func OpenChannelCloseErr(b []byte) (_ ChannelClose, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ChannelClose{msg}, nil
+	return ChannelClose{msg}, err
 }
 
 func ParseChannelClose(b []byte) (_ ChannelClose, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return ChannelClose{}, size, channelCloseDescriptor.DescribeError(err)
 	}
 	return ChannelClose{msg}, size, nil
 }
@@ -438,7 +721,6 @@ func (m ChannelClose) Data() spec.Bytes { return m.msg.Bytes(2) }
 func (m ChannelClose) HasId() bool   { return m.msg.HasField(1) }
 func (m ChannelClose) HasData() bool { return m.msg.HasField(2) }
 
-func (m ChannelClose) IsEmpty() bool       { return m.msg.Empty() }
 func (m ChannelClose) Clone() ChannelClose { return ChannelClose{m.msg.Clone()} }
 func (m ChannelClose) CloneToArena(a alloc.Arena) ChannelClose {
 	return ChannelClose{m.msg.CloneToArena(a)}
@@ -446,7 +728,49 @@ func (m ChannelClose) CloneToArena(a alloc.Arena) ChannelClose {
 func (m ChannelClose) CloneToBuffer(b buffer.Buffer) ChannelClose {
 	return ChannelClose{m.msg.CloneToBuffer(b)}
 }
+
+func (m ChannelClose) IsEmpty() bool        { return m.msg.Empty() }
 func (m ChannelClose) Unwrap() spec.Message { return m.msg }
+func (m ChannelClose) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(channelCloseDescriptor, m.msg)
+}
+
+func (m *ChannelClose) UnmarshalJSON(b []byte) error {
+	w := NewChannelCloseWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m ChannelClose) String() string {
+	return spec.MessageString(channelCloseDescriptor, m.msg)
+}
+
+func (m ChannelClose) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, channelCloseDescriptor, m.msg)
+}
+
+var channelCloseDescriptor = &spec.MessageDescriptor{
+	Package: "pmpx",
+	Name:    "ChannelClose",
+}
+
+func init() {
+	channelCloseDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "id", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindBin128, Name: "bin128"}},
+		{Name: "data", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindBytes, Name: "bytes"}},
+	}
+}
+
+func (m ChannelClose) Descriptor() *spec.MessageDescriptor {
+	return channelCloseDescriptor
+}
 
 // ChannelData
 
@@ -465,16 +789,13 @@ func OpenChannelData(b []byte) ChannelData {
 
 func OpenChannelDataErr(b []byte) (_ ChannelData, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ChannelData{msg}, nil
+	return ChannelData{msg}, err
 }
 
 func ParseChannelData(b []byte) (_ ChannelData, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return ChannelData{}, size, channelDataDescriptor.DescribeError(err)
 	}
 	return ChannelData{msg}, size, nil
 }
@@ -485,7 +806,6 @@ func (m ChannelData) Data() spec.Bytes { return m.msg.Bytes(2) }
 func (m ChannelData) HasId() bool   { return m.msg.HasField(1) }
 func (m ChannelData) HasData() bool { return m.msg.HasField(2) }
 
-func (m ChannelData) IsEmpty() bool      { return m.msg.Empty() }
 func (m ChannelData) Clone() ChannelData { return ChannelData{m.msg.Clone()} }
 func (m ChannelData) CloneToArena(a alloc.Arena) ChannelData {
 	return ChannelData{m.msg.CloneToArena(a)}
@@ -493,7 +813,49 @@ func (m ChannelData) CloneToArena(a alloc.Arena) ChannelData {
 func (m ChannelData) CloneToBuffer(b buffer.Buffer) ChannelData {
 	return ChannelData{m.msg.CloneToBuffer(b)}
 }
+
+func (m ChannelData) IsEmpty() bool        { return m.msg.Empty() }
 func (m ChannelData) Unwrap() spec.Message { return m.msg }
+func (m ChannelData) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(channelDataDescriptor, m.msg)
+}
+
+func (m *ChannelData) UnmarshalJSON(b []byte) error {
+	w := NewChannelDataWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m ChannelData) String() string {
+	return spec.MessageString(channelDataDescriptor, m.msg)
+}
+
+func (m ChannelData) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, channelDataDescriptor, m.msg)
+}
+
+var channelDataDescriptor = &spec.MessageDescriptor{
+	Package: "pmpx",
+	Name:    "ChannelData",
+}
+
+func init() {
+	channelDataDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "id", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindBin128, Name: "bin128"}},
+		{Name: "data", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindBytes, Name: "bytes"}},
+	}
+}
+
+func (m ChannelData) Descriptor() *spec.MessageDescriptor {
+	return channelDataDescriptor
+}
 
 // ChannelWindow
 
@@ -512,16 +874,13 @@ func OpenChannelWindow(b []byte) ChannelWindow {
 
 func OpenChannelWindowErr(b []byte) (_ ChannelWindow, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return ChannelWindow{msg}, nil
+	return ChannelWindow{msg}, err
 }
 
 func ParseChannelWindow(b []byte) (_ ChannelWindow, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return ChannelWindow{}, size, channelWindowDescriptor.DescribeError(err)
 	}
 	return ChannelWindow{msg}, size, nil
 }
@@ -532,7 +891,6 @@ func (m ChannelWindow) Delta() int32   { return m.msg.Int32(2) }
 func (m ChannelWindow) HasId() bool    { return m.msg.HasField(1) }
 func (m ChannelWindow) HasDelta() bool { return m.msg.HasField(2) }
 
-func (m ChannelWindow) IsEmpty() bool        { return m.msg.Empty() }
 func (m ChannelWindow) Clone() ChannelWindow { return ChannelWindow{m.msg.Clone()} }
 func (m ChannelWindow) CloneToArena(a alloc.Arena) ChannelWindow {
 	return ChannelWindow{m.msg.CloneToArena(a)}
@@ -540,7 +898,49 @@ func (m ChannelWindow) CloneToArena(a alloc.Arena) ChannelWindow {
 func (m ChannelWindow) CloneToBuffer(b buffer.Buffer) ChannelWindow {
 	return ChannelWindow{m.msg.CloneToBuffer(b)}
 }
+
+func (m ChannelWindow) IsEmpty() bool        { return m.msg.Empty() }
 func (m ChannelWindow) Unwrap() spec.Message { return m.msg }
+func (m ChannelWindow) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(channelWindowDescriptor, m.msg)
+}
+
+func (m *ChannelWindow) UnmarshalJSON(b []byte) error {
+	w := NewChannelWindowWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m ChannelWindow) String() string {
+	return spec.MessageString(channelWindowDescriptor, m.msg)
+}
+
+func (m ChannelWindow) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, channelWindowDescriptor, m.msg)
+}
+
+var channelWindowDescriptor = &spec.MessageDescriptor{
+	Package: "pmpx",
+	Name:    "ChannelWindow",
+}
+
+func init() {
+	channelWindowDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "id", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindBin128, Name: "bin128"}},
+		{Name: "delta", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindInt32, Name: "int32"}},
+	}
+}
+
+func (m ChannelWindow) Descriptor() *spec.MessageDescriptor {
+	return channelWindowDescriptor
+}
 
 // MessageWriter
 
@@ -550,16 +950,16 @@ type MessageWriter struct {
 
 func NewMessageWriter() MessageWriter {
 	w := spec.NewMessageWriter()
-	return MessageWriter{w}
+	return MessageWriter{w: w}
 }
 
 func NewMessageWriterBuffer(b buffer.Buffer) MessageWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return MessageWriter{w}
+	return MessageWriter{w: w}
 }
 
 func NewMessageWriterTo(w spec.MessageWriter) MessageWriter {
-	return MessageWriter{w}
+	return MessageWriter{w: w}
 }
 
 func (w MessageWriter) Code(v Code) { spec.WriteField(w.w.Field(1), v, EncodeCodeTo) }
@@ -613,6 +1013,10 @@ func (w MessageWriter) CopyChannelWindow(v ChannelWindow) error {
 	return w.w.Field(13).Any(v.Unwrap().Raw())
 }
 
+func (w MessageWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, messageDescriptor, b)
+}
+
 func (w MessageWriter) Merge(msg Message) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -641,16 +1045,16 @@ type ConnectRequestWriter struct {
 
 func NewConnectRequestWriter() ConnectRequestWriter {
 	w := spec.NewMessageWriter()
-	return ConnectRequestWriter{w}
+	return ConnectRequestWriter{w: w}
 }
 
 func NewConnectRequestWriterBuffer(b buffer.Buffer) ConnectRequestWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return ConnectRequestWriter{w}
+	return ConnectRequestWriter{w: w}
 }
 
 func NewConnectRequestWriterTo(w spec.MessageWriter) ConnectRequestWriter {
-	return ConnectRequestWriter{w}
+	return ConnectRequestWriter{w: w}
 }
 
 func (w ConnectRequestWriter) Versions() spec.ValueListWriter[Version] {
@@ -662,6 +1066,10 @@ func (w ConnectRequestWriter) Compression() spec.ValueListWriter[ConnectCompress
 	return spec.NewValueListWriter(w1, EncodeConnectCompressionTo)
 }
 
+func (w ConnectRequestWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, connectRequestDescriptor, b)
+}
+
 func (w ConnectRequestWriter) Merge(msg ConnectRequest) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -690,16 +1098,16 @@ type ConnectResponseWriter struct {
 
 func NewConnectResponseWriter() ConnectResponseWriter {
 	w := spec.NewMessageWriter()
-	return ConnectResponseWriter{w}
+	return ConnectResponseWriter{w: w}
 }
 
 func NewConnectResponseWriterBuffer(b buffer.Buffer) ConnectResponseWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return ConnectResponseWriter{w}
+	return ConnectResponseWriter{w: w}
 }
 
 func NewConnectResponseWriterTo(w spec.MessageWriter) ConnectResponseWriter {
-	return ConnectResponseWriter{w}
+	return ConnectResponseWriter{w: w}
 }
 
 func (w ConnectResponseWriter) Ok(v bool)         { w.w.Field(1).Bool(v) }
@@ -709,6 +1117,10 @@ func (w ConnectResponseWriter) Compression(v ConnectCompression) {
 	spec.WriteField(w.w.Field(11), v, EncodeConnectCompressionTo)
 }
 
+func (w ConnectResponseWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, connectResponseDescriptor, b)
+}
+
 func (w ConnectResponseWriter) Merge(msg ConnectResponse) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -737,16 +1149,16 @@ type BatchWriter struct {
 
 func NewBatchWriter() BatchWriter {
 	w := spec.NewMessageWriter()
-	return BatchWriter{w}
+	return BatchWriter{w: w}
 }
 
 func NewBatchWriterBuffer(b buffer.Buffer) BatchWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return BatchWriter{w}
+	return BatchWriter{w: w}
 }
 
 func NewBatchWriterTo(w spec.MessageWriter) BatchWriter {
-	return BatchWriter{w}
+	return BatchWriter{w: w}
 }
 
 func (w BatchWriter) List() spec.MessageListWriter[MessageWriter] {
@@ -754,6 +1166,10 @@ func (w BatchWriter) List() spec.MessageListWriter[MessageWriter] {
 	return spec.NewMessageListWriter(w1, NewMessageWriterTo)
 }
 
+func (w BatchWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, batchDescriptor, b)
+}
+
 func (w BatchWriter) Merge(msg Batch) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -782,22 +1198,26 @@ type ChannelOpenWriter struct {
 
 func NewChannelOpenWriter() ChannelOpenWriter {
 	w := spec.NewMessageWriter()
-	return ChannelOpenWriter{w}
+	return ChannelOpenWriter{w: w}
 }
 
 func NewChannelOpenWriterBuffer(b buffer.Buffer) ChannelOpenWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return ChannelOpenWriter{w}
+	return ChannelOpenWriter{w: w}
 }
 
 func NewChannelOpenWriterTo(w spec.MessageWriter) ChannelOpenWriter {
-	return ChannelOpenWriter{w}
+	return ChannelOpenWriter{w: w}
 }
 
 func (w ChannelOpenWriter) Id(v bin.Bin128) { w.w.Field(1).Bin128(v) }
 func (w ChannelOpenWriter) Window(v int32)  { w.w.Field(2).Int32(v) }
 func (w ChannelOpenWriter) Data(v []byte)   { w.w.Field(3).Bytes(v) }
 
+func (w ChannelOpenWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, channelOpenDescriptor, b)
+}
+
 func (w ChannelOpenWriter) Merge(msg ChannelOpen) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -826,21 +1246,25 @@ type ChannelCloseWriter struct {
 
 func NewChannelCloseWriter() ChannelCloseWriter {
 	w := spec.NewMessageWriter()
-	return ChannelCloseWriter{w}
+	return ChannelCloseWriter{w: w}
 }
 
 func NewChannelCloseWriterBuffer(b buffer.Buffer) ChannelCloseWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return ChannelCloseWriter{w}
+	return ChannelCloseWriter{w: w}
 }
 
 func NewChannelCloseWriterTo(w spec.MessageWriter) ChannelCloseWriter {
-	return ChannelCloseWriter{w}
+	return ChannelCloseWriter{w: w}
 }
 
 func (w ChannelCloseWriter) Id(v bin.Bin128) { w.w.Field(1).Bin128(v) }
 func (w ChannelCloseWriter) Data(v []byte)   { w.w.Field(2).Bytes(v) }
 
+func (w ChannelCloseWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, channelCloseDescriptor, b)
+}
+
 func (w ChannelCloseWriter) Merge(msg ChannelClose) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -869,21 +1293,25 @@ type ChannelDataWriter struct {
 
 func NewChannelDataWriter() ChannelDataWriter {
 	w := spec.NewMessageWriter()
-	return ChannelDataWriter{w}
+	return ChannelDataWriter{w: w}
 }
 
 func NewChannelDataWriterBuffer(b buffer.Buffer) ChannelDataWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return ChannelDataWriter{w}
+	return ChannelDataWriter{w: w}
 }
 
 func NewChannelDataWriterTo(w spec.MessageWriter) ChannelDataWriter {
-	return ChannelDataWriter{w}
+	return ChannelDataWriter{w: w}
 }
 
 func (w ChannelDataWriter) Id(v bin.Bin128) { w.w.Field(1).Bin128(v) }
 func (w ChannelDataWriter) Data(v []byte)   { w.w.Field(2).Bytes(v) }
 
+func (w ChannelDataWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, channelDataDescriptor, b)
+}
+
 func (w ChannelDataWriter) Merge(msg ChannelData) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -912,21 +1340,25 @@ type ChannelWindowWriter struct {
 
 func NewChannelWindowWriter() ChannelWindowWriter {
 	w := spec.NewMessageWriter()
-	return ChannelWindowWriter{w}
+	return ChannelWindowWriter{w: w}
 }
 
 func NewChannelWindowWriterBuffer(b buffer.Buffer) ChannelWindowWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return ChannelWindowWriter{w}
+	return ChannelWindowWriter{w: w}
 }
 
 func NewChannelWindowWriterTo(w spec.MessageWriter) ChannelWindowWriter {
-	return ChannelWindowWriter{w}
+	return ChannelWindowWriter{w: w}
 }
 
 func (w ChannelWindowWriter) Id(v bin.Bin128) { w.w.Field(1).Bin128(v) }
 func (w ChannelWindowWriter) Delta(v int32)   { w.w.Field(2).Int32(v) }
 
+func (w ChannelWindowWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, channelWindowDescriptor, b)
+}
+
 func (w ChannelWindowWriter) Merge(msg ChannelWindow) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -1,6 +1,7 @@
 package prpc
 
 import (
+	"fmt"
 	"github.com/basecomplextech/baselibrary/alloc"
 	"github.com/basecomplextech/baselibrary/async"
 	"github.com/basecomplextech/baselibrary/bin"
@@ -12,6 +13,7 @@ import (
 )
 
 var (
+	_ fmt.State
 	_ alloc.Buffer
 	_ async.Context
 	_ bin.Bin128
@@ -69,6 +71,35 @@ func (e MessageType) String() string {
 	return ""
 }
 
+func (e MessageType) MarshalJSON() ([]byte, error) {
+	return spec.MarshalEnumJSON(messageTypeDescriptor, int32(e))
+}
+
+func (e *MessageType) UnmarshalJSON(b []byte) error {
+	v, err := spec.UnmarshalEnumJSON(messageTypeDescriptor, b)
+	if err != nil {
+		return err
+	}
+	*e = MessageType(v)
+	return nil
+}
+
+var messageTypeDescriptor = &spec.EnumDescriptor{
+	Package: "prpc",
+	Name:    "MessageType",
+	Values: []*spec.EnumValueDescriptor{
+		{Name: "UNDEFINED", Number: 0},
+		{Name: "REQUEST", Number: 1},
+		{Name: "RESPONSE", Number: 2},
+		{Name: "MESSAGE", Number: 3},
+		{Name: "END", Number: 4},
+	},
+}
+
+func (e MessageType) Descriptor() *spec.EnumDescriptor {
+	return messageTypeDescriptor
+}
+
 // Message
 
 type Message struct {
@@ -86,16 +117,13 @@ func OpenMessage(b []byte) Message {
 
 func OpenMessageErr(b []byte) (_ Message, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Message{msg}, nil
+	return Message{msg}, err
 }
 
 func ParseMessage(b []byte) (_ Message, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return Message{}, size, messageDescriptor.DescribeError(err)
 	}
 	return Message{msg}, size, nil
 }
@@ -110,11 +138,54 @@ func (m Message) HasReq() bool  { return m.msg.HasField(2) }
 func (m Message) HasResp() bool { return m.msg.HasField(3) }
 func (m Message) HasMsg() bool  { return m.msg.HasField(4) }
 
-func (m Message) IsEmpty() bool                         { return m.msg.Empty() }
 func (m Message) Clone() Message                        { return Message{m.msg.Clone()} }
 func (m Message) CloneToArena(a alloc.Arena) Message    { return Message{m.msg.CloneToArena(a)} }
 func (m Message) CloneToBuffer(b buffer.Buffer) Message { return Message{m.msg.CloneToBuffer(b)} }
-func (m Message) Unwrap() spec.Message                  { return m.msg }
+
+func (m Message) IsEmpty() bool        { return m.msg.Empty() }
+func (m Message) Unwrap() spec.Message { return m.msg }
+func (m Message) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(messageDescriptor, m.msg)
+}
+
+func (m *Message) UnmarshalJSON(b []byte) error {
+	w := NewMessageWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Message) String() string {
+	return spec.MessageString(messageDescriptor, m.msg)
+}
+
+func (m Message) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, messageDescriptor, m.msg)
+}
+
+var messageDescriptor = &spec.MessageDescriptor{
+	Package: "prpc",
+	Name:    "Message",
+}
+
+func init() {
+	messageDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "type", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindEnum, Name: "MessageType", Enum: messageTypeDescriptor}},
+		{Name: "req", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Request", Message: requestDescriptor}},
+		{Name: "resp", Tag: 3, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Response", Message: responseDescriptor}},
+		{Name: "msg", Tag: 4, Type: &spec.TypeDescriptor{Kind: spec.KindBytes, Name: "bytes"}},
+	}
+}
+
+func (m Message) Descriptor() *spec.MessageDescriptor {
+	return messageDescriptor
+}
 
 // Request
 
@@ -133,16 +204,13 @@ func OpenRequest(b []byte) Request {
 
 func OpenRequestErr(b []byte) (_ Request, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Request{msg}, nil
+	return Request{msg}, err
 }
 
 func ParseRequest(b []byte) (_ Request, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return Request{}, size, requestDescriptor.DescribeError(err)
 	}
 	return Request{msg}, size, nil
 }
@@ -151,11 +219,51 @@ func (m Request) Calls() spec.MessageList[Call] {
 	return spec.NewMessageList(m.msg.List(1), OpenCallErr)
 }
 func (m Request) HasCalls() bool                        { return m.msg.HasField(1) }
-func (m Request) IsEmpty() bool                         { return m.msg.Empty() }
 func (m Request) Clone() Request                        { return Request{m.msg.Clone()} }
 func (m Request) CloneToArena(a alloc.Arena) Request    { return Request{m.msg.CloneToArena(a)} }
 func (m Request) CloneToBuffer(b buffer.Buffer) Request { return Request{m.msg.CloneToBuffer(b)} }
-func (m Request) Unwrap() spec.Message                  { return m.msg }
+
+func (m Request) IsEmpty() bool        { return m.msg.Empty() }
+func (m Request) Unwrap() spec.Message { return m.msg }
+func (m Request) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(requestDescriptor, m.msg)
+}
+
+func (m *Request) UnmarshalJSON(b []byte) error {
+	w := NewRequestWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Request) String() string {
+	return spec.MessageString(requestDescriptor, m.msg)
+}
+
+func (m Request) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, requestDescriptor, m.msg)
+}
+
+var requestDescriptor = &spec.MessageDescriptor{
+	Package: "prpc",
+	Name:    "Request",
+}
+
+func init() {
+	requestDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "calls", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindList, Name: "[]Call", Element: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Call", Message: callDescriptor}}},
+	}
+}
+
+func (m Request) Descriptor() *spec.MessageDescriptor {
+	return requestDescriptor
+}
 
 // Call
 
@@ -174,16 +282,13 @@ func OpenCall(b []byte) Call {
 
 func OpenCallErr(b []byte) (_ Call, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Call{msg}, nil
+	return Call{msg}, err
 }
 
 func ParseCall(b []byte) (_ Call, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return Call{}, size, callDescriptor.DescribeError(err)
 	}
 	return Call{msg}, size, nil
 }
@@ -194,11 +299,52 @@ func (m Call) Input() spec.Message { return m.msg.Field(2).Message() }
 func (m Call) HasMethod() bool { return m.msg.HasField(1) }
 func (m Call) HasInput() bool  { return m.msg.HasField(2) }
 
-func (m Call) IsEmpty() bool                      { return m.msg.Empty() }
 func (m Call) Clone() Call                        { return Call{m.msg.Clone()} }
 func (m Call) CloneToArena(a alloc.Arena) Call    { return Call{m.msg.CloneToArena(a)} }
 func (m Call) CloneToBuffer(b buffer.Buffer) Call { return Call{m.msg.CloneToBuffer(b)} }
-func (m Call) Unwrap() spec.Message               { return m.msg }
+
+func (m Call) IsEmpty() bool        { return m.msg.Empty() }
+func (m Call) Unwrap() spec.Message { return m.msg }
+func (m Call) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(callDescriptor, m.msg)
+}
+
+func (m *Call) UnmarshalJSON(b []byte) error {
+	w := NewCallWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Call) String() string {
+	return spec.MessageString(callDescriptor, m.msg)
+}
+
+func (m Call) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, callDescriptor, m.msg)
+}
+
+var callDescriptor = &spec.MessageDescriptor{
+	Package: "prpc",
+	Name:    "Call",
+}
+
+func init() {
+	callDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "method", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "input", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindAnyMessage, Name: "message"}},
+	}
+}
+
+func (m Call) Descriptor() *spec.MessageDescriptor {
+	return callDescriptor
+}
 
 // Response
 
@@ -217,16 +363,13 @@ func OpenResponse(b []byte) Response {
 
 func OpenResponseErr(b []byte) (_ Response, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Response{msg}, nil
+	return Response{msg}, err
 }
 
 func ParseResponse(b []byte) (_ Response, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return Response{}, size, responseDescriptor.DescribeError(err)
 	}
 	return Response{msg}, size, nil
 }
@@ -237,11 +380,52 @@ func (m Response) Result() spec.Value { return m.msg.Field(2) }
 func (m Response) HasStatus() bool { return m.msg.HasField(1) }
 func (m Response) HasResult() bool { return m.msg.HasField(2) }
 
-func (m Response) IsEmpty() bool                          { return m.msg.Empty() }
 func (m Response) Clone() Response                        { return Response{m.msg.Clone()} }
 func (m Response) CloneToArena(a alloc.Arena) Response    { return Response{m.msg.CloneToArena(a)} }
 func (m Response) CloneToBuffer(b buffer.Buffer) Response { return Response{m.msg.CloneToBuffer(b)} }
-func (m Response) Unwrap() spec.Message                   { return m.msg }
+
+func (m Response) IsEmpty() bool        { return m.msg.Empty() }
+func (m Response) Unwrap() spec.Message { return m.msg }
+func (m Response) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(responseDescriptor, m.msg)
+}
+
+func (m *Response) UnmarshalJSON(b []byte) error {
+	w := NewResponseWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Response) String() string {
+	return spec.MessageString(responseDescriptor, m.msg)
+}
+
+func (m Response) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, responseDescriptor, m.msg)
+}
+
+var responseDescriptor = &spec.MessageDescriptor{
+	Package: "prpc",
+	Name:    "Response",
+}
+
+func init() {
+	responseDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "status", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindMessage, Name: "Status", Message: statusDescriptor}},
+		{Name: "result", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindAny, Name: "any"}},
+	}
+}
+
+func (m Response) Descriptor() *spec.MessageDescriptor {
+	return responseDescriptor
+}
 
 // Status
 
@@ -260,16 +444,13 @@ func OpenStatus(b []byte) Status {
 
 func OpenStatusErr(b []byte) (_ Status, err error) {
 	msg, err := spec.OpenMessageErr(b)
-	if err != nil {
-		return
-	}
-	return Status{msg}, nil
+	return Status{msg}, err
 }
 
 func ParseStatus(b []byte) (_ Status, size int, err error) {
 	msg, size, err := spec.ParseMessage(b)
-	if err != nil || size == 0 {
-		return
+	if err != nil {
+		return Status{}, size, statusDescriptor.DescribeError(err)
 	}
 	return Status{msg}, size, nil
 }
@@ -280,11 +461,52 @@ func (m Status) Message() spec.String { return m.msg.String(2) }
 func (m Status) HasCode() bool    { return m.msg.HasField(1) }
 func (m Status) HasMessage() bool { return m.msg.HasField(2) }
 
-func (m Status) IsEmpty() bool                        { return m.msg.Empty() }
 func (m Status) Clone() Status                        { return Status{m.msg.Clone()} }
 func (m Status) CloneToArena(a alloc.Arena) Status    { return Status{m.msg.CloneToArena(a)} }
 func (m Status) CloneToBuffer(b buffer.Buffer) Status { return Status{m.msg.CloneToBuffer(b)} }
-func (m Status) Unwrap() spec.Message                 { return m.msg }
+
+func (m Status) IsEmpty() bool        { return m.msg.Empty() }
+func (m Status) Unwrap() spec.Message { return m.msg }
+func (m Status) MarshalJSON() ([]byte, error) {
+	return spec.MarshalMessageJSON(statusDescriptor, m.msg)
+}
+
+func (m *Status) UnmarshalJSON(b []byte) error {
+	w := NewStatusWriter()
+	if err := w.WriteJSON(b); err != nil {
+		return err
+	}
+	m1, err := w.Build()
+	if err != nil {
+		return err
+	}
+	*m = m1
+	return nil
+}
+
+func (m Status) String() string {
+	return spec.MessageString(statusDescriptor, m.msg)
+}
+
+func (m Status) Format(f fmt.State, verb rune) {
+	spec.FormatMessage(f, verb, statusDescriptor, m.msg)
+}
+
+var statusDescriptor = &spec.MessageDescriptor{
+	Package: "prpc",
+	Name:    "Status",
+}
+
+func init() {
+	statusDescriptor.Fields = []*spec.FieldDescriptor{
+		{Name: "code", Tag: 1, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+		{Name: "message", Tag: 2, Type: &spec.TypeDescriptor{Kind: spec.KindString, Name: "string"}},
+	}
+}
+
+func (m Status) Descriptor() *spec.MessageDescriptor {
+	return statusDescriptor
+}
 
 // MessageWriter
 
@@ -294,16 +516,16 @@ type MessageWriter struct {
 
 func NewMessageWriter() MessageWriter {
 	w := spec.NewMessageWriter()
-	return MessageWriter{w}
+	return MessageWriter{w: w}
 }
 
 func NewMessageWriterBuffer(b buffer.Buffer) MessageWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return MessageWriter{w}
+	return MessageWriter{w: w}
 }
 
 func NewMessageWriterTo(w spec.MessageWriter) MessageWriter {
-	return MessageWriter{w}
+	return MessageWriter{w: w}
 }
 
 func (w MessageWriter) Type(v MessageType) { spec.WriteField(w.w.Field(1), v, EncodeMessageTypeTo) }
@@ -323,6 +545,10 @@ func (w MessageWriter) CopyResp(v Response) error {
 }
 func (w MessageWriter) Msg(v []byte) { w.w.Field(4).Bytes(v) }
 
+func (w MessageWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, messageDescriptor, b)
+}
+
 func (w MessageWriter) Merge(msg Message) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -351,16 +577,16 @@ type RequestWriter struct {
 
 func NewRequestWriter() RequestWriter {
 	w := spec.NewMessageWriter()
-	return RequestWriter{w}
+	return RequestWriter{w: w}
 }
 
 func NewRequestWriterBuffer(b buffer.Buffer) RequestWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return RequestWriter{w}
+	return RequestWriter{w: w}
 }
 
 func NewRequestWriterTo(w spec.MessageWriter) RequestWriter {
-	return RequestWriter{w}
+	return RequestWriter{w: w}
 }
 
 func (w RequestWriter) Calls() spec.MessageListWriter[CallWriter] {
@@ -368,6 +594,10 @@ func (w RequestWriter) Calls() spec.MessageListWriter[CallWriter] {
 	return spec.NewMessageListWriter(w1, NewCallWriterTo)
 }
 
+func (w RequestWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, requestDescriptor, b)
+}
+
 func (w RequestWriter) Merge(msg Request) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -396,22 +626,26 @@ type CallWriter struct {
 
 func NewCallWriter() CallWriter {
 	w := spec.NewMessageWriter()
-	return CallWriter{w}
+	return CallWriter{w: w}
 }
 
 func NewCallWriterBuffer(b buffer.Buffer) CallWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return CallWriter{w}
+	return CallWriter{w: w}
 }
 
 func NewCallWriterTo(w spec.MessageWriter) CallWriter {
-	return CallWriter{w}
+	return CallWriter{w: w}
 }
 
 func (w CallWriter) Method(v string)                { w.w.Field(1).String(v) }
 func (w CallWriter) Input() spec.MessageWriter      { return w.w.Field(2).Message() }
 func (w CallWriter) CopyInput(v spec.Message) error { return w.w.Field(2).Any(v.Raw()) }
 
+func (w CallWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, callDescriptor, b)
+}
+
 func (w CallWriter) Merge(msg Call) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -440,16 +674,16 @@ type ResponseWriter struct {
 
 func NewResponseWriter() ResponseWriter {
 	w := spec.NewMessageWriter()
-	return ResponseWriter{w}
+	return ResponseWriter{w: w}
 }
 
 func NewResponseWriterBuffer(b buffer.Buffer) ResponseWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return ResponseWriter{w}
+	return ResponseWriter{w: w}
 }
 
 func NewResponseWriterTo(w spec.MessageWriter) ResponseWriter {
-	return ResponseWriter{w}
+	return ResponseWriter{w: w}
 }
 
 func (w ResponseWriter) Status() StatusWriter {
@@ -462,6 +696,10 @@ func (w ResponseWriter) CopyStatus(v Status) error {
 func (w ResponseWriter) Result() spec.FieldWriter      { return w.w.Field(2) }
 func (w ResponseWriter) CopyResult(v spec.Value) error { return w.w.Field(2).Any(v) }
 
+func (w ResponseWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, responseDescriptor, b)
+}
+
 func (w ResponseWriter) Merge(msg Response) error {
 	return w.w.Merge(msg.Unwrap())
 }
@@ -490,21 +728,25 @@ type StatusWriter struct {
 
 func NewStatusWriter() StatusWriter {
 	w := spec.NewMessageWriter()
-	return StatusWriter{w}
+	return StatusWriter{w: w}
 }
 
 func NewStatusWriterBuffer(b buffer.Buffer) StatusWriter {
 	w := spec.NewMessageWriterBuffer(b)
-	return StatusWriter{w}
+	return StatusWriter{w: w}
 }
 
 func NewStatusWriterTo(w spec.MessageWriter) StatusWriter {
-	return StatusWriter{w}
+	return StatusWriter{w: w}
 }
 
 func (w StatusWriter) Code(v string)    { w.w.Field(1).String(v) }
 func (w StatusWriter) Message(v string) { w.w.Field(2).String(v) }
 
+func (w StatusWriter) WriteJSON(b []byte) error {
+	return spec.WriteMessageJSON(w.w, statusDescriptor, b)
+}
+
 func (w StatusWriter) Merge(msg Status) error {
 	return w.w.Merge(msg.Unwrap())
 }
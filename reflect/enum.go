@@ -0,0 +1,56 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package reflect
+
+import (
+	"strconv"
+
+	"github.com/basecomplextech/spec"
+)
+
+// Enum is an enum value with a descriptor.
+type Enum struct {
+	desc   *spec.EnumDescriptor
+	number int32
+}
+
+func newEnum(desc *spec.EnumDescriptor, number int32) Enum {
+	return Enum{
+		desc:   desc,
+		number: number,
+	}
+}
+
+// Descriptor returns the enum descriptor.
+func (e Enum) Descriptor() *spec.EnumDescriptor {
+	return e.desc
+}
+
+// Number returns the enum value number.
+func (e Enum) Number() int32 {
+	return e.number
+}
+
+// Name returns the enum value name or an empty string if the value is unknown.
+func (e Enum) Name() string {
+	if e.desc == nil {
+		return ""
+	}
+
+	v := e.desc.ValueByNumber(e.number)
+	if v == nil {
+		return ""
+	}
+	return v.Name
+}
+
+// String returns the enum value name or its number if the value is unknown.
+func (e Enum) String() string {
+	name := e.Name()
+	if name != "" {
+		return name
+	}
+	return strconv.Itoa(int(e.number))
+}
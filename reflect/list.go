@@ -0,0 +1,43 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package reflect
+
+import (
+	"github.com/basecomplextech/spec"
+)
+
+// List is a list with an element type descriptor.
+type List struct {
+	elem *spec.TypeDescriptor
+	list spec.List
+}
+
+func newList(elem *spec.TypeDescriptor, list spec.List) List {
+	return List{
+		elem: elem,
+		list: list,
+	}
+}
+
+// Element returns the element type descriptor.
+func (l List) Element() *spec.TypeDescriptor {
+	return l.elem
+}
+
+// Unwrap returns the underlying list.
+func (l List) Unwrap() spec.List {
+	return l.list
+}
+
+// Len returns the number of elements in the list.
+func (l List) Len() int {
+	return l.list.Len()
+}
+
+// Get returns an element at index i, panics on out of range.
+func (l List) Get(i int) Value {
+	raw := l.list.Get(i)
+	return newValue(l.elem, raw)
+}
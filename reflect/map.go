@@ -0,0 +1,56 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package reflect
+
+import (
+	"github.com/basecomplextech/spec"
+)
+
+// Map is a map with key and value type descriptors.
+type Map struct {
+	key  *spec.TypeDescriptor
+	elem *spec.TypeDescriptor
+	map_ spec.Map
+}
+
+func newMap(key *spec.TypeDescriptor, elem *spec.TypeDescriptor, m spec.Map) Map {
+	return Map{
+		key:  key,
+		elem: elem,
+		map_: m,
+	}
+}
+
+// KeyType returns the key type descriptor.
+func (m Map) KeyType() *spec.TypeDescriptor {
+	return m.key
+}
+
+// Element returns the value type descriptor.
+func (m Map) Element() *spec.TypeDescriptor {
+	return m.elem
+}
+
+// Unwrap returns the underlying map.
+func (m Map) Unwrap() spec.Map {
+	return m.map_
+}
+
+// Len returns the number of entries in the map.
+func (m Map) Len() int {
+	return m.map_.Len()
+}
+
+// Key returns a key at index i, panics on out of range.
+func (m Map) Key(i int) Value {
+	raw := m.map_.Key(i)
+	return newValue(m.key, raw)
+}
+
+// Value returns a value at index i, panics on out of range.
+func (m Map) Value(i int) Value {
+	raw := m.map_.Value(i)
+	return newValue(m.elem, raw)
+}
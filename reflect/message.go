@@ -0,0 +1,165 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package reflect
+
+import (
+	"fmt"
+
+	"github.com/basecomplextech/spec"
+)
+
+// Message is a message with a descriptor.
+type Message struct {
+	desc *spec.MessageDescriptor
+	msg  spec.Message
+}
+
+// NewMessage returns a new reflected message.
+func NewMessage(desc *spec.MessageDescriptor, msg spec.Message) Message {
+	return Message{
+		desc: desc,
+		msg:  msg,
+	}
+}
+
+// Descriptor returns the message descriptor, nil for messages without a schema.
+func (m Message) Descriptor() *spec.MessageDescriptor {
+	return m.desc
+}
+
+// Unwrap returns the underlying message.
+func (m Message) Unwrap() spec.Message {
+	return m.msg
+}
+
+// Fields
+
+// Has returns true if the message has a field with a name.
+func (m Message) Has(name string) bool {
+	field := m.field(name)
+	if field == nil {
+		return false
+	}
+	return m.msg.HasField(field.Tag)
+}
+
+// Get returns a field value by a name or an empty value.
+func (m Message) Get(name string) Value {
+	v, _ := m.GetErr(name)
+	return v
+}
+
+// GetErr returns a field value by a name or an error if the field is unknown.
+func (m Message) GetErr(name string) (Value, error) {
+	field := m.field(name)
+	if field == nil {
+		return Value{}, fmt.Errorf("reflect: unknown field %q", name)
+	}
+
+	raw := m.msg.Field(field.Tag)
+	return newValue(field.Type, raw), nil
+}
+
+// Lookup returns a nested field value by a path of field names, i.e. "submessage", "value".
+// The method descends into messages and structs.
+func (m Message) Lookup(path ...string) (Value, bool) {
+	if len(path) == 0 {
+		return Value{}, false
+	}
+
+	v := m.Get(path[0])
+	for _, name := range path[1:] {
+		switch v.Kind() {
+		case spec.KindMessage:
+			v = v.Message().Get(name)
+		case spec.KindStruct:
+			v = v.Struct().Get(name)
+		default:
+			return Value{}, false
+		}
+	}
+	return v, !v.IsEmpty()
+}
+
+// Range iterates over present fields in the schema order, stops when the function returns false.
+func (m Message) Range(fn func(field *spec.FieldDescriptor, v Value) bool) {
+	if m.desc == nil {
+		return
+	}
+
+	for _, field := range m.desc.Fields {
+		raw := m.msg.Field(field.Tag)
+		if raw == nil {
+			continue
+		}
+
+		v := newValue(field.Type, raw)
+		if !fn(field, v) {
+			return
+		}
+	}
+}
+
+// Walk recursively walks over present fields and nested messages, structs and lists.
+// The path contains field names and list indexes as "[i]", it is reused between calls.
+func (m Message) Walk(fn func(path []string, v Value) error) error {
+	return walkMessage(nil, m, fn)
+}
+
+// internal
+
+func (m Message) field(name string) *spec.FieldDescriptor {
+	if m.desc == nil {
+		return nil
+	}
+	return m.desc.Field(name)
+}
+
+func walkMessage(path []string, m Message, fn func(path []string, v Value) error) (err error) {
+	m.Range(func(field *spec.FieldDescriptor, v Value) bool {
+		err = walkValue(append(path, field.Name), v, fn)
+		return err == nil
+	})
+	return err
+}
+
+func walkValue(path []string, v Value, fn func(path []string, v Value) error) error {
+	if err := fn(path, v); err != nil {
+		return err
+	}
+
+	switch v.Kind() {
+	case spec.KindMessage:
+		return walkMessage(path, v.Message(), fn)
+
+	case spec.KindStruct:
+		s := v.Struct()
+		for i, field := range s.Fields() {
+			v1 := s.GetAt(i)
+			if err := walkValue(append(path, field.Name), v1, fn); err != nil {
+				return err
+			}
+		}
+
+	case spec.KindList:
+		l := v.List()
+		for i := 0; i < l.Len(); i++ {
+			v1 := l.Get(i)
+			if err := walkValue(append(path, fmt.Sprintf("[%d]", i)), v1, fn); err != nil {
+				return err
+			}
+		}
+
+	case spec.KindMap:
+		m := v.Map()
+		for i := 0; i < m.Len(); i++ {
+			v1 := m.Value(i)
+			if err := walkValue(append(path, fmt.Sprintf("[%d]", i)), v1, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package reflect
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/basecomplextech/spec"
+	"github.com/basecomplextech/spec/internal/tests/pkg1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testMessage(t *testing.T) Message {
+	obj := pkg1.TestObject(t)
+
+	msg, err := obj.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return MessageOf(msg)
+}
+
+// Descriptor
+
+func TestMessage_Descriptor__should_return_generated_descriptor(t *testing.T) {
+	m := testMessage(t)
+
+	desc := m.Descriptor()
+	require.NotNil(t, desc)
+	assert.Equal(t, "pkg1", desc.Package)
+	assert.Equal(t, "Message", desc.Name)
+
+	field := desc.Field("submessages1")
+	require.NotNil(t, field)
+	assert.Equal(t, uint16(75), field.Tag)
+	assert.Equal(t, spec.KindList, field.Type.Kind)
+	assert.Equal(t, "[]pkg2.Submessage", field.Type.Name)
+	assert.Equal(t, "Submessage", field.Type.Element.Message.Name)
+	assert.Equal(t, "pkg2", field.Type.Element.Message.Package)
+}
+
+func TestMessage_Descriptor__should_resolve_recursive_references(t *testing.T) {
+	desc := pkg1.Submessage{}.Descriptor()
+
+	field := desc.Field("next")
+	require.NotNil(t, field)
+	assert.Same(t, desc, field.Type.Message)
+}
+
+// Get
+
+func TestMessage_Get__should_return_field_by_name(t *testing.T) {
+	m := testMessage(t)
+
+	assert.Equal(t, true, m.Get("bool").Interface())
+	assert.Equal(t, int32(math.MaxInt32), m.Get("int32").Interface())
+	assert.Equal(t, "hello, world", m.Get("string").Interface())
+	assert.Equal(t, []byte("goodbye, world"), m.Get("bytes1").Interface())
+	assert.Equal(t, "ONE", m.Get("enum1").Enum().Name())
+}
+
+func TestMessage_Get__should_return_empty_value_on_unknown_field(t *testing.T) {
+	m := testMessage(t)
+
+	v := m.Get("unknown")
+	assert.True(t, v.IsEmpty())
+	assert.Nil(t, v.Interface())
+
+	_, err := m.GetErr("unknown")
+	assert.Error(t, err)
+}
+
+func TestMessage_Get__should_return_struct_fields(t *testing.T) {
+	m := testMessage(t)
+
+	s := m.Get("struct1").Struct()
+	assert.Equal(t, int32(1), s.Get("key").Interface())
+	assert.Equal(t, int32(-1), s.Get("value").Interface())
+}
+
+func TestMessage_Get__should_return_list_elements(t *testing.T) {
+	m := testMessage(t)
+
+	list := m.Get("submessages").List()
+	require.Equal(t, 10, list.Len())
+
+	sub := list.Get(3).Message()
+	assert.Equal(t, "value 003", sub.Get("value").Interface())
+}
+
+// Lookup
+
+func TestMessage_Lookup__should_return_nested_field(t *testing.T) {
+	m := testMessage(t)
+
+	v, ok := m.Lookup("submessage1", "value", "x")
+	require.True(t, ok)
+	assert.Equal(t, int32(0), v.Interface())
+
+	v, ok = m.Lookup("submessage", "value")
+	require.True(t, ok)
+	assert.Equal(t, "value 000", v.Interface())
+
+	_, ok = m.Lookup("int32", "value")
+	assert.False(t, ok)
+}
+
+// Walk
+
+func TestMessage_Walk__should_walk_nested_values(t *testing.T) {
+	m := testMessage(t)
+
+	var paths []string
+	err := m.Walk(func(path []string, v Value) error {
+		paths = append(paths, strings.Join(path, "."))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Contains(t, paths, "bool")
+	assert.Contains(t, paths, "struct1.key")
+	assert.Contains(t, paths, "submessage.value")
+	assert.Contains(t, paths, "submessages1.[9].value.y")
+}
@@ -0,0 +1,24 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+// Package reflect provides runtime access to generated messages by field names
+// using their descriptors.
+package reflect
+
+import (
+	"github.com/basecomplextech/spec"
+)
+
+// DescribedMessage is implemented by generated messages.
+type DescribedMessage interface {
+	spec.MessageType
+
+	// Descriptor returns a message descriptor.
+	Descriptor() *spec.MessageDescriptor
+}
+
+// MessageOf returns a reflected generated message.
+func MessageOf(m DescribedMessage) Message {
+	return NewMessage(m.Descriptor(), m.Unwrap())
+}
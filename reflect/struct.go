@@ -0,0 +1,78 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package reflect
+
+import (
+	"github.com/basecomplextech/spec"
+)
+
+// Struct is a struct with a descriptor.
+type Struct struct {
+	desc   *spec.StructDescriptor
+	values []spec.Value // field values ordered as in the descriptor
+}
+
+func newStruct(desc *spec.StructDescriptor, raw spec.Value) Struct {
+	s := Struct{desc: desc}
+	if desc == nil {
+		return s
+	}
+
+	dataSize, size, err := spec.DecodeStruct(raw)
+	if err != nil || size == 0 {
+		return s
+	}
+
+	// Struct fields are written in order, so decode them in reverse order
+	b := raw[len(raw)-size:]
+	off := len(b) - (size - dataSize)
+
+	values := make([]spec.Value, len(desc.Fields))
+	for i := len(desc.Fields) - 1; i >= 0; i-- {
+		v, err := spec.OpenValueErr(b[:off])
+		if err != nil {
+			return s
+		}
+
+		values[i] = v
+		off -= len(v)
+	}
+
+	s.values = values
+	return s
+}
+
+// Descriptor returns the struct descriptor.
+func (s Struct) Descriptor() *spec.StructDescriptor {
+	return s.desc
+}
+
+// Fields returns the struct field descriptors.
+func (s Struct) Fields() []*spec.StructFieldDescriptor {
+	if s.desc == nil {
+		return nil
+	}
+	return s.desc.Fields
+}
+
+// Get returns a field value by a name or an empty value.
+func (s Struct) Get(name string) Value {
+	for i, field := range s.Fields() {
+		if field.Name == name {
+			return s.GetAt(i)
+		}
+	}
+	return Value{}
+}
+
+// GetAt returns a field value by an index or an empty value.
+func (s Struct) GetAt(i int) Value {
+	if i < 0 || i >= len(s.values) {
+		return Value{}
+	}
+
+	field := s.desc.Fields[i]
+	return newValue(field.Type, s.values[i])
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package reflect
+
+import (
+	"github.com/basecomplextech/spec"
+)
+
+// Value is a raw value with a type descriptor.
+type Value struct {
+	typ *spec.TypeDescriptor
+	raw spec.Value
+}
+
+func newValue(typ *spec.TypeDescriptor, raw spec.Value) Value {
+	return Value{
+		typ: typ,
+		raw: raw,
+	}
+}
+
+// Type returns the value type descriptor.
+func (v Value) Type() *spec.TypeDescriptor {
+	return v.typ
+}
+
+// Kind returns the value type kind or undefined.
+func (v Value) Kind() spec.Kind {
+	if v.typ == nil {
+		return spec.KindUndefined
+	}
+	return v.typ.Kind
+}
+
+// Raw returns the underlying raw value.
+func (v Value) Raw() spec.Value {
+	return v.raw
+}
+
+// IsEmpty returns true if the value is absent.
+func (v Value) IsEmpty() bool {
+	return len(v.raw) == 0
+}
+
+// Types
+
+// Enum returns an enum value.
+func (v Value) Enum() Enum {
+	var desc *spec.EnumDescriptor
+	if v.typ != nil {
+		desc = v.typ.Enum
+	}
+
+	number := v.raw.Int32()
+	return newEnum(desc, number)
+}
+
+// Message returns a message value, the descriptor is nil for any messages.
+func (v Value) Message() Message {
+	var desc *spec.MessageDescriptor
+	if v.typ != nil {
+		desc = v.typ.Message
+	}
+
+	msg := v.raw.Message()
+	return NewMessage(desc, msg)
+}
+
+// Struct returns a struct value.
+func (v Value) Struct() Struct {
+	var desc *spec.StructDescriptor
+	if v.typ != nil {
+		desc = v.typ.Struct
+	}
+	return newStruct(desc, v.raw)
+}
+
+// List returns a list value.
+func (v Value) List() List {
+	var elem *spec.TypeDescriptor
+	if v.typ != nil {
+		elem = v.typ.Element
+	}
+
+	list := v.raw.List()
+	return newList(elem, list)
+}
+
+// Map returns a map value.
+func (v Value) Map() Map {
+	var key, elem *spec.TypeDescriptor
+	if v.typ != nil {
+		key = v.typ.Key
+		elem = v.typ.Element
+	}
+
+	m := v.raw.Map()
+	return newMap(key, elem, m)
+}
+
+// Interface decodes and returns the value as a go value.
+//
+// Primitives are returned as go types, bytes and strings are cloned,
+// enums, messages, structs, lists and maps are returned as reflected values,
+// any values are returned as [spec.Value].
+func (v Value) Interface() any {
+	if len(v.raw) == 0 {
+		return nil
+	}
+
+	switch v.Kind() {
+	case spec.KindBool:
+		return v.raw.Bool()
+	case spec.KindByte:
+		return v.raw.Byte()
+
+	case spec.KindInt16:
+		return v.raw.Int16()
+	case spec.KindInt32:
+		return v.raw.Int32()
+	case spec.KindInt64:
+		return v.raw.Int64()
+
+	case spec.KindUint16:
+		return v.raw.Uint16()
+	case spec.KindUint32:
+		return v.raw.Uint32()
+	case spec.KindUint64:
+		return v.raw.Uint64()
+
+	case spec.KindBin64:
+		return v.raw.Bin64()
+	case spec.KindBin128:
+		return v.raw.Bin128()
+	case spec.KindBin256:
+		return v.raw.Bin256()
+
+	case spec.KindFloat32:
+		return v.raw.Float32()
+	case spec.KindFloat64:
+		return v.raw.Float64()
+
+	case spec.KindBytes:
+		return v.raw.Bytes().Clone()
+	case spec.KindString:
+		return v.raw.String().Clone()
+
+	case spec.KindEnum:
+		return v.Enum()
+	case spec.KindMessage,
+		spec.KindAnyMessage:
+		return v.Message()
+	case spec.KindStruct:
+		return v.Struct()
+	case spec.KindList:
+		return v.List()
+	case spec.KindMap:
+		return v.Map()
+	}
+
+	return v.raw
+}
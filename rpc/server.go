@@ -5,12 +5,16 @@
 package rpc
 
 import (
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/basecomplextech/baselibrary/async"
 	"github.com/basecomplextech/baselibrary/logging"
 	"github.com/basecomplextech/baselibrary/ref"
 	"github.com/basecomplextech/baselibrary/status"
+	"github.com/basecomplextech/spec"
 	"github.com/basecomplextech/spec/mpx"
 	"github.com/basecomplextech/spec/proto/prpc"
 )
@@ -29,6 +33,13 @@ type Server interface {
 	Options() Options
 }
 
+// DeprecatedCounter is an optional server interface which counts calls to deprecated methods,
+// servers returned by NewServer implement it.
+type DeprecatedCounter interface {
+	// DeprecatedCalls returns the numbers of calls to deprecated methods by method names.
+	DeprecatedCalls() map[string]int64
+}
+
 // NewServer returns a new RPC server.
 func NewServer(address string, handler Handler, logger logging.Logger, opts Options) Server {
 	return newServer(address, handler, logger, opts)
@@ -36,11 +47,20 @@ func NewServer(address string, handler Handler, logger logging.Logger, opts Opti
 
 // internal
 
+var (
+	_ Server            = (*server)(nil)
+	_ DeprecatedCounter = (*server)(nil)
+)
+
 type server struct {
 	mpx.Server
 
 	handler Handler
 	logger  logging.Logger
+	parse   spec.ParseOptions
+
+	logDeprecated bool
+	deprecated    sync.Map // map[string]*atomic.Int64
 }
 
 func newServer(address string, handler Handler, logger logging.Logger, opts Options) *server {
@@ -49,9 +69,21 @@ func newServer(address string, handler Handler, logger logging.Logger, opts Opti
 		logger:  logger,
 	}
 	s.Server = mpx.NewServer(address, s, logger, opts)
+	s.parse = s.Server.Options().Parse
+	s.logDeprecated = s.Server.Options().LogDeprecated
 	return s
 }
 
+// DeprecatedCalls returns the numbers of calls to deprecated methods by method names.
+func (s *server) DeprecatedCalls() map[string]int64 {
+	m := make(map[string]int64)
+	s.deprecated.Range(func(key, value any) bool {
+		m[key.(string)] = value.(*atomic.Int64).Load()
+		return true
+	})
+	return m
+}
+
 // HandleChannel handles an incoming TCP channel.
 func (s *server) HandleChannel(ctx Context, ch mpx.Channel) (st status.Status) {
 	// Receive message
@@ -61,11 +93,12 @@ func (s *server) HandleChannel(ctx Context, ch mpx.Channel) (st status.Status) {
 	}
 	start := time.Now()
 
-	// Parse message
-	msg, _, err := prpc.ParseMessage(b)
+	// Parse message, check limits
+	msg1, _, err := spec.ParseMessageOpts(b, s.parse)
 	if err != nil {
 		return WrapErrorf(err, "failed to parse request message")
 	}
+	msg := prpc.NewMessage(msg1)
 
 	// Check request
 	typ := msg.Type()
@@ -75,7 +108,7 @@ func (s *server) HandleChannel(ctx Context, ch mpx.Channel) (st status.Status) {
 	}
 
 	// Make channel
-	ch1 := newServerChannel(ch, msg.Req())
+	ch1 := newServerChannel(ch, msg.Req(), s.parse)
 	defer ch1.Free()
 
 	// Handle request
@@ -103,6 +136,11 @@ func (s *server) HandleChannel(ctx Context, ch mpx.Channel) (st status.Status) {
 		}
 	}
 
+	// Count deprecated calls
+	if ch1.Deprecated() {
+		s.countDeprecated(method)
+	}
+
 	// Skip response for oneway methods
 	if st.Code == CodeSkipResponse {
 		return status.OK
@@ -128,6 +166,19 @@ func (s *server) handleRequest(ctx Context, ch *serverChannel) (result ref.R[[]b
 	return s.handler.Handle(ctx, ch)
 }
 
+func (s *server) countDeprecated(method string) {
+	v, ok := s.deprecated.Load(method)
+	if !ok {
+		// Clone method, the string is valid until the channel is freed
+		v, _ = s.deprecated.LoadOrStore(strings.Clone(method), new(atomic.Int64))
+	}
+	v.(*atomic.Int64).Add(1)
+
+	if s.logDeprecated && s.logger.WarnOn() {
+		s.logger.Warn("RPC server deprecated method called", "method", method)
+	}
+}
+
 func requestMethod(b []byte, req prpc.Request) []byte {
 	calls := req.Calls()
 
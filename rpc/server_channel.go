@@ -13,6 +13,7 @@ import (
 	"github.com/basecomplextech/baselibrary/pools"
 	"github.com/basecomplextech/baselibrary/ref"
 	"github.com/basecomplextech/baselibrary/status"
+	"github.com/basecomplextech/spec"
 	"github.com/basecomplextech/spec/mpx"
 	"github.com/basecomplextech/spec/proto/prpc"
 )
@@ -59,6 +60,22 @@ type internalServerChannel interface {
 
 	// SendResponse sends a response and closes the channel.
 	SendResponse(ctx async.Context, result []byte, st status.Status) status.Status
+
+	// Deprecated returns true if the channel has been marked as calling a deprecated method.
+	Deprecated() bool
+
+	// markDeprecated marks the channel as calling a deprecated method.
+	markDeprecated()
+}
+
+// MarkDeprecated marks a server channel as calling a deprecated method,
+// used by generated handlers, the server logs and counts such calls.
+func MarkDeprecated(ch ServerChannel) {
+	ch1, ok := ch.(internalServerChannel)
+	if !ok {
+		return
+	}
+	ch1.markDeprecated()
 }
 
 var _ ServerChannel = (*serverChannel)(nil)
@@ -72,6 +89,9 @@ type serverChannel struct {
 type serverChannelState struct {
 	ch     mpx.Channel // unowned
 	method []byte      // call method names, separated by '/'
+	parse  spec.ParseOptions
+
+	deprecated atomic.Bool // deprecated method called
 
 	// send
 	sendMu      sync.Mutex
@@ -87,9 +107,10 @@ type serverChannelState struct {
 	recvError  status.Status
 }
 
-func newServerChannel(ch mpx.Channel, req prpc.Request) *serverChannel {
+func newServerChannel(ch mpx.Channel, req prpc.Request, parse spec.ParseOptions) *serverChannel {
 	s := acquireServerState()
 	s.ch = ch
+	s.parse = parse
 	s.method = requestMethod(s.method, req)
 	s.recvReq = req
 
@@ -116,6 +137,28 @@ func (ch *serverChannel) Method() string {
 	return unsafeString(s.method)
 }
 
+// Deprecated returns true if the channel has been marked as calling a deprecated method.
+func (ch *serverChannel) Deprecated() bool {
+	s, ok := ch.acquire()
+	if !ok {
+		return false
+	}
+	defer ch.release()
+
+	return s.deprecated.Load()
+}
+
+// markDeprecated marks the channel as calling a deprecated method.
+func (ch *serverChannel) markDeprecated() {
+	s, ok := ch.acquire()
+	if !ok {
+		return
+	}
+	defer ch.release()
+
+	s.deprecated.Store(true)
+}
+
 // Context returns a channel context.
 func (ch *serverChannel) Context() Context {
 	s, ok := ch.acquire()
@@ -207,11 +250,11 @@ func (ch *serverChannel) ReceiveAsync(ctx async.Context) ([]byte, bool, status.S
 			return nil, false, st
 		}
 
-		var err error
-		msg, _, err = prpc.ParseMessage(b)
+		msg1, _, err := spec.ParseMessageOpts(b, s.parse)
 		if err != nil {
 			return nil, false, WrapError(err)
 		}
+		msg = prpc.NewMessage(msg1)
 	}
 
 	// Handle message
@@ -388,6 +431,8 @@ func releaseServerState(s *serverChannelState) {
 func (s *serverChannelState) reset() {
 	s.ch = nil
 	s.method = s.method[:0]
+	s.parse = spec.ParseOptions{}
+	s.deprecated.Store(false)
 
 	s.sendReq = false
 	s.sendEnd = false
@@ -21,6 +21,10 @@ import (
 
 func testServer(t tests.T, handle HandleFunc) *server {
 	opts := Default()
+	return testServerOpts(t, handle, opts)
+}
+
+func testServerOpts(t tests.T, handle HandleFunc, opts Options) *server {
 	logger := logging.TestLogger(t)
 	server := newServer("localhost:0", handle, logger, opts)
 
@@ -142,3 +146,25 @@ func TestServer_handleRequest__should_handle_errors(t *testing.T) {
 	assert.Equal(t, status.CodeUnauthorized, st.Code)
 	assert.Equal(t, "test unauthorized", st.Message)
 }
+
+func TestServer_handleRequest__should_reject_request_exceeding_parse_limits(t *testing.T) {
+	handled := false
+	handle := func(ctx Context, ch ServerChannel) (ref.R[[]byte], status.Status) {
+		handled = true
+		return nil, status.OK
+	}
+
+	opts := Default()
+	opts.Parse.MaxBytesLen = 4
+
+	server := testServerOpts(t, handle, opts)
+	client := testClient(t, server)
+	defer client.Close()
+
+	ctx := async.NoContext()
+	req := testEchoRequest(t, "request")
+	_, st := client.Request(ctx, req)
+
+	assert.False(t, st.OK())
+	assert.False(t, handled)
+}
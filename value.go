@@ -31,3 +31,8 @@ func OpenValueErr(b []byte) (Value, error) {
 func ParseValue(b []byte) (_ Value, n int, err error) {
 	return types.ParseValue(b)
 }
+
+// ParseValueOpts recursively parses and returns a value, checks the parse limits.
+func ParseValueOpts(b []byte, opts ParseOptions) (_ Value, n int, err error) {
+	return types.ParseValueOpts(b, opts)
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package wellknown
+
+import (
+	"cmp"
+	"fmt"
+	"math/big"
+
+	"github.com/basecomplextech/spec/internal/canonical"
+)
+
+// MaxDecimalScale is the max number of decimal fractional digits.
+const MaxDecimalScale = canonical.MaxDecimalScale
+
+// NewDecimal returns a decimal value * 10^-scale, i.e. NewDecimal(12345, 2) is 123.45.
+func NewDecimal(value int64, scale int32) Decimal {
+	return Decimal{Value: value, Scale: scale}
+}
+
+// ParseDecimal parses a plain decimal, i.e. "-123.4500", the scale is preserved.
+func ParseDecimal(s string) (Decimal, error) {
+	value, scale, err := canonical.ParseDecimal(s)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return Decimal{Value: value, Scale: scale}, nil
+}
+
+// IsZero returns true if the decimal is zero with any scale.
+func (d Decimal) IsZero() bool {
+	return d.Value == 0
+}
+
+// Valid returns true if the scale is in [0, MaxDecimalScale].
+func (d Decimal) Valid() bool {
+	return d.Scale >= 0 && d.Scale <= MaxDecimalScale
+}
+
+// Cmp compares decimals numerically, returns -1, 0 or +1, i.e. 1.5 and 1.50 are equal.
+//
+// Decimals are compared by their exponents and digits, so any scales are supported,
+// including invalid ones from untrusted input.
+func (d Decimal) Cmp(d1 Decimal) int {
+	if d.Scale == d1.Scale {
+		return cmp.Compare(d.Value, d1.Value)
+	}
+
+	sign0 := cmp.Compare(d.Value, 0)
+	sign1 := cmp.Compare(d1.Value, 0)
+	switch {
+	case sign0 != sign1:
+		return cmp.Compare(sign0, sign1)
+	case sign0 == 0:
+		return 0
+	}
+	return sign0 * cmpDecimalAbs(d, d1)
+}
+
+// Rat returns the decimal as an exact rational number,
+// or an error if the scale is outside [0, MaxDecimalScale].
+func (d Decimal) Rat() (*big.Rat, error) {
+	if !d.Valid() {
+		return nil, fmt.Errorf("decimal scale %d out of range [0, %d]", d.Scale, MaxDecimalScale)
+	}
+
+	r := new(big.Rat).SetInt64(d.Value)
+	return r.Quo(r, new(big.Rat).SetInt(pow10(int(d.Scale)))), nil
+}
+
+// String returns a canonical decimal string, i.e. "-123.4500".
+//
+// Scales outside [0, MaxDecimalScale] are formatted in the exponent form, i.e. "123e-20".
+func (d Decimal) String() string {
+	return canonical.FormatDecimal(d.Value, d.Scale)
+}
+
+// MarshalText returns a canonical decimal string.
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a plain decimal.
+func (d *Decimal) UnmarshalText(b []byte) error {
+	d1, err := ParseDecimal(string(b))
+	if err != nil {
+		return err
+	}
+
+	*d = d1
+	return nil
+}
+
+// private
+
+// cmpDecimalAbs compares absolute decimal values, first by their exponents,
+// then by their digits aligned to the same length.
+func cmpDecimalAbs(d0 Decimal, d1 Decimal) int {
+	v0, v1 := absUint64(d0.Value), absUint64(d1.Value)
+	n0, n1 := digits(v0), digits(v1)
+
+	// Exponent of the first digit plus one, cannot overflow int64
+	e0 := int64(n0) - int64(d0.Scale)
+	e1 := int64(n1) - int64(d1.Scale)
+	if e0 != e1 {
+		return cmp.Compare(e0, e1)
+	}
+
+	// Same exponents, at most 19 digits
+	x0 := new(big.Int).SetUint64(v0)
+	x1 := new(big.Int).SetUint64(v1)
+	switch {
+	case n0 < n1:
+		x0.Mul(x0, pow10(n1-n0))
+	case n1 < n0:
+		x1.Mul(x1, pow10(n0-n1))
+	}
+	return x0.Cmp(x1)
+}
+
+func absUint64(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+	return uint64(v)
+}
+
+// digits returns the number of decimal digits in a non-zero value.
+func digits(v uint64) int {
+	n := 0
+	for ; v > 0; v /= 10 {
+		n++
+	}
+	return n
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
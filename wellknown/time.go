@@ -0,0 +1,108 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package wellknown
+
+import (
+	"time"
+
+	"github.com/basecomplextech/spec/internal/canonical"
+)
+
+// Timestamp
+
+// FromTime returns a timestamp of a time.
+func FromTime(t time.Time) Timestamp {
+	return Timestamp{
+		Seconds: t.Unix(),
+		Nanos:   int32(t.Nanosecond()),
+	}
+}
+
+// ToTime returns a timestamp as a UTC time.
+func ToTime(ts Timestamp) time.Time {
+	return time.Unix(ts.Seconds, int64(ts.Nanos)).UTC()
+}
+
+// ParseTimestamp parses an RFC 3339 timestamp.
+func ParseTimestamp(s string) (Timestamp, error) {
+	seconds, nanos, err := canonical.ParseTimestamp(s)
+	if err != nil {
+		return Timestamp{}, err
+	}
+	return Timestamp{Seconds: seconds, Nanos: nanos}, nil
+}
+
+// Time returns the timestamp as a UTC time.
+func (ts Timestamp) Time() time.Time {
+	return ToTime(ts)
+}
+
+// String returns a canonical RFC 3339 string in UTC.
+func (ts Timestamp) String() string {
+	return canonical.FormatTimestamp(ts.Seconds, ts.Nanos)
+}
+
+// MarshalText returns a canonical RFC 3339 string in UTC.
+func (ts Timestamp) MarshalText() ([]byte, error) {
+	return []byte(ts.String()), nil
+}
+
+// UnmarshalText parses an RFC 3339 timestamp.
+func (ts *Timestamp) UnmarshalText(b []byte) error {
+	ts1, err := ParseTimestamp(string(b))
+	if err != nil {
+		return err
+	}
+
+	*ts = ts1
+	return nil
+}
+
+// Duration
+
+// FromDuration returns a duration of a time.Duration.
+func FromDuration(d time.Duration) Duration {
+	return Duration{Nanos: int64(d)}
+}
+
+// ToDuration returns a duration as a time.Duration.
+func ToDuration(d Duration) time.Duration {
+	return time.Duration(d.Nanos)
+}
+
+// ParseDuration parses a canonical duration or a Go duration, i.e. "1.5s" or "1h30m".
+func ParseDuration(s string) (Duration, error) {
+	nanos, err := canonical.ParseDuration(s)
+	if err != nil {
+		return Duration{}, err
+	}
+	return Duration{Nanos: nanos}, nil
+}
+
+// Std returns the duration as a time.Duration.
+func (d Duration) Std() time.Duration {
+	return ToDuration(d)
+}
+
+// String returns a canonical duration string in seconds, i.e. "1.5s".
+func (d Duration) String() string {
+	return canonical.FormatDuration(d.Nanos)
+}
+
+// MarshalText returns a canonical duration string in seconds.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText parses a canonical duration or a Go duration.
+func (d *Duration) UnmarshalText(b []byte) error {
+	d1, err := ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+
+	*d = d1
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package wellknown
+
+import (
+	"crypto/rand"
+
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/spec/internal/canonical"
+)
+
+// NewUUID returns a random version 4 UUID.
+func NewUUID() UUID {
+	var b [bin.Len128]byte
+	rand.Read(b[:])
+
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // RFC 4122 variant
+	return UUID{Value: bin.New128(b)}
+}
+
+// ParseUUID parses a UUID in the hex groups form, i.e. "01234567-89ab-cdef-0123-456789abcdef".
+func ParseUUID(s string) (UUID, error) {
+	v, err := canonical.ParseUUID(s)
+	if err != nil {
+		return UUID{}, err
+	}
+	return UUID{Value: v}, nil
+}
+
+// IsZero returns true if the UUID is zero.
+func (u UUID) IsZero() bool {
+	return u.Value.IsZero()
+}
+
+// String returns a canonical UUID string in lowercase hex groups.
+func (u UUID) String() string {
+	return canonical.FormatUUID(u.Value)
+}
+
+// MarshalText returns a canonical UUID string.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText parses a UUID in the hex groups form.
+func (u *UUID) UnmarshalText(b []byte) error {
+	u1, err := ParseUUID(string(b))
+	if err != nil {
+		return err
+	}
+
+	*u = u1
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+//go:generate spec generate --skip-rpc .
+
+// Package wellknown provides well-known schema types: timestamps, durations,
+// exact decimals and UUIDs.
+//
+// Import the schema package in spec files and use its types in messages:
+//
+//	import (
+//	    "github.com/basecomplextech/spec/wellknown"
+//	)
+//
+//	message Order {
+//	    id      wellknown.UUID      1;
+//	    created wellknown.Timestamp 2;
+//	    timeout wellknown.Duration  3;
+//	    price   wellknown.Decimal   4;
+//	}
+//
+// Generated message getters and writers use time.Time for timestamps and time.Duration
+// for durations, decimals and UUIDs are used as is. Canonical JSON and text marshalers
+// encode the types as strings:
+//
+//	timestamp  RFC 3339 in UTC, i.e. "2025-01-02T03:04:05.5Z"
+//	duration   seconds, i.e. "1.5s", Go durations such as "1h30m" are also parsed
+//	decimal    plain decimal with its scale, i.e. "-123.4500"
+//	uuid       lowercase hex groups, i.e. "01234567-89ab-cdef-0123-456789abcdef"
+package wellknown
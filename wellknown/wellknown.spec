@@ -0,0 +1,42 @@
+// Well-known types, import them as "github.com/basecomplextech/spec/wellknown".
+//
+// Generated Go code maps timestamps to time.Time and durations to time.Duration in message
+// fields, decimals and UUIDs are mapped to wellknown.Decimal and wellknown.UUID.
+// Canonical JSON encodes all of them as strings in their canonical text forms.
+
+options (
+    go_package="github.com/basecomplextech/spec/wellknown"
+)
+
+// Timestamp is a point in time independent of any time zone, seconds and nanoseconds
+// are counted since the Unix epoch, nanoseconds are in [0, 999999999].
+struct Timestamp [
+    wellknown="timestamp",
+    go_type="time.Time",
+    go_from="github.com/basecomplextech/spec/wellknown.ToTime",
+    go_to="github.com/basecomplextech/spec/wellknown.FromTime"
+] {
+    seconds int64;
+    nanos   int32;
+}
+
+// Duration is a signed span of time in nanoseconds.
+struct Duration [
+    wellknown="duration",
+    go_type="time.Duration",
+    go_from="github.com/basecomplextech/spec/wellknown.ToDuration",
+    go_to="github.com/basecomplextech/spec/wellknown.FromDuration"
+] {
+    nanos   int64;
+}
+
+// Decimal is an exact decimal number, value * 10^-scale, the scale is in [0, 18].
+struct Decimal [wellknown="decimal"] {
+    value   int64;
+    scale   int32;
+}
+
+// UUID is a 128-bit universally unique identifier.
+struct UUID [wellknown="uuid"] {
+    value   bin128;
+}
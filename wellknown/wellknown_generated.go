@@ -0,0 +1,355 @@
+package wellknown
+
+import (
+	"fmt"
+	"github.com/basecomplextech/baselibrary/alloc"
+	"github.com/basecomplextech/baselibrary/async"
+	"github.com/basecomplextech/baselibrary/bin"
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/baselibrary/pools"
+	"github.com/basecomplextech/baselibrary/ref"
+	"github.com/basecomplextech/baselibrary/status"
+	"github.com/basecomplextech/spec"
+)
+
+var (
+	_ fmt.State
+	_ alloc.Buffer
+	_ async.Context
+	_ bin.Bin128
+	_ buffer.Buffer
+	_ spec.MessageTable
+	_ pools.Pool[any]
+	_ ref.Ref
+	_ spec.Type
+	_ status.Status
+)
+
+// Timestamp
+
+// Timestamp is a point in time independent of any time zone, seconds and nanoseconds
+// are counted since the Unix epoch, nanoseconds are in [0, 999999999].
+type Timestamp struct {
+	Seconds int64 `json:"seconds"`
+	Nanos   int32 `json:"nanos"`
+}
+
+func OpenTimestamp(b []byte) Timestamp {
+	s, _, _ := DecodeTimestamp(b)
+	return s
+}
+
+func DecodeTimestamp(b []byte) (s Timestamp, size int, err error) {
+	size, err = s.Decode(b)
+	return s, size, err
+}
+
+func EncodeTimestampTo(b buffer.Buffer, s Timestamp) (int, error) {
+	return s.EncodeTo(b)
+}
+
+func (s *Timestamp) Decode(b []byte) (size int, err error) {
+	dataSize, size, err := spec.DecodeStruct(b)
+	if err != nil || size == 0 {
+		return
+	}
+
+	b = b[len(b)-size:]
+	n := size - dataSize
+	off := len(b) - n
+
+	// Decode in reverse order
+
+	s.Nanos, n, err = spec.DecodeInt32(b[:off])
+	if err != nil {
+		return
+	}
+	off -= n
+
+	s.Seconds, n, err = spec.DecodeInt64(b[:off])
+	if err != nil {
+		return
+	}
+	off -= n
+
+	return size, err
+}
+
+func (s Timestamp) EncodeTo(b buffer.Buffer) (int, error) {
+	var dataSize, n int
+	var err error
+
+	n, err = spec.EncodeInt64(b, s.Seconds)
+	if err != nil {
+		return 0, err
+	}
+	dataSize += n
+
+	n, err = spec.EncodeInt32(b, s.Nanos)
+	if err != nil {
+		return 0, err
+	}
+	dataSize += n
+
+	n, err = spec.EncodeStruct(b, dataSize)
+	if err != nil {
+		return 0, err
+	}
+	return dataSize + n, nil
+}
+
+var timestampDescriptor = &spec.StructDescriptor{
+	Package:     "wellknown",
+	Name:        "Timestamp",
+	Annotations: spec.Annotations{"wellknown": "timestamp", "go_type": "time.Time", "go_from": "github.com/basecomplextech/spec/wellknown.ToTime", "go_to": "github.com/basecomplextech/spec/wellknown.FromTime"},
+}
+
+func init() {
+	timestampDescriptor.Fields = []*spec.StructFieldDescriptor{
+		{Name: "seconds", Type: &spec.TypeDescriptor{Kind: spec.KindInt64, Name: "int64"}},
+		{Name: "nanos", Type: &spec.TypeDescriptor{Kind: spec.KindInt32, Name: "int32"}},
+	}
+}
+
+func (s Timestamp) Descriptor() *spec.StructDescriptor {
+	return timestampDescriptor
+}
+
+// Duration
+
+// Duration is a signed span of time in nanoseconds.
+type Duration struct {
+	Nanos int64 `json:"nanos"`
+}
+
+func OpenDuration(b []byte) Duration {
+	s, _, _ := DecodeDuration(b)
+	return s
+}
+
+func DecodeDuration(b []byte) (s Duration, size int, err error) {
+	size, err = s.Decode(b)
+	return s, size, err
+}
+
+func EncodeDurationTo(b buffer.Buffer, s Duration) (int, error) {
+	return s.EncodeTo(b)
+}
+
+func (s *Duration) Decode(b []byte) (size int, err error) {
+	dataSize, size, err := spec.DecodeStruct(b)
+	if err != nil || size == 0 {
+		return
+	}
+
+	b = b[len(b)-size:]
+	n := size - dataSize
+	off := len(b) - n
+
+	// Decode in reverse order
+
+	s.Nanos, n, err = spec.DecodeInt64(b[:off])
+	if err != nil {
+		return
+	}
+	off -= n
+
+	return size, err
+}
+
+func (s Duration) EncodeTo(b buffer.Buffer) (int, error) {
+	var dataSize, n int
+	var err error
+
+	n, err = spec.EncodeInt64(b, s.Nanos)
+	if err != nil {
+		return 0, err
+	}
+	dataSize += n
+
+	n, err = spec.EncodeStruct(b, dataSize)
+	if err != nil {
+		return 0, err
+	}
+	return dataSize + n, nil
+}
+
+var durationDescriptor = &spec.StructDescriptor{
+	Package:     "wellknown",
+	Name:        "Duration",
+	Annotations: spec.Annotations{"wellknown": "duration", "go_type": "time.Duration", "go_from": "github.com/basecomplextech/spec/wellknown.ToDuration", "go_to": "github.com/basecomplextech/spec/wellknown.FromDuration"},
+}
+
+func init() {
+	durationDescriptor.Fields = []*spec.StructFieldDescriptor{
+		{Name: "nanos", Type: &spec.TypeDescriptor{Kind: spec.KindInt64, Name: "int64"}},
+	}
+}
+
+func (s Duration) Descriptor() *spec.StructDescriptor {
+	return durationDescriptor
+}
+
+// Decimal
+
+// Decimal is an exact decimal number, value * 10^-scale, the scale is in [0, 18].
+type Decimal struct {
+	Value int64 `json:"value"`
+	Scale int32 `json:"scale"`
+}
+
+func OpenDecimal(b []byte) Decimal {
+	s, _, _ := DecodeDecimal(b)
+	return s
+}
+
+func DecodeDecimal(b []byte) (s Decimal, size int, err error) {
+	size, err = s.Decode(b)
+	return s, size, err
+}
+
+func EncodeDecimalTo(b buffer.Buffer, s Decimal) (int, error) {
+	return s.EncodeTo(b)
+}
+
+func (s *Decimal) Decode(b []byte) (size int, err error) {
+	dataSize, size, err := spec.DecodeStruct(b)
+	if err != nil || size == 0 {
+		return
+	}
+
+	b = b[len(b)-size:]
+	n := size - dataSize
+	off := len(b) - n
+
+	// Decode in reverse order
+
+	s.Scale, n, err = spec.DecodeInt32(b[:off])
+	if err != nil {
+		return
+	}
+	off -= n
+
+	s.Value, n, err = spec.DecodeInt64(b[:off])
+	if err != nil {
+		return
+	}
+	off -= n
+
+	return size, err
+}
+
+func (s Decimal) EncodeTo(b buffer.Buffer) (int, error) {
+	var dataSize, n int
+	var err error
+
+	n, err = spec.EncodeInt64(b, s.Value)
+	if err != nil {
+		return 0, err
+	}
+	dataSize += n
+
+	n, err = spec.EncodeInt32(b, s.Scale)
+	if err != nil {
+		return 0, err
+	}
+	dataSize += n
+
+	n, err = spec.EncodeStruct(b, dataSize)
+	if err != nil {
+		return 0, err
+	}
+	return dataSize + n, nil
+}
+
+var decimalDescriptor = &spec.StructDescriptor{
+	Package:     "wellknown",
+	Name:        "Decimal",
+	Annotations: spec.Annotations{"wellknown": "decimal"},
+}
+
+func init() {
+	decimalDescriptor.Fields = []*spec.StructFieldDescriptor{
+		{Name: "value", Type: &spec.TypeDescriptor{Kind: spec.KindInt64, Name: "int64"}},
+		{Name: "scale", Type: &spec.TypeDescriptor{Kind: spec.KindInt32, Name: "int32"}},
+	}
+}
+
+func (s Decimal) Descriptor() *spec.StructDescriptor {
+	return decimalDescriptor
+}
+
+// UUID
+
+// UUID is a 128-bit universally unique identifier.
+type UUID struct {
+	Value bin.Bin128 `json:"value"`
+}
+
+func OpenUUID(b []byte) UUID {
+	s, _, _ := DecodeUUID(b)
+	return s
+}
+
+func DecodeUUID(b []byte) (s UUID, size int, err error) {
+	size, err = s.Decode(b)
+	return s, size, err
+}
+
+func EncodeUUIDTo(b buffer.Buffer, s UUID) (int, error) {
+	return s.EncodeTo(b)
+}
+
+func (s *UUID) Decode(b []byte) (size int, err error) {
+	dataSize, size, err := spec.DecodeStruct(b)
+	if err != nil || size == 0 {
+		return
+	}
+
+	b = b[len(b)-size:]
+	n := size - dataSize
+	off := len(b) - n
+
+	// Decode in reverse order
+
+	s.Value, n, err = spec.DecodeBin128(b[:off])
+	if err != nil {
+		return
+	}
+	off -= n
+
+	return size, err
+}
+
+func (s UUID) EncodeTo(b buffer.Buffer) (int, error) {
+	var dataSize, n int
+	var err error
+
+	n, err = spec.EncodeBin128(b, s.Value)
+	if err != nil {
+		return 0, err
+	}
+	dataSize += n
+
+	n, err = spec.EncodeStruct(b, dataSize)
+	if err != nil {
+		return 0, err
+	}
+	return dataSize + n, nil
+}
+
+var uUIDDescriptor = &spec.StructDescriptor{
+	Package:     "wellknown",
+	Name:        "UUID",
+	Annotations: spec.Annotations{"wellknown": "uuid"},
+}
+
+func init() {
+	uUIDDescriptor.Fields = []*spec.StructFieldDescriptor{
+		{Name: "value", Type: &spec.TypeDescriptor{Kind: spec.KindBin128, Name: "bin128"}},
+	}
+}
+
+func (s UUID) Descriptor() *spec.StructDescriptor {
+	return uUIDDescriptor
+}
@@ -0,0 +1,181 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package wellknown
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Timestamp
+
+func TestTimestamp__should_convert_time(t *testing.T) {
+	tm := time.Date(1969, 12, 31, 23, 59, 59, 1, time.UTC)
+
+	ts := FromTime(tm)
+	assert.Equal(t, Timestamp{Seconds: -1, Nanos: 1}, ts)
+	assert.Equal(t, tm, ToTime(ts))
+	assert.Equal(t, "1969-12-31T23:59:59.000000001Z", ts.String())
+}
+
+func TestParseTimestamp__should_parse_rfc3339(t *testing.T) {
+	ts, err := ParseTimestamp("2025-01-02T05:04:05.25+02:00")
+	require.NoError(t, err)
+	assert.Equal(t, "2025-01-02T03:04:05.25Z", ts.String())
+
+	_, err = ParseTimestamp("2025-01-02")
+	assert.Error(t, err)
+}
+
+// Duration
+
+func TestDuration__should_format_seconds(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                        "0s",
+		time.Nanosecond:          "0.000000001s",
+		-1500 * time.Millisecond: "-1.5s",
+		90 * time.Minute:         "5400s",
+		math.MinInt64:            "-9223372036.854775808s",
+	}
+
+	for d, s := range tests {
+		assert.Equal(t, s, FromDuration(d).String())
+
+		d1, err := ParseDuration(s)
+		require.NoError(t, err)
+		assert.Equal(t, d, d1.Std())
+	}
+}
+
+// Decimal
+
+func TestDecimal__should_format_and_parse(t *testing.T) {
+	tests := map[string]Decimal{
+		"0":                     {},
+		"-123.4500":             {Value: -1234500, Scale: 4},
+		"0.05":                  {Value: 5, Scale: 2},
+		"-0.000000000000000001": {Value: -1, Scale: 18},
+		"9223372036854775807":   {Value: math.MaxInt64},
+		"-9223372036854775808":  {Value: math.MinInt64},
+	}
+
+	for s, d := range tests {
+		assert.Equal(t, s, d.String())
+
+		d1, err := ParseDecimal(s)
+		require.NoError(t, err)
+		assert.Equal(t, d, d1)
+	}
+}
+
+func TestDecimal__should_format_invalid_scale_as_exponent(t *testing.T) {
+	assert.Equal(t, "123e3", NewDecimal(123, -3).String())
+	assert.Equal(t, "123e-20", NewDecimal(123, 20).String())
+}
+
+func TestParseDecimal__should_return_error_on_invalid_decimal(t *testing.T) {
+	invalid := []string{
+		"",
+		"-",
+		".5",
+		"1.",
+		"1e3",
+		"1.2.3",
+		"9223372036854775808",
+		"0.0000000000000000001",
+	}
+
+	for _, s := range invalid {
+		_, err := ParseDecimal(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestDecimal_Cmp__should_compare_numerically(t *testing.T) {
+	assert.Equal(t, 0, NewDecimal(15, 1).Cmp(NewDecimal(150, 2)))
+	assert.Equal(t, -1, NewDecimal(-1, 0).Cmp(NewDecimal(1, 18)))
+	assert.Equal(t, 1, NewDecimal(math.MaxInt64, 0).Cmp(NewDecimal(math.MaxInt64, 1)))
+	assert.Equal(t, -1, NewDecimal(math.MinInt64, 0).Cmp(NewDecimal(math.MinInt64, 1)))
+	assert.Equal(t, 0, NewDecimal(-15, 1).Cmp(NewDecimal(-1500, 3)))
+	assert.Equal(t, 1, NewDecimal(2, 1).Cmp(NewDecimal(15, 2)))
+}
+
+func TestDecimal_Cmp__should_compare_huge_scales(t *testing.T) {
+	tiny := NewDecimal(1, math.MaxInt32)
+	huge := NewDecimal(1, math.MinInt32)
+
+	assert.Equal(t, -1, tiny.Cmp(NewDecimal(1, 18)))
+	assert.Equal(t, 1, huge.Cmp(NewDecimal(math.MaxInt64, 0)))
+	assert.Equal(t, 1, NewDecimal(-1, math.MaxInt32).Cmp(NewDecimal(-1, 0)))
+	assert.Equal(t, 0, NewDecimal(10, math.MaxInt32).Cmp(NewDecimal(1, math.MaxInt32-1)))
+}
+
+func TestDecimal_Rat__should_return_error_when_scale_out_of_range(t *testing.T) {
+	r, err := NewDecimal(12345, 2).Rat()
+	require.NoError(t, err)
+	assert.Equal(t, "2469/20", r.String())
+
+	_, err = NewDecimal(1, math.MaxInt32).Rat()
+	assert.ErrorContains(t, err, "decimal scale 2147483647 out of range")
+
+	_, err = NewDecimal(1, -1).Rat()
+	assert.Error(t, err)
+}
+
+// UUID
+
+func TestUUID__should_format_and_parse(t *testing.T) {
+	u, err := ParseUUID("01234567-89AB-cdef-0123-456789ABCDEF")
+	require.NoError(t, err)
+	assert.Equal(t, "01234567-89ab-cdef-0123-456789abcdef", u.String())
+
+	_, err = ParseUUID("0123456789abcdef0123456789abcdef")
+	assert.Error(t, err)
+}
+
+func TestNewUUID__should_return_version4_uuid(t *testing.T) {
+	u := NewUUID()
+	s := u.String()
+
+	assert.Equal(t, byte('4'), s[14])
+	assert.Contains(t, "89ab", string(s[19]))
+	assert.NotEqual(t, u, NewUUID())
+}
+
+// JSON
+
+func TestTypes__should_marshal_json_strings(t *testing.T) {
+	type Value struct {
+		Timestamp Timestamp `json:"timestamp"`
+		Duration  Duration  `json:"duration"`
+		Decimal   Decimal   `json:"decimal"`
+		UUID      UUID      `json:"uuid"`
+	}
+
+	v := Value{
+		Timestamp: FromTime(time.Unix(1, 0)),
+		Duration:  FromDuration(time.Second),
+		Decimal:   NewDecimal(1, 2),
+	}
+
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"timestamp": "1970-01-01T00:00:01Z",
+		"duration": "1s",
+		"decimal": "0.01",
+		"uuid": "00000000-0000-0000-0000-000000000000"
+	}`, string(b))
+
+	var v1 Value
+	err = json.Unmarshal(b, &v1)
+	require.NoError(t, err)
+	assert.Equal(t, v, v1)
+}
@@ -0,0 +1,28 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import (
+	"github.com/basecomplextech/baselibrary/buffer"
+	"github.com/basecomplextech/spec/internal/writer"
+)
+
+type MapWriter = writer.MapWriter
+
+// NewMapWriter returns a new map writer with a new empty buffer.
+//
+// The writer is released on end.
+func NewMapWriter() MapWriter {
+	w := writer.New(true /* release */)
+	return w.Map()
+}
+
+// NewMapWriterBuffer returns a new map writer with the given buffer.
+//
+// The writer is freed on end.
+func NewMapWriterBuffer(buf buffer.Buffer) MapWriter {
+	w := writer.Acquire(buf)
+	return w.Map()
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import "github.com/basecomplextech/spec/internal/writer"
+
+// MessageMapWriter writes a map of messages.
+type MessageMapWriter[K, T any] struct {
+	w        MapWriter
+	writeKey writer.WriteFunc[K]
+	next     func(MessageWriter) T
+}
+
+// NewMessageMapWriter returns a new message map writer.
+func NewMessageMapWriter[K, T any](w MapWriter, writeKey writer.WriteFunc[K],
+	next func(w MessageWriter) T) (_ MessageMapWriter[K, T]) {
+
+	return MessageMapWriter[K, T]{
+		w:        w,
+		writeKey: writeKey,
+		next:     next,
+	}
+}
+
+// Put adds an entry and returns its message, the map is sorted by keys on end.
+func (b MessageMapWriter[K, T]) Put(key K) (_ T) {
+	writer.WriteKey(b.w, key, b.writeKey)
+
+	msg := b.w.Message()
+	return b.next(msg)
+}
+
+// Copy adds an entry with a message copy.
+func (b MessageMapWriter[K, T]) Copy(key K, msg MessageType) error {
+	if err := writer.WriteKey(b.w, key, b.writeKey); err != nil {
+		return err
+	}
+
+	raw := msg.Unwrap().Raw()
+	return b.w.Any(raw)
+}
+
+// Len returns the number of written entries.
+// The method is only valid when there is no pending entry.
+func (b MessageMapWriter[K, T]) Len() int {
+	return b.w.Len()
+}
+
+// Err returns the current build error.
+func (b MessageMapWriter[K, T]) Err() error {
+	return b.w.Err()
+}
+
+// End ends the map.
+func (b MessageMapWriter[K, T]) End() error {
+	return b.w.End()
+}
@@ -0,0 +1,46 @@
+// Copyright 2025 Ivan Korobkov. All rights reserved.
+// Use of this software is governed by the MIT License
+// that can be found in the LICENSE file.
+
+package spec
+
+import "github.com/basecomplextech/spec/internal/writer"
+
+// ValueMapWriter writes a map of primitive values.
+type ValueMapWriter[K, V any] struct {
+	w        MapWriter
+	writeKey writer.WriteFunc[K]
+	write    writer.WriteFunc[V]
+}
+
+// NewValueMapWriter returns a new value map writer.
+func NewValueMapWriter[K, V any](w MapWriter, writeKey writer.WriteFunc[K],
+	write writer.WriteFunc[V]) (_ ValueMapWriter[K, V]) {
+
+	return ValueMapWriter[K, V]{
+		w:        w,
+		writeKey: writeKey,
+		write:    write,
+	}
+}
+
+// Put adds the next entry, the map is sorted by keys on end.
+func (b ValueMapWriter[K, V]) Put(key K, value V) error {
+	return writer.WriteEntry(b.w, key, value, b.writeKey, b.write)
+}
+
+// Len returns the number of written entries.
+// The method is only valid when there is no pending entry.
+func (b ValueMapWriter[K, V]) Len() int {
+	return b.w.Len()
+}
+
+// Err returns the current build error.
+func (b ValueMapWriter[K, V]) Err() error {
+	return b.w.Err()
+}
+
+// End ends the map.
+func (b ValueMapWriter[K, V]) End() error {
+	return b.w.End()
+}
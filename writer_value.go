@@ -5,6 +5,7 @@
 package spec
 
 import (
+	"github.com/basecomplextech/baselibrary/bin"
 	"github.com/basecomplextech/baselibrary/buffer"
 	"github.com/basecomplextech/spec/internal/writer"
 )
@@ -26,3 +27,34 @@ func NewValueWriterBuffer(buf buffer.Buffer) ValueWriter {
 	w := writer.Acquire(buf)
 	return w.Value()
 }
+
+// internal
+
+// valueWriter is implemented by field, list, map and value writers.
+type valueWriter interface {
+	Any(b []byte) error
+	Bool(v bool) error
+	Byte(v byte) error
+
+	Int16(v int16) error
+	Int32(v int32) error
+	Int64(v int64) error
+
+	Uint16(v uint16) error
+	Uint32(v uint32) error
+	Uint64(v uint64) error
+
+	Bin64(v bin.Bin64) error
+	Bin128(v bin.Bin128) error
+	Bin256(v bin.Bin256) error
+
+	Float32(v float32) error
+	Float64(v float64) error
+
+	Bytes(v []byte) error
+	String(v string) error
+
+	List() ListWriter
+	Map() MapWriter
+	Message() MessageWriter
+}